@@ -58,14 +58,29 @@ func (n AuthTokenDeleteResult) GetResponse() *api.Response {
 }
 
 type AuthTokenListResult struct {
-	Items    []*AuthToken
-	response *api.Response
+	Items        []*AuthToken
+	ResponseType string   `json:"response_type,omitempty"`
+	ListToken    string   `json:"list_token,omitempty"`
+	RemovedIds   []string `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n AuthTokenListResult) GetItems() []*AuthToken {
 	return n.Items
 }
 
+func (n AuthTokenListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n AuthTokenListResult) GetListToken() string {
+	return n.ListToken
+}
+
+func (n AuthTokenListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n AuthTokenListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -193,3 +193,27 @@ func WithVaultCredentialLibraryPath(inPath string) Option {
 		o.postMap["attributes"] = val
 	}
 }
+
+func WithVaultCredentialLibrarySecretVersion(inSecretVersion uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["secret_version"] = inSecretVersion
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialLibrarySecretVersion() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["secret_version"] = nil
+		o.postMap["attributes"] = val
+	}
+}
@@ -0,0 +1,149 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SetRotation creates the rotation configuration of the static credential if
+// version is zero, otherwise it replaces the existing rotation configuration
+// of the provided version. The attributes passed to the rotator can be
+// provided with WithAttributes.
+func (c *Client) SetRotation(ctx context.Context, credentialId string, version uint32, rotator string, rotationIntervalSeconds, gracePeriodSeconds uint32, opt ...Option) (*RotationConfigReadResult, error) {
+	if credentialId == "" {
+		return nil, fmt.Errorf("empty credentialId value passed into SetRotation request")
+	}
+	if rotator == "" {
+		return nil, fmt.Errorf("empty rotator value passed into SetRotation request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["rotator"] = rotator
+	opts.postMap["rotation_interval_seconds"] = rotationIntervalSeconds
+	opts.postMap["grace_period_seconds"] = gracePeriodSeconds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("credentials/%s:set-rotation", url.PathEscape(credentialId)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetRotation request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetRotation call: %w", err)
+	}
+
+	target := new(RotationConfigReadResult)
+	target.Item = new(RotationConfig)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetRotation response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ReadRotation returns the rotation configuration of the static credential.
+func (c *Client) ReadRotation(ctx context.Context, credentialId string, opt ...Option) (*RotationConfigReadResult, error) {
+	if credentialId == "" {
+		return nil, fmt.Errorf("empty credentialId value passed into ReadRotation request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("credentials/%s:read-rotation", url.PathEscape(credentialId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadRotation request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadRotation call: %w", err)
+	}
+
+	target := new(RotationConfigReadResult)
+	target.Item = new(RotationConfig)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadRotation response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// RemoveRotation deletes the rotation configuration of the static credential,
+// which stops it from being rotated.
+func (c *Client) RemoveRotation(ctx context.Context, credentialId string, opt ...Option) (*CredentialDeleteResult, error) {
+	if credentialId == "" {
+		return nil, fmt.Errorf("empty credentialId value passed into RemoveRotation request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("credentials/%s:remove-rotation", url.PathEscape(credentialId)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveRotation request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveRotation call: %w", err)
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveRotation response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	target := &CredentialDeleteResult{
+		response: resp,
+	}
+	return target, nil
+}
@@ -0,0 +1,37 @@
+// Code generated by "make api"; DO NOT EDIT.
+package credentials
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type RotationConfig struct {
+	CredentialId            string                 `json:"credential_id,omitempty"`
+	Rotator                 string                 `json:"rotator,omitempty"`
+	Attributes              map[string]interface{} `json:"attributes,omitempty"`
+	RotationIntervalSeconds uint32                 `json:"rotation_interval_seconds,omitempty"`
+	GracePeriodSeconds      uint32                 `json:"grace_period_seconds,omitempty"`
+	SecretVersion           uint64                 `json:"secret_version,omitempty"`
+	LastRotationTime        time.Time              `json:"last_rotation_time,omitempty"`
+	NextRotationTime        time.Time              `json:"next_rotation_time,omitempty"`
+	CreatedTime             time.Time              `json:"created_time,omitempty"`
+	UpdatedTime             time.Time              `json:"updated_time,omitempty"`
+	Version                 uint32                 `json:"version,omitempty"`
+
+	response *api.Response
+}
+
+type RotationConfigReadResult struct {
+	Item     *RotationConfig
+	response *api.Response
+}
+
+func (n RotationConfigReadResult) GetItem() *RotationConfig {
+	return n.Item
+}
+
+func (n RotationConfigReadResult) GetResponse() *api.Response {
+	return n.response
+}
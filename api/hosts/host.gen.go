@@ -64,14 +64,29 @@ func (n HostDeleteResult) GetResponse() *api.Response {
 }
 
 type HostListResult struct {
-	Items    []*Host
-	response *api.Response
+	Items        []*Host
+	ResponseType string   `json:"response_type,omitempty"`
+	ListToken    string   `json:"list_token,omitempty"`
+	RemovedIds   []string `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n HostListResult) GetItems() []*Host {
 	return n.Items
 }
 
+func (n HostListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n HostListResult) GetListToken() string {
+	return n.ListToken
+}
+
+func (n HostListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n HostListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -27,6 +27,7 @@ type HostSet struct {
 	HostIds             []string               `json:"host_ids,omitempty"`
 	PreferredEndpoints  []string               `json:"preferred_endpoints,omitempty"`
 	SyncIntervalSeconds int32                  `json:"sync_interval_seconds,omitempty"`
+	HealthCheck         *HealthCheck           `json:"health_check,omitempty"`
 	Attributes          map[string]interface{} `json:"attributes,omitempty"`
 	AuthorizedActions   []string               `json:"authorized_actions,omitempty"`
 
@@ -98,6 +98,18 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithHealthCheck(inHealthCheck *HealthCheck) Option {
+	return func(o *options) {
+		o.postMap["health_check"] = inHealthCheck
+	}
+}
+
+func DefaultHealthCheck() Option {
+	return func(o *options) {
+		o.postMap["health_check"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
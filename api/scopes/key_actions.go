@@ -0,0 +1,105 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type KeyRotationResult = KeyRewrapStatusReadResult
+
+// RotateKeys rotates the keys of the scope. If rewrap is true, the values
+// encrypted with the previous versions of the keys are re-encrypted in the
+// background and the returned result contains the progress of the rewrap;
+// otherwise its item is nil.
+func (c *Client) RotateKeys(ctx context.Context, scopeId string, rewrap bool, opt ...Option) (*KeyRotationResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RotateKeys request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts.postMap["scope_id"] = scopeId
+	opts.postMap["rewrap"] = rewrap
+
+	req, err := c.client.NewRequest(ctx, "POST", "scopes:rotate-keys", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateKeys request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateKeys call: %w", err)
+	}
+
+	body := new(struct {
+		Item *KeyRewrapStatus `json:"item,omitempty"`
+	})
+	apiErr, err := resp.Decode(body)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target := &KeyRotationResult{Item: body.Item}
+	target.response = resp
+	return target, nil
+}
+
+// ReadKeyRewrapStatus returns the progress of the last rewrap started for the
+// scope.
+func (c *Client) ReadKeyRewrapStatus(ctx context.Context, scopeId string, opt ...Option) (*KeyRewrapStatusReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReadKeyRewrapStatus request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", "scopes:read-key-rewrap-status", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadKeyRewrapStatus request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadKeyRewrapStatus call: %w", err)
+	}
+
+	target := new(KeyRewrapStatusReadResult)
+	target.Item = new(KeyRewrapStatus)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadKeyRewrapStatus response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
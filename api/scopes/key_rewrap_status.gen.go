@@ -0,0 +1,33 @@
+// Code generated by "make api"; DO NOT EDIT.
+package scopes
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type KeyRewrapStatus struct {
+	ScopeId        string    `json:"scope_id,omitempty"`
+	TotalCount     int64     `json:"total_count,omitempty"`
+	CompletedCount int64     `json:"completed_count,omitempty"`
+	Completed      bool      `json:"completed,omitempty"`
+	CreatedTime    time.Time `json:"created_time,omitempty"`
+	UpdatedTime    time.Time `json:"updated_time,omitempty"`
+	CompletedTime  time.Time `json:"completed_time,omitempty"`
+
+	response *api.Response
+}
+
+type KeyRewrapStatusReadResult struct {
+	Item     *KeyRewrapStatus
+	response *api.Response
+}
+
+func (n KeyRewrapStatusReadResult) GetItem() *KeyRewrapStatus {
+	return n.Item
+}
+
+func (n KeyRewrapStatusReadResult) GetResponse() *api.Response {
+	return n.response
+}
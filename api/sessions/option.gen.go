@@ -24,6 +24,8 @@ type options struct {
 	withSkipCurlOutput      bool
 	withFilter              string
 	withRecursive           bool
+	withPageSize            uint32
+	withListToken           string
 }
 
 func getDefaultOptions() options {
@@ -50,6 +52,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
+	}
 	return opts, apiOpts
 }
 
@@ -88,6 +96,22 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+// WithPageSize tells the API to return at most the provided number of items
+// when listing, along with a list token to request the next page.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to continue or refresh the listing the
+// provided list token was returned by.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
+
 func WithIncludeTerminated(inIncludeTerminated bool) Option {
 	return func(o *options) {
 		o.queryMap["include_terminated"] = fmt.Sprintf("%v", inIncludeTerminated)
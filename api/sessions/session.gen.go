@@ -66,14 +66,29 @@ func (n SessionDeleteResult) GetResponse() *api.Response {
 }
 
 type SessionListResult struct {
-	Items    []*Session
-	response *api.Response
+	Items        []*Session
+	ResponseType string   `json:"response_type,omitempty"`
+	ListToken    string   `json:"list_token,omitempty"`
+	RemovedIds   []string `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n SessionListResult) GetItems() []*Session {
 	return n.Items
 }
 
+func (n SessionListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n SessionListResult) GetListToken() string {
+	return n.ListToken
+}
+
+func (n SessionListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n SessionListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -0,0 +1,37 @@
+// Code generated by "make api"; DO NOT EDIT.
+package targets
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+type RdpTargetAttributes struct {
+	DefaultPort uint32 `json:"default_port,omitempty"`
+}
+
+func AttributesMapToRdpTargetAttributes(in map[string]interface{}) (*RdpTargetAttributes, error) {
+	if in == nil {
+		return nil, fmt.Errorf("nil input map")
+	}
+	var out RdpTargetAttributes
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  &out,
+		TagName: "json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating mapstructure decoder: %w", err)
+	}
+	if err := dec.Decode(in); err != nil {
+		return nil, fmt.Errorf("error decoding: %w", err)
+	}
+	return &out, nil
+}
+
+func (pt *Target) GetRdpTargetAttributes() (*RdpTargetAttributes, error) {
+	if pt.Type != "rdp" {
+		return nil, fmt.Errorf("asked to fetch %s-type attributes but target is of type %s", "rdp", pt.Type)
+	}
+	return AttributesMapToRdpTargetAttributes(pt.Attributes)
+}
@@ -26,6 +26,7 @@ type Target struct {
 	HostSources                            []*HostSource          `json:"host_sources,omitempty"`
 	SessionMaxSeconds                      uint32                 `json:"session_max_seconds,omitempty"`
 	SessionConnectionLimit                 int32                  `json:"session_connection_limit,omitempty"`
+	SessionIdleTimeoutSeconds              uint32                 `json:"session_idle_timeout_seconds,omitempty"`
 	WorkerFilter                           string                 `json:"worker_filter,omitempty"`
 	ApplicationCredentialSourceIds         []string               `json:"application_credential_source_ids,omitempty"`
 	ApplicationCredentialSources           []*CredentialSource    `json:"application_credential_sources,omitempty"`
@@ -69,14 +70,29 @@ func (n TargetDeleteResult) GetResponse() *api.Response {
 }
 
 type TargetListResult struct {
-	Items    []*Target
-	response *api.Response
+	Items        []*Target
+	ResponseType string   `json:"response_type,omitempty"`
+	ListToken    string   `json:"list_token,omitempty"`
+	RemovedIds   []string `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n TargetListResult) GetItems() []*Target {
 	return n.Items
 }
 
+func (n TargetListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n TargetListResult) GetListToken() string {
+	return n.ListToken
+}
+
+func (n TargetListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n TargetListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -62,14 +62,29 @@ func (n UserDeleteResult) GetResponse() *api.Response {
 }
 
 type UserListResult struct {
-	Items    []*User
-	response *api.Response
+	Items        []*User
+	ResponseType string   `json:"response_type,omitempty"`
+	ListToken    string   `json:"list_token,omitempty"`
+	RemovedIds   []string `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n UserListResult) GetItems() []*User {
 	return n.Items
 }
 
+func (n UserListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n UserListResult) GetListToken() string {
+	return n.ListToken
+}
+
+func (n UserListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n UserListResult) GetResponse() *api.Response {
 	return n.response
 }
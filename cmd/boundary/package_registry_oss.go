@@ -4,4 +4,8 @@ import (
 	// Enable tcp target support.
 	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/tcp"
 	_ "github.com/hashicorp/boundary/internal/target/tcp"
+
+	// Enable rdp target support.
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/rdp"
+	_ "github.com/hashicorp/boundary/internal/target/rdp"
 )
@@ -43,6 +43,7 @@ const (
 	StatusField                                 = "status"
 	StatesField                                 = "states"
 	SessionConnectionLimitField                 = "session_connection_limit"
+	SessionIdleTimeoutSecondsField              = "session_idle_timeout_seconds"
 	SessionMaxSecondsField                      = "session_max_seconds"
 	WorkerFilterField                           = "worker_filter"
 	AccountIdsField                             = "account_ids"
@@ -60,6 +61,7 @@ const (
 	BrokeredCredentialSourcesField              = "brokered_credential_sources"
 	PreferredEndpointsField                     = "preferred_endpoints"
 	SyncIntervalSecondsField                    = "sync_interval_seconds"
+	HealthCheckField                            = "health_check"
 	PluginIdField                               = "plugin_id"
 	PluginField                                 = "plugin"
 	PluginNameField                             = "plugin_name"
@@ -86,4 +88,6 @@ const (
 	ActiveConnectionCountField                  = "active_connection_count"
 	ControllerGeneratedActivationToken          = "controller_generated_activation_token"
 	ReleaseVersionField                         = "release_version"
+	ValueField                                  = "value"
+	DestinationIdField                          = "destination_id"
 )
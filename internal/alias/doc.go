@@ -0,0 +1,28 @@
+// Package alias provides an alias, a globally unique and user friendly name
+// which can be used in place of the id of a target.
+//
+// The value of an alias is a DNS-style name, like prod.postgres.primary,
+// made of lowercase labels separated by dots. Aliases only exist in the
+// global scope and their values are unique across the whole system. The
+// destination of an alias is a target. An alias can also hold the id of a
+// host of its target, which is used when a session is authorized with the
+// alias and no host is requested. If the destination target or the host of
+// an alias is deleted, the alias is kept but no longer points to it.
+//
+// # Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting aliases. A new repository should be created for each transaction.
+// For example:
+//
+//	var wrapper wrapping.Wrapper
+//	... init wrapper...
+//
+//	// db implements both the reader and writer interfaces.
+//	db, _ := db.Open(db.Postgres, url)
+//
+//	var repo *alias.Repository
+//
+//	repo, _ = alias.NewRepository(ctx, db, db, kms)
+//	a, _ := repo.LookupAliasByValue(ctx, "prod.postgres.primary")
+package alias
@@ -0,0 +1,71 @@
+package alias
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName          string
+	withDescription   string
+	withDestinationId string
+	withHostId        string
+	withLimit         int
+	withPublicId      string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithDestinationId provides an optional destination target id.
+func WithDestinationId(id string) Option {
+	return func(o *options) {
+		o.withDestinationId = id
+	}
+}
+
+// WithHostId provides an optional host id.
+func WithHostId(id string) Option {
+	return func(o *options) {
+		o.withHostId = id
+	}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are
+// returned. If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithPublicId provides an optional public id.
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
@@ -0,0 +1,21 @@
+package alias
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// PublicId prefixes for the resources in the alias package.
+const (
+	AliasPrefix = "alt"
+)
+
+func newAliasId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(AliasPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "alias.newAliasId")
+	}
+	return id, nil
+}
@@ -0,0 +1,19 @@
+package alias
+
+const (
+	// hostInDestinationQuery returns a row if the host is a member of one of
+	// the host sets of the target.
+	hostInDestinationQuery = `
+select 1
+  from target_host_set ths
+  join (select host_id, set_id
+          from static_host_set_member
+         union all
+        select host_id, set_id
+          from host_plugin_set_member) m
+    on m.set_id = ths.host_set_id
+ where ths.target_id = @target_id
+   and m.host_id     = @host_id
+ limit 1;
+`
+)
@@ -0,0 +1,349 @@
+package alias
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// CreateAlias inserts a into the repository and returns a new Alias
+// containing the alias's PublicId. a is not changed. a must contain a valid
+// ScopeId, which must be the global scope, and a valid Value. a must not
+// contain a PublicId. The PublicId is generated and assigned by this method.
+// WithPublicId is the only valid option.
+//
+// a.Name, a.Description, a.DestinationId and a.HostId are optional. If
+// a.Name is set, it must be unique within a.ScopeId. a.Value must be unique.
+// a.HostId requires a.DestinationId and must be a host of one of the host
+// sources of the destination target.
+//
+// Both a.CreateTime and a.UpdateTime are ignored.
+func (r *Repository) CreateAlias(ctx context.Context, a *Alias, opt ...Option) (*Alias, error) {
+	const op = "alias.(Repository).CreateAlias"
+	switch {
+	case a == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case a.ScopeId != scope.Global.String():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "aliases can only be created in the global scope")
+	case a.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	case a.HostId != "" && a.DestinationId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "host id requires a destination id")
+	}
+	if err := ValidateValue(ctx, a.Value); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	a = a.clone()
+
+	opts := getOpts(opt...)
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AliasPrefix+"_") {
+			return nil, errors.New(ctx,
+				errors.InvalidPublicId,
+				op,
+				fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, AliasPrefix),
+			)
+		}
+		a.PublicId = opts.withPublicId
+	} else {
+		id, err := newAliasId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		a.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_CREATE)
+
+	var newAlias *Alias
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			newAlias = a.clone()
+			if err := w.Create(ctx, newAlias, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := validateHost(ctx, reader, newAlias); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("alias value %s or name %s already exists", a.Value, a.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", a.ScopeId)))
+	}
+	return newAlias, nil
+}
+
+// UpdateAlias updates the repository entry for a.PublicId with the values in
+// a for the fields listed in fieldMask. It returns a new Alias containing the
+// updated values and a count of the number of records updated. a is not
+// changed.
+//
+// a must contain a valid PublicId. Only a.Name, a.Description, a.Value,
+// a.DestinationId and a.HostId can be updated. If a.Name is set to a
+// non-empty string, it must be unique within a.ScopeId. a.Value cannot be
+// set to an empty string and must be unique. The host of the updated alias
+// must be a host of one of the host sources of its destination target.
+//
+// An attribute of a will be set to NULL in the database if the attribute in
+// a is the zero value and it is included in fieldMask. Clearing the
+// destination of an alias also clears its host.
+func (r *Repository) UpdateAlias(ctx context.Context, a *Alias, version uint32, fieldMask []string, opt ...Option) (*Alias, int, error) {
+	const op = "alias.(Repository).UpdateAlias"
+	switch {
+	case a == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.PublicId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case a.ScopeId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case len(fieldMask) == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	var dbMask, nullFields []string
+	var clearsDestination, setsHost bool
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && a.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && a.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && a.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && a.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("value", f):
+			if err := ValidateValue(ctx, a.Value); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			dbMask = append(dbMask, "value")
+		case strings.EqualFold("destinationid", f) && a.DestinationId == "":
+			nullFields = append(nullFields, "destination_id")
+			clearsDestination = true
+		case strings.EqualFold("destinationid", f) && a.DestinationId != "":
+			dbMask = append(dbMask, "destination_id")
+		case strings.EqualFold("hostid", f) && a.HostId == "":
+			nullFields = append(nullFields, "host_id")
+		case strings.EqualFold("hostid", f) && a.HostId != "":
+			dbMask = append(dbMask, "host_id")
+			setsHost = true
+
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	if clearsDestination && setsHost {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "host id requires a destination id")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	a = a.clone()
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedAlias *Alias
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedAlias = a.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedAlias,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if rowsUpdated == 1 {
+				// The destination or the host may not be in the field mask,
+				// so the host is validated against the stored alias.
+				updated := allocAlias()
+				updated.PublicId = a.PublicId
+				if err := reader.LookupByPublicId(ctx, updated); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if err := validateHost(ctx, reader, updated); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s: alias value %s or name %s already exists", a.PublicId, a.Value, a.Name)))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", a.PublicId)))
+	}
+
+	return returnedAlias, rowsUpdated, nil
+}
+
+// LookupAlias returns the Alias for id. Returns nil, nil if no Alias is found
+// for id.
+func (r *Repository) LookupAlias(ctx context.Context, id string, opt ...Option) (*Alias, error) {
+	const op = "alias.(Repository).LookupAlias"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	a := allocAlias()
+	a.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	return a, nil
+}
+
+// LookupAliasByValue returns the Alias with the value, which is normalized
+// before the lookup. Returns nil, nil if no Alias is found for the value.
+func (r *Repository) LookupAliasByValue(ctx context.Context, value string, opt ...Option) (*Alias, error) {
+	const op = "alias.(Repository).LookupAliasByValue"
+	value = NormalizeValue(value)
+	if value == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no value")
+	}
+	a := allocAlias()
+	if err := r.reader.LookupWhere(ctx, a, "value = ?", []interface{}{value}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", value)))
+	}
+	return a, nil
+}
+
+// ListAliases returns a slice of Aliases for the scope IDs. WithLimit is the
+// only option supported.
+func (r *Repository) ListAliases(ctx context.Context, scopeIds []string, opt ...Option) ([]*Alias, error) {
+	const op = "alias.(Repository).ListAliases"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var aliases []*Alias
+	err := r.reader.SearchWhere(ctx, &aliases, "scope_id in (?)", []interface{}{scopeIds}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return aliases, nil
+}
+
+// DeleteAlias deletes id from the repository returning a count of the number
+// of records deleted.
+func (r *Repository) DeleteAlias(ctx context.Context, id string, opt ...Option) (int, error) {
+	const op = "alias.(Repository).DeleteAlias"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+
+	a := allocAlias()
+	a.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteAlias := a.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deleteAlias, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", a.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
+
+// validateHost returns an error if the host of a is not a host of one of
+// the host sources of the destination of a.
+func validateHost(ctx context.Context, r db.Reader, a *Alias) error {
+	const op = "alias.validateHost"
+	if a.HostId == "" {
+		return nil
+	}
+	rows, err := r.Query(ctx, hostInDestinationQuery, []interface{}{
+		sql.Named("target_id", a.DestinationId),
+		sql.Named("host_id", a.HostId),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("host %s is not a host of the host sources of target %s", a.HostId, a.DestinationId))
+	}
+	return nil
+}
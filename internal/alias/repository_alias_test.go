@@ -0,0 +1,407 @@
+package alias
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/alias/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cat := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	hosts := static.TestHosts(t, conn, cat.GetPublicId(), 2)
+	h, otherHost := hosts[0], hosts[1]
+	hs := static.TestSets(t, conn, cat.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	tests := []struct {
+		name      string
+		in        *Alias
+		opts      []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-alias",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded-alias",
+			in:        &Alias{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "valid.alias", WithName("valid"), WithDescription("desc"))
+				require.NoError(t, err)
+				return a
+			}(),
+		},
+		{
+			name: "valid-with-destination-and-host",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "prod.postgres.primary", WithDestinationId(tar.GetPublicId()), WithHostId(h.GetPublicId()))
+				require.NoError(t, err)
+				return a
+			}(),
+		},
+		{
+			name: "valid-with-public-id",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "with.public.id")
+				require.NoError(t, err)
+				return a
+			}(),
+			opts: []Option{WithPublicId(AliasPrefix + "_1234567890")},
+		},
+		{
+			name: "wrong-public-id-prefix",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "wrong.prefix")
+				require.NoError(t, err)
+				return a
+			}(),
+			opts:      []Option{WithPublicId("ttcp_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+		{
+			name: "not-global-scope",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, prj.GetPublicId(), "project.alias")
+				require.NoError(t, err)
+				return a
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-value",
+			in: &Alias{
+				Alias: &store.Alias{
+					ScopeId: scope.Global.String(),
+					Value:   "Not_A_Dns_Name",
+				},
+			},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "host-without-destination",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "host.only")
+				require.NoError(t, err)
+				a.HostId = h.GetPublicId()
+				return a
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "host-not-in-destination-host-sources",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "other.host", WithDestinationId(tar.GetPublicId()), WithHostId(otherHost.GetPublicId()))
+				require.NoError(t, err)
+				return a
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "unknown-destination",
+			in: func() *Alias {
+				a, err := NewAlias(ctx, scope.Global.String(), "unknown.destination", WithDestinationId("ttcp_1234567890"))
+				require.NoError(t, err)
+				return a
+			}(),
+			wantIsErr: errors.NotSpecificIntegrity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAlias(ctx, tt.in, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.NotSame(tt.in, got)
+			assert.Empty(tt.in.PublicId)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(tt.in.Value, got.Value)
+			assert.Equal(got.CreateTime, got.UpdateTime)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("duplicate-value", func(t *testing.T) {
+		assert := assert.New(t)
+		a, err := NewAlias(ctx, scope.Global.String(), "Duplicate.Value")
+		require.NoError(t, err)
+		_, err = repo.CreateAlias(ctx, a)
+		require.NoError(t, err)
+
+		a, err = NewAlias(ctx, scope.Global.String(), "duplicate.value.")
+		require.NoError(t, err)
+		got, err := repo.CreateAlias(ctx, a)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err: %q got: %q", errors.NotUnique, err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_LookupAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	a := TestAlias(t, conn, "lookup.alias")
+
+	t.Run("by-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAlias(ctx, a.GetPublicId())
+		require.NoError(err)
+		assert.Equal(a.GetValue(), got.GetValue())
+
+		got, err = repo.LookupAlias(ctx, AliasPrefix+"_1234567890")
+		require.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAlias(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("by-value", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAliasByValue(ctx, "Lookup.Alias")
+		require.NoError(err)
+		assert.Equal(a.GetPublicId(), got.GetPublicId())
+
+		got, err = repo.LookupAliasByValue(ctx, "unknown.alias")
+		require.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAliasByValue(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_UpdateAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cat := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	hosts := static.TestHosts(t, conn, cat.GetPublicId(), 2)
+	h, otherHost := hosts[0], hosts[1]
+	hs := static.TestSets(t, conn, cat.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	t.Run("value-and-destination", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, conn, "update.alias")
+		a.Value = "updated.alias"
+		a.DestinationId = tar.GetPublicId()
+		a.HostId = h.GetPublicId()
+		got, n, err := repo.UpdateAlias(ctx, a, a.Version, []string{"Value", "DestinationId", "HostId"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal("updated.alias", got.GetValue())
+		assert.Equal(tar.GetPublicId(), got.GetDestinationId())
+		assert.Equal(h.GetPublicId(), got.GetHostId())
+		assert.Equal(a.Version+1, got.Version)
+
+		got.DestinationId = ""
+		got.HostId = ""
+		got, n, err = repo.UpdateAlias(ctx, got, got.Version, []string{"DestinationId", "HostId"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Empty(got.GetDestinationId())
+		assert.Empty(got.GetHostId())
+	})
+
+	t.Run("host-not-in-destination-host-sources", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, conn, "other.host", WithDestinationId(tar.GetPublicId()))
+		a.HostId = otherHost.GetPublicId()
+		_, _, err := repo.UpdateAlias(ctx, a, a.Version, []string{"HostId"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		got, err := repo.LookupAlias(ctx, a.GetPublicId())
+		require.NoError(err)
+		assert.Empty(got.GetHostId())
+	})
+
+	t.Run("clear-destination-and-set-host", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, conn, "clear.destination", WithDestinationId(tar.GetPublicId()))
+		a.DestinationId = ""
+		a.HostId = h.GetPublicId()
+		_, _, err := repo.UpdateAlias(ctx, a, a.Version, []string{"DestinationId", "HostId"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("invalid-value", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, conn, "invalid.update")
+		a.Value = ""
+		_, _, err := repo.UpdateAlias(ctx, a, a.Version, []string{"Value"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("invalid-field-mask", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, conn, "invalid.mask")
+		_, _, err := repo.UpdateAlias(ctx, a, a.Version, []string{"ScopeId"})
+		assert.True(errors.Match(errors.T(errors.InvalidFieldMask), err))
+		_, _, err = repo.UpdateAlias(ctx, a, a.Version, nil)
+		assert.True(errors.Match(errors.T(errors.EmptyFieldMask), err))
+	})
+
+	t.Run("duplicate-value", func(t *testing.T) {
+		assert := assert.New(t)
+		TestAlias(t, conn, "taken.alias")
+		a := TestAlias(t, conn, "not.taken.alias")
+		a.Value = "taken.alias"
+		_, _, err := repo.UpdateAlias(ctx, a, a.Version, []string{"Value"})
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+}
+
+func TestRepository_ListAliases(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	TestAlias(t, conn, "first.alias")
+	TestAlias(t, conn, "second.alias")
+
+	got, err := repo.ListAliases(ctx, []string{scope.Global.String()})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = repo.ListAliases(ctx, []string{scope.Global.String()}, WithLimit(1))
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = repo.ListAliases(ctx, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestRepository_DeleteAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	a := TestAlias(t, conn, "delete.alias")
+	n, err := repo.DeleteAlias(ctx, a.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = repo.DeleteAlias(ctx, a.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = repo.DeleteAlias(ctx, "")
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestAlias_DeletedDestination(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	a := TestAlias(t, conn, "deleted.destination", WithDestinationId(tar.GetPublicId()))
+
+	_, err = rw.Exec(ctx, "delete from target where public_id = ?", []interface{}{tar.GetPublicId()})
+	require.NoError(t, err)
+
+	got, err := repo.LookupAlias(ctx, a.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Empty(t, got.GetDestinationId())
+}
+
+func TestRepository_DeleteTargetWithHostAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	targetRepo, err := target.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cat := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, cat.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, cat.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	a, err := NewAlias(ctx, scope.Global.String(), "pinned.host", WithDestinationId(tar.GetPublicId()), WithHostId(h.GetPublicId()))
+	require.NoError(t, err)
+	a, err = repo.CreateAlias(ctx, a)
+	require.NoError(t, err)
+
+	n, err := targetRepo.DeleteTarget(ctx, tar.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, err := repo.LookupAlias(ctx, a.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Empty(t, got.GetDestinationId())
+	assert.Empty(t, got.GetHostId())
+}
@@ -0,0 +1,30 @@
+package alias
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAlias creates an alias in the global scope with the provided value in
+// the provided DB. Name, description, destination id and host id are
+// supported options. If any errors are encountered during the creation of the
+// alias, the test will fail.
+func TestAlias(t testing.TB, conn *db.DB, value string, opt ...Option) *Alias {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+
+	a, err := NewAlias(ctx, scope.Global.String(), value, opt...)
+	require.NoError(err)
+	id, err := newAliasId(ctx)
+	require.NoError(err)
+	a.PublicId = id
+
+	w := db.New(conn)
+	require.NoError(w.Create(ctx, a))
+	return a
+}
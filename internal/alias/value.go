@@ -0,0 +1,43 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// MaxValueLength is the maximum length of the value of an alias, which is
+// the maximum length of a DNS name.
+const MaxValueLength = 253
+
+// labelRegexp matches a label of a DNS name: up to 63 lowercase letters,
+// digits and hyphens, not starting or ending with a hyphen.
+var labelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// NormalizeValue returns the value in the form it is stored in: lowercase
+// and without a trailing dot.
+func NormalizeValue(v string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), ".")
+}
+
+// ValidateValue returns an error if the value is not a valid alias value. A
+// valid value is a DNS-style name made of lowercase labels separated by dots.
+// As labels cannot contain underscores, a value is never a public id.
+func ValidateValue(ctx context.Context, v string) error {
+	const op = "alias.ValidateValue"
+	switch {
+	case v == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing value")
+	case len(v) > MaxValueLength:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("value is longer than %d characters", MaxValueLength))
+	}
+	for _, l := range strings.Split(v, ".") {
+		if !labelRegexp.MatchString(l) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("value %q is not a lowercase dns name", v))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package alias
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "prod", wantErr: false},
+		{value: "prod.postgres.primary", wantErr: false},
+		{value: "prod-1.postgres-2.a", wantErr: false},
+		{value: "0.example", wantErr: false},
+		{value: strings.Repeat("a", 63) + ".example", wantErr: false},
+		{value: "", wantErr: true},
+		{value: "Prod.postgres", wantErr: true},
+		{value: "prod..postgres", wantErr: true},
+		{value: ".prod", wantErr: true},
+		{value: "prod.", wantErr: true},
+		{value: "-prod.postgres", wantErr: true},
+		{value: "prod-.postgres", wantErr: true},
+		{value: "prod postgres", wantErr: true},
+		{value: "ttcp_1234567890", wantErr: true},
+		{value: strings.Repeat("a", 64) + ".example", wantErr: true},
+		{value: strings.Repeat("a.", 127), wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateValue(ctx, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "prod.postgres.primary", NormalizeValue(" Prod.Postgres.Primary. "))
+	assert.Equal(t, "prod", NormalizeValue("prod"))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
@@ -107,6 +108,10 @@ type structInfo struct {
 	// listing
 	recursiveListing bool
 
+	// paginatedListing indicates that the collection supports pagination when
+	// listing
+	paginatedListing bool
+
 	// extraFields allows specifying extra options that will be created for a
 	// given type, e.g. arguments only valid for one call or purpose and not
 	// conveyed within the item itself
@@ -156,6 +161,11 @@ var inputStructs = []*structInfo{
 		outFile:     "plugins/plugin_info.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:             &scopes.KeyRewrapStatus{},
+		outFile:             "scopes/key_rewrap_status.gen.go",
+		createResponseTypes: []string{ReadResponseType},
+	},
 	{
 		inProto: &scopes.Scope{},
 		outFile: "scopes/scope.gen.go",
@@ -213,6 +223,7 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
+		paginatedListing:    true,
 	},
 	// Group related resources
 	{
@@ -242,6 +253,23 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
 	},
+	// Alias related resources
+	{
+		inProto: &aliases.Alias{},
+		outFile: "aliases/alias.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			commonCreateTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "aliases",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
 	// Role related resources
 	{
 		inProto:     &roles.Grant{},
@@ -423,6 +451,7 @@ var inputStructs = []*structInfo{
 		pluralResourceName:  "auth-tokens",
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
+		paginatedListing:    true,
 	},
 	// Credentials
 	{
@@ -549,6 +578,29 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:     &credentials.SshCertificateAttributes{},
+		outFile:     "credentials/ssh_certificate_attributes.gen.go",
+		subtypeName: "SshCertificateCredential",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Username",
+				SkipDefault: true,
+			},
+			{
+				Name:        "Certificate",
+				SkipDefault: true,
+			},
+			{
+				Name:        "PrivateKey",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "Credential",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto:     &credentials.JsonAttributes{},
 		outFile:     "credentials/json_attributes.gen.go",
@@ -564,6 +616,11 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:             &credentials.RotationConfig{},
+		outFile:             "credentials/rotation_config.gen.go",
+		createResponseTypes: []string{ReadResponseType},
+	},
 	{
 		inProto: &credentials.Credential{},
 		outFile: "credentials/credential.gen.go",
@@ -666,6 +723,7 @@ var inputStructs = []*structInfo{
 		parentTypeName:      "host-catalog",
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		paginatedListing:    true,
 	},
 	{
 		inProto:        &hosts.StaticHostAttributes{},
@@ -676,6 +734,10 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto: &hostsets.HealthCheck{},
+		outFile: "hostsets/health_check.gen.go",
+	},
 	{
 		inProto: &hostsets.HostSet{},
 		outFile: "hostsets/host_set.gen.go",
@@ -748,6 +810,15 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &targets.RdpTargetAttributes{},
+		outFile:        "targets/rdp_target_attributes.gen.go",
+		subtypeName:    "RdpTarget",
+		parentTypeName: "Target",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -824,6 +895,7 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
+		paginatedListing:    true,
 	},
 	{
 		inProto: &sessions.SessionState{},
@@ -853,6 +925,7 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		fieldFilter:         []string{"private_key"},
 		recursiveListing:    true,
+		paginatedListing:    true,
 	},
 	{
 		inProto: &workers.Certificate{},
@@ -49,13 +49,20 @@ type templateInput struct {
 	VersionEnabled        bool
 	CreateResponseTypes   []string
 	RecursiveListing      bool
+	PaginatedListing      bool
 }
 
 func fillTemplates() {
 	optionsMap := map[string]map[string]fieldInfo{}
 	inputMap := map[string]*structInfo{}
+	// A package's options support pagination if any of its structs do, as
+	// the last struct of a package is often an attributes struct.
+	paginatedMap := map[string]bool{}
 	for _, in := range inputStructs {
 		inputMap[in.generatedStructure.pkg] = in
+		if in.paginatedListing {
+			paginatedMap[in.generatedStructure.pkg] = true
+		}
 		outBuf := new(bytes.Buffer)
 		input := templateInput{
 			Name:                in.generatedStructure.name,
@@ -67,6 +74,7 @@ func fillTemplates() {
 			VersionEnabled:      in.versionEnabled,
 			CreateResponseTypes: in.createResponseTypes,
 			RecursiveListing:    in.recursiveListing,
+			PaginatedListing:    in.paginatedListing,
 		}
 		if in.packageOverride != "" {
 			input.Package = in.packageOverride
@@ -188,6 +196,7 @@ func fillTemplates() {
 			Package:          pkg,
 			Fields:           fields,
 			RecursiveListing: inputMap[pkg].recursiveListing,
+			PaginatedListing: paginatedMap[pkg],
 		}
 
 		if err := optionTemplate.Execute(outBuf, input); err != nil {
@@ -636,14 +645,29 @@ func (n {{ .Name }}DeleteResult) GetResponse() *api.Response {
 {{ end }}
 {{ if ( hasResponseType .CreateResponseTypes "list" ) }}
 type {{ .Name }}ListResult struct {
-	Items []*{{ .Name }}
+	Items []*{{ .Name }}{{ if .PaginatedListing }}
+	ResponseType string `, "`json:\"response_type,omitempty\"`", `
+	ListToken string `, "`json:\"list_token,omitempty\"`", `
+	RemovedIds []string `, "`json:\"removed_ids,omitempty\"`", `{{ end }}
 	response *api.Response
 }
 
 func (n {{ .Name }}ListResult) GetItems() []*{{ .Name }} {
 	return n.Items
 }
+{{ if .PaginatedListing }}
+func (n {{ .Name }}ListResult) GetResponseType() string {
+	return n.ResponseType
+}
+
+func (n {{ .Name }}ListResult) GetListToken() string {
+	return n.ListToken
+}
 
+func (n {{ .Name }}ListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+{{ end }}
 func (n {{ .Name }}ListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -700,6 +724,8 @@ type options struct {
 	withSkipCurlOutput bool
 	withFilter string
 	{{ if .RecursiveListing }} withRecursive bool {{ end }}
+	{{ if .PaginatedListing }} withPageSize uint32
+	withListToken string {{ end }}
 }
 
 func getDefaultOptions() options {
@@ -725,6 +751,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	}{{ if .RecursiveListing }}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
+	} {{ end }}{{ if .PaginatedListing }}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
 	} {{ end }}
 	return opts, apiOpts
 }
@@ -763,6 +795,22 @@ func WithRecursive(recurse bool) Option {
 		o.withRecursive = true
 	}
 }
+{{ end }}{{ if .PaginatedListing }}
+// WithPageSize tells the API to return at most the provided number of items
+// when listing, along with a list token to request the next page.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to continue or refresh the listing the
+// provided list token was returned by.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
 {{ end }}
 {{ range $fieldIndex, $field := .Fields }}
 {{ $subtypes := (removeDups $field.SubtypeNames ) }}
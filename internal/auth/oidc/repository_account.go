@@ -129,6 +129,34 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
+// LookupAccountBySubject will look up the account with the subject for the
+// current issuer of the auth method. If the account is not found, it will
+// return nil, nil.
+func (r *Repository) LookupAccountBySubject(ctx context.Context, withAuthMethodId, withSubject string) (*Account, error) {
+	const op = "oidc.(Repository).LookupAccountBySubject"
+	if withAuthMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	if withSubject == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing subject")
+	}
+	am, err := r.lookupAuthMethod(ctx, withAuthMethodId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", withAuthMethodId))
+	}
+	a := AllocAccount()
+	if err := r.reader.LookupWhere(ctx, a, "auth_method_id = ? and issuer = ? and subject = ?", []interface{}{withAuthMethodId, am.Issuer, withSubject}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s in auth method %s", withSubject, withAuthMethodId)))
+	}
+	return a, nil
+}
+
 // ListAccounts in an auth method and supports WithLimit option.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "oidc.(Repository).ListAccounts"
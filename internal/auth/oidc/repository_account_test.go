@@ -540,6 +540,82 @@ func TestRepository_LookupAccount(t *testing.T) {
 	}
 }
 
+func TestRepository_LookupAccountBySubject(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	ctx := context.Background()
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	authMethod := TestAuthMethod(
+		t, conn, databaseWrapper, org.PublicId, ActivePrivateState,
+		"alice-rp", "fido",
+		WithSigningAlgs(RS256),
+		WithIssuer(TestConvertToUrls(t, "https://www.alice.com")[0]),
+		WithApiUrl(TestConvertToUrls(t, "https://www.alice.com/callback")[0]),
+	)
+	account := TestAccount(t, conn, authMethod, "spiffe://mesh/ns/prod/sa/deployer")
+
+	tests := []struct {
+		name         string
+		authMethodId string
+		subject      string
+		want         *Account
+		wantIsErr    errors.Code
+	}{
+		{
+			name:      "With no auth method id",
+			subject:   "spiffe://mesh/ns/prod/sa/deployer",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:         "With no subject",
+			authMethodId: authMethod.GetPublicId(),
+			wantIsErr:    errors.InvalidParameter,
+		},
+		{
+			name:         "With non existing auth method",
+			authMethodId: "amoidc_1234567890",
+			subject:      "spiffe://mesh/ns/prod/sa/deployer",
+			wantIsErr:    errors.RecordNotFound,
+		},
+		{
+			name:         "With non existing subject",
+			authMethodId: authMethod.GetPublicId(),
+			subject:      "spiffe://mesh/ns/prod/sa/unknown",
+		},
+		{
+			name:         "With existing subject",
+			authMethodId: authMethod.GetPublicId(),
+			subject:      "spiffe://mesh/ns/prod/sa/deployer",
+			want:         account,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(ctx, rw, rw, kmsCache)
+			assert.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.LookupAccountBySubject(ctx, tt.authMethodId, tt.subject)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			assert.EqualValues(tt.want, got)
+		})
+	}
+}
+
 func TestRepository_DeleteAccount(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -0,0 +1,43 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_oidc_method", authMethodRewrapFn)
+}
+
+func authMethodRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "oidc.authMethodRewrapFn"
+	var authMethods []*AuthMethod
+	if err := reader.SearchWhere(ctx, &authMethods, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list auth methods to rewrap"))
+	}
+	if len(authMethods) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, am := range authMethods {
+		if err := am.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := am.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, am, []string{"CtClientSecret", "ClientSecretHmac", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(am.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -142,6 +142,26 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
+// LookupAccountByLoginName will look up the account with the login name in
+// the auth method. If the account is not found, it will return nil, nil.
+func (r *Repository) LookupAccountByLoginName(ctx context.Context, withAuthMethodId, withLoginName string) (*Account, error) {
+	const op = "password.(Repository).LookupAccountByLoginName"
+	if withAuthMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	if withLoginName == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name")
+	}
+	a := allocAccount()
+	if err := r.reader.LookupWhere(ctx, a, "auth_method_id = ? and login_name = ?", []interface{}{withAuthMethodId, withLoginName}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s in auth method %s", withLoginName, withAuthMethodId)))
+	}
+	return a, nil
+}
+
 // ListAccounts in an auth method and supports WithLimit option.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "password.(Repository).ListAccounts"
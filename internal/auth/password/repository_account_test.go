@@ -396,6 +396,71 @@ func TestRepository_LookupAccount(t *testing.T) {
 	}
 }
 
+func TestRepository_LookupAccountByLoginName(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	authMethods := TestAuthMethods(t, conn, org.GetPublicId(), 2)
+	account := TestAccount(t, conn, authMethods[0].GetPublicId(), "name1")
+
+	tests := []struct {
+		name         string
+		authMethodId string
+		loginName    string
+		want         *Account
+		wantIsErr    errors.Code
+	}{
+		{
+			name:      "With no auth method id",
+			loginName: "name1",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:         "With no login name",
+			authMethodId: authMethods[0].GetPublicId(),
+			wantIsErr:    errors.InvalidParameter,
+		},
+		{
+			name:         "With non existing login name",
+			authMethodId: authMethods[0].GetPublicId(),
+			loginName:    "name2",
+		},
+		{
+			name:         "With login name in another auth method",
+			authMethodId: authMethods[1].GetPublicId(),
+			loginName:    "name1",
+		},
+		{
+			name:         "With existing login name",
+			authMethodId: authMethods[0].GetPublicId(),
+			loginName:    "name1",
+			want:         account,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			assert.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.LookupAccountByLoginName(context.Background(), tt.authMethodId, tt.loginName)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			assert.EqualValues(tt.want, got)
+		})
+	}
+}
+
 func TestRepository_DeleteAccount(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
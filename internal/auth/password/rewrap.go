@@ -0,0 +1,43 @@
+package password
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_password_argon2_cred", argon2CredentialRewrapFn)
+}
+
+func argon2CredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "password.argon2CredentialRewrapFn"
+	var creds []*Argon2Credential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list credentials to rewrap"))
+	}
+	if len(creds) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range creds {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, c, []string{"CtSalt", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.PrivateId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/pagination"
 )
 
 var (
@@ -31,6 +32,7 @@ type options struct {
 	withLimit                    int
 	withStatus                   Status
 	withPublicId                 string
+	withPage                     *pagination.Page
 }
 
 func getDefaultOptions() options {
@@ -91,3 +93,11 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithPage provides an option to list a page of auth tokens. It overrides
+// the WithLimit option.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 var (
@@ -188,6 +190,11 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 // value is not included in the returned AuthToken. If no valid auth token is found nil, nil is returned.
 // All options are ignored.
 //
+// The token is read from the read replica of the database when the reader of the repository is a
+// db.ReplicaReader, and from the primary when it is not found on the replica. Since the replica may lag
+// behind the primary, a token which is expired or stale on the replica is read again from the primary
+// before being invalidated, and a token deleted from the primary may still be valid for the replication lag.
+//
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
 	const op = "authtoken.(Repository).ValidateToken"
@@ -198,33 +205,44 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
 
-	retAT, err := r.LookupAuthToken(ctx, id, withTokenValue())
-	if err != nil {
-		retAT = nil
-		if errors.IsNotFoundError(err) {
+	var retAT *AuthToken
+	var sinceLastAccessed time.Duration
+	var invalid bool
+	for _, lookupCtx := range []context.Context{db.WithReplicaReads(ctx), ctx} {
+		var err error
+		retAT, err = r.LookupAuthToken(lookupCtx, id, withTokenValue())
+		if err != nil {
+			retAT = nil
+			if errors.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if retAT == nil {
 			return nil, nil
 		}
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	if retAT == nil {
-		return nil, nil
-	}
 
-	// If the token is too old or stale invalidate it and return nothing.
-	exp, err := ptypes.Timestamp(retAT.GetExpirationTime().GetTimestamp())
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("expiration time"), errors.WithCode(errors.InvalidTimeStamp))
-	}
-	lastAccessed, err := ptypes.Timestamp(retAT.GetApproximateLastAccessTime().GetTimestamp())
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
-	}
+		// If the token is too old or stale invalidate it and return nothing.
+		exp, err := ptypes.Timestamp(retAT.GetExpirationTime().GetTimestamp())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+		}
+		lastAccessed, err := ptypes.Timestamp(retAT.GetApproximateLastAccessTime().GetTimestamp())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
+		}
 
-	now := time.Now()
-	sinceLastAccessed := now.Sub(lastAccessed) + timeSkew
-	// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
-	// if it can be eliminated.
-	if now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= r.timeToStaleDuration {
+		now := time.Now()
+		sinceLastAccessed = now.Sub(lastAccessed) + timeSkew
+		// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
+		// if it can be eliminated.
+		invalid = now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= r.timeToStaleDuration
+		if !invalid {
+			break
+		}
+	}
+	var err error
+	if invalid {
 		// If the token has expired or has become too stale, delete it from the DB.
 		_, err = r.writer.DoTx(
 			ctx,
@@ -288,7 +306,7 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 }
 
 // ListAuthTokens lists auth tokens in the given scopes and supports the
-// WithLimit option.
+// WithLimit and WithPage options.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
 	const op = "authtoken.(Repository).ListAuthTokens"
 	if len(withScopeIds) == 0 {
@@ -298,8 +316,20 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 
 	// use the view, to bring in the required account columns. Just don't forget
 	// to convert them before returning them
+	where, args := "auth_account_id in (select public_id from auth_account where scope_id in (?))", []interface{}{withScopeIds}
+	dbOpts := []db.Option{db.WithLimit(opts.withLimit)}
+	if opts.withPage != nil {
+		if pageWhere, pageArgs := opts.withPage.Where(); pageWhere != "" {
+			where += " and " + pageWhere
+			args = append(args, pageArgs...)
+		}
+		if opts.withPage.Size != 0 {
+			dbOpts[0] = db.WithLimit(opts.withPage.Size)
+		}
+		dbOpts = append(dbOpts, db.WithOrder(opts.withPage.OrderBy()))
+	}
 	var atvs []*authTokenView
-	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []interface{}{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &atvs, where, args, dbOpts...); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	authTokens := make([]*AuthToken, 0, len(atvs))
@@ -312,6 +342,17 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 	return authTokens, nil
 }
 
+// ListDeletedIds lists the public ids of the auth tokens of the scopes
+// deleted after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, scopeIds []string, since time.Time) ([]string, error) {
+	const op = "authtoken.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.AuthToken, scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // DeleteAuthToken deletes the token with the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Option) (int, error) {
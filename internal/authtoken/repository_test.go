@@ -493,6 +493,66 @@ func TestRepository_ValidateToken(t *testing.T) {
 	}
 }
 
+// staleReplicaReader is a replica on which the auth tokens have not been
+// accessed for a day.
+type staleReplicaReader struct {
+	db.Reader
+}
+
+func (r staleReplicaReader) LookupByPublicId(ctx context.Context, resource db.ResourcePublicIder, opt ...db.Option) error {
+	if err := r.Reader.LookupByPublicId(ctx, resource, opt...); err != nil {
+		return err
+	}
+	if atv, ok := resource.(*authTokenView); ok {
+		atv.ApproximateLastAccessTime = timestamp.New(time.Now().Add(-24 * time.Hour))
+	}
+	return nil
+}
+
+func TestRepository_ValidateToken_replica(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	t.Run("not-replicated", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		replicaConn, _ := db.TestSetup(t, "postgres")
+		reader, err := db.NewReplicaReader(ctx, db.New(replicaConn), rw)
+		require.NoError(err)
+		repo, err := NewRepository(reader, rw, kms)
+		require.NoError(err)
+
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(at.GetPublicId(), got.GetPublicId())
+	})
+
+	t.Run("stale-on-replica", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		reader, err := db.NewReplicaReader(ctx, staleReplicaReader{Reader: rw}, rw)
+		require.NoError(err)
+		repo, err := NewRepository(reader, rw, kms, WithTokenTimeToStaleDuration(time.Hour))
+		require.NoError(err)
+
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(at.GetPublicId(), got.GetPublicId())
+
+		// The token was not deleted from the primary.
+		got, err = repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+}
+
 func TestRepository_ValidateToken_expired(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
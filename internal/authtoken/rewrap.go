@@ -0,0 +1,44 @@
+package authtoken
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_token", authTokenRewrapFn)
+}
+
+func authTokenRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "authtoken.authTokenRewrapFn"
+	var tokens []*AuthToken
+	if err := reader.SearchWhere(ctx, &tokens, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list auth tokens to rewrap"))
+	}
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, at := range tokens {
+		if err := at.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := at.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		// Tokens are not replicated, so they don't need oplog entries.
+		n, err := writer.Update(ctx, at, []string{"CtToken", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(at.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
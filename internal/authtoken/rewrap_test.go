@@ -0,0 +1,52 @@
+package authtoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthTokenRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	at := TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+	stored := allocAuthToken()
+	stored.PublicId = at.GetPublicId()
+	require.NoError(t, rw.LookupByPublicId(ctx, stored))
+	previousKeyId := stored.GetKeyId()
+
+	require.NoError(t, kmsCache.RotateKeys(ctx, org.GetPublicId()))
+
+	n, err := authTokenRewrapFn(ctx, previousKeyId, org.GetPublicId(), rw, rw, kmsCache)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	rewrapped := allocAuthToken()
+	rewrapped.PublicId = at.GetPublicId()
+	require.NoError(t, rw.LookupByPublicId(ctx, rewrapped))
+	assert.NotEqual(t, previousKeyId, rewrapped.GetKeyId())
+	assert.NotEqual(t, stored.GetCtToken(), rewrapped.GetCtToken())
+
+	// The rewrapped token is still valid.
+	got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, at.GetPublicId(), got.GetPublicId())
+
+	// Nothing is left to rewrap.
+	n, err = authTokenRewrapFn(ctx, previousKeyId, org.GetPublicId(), rw, rw, kmsCache)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
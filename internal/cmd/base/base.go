@@ -35,6 +35,8 @@ const (
 	EnabledPluginHostLoopback
 	EnabledPluginHostAws
 	EnabledPluginHostAzure
+	EnabledPluginHostGcp
+	EnabledPluginCredentialLoopback
 )
 
 func (e EnabledPlugin) String() string {
@@ -45,6 +47,10 @@ func (e EnabledPlugin) String() string {
 		return "AWS"
 	case EnabledPluginHostAzure:
 		return "Azure"
+	case EnabledPluginHostGcp:
+		return "GCP"
+	case EnabledPluginCredentialLoopback:
+		return "CredentialLoopback"
 	default:
 		return ""
 	}
@@ -95,6 +101,7 @@ type Command struct {
 	flagTLSInsecure   bool
 
 	flagFormat           string
+	FlagColumns          []string
 	FlagToken            string
 	FlagTokenName        string
 	FlagKeyringType      string
@@ -469,7 +476,14 @@ func (c *Command) FlagSet(bit FlagSetBit) *FlagSets {
 					Default:    "table",
 					EnvVar:     EnvBoundaryCLIFormat,
 					Completion: complete.PredictSet("table", "json", "yaml"),
-					Usage:      "Print the output in the given format. Valid formats are \"table\" or \"json\".",
+					Usage:      "Print the output in the given format. Valid formats are \"table\", \"json\", or \"yaml\".",
+				})
+
+				f.VarFlag(&VarFlag{
+					Name:       "columns",
+					Value:      newColumnsValue(&c.FlagColumns),
+					Completion: complete.PredictAnything,
+					Usage:      "A comma-separated list of fields to print when using the \"table\" format, e.g. \"id,name,scope_id\". Nested fields can be selected with a dot, e.g. \"scope.name\". When set, list results are printed as a table with one row per item and one column per field. Unknown fields are rejected.",
 				})
 			}
 		}
@@ -0,0 +1,304 @@
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/globals"
+)
+
+// columnFields contains the names of the fields of resources, which are the
+// fields that can be selected with the columns flag.
+var columnFields = map[string]struct{}{
+	globals.IdField:                                     {},
+	globals.VersionField:                                {},
+	globals.NameField:                                   {},
+	globals.DescriptionField:                            {},
+	globals.CreatedTimeField:                            {},
+	globals.UpdatedTimeField:                            {},
+	globals.TypeField:                                   {},
+	globals.AttributesField:                             {},
+	globals.ScopeIdField:                                {},
+	globals.ScopeField:                                  {},
+	globals.AuthMethodIdField:                           {},
+	globals.AccountIdField:                              {},
+	globals.UserIdField:                                 {},
+	globals.IsPrimaryField:                              {},
+	globals.AuthorizedActionsField:                      {},
+	globals.AuthorizedCollectionActionsField:            {},
+	globals.ExpirationTimeField:                         {},
+	globals.ApproximateLastUsedTimeField:                {},
+	globals.MembersField:                                {},
+	globals.MemberIdsField:                              {},
+	globals.HostCatalogIdField:                          {},
+	globals.HostSetIdsField:                             {},
+	globals.HostSourceIdsField:                          {},
+	globals.HostIdsField:                                {},
+	globals.PrincipalIdsField:                           {},
+	globals.PrincipalsField:                             {},
+	globals.GrantScopeIdField:                           {},
+	globals.GrantsField:                                 {},
+	globals.GrantStringsField:                           {},
+	globals.PrimaryAuthMethodIdField:                    {},
+	globals.TargetIdField:                               {},
+	globals.HostIdField:                                 {},
+	globals.HostSetIdField:                              {},
+	globals.HostSetsField:                               {},
+	globals.HostSourcesField:                            {},
+	globals.AuthTokenIdField:                            {},
+	globals.EndpointField:                               {},
+	globals.CertificateField:                            {},
+	globals.TerminationReasonField:                      {},
+	globals.StatusField:                                 {},
+	globals.StatesField:                                 {},
+	globals.SessionConnectionLimitField:                 {},
+	globals.SessionIdleTimeoutSecondsField:              {},
+	globals.SessionMaxSecondsField:                      {},
+	globals.WorkerFilterField:                           {},
+	globals.AccountIdsField:                             {},
+	globals.AccountsField:                               {},
+	globals.LoginNameField:                              {},
+	globals.FullNameField:                               {},
+	globals.PrimaryAccountIdField:                       {},
+	globals.EmailField:                                  {},
+	globals.ManagedGroupIdsField:                        {},
+	globals.FilterField:                                 {},
+	globals.CredentialStoreIdField:                      {},
+	globals.ApplicationCredentialSourceIdsField:         {},
+	globals.ApplicationCredentialSourcesField:           {},
+	globals.BrokeredCredentialSourceIdsField:            {},
+	globals.BrokeredCredentialSourcesField:              {},
+	globals.PreferredEndpointsField:                     {},
+	globals.SyncIntervalSecondsField:                    {},
+	globals.HealthCheckField:                            {},
+	globals.PluginIdField:                               {},
+	globals.PluginField:                                 {},
+	globals.PluginNameField:                             {},
+	globals.IpAddressesField:                            {},
+	globals.DnsNamesField:                               {},
+	globals.SecretsHmacField:                            {},
+	globals.ExternalIdField:                             {},
+	globals.InjectedApplicationCredentialSourceIdsField: {},
+	globals.InjectedApplicationCredentialSourcesField:   {},
+	globals.ConnectionsField:                            {},
+	globals.CredentialTypeField:                         {},
+	globals.CredentialMappingOverridesField:             {},
+	globals.LastStatusTimeField:                         {},
+	globals.AddressField:                                {},
+	globals.CanonicalAddressField:                       {},
+	globals.TagsField:                                   {},
+	globals.CanonicalTagsField:                          {},
+	globals.ConfigTagsField:                             {},
+	globals.ApiTagsField:                                {},
+	globals.ConfigurationField:                          {},
+	globals.WorkerGeneratedAuthTokenField:               {},
+	globals.WorkerProvidedConfigurationField:            {},
+	globals.ActiveConnectionCountField:                  {},
+	globals.ControllerGeneratedActivationToken:          {},
+	globals.ReleaseVersionField:                         {},
+	globals.ValueField:                                  {},
+	globals.DestinationIdField:                          {},
+}
+
+// validateColumn checks that the given field is the name of a field of
+// resources. Only the top-level field of nested fields is checked, as nested
+// fields, such as type specific attributes, vary between resources.
+func validateColumn(field string) error {
+	top := strings.SplitN(field, ".", 2)[0]
+	if _, ok := columnFields[top]; !ok {
+		return fmt.Errorf("unknown field %q", top)
+	}
+	return nil
+}
+
+// columnsValue is the flag.Value of the columns flag. It splits the given
+// comma-separated fields and rejects unknown fields.
+type columnsValue struct {
+	target *[]string
+}
+
+func newColumnsValue(target *[]string) *columnsValue {
+	*target = nil
+	return &columnsValue{target: target}
+}
+
+func (c *columnsValue) Set(val string) error {
+	for _, f := range strings.Split(val, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if err := validateColumn(f); err != nil {
+			return err
+		}
+		*c.target = append(*c.target, f)
+	}
+	return nil
+}
+
+func (c *columnsValue) Get() interface{} { return *c.target }
+func (c *columnsValue) String() string   { return strings.Join(*c.target, ",") }
+func (c *columnsValue) Example() string  { return "string" }
+func (c *columnsValue) Hidden() bool     { return false }
+
+// columnHeaders contains the headers of the columns whose field names do not
+// translate into a header word by word.
+var columnHeaders = map[string]string{
+	globals.SyncIntervalSecondsField:       "Sync Interval (Seconds)",
+	globals.SessionMaxSecondsField:         "Session Max (Seconds)",
+	globals.SessionIdleTimeoutSecondsField: "Session Idle Timeout (Seconds)",
+	globals.ActiveConnectionCountField:     "Active Connections",
+}
+
+// headerWords contains the words of field names which are not simply
+// capitalized in headers.
+var headerWords = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"api":  "API",
+	"dns":  "DNS",
+	"hmac": "HMAC",
+	"ip":   "IP",
+}
+
+// ColumnHeader returns the header of the column displaying the given field.
+// Nested fields are separated by a dot, e.g. "scope.id".
+func ColumnHeader(field string) string {
+	parts := strings.Split(field, ".")
+	for i, p := range parts {
+		if h, ok := columnHeaders[p]; ok {
+			parts[i] = h
+			continue
+		}
+		words := strings.Split(p, "_")
+		for j, w := range words {
+			if h, ok := headerWords[w]; ok {
+				words[j] = h
+				continue
+			}
+			if w == "" {
+				continue
+			}
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			words[j] = string(r)
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+	return strings.Join(parts, " ")
+}
+
+// PrintColumnItem prints the fields given by the columns flag of the given
+// item as a table to the UI
+func (c *Command) PrintColumnItem(resp *api.Response) bool {
+	if resp == nil {
+		c.PrintCliError(errors.New("Error formatting as table: no response given to item formatter"))
+		return false
+	}
+	var item map[string]interface{}
+	if err := decodeColumnJson(resp.Body.Bytes(), &item); err != nil {
+		c.PrintCliError(fmt.Errorf("Error unmarshaling response body at format time: %w", err))
+		return false
+	}
+	c.UI.Output(FormatColumns(c.FlagColumns, []map[string]interface{}{item}))
+	return true
+}
+
+// PrintColumnItems prints the fields given by the columns flag of the given
+// items as a table to the UI, one row per item
+func (c *Command) PrintColumnItems(resp *api.Response) bool {
+	if resp == nil {
+		c.PrintCliError(errors.New("Error formatting as table: no response given to items formatter"))
+		return false
+	}
+	var input struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := decodeColumnJson(resp.Body.Bytes(), &input); err != nil {
+		c.PrintCliError(fmt.Errorf("Error unmarshaling response body at format time: %w", err))
+		return false
+	}
+	if len(input.Items) == 0 {
+		c.UI.Output("No items found")
+		return true
+	}
+	c.UI.Output(FormatColumns(c.FlagColumns, input.Items))
+	return true
+}
+
+// FormatColumns formats the given fields of the items as a table, one row per
+// item and one column per field. Nested fields are separated by a dot, e.g.
+// "scope.id". Fields which are not present in an item are left empty.
+func FormatColumns(fields []string, items []map[string]interface{}) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	headers := make([]string, 0, len(fields))
+	for _, f := range fields {
+		headers = append(headers, ColumnHeader(f))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, item := range items {
+		row := make([]string, 0, len(fields))
+		for _, f := range fields {
+			row = append(row, columnValue(item, f))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// columnValue returns the string representation of the given, possibly
+// nested, field of the item.
+func columnValue(item map[string]interface{}, field string) string {
+	var v interface{} = item
+	for _, p := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if v, ok = m[p]; !ok {
+			return ""
+		}
+	}
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return fmt.Sprintf("%t", t)
+	case []interface{}:
+		vals := make([]string, 0, len(t))
+		for _, e := range t {
+			switch e.(type) {
+			case map[string]interface{}, []interface{}:
+				b, _ := json.Marshal(e)
+				vals = append(vals, string(b))
+			default:
+				vals = append(vals, fmt.Sprintf("%v", e))
+			}
+		}
+		return strings.Join(vals, ",")
+	default:
+		b, _ := json.Marshal(t)
+		return string(b)
+	}
+}
+
+func decodeColumnJson(b []byte, v interface{}) error {
+	if len(b) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
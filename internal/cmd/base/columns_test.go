@@ -0,0 +1,139 @@
+package base
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnHeader(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "id", want: "ID"},
+		{field: "name", want: "Name"},
+		{field: "scope_id", want: "Scope ID"},
+		{field: "created_time", want: "Created Time"},
+		{field: "scope.name", want: "Scope Name"},
+		{field: "attributes.ip_addresses", want: "Attributes IP Addresses"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.want, ColumnHeader(tt.field))
+		})
+	}
+}
+
+func TestFormatColumns(t *testing.T) {
+	var items []map[string]interface{}
+	require.NoError(t, decodeColumnJson([]byte(`[
+		{"id": "ttcp_1234567890", "name": "web", "version": 3, "scope": {"id": "p_1234567890"}, "attributes": {"default_port": 22}, "host_source_ids": ["hsst_1", "hsst_2"]},
+		{"id": "ttcp_0987654321", "version": 12, "scope": {"id": "p_1234567890"}}
+	]`), &items))
+
+	got := FormatColumns([]string{"id", "name", "version", "scope.id", "attributes.default_port", "host_source_ids"}, items)
+	want := "" +
+		"ID               Name  Version  Scope ID      Attributes Default Port  Host Source IDs\n" +
+		"ttcp_1234567890  web   3        p_1234567890  22                       hsst_1,hsst_2\n" +
+		"ttcp_0987654321        12       p_1234567890"
+	assert.Equal(t, want, trimLines(got))
+}
+
+func TestYamlFormatter(t *testing.T) {
+	output := struct {
+		StatusCode int             `json:"status_code"`
+		Item       json.RawMessage `json:"item"`
+	}{
+		StatusCode: 200,
+		Item:       json.RawMessage(`{"id":"ttcp_1234567890","name":"123","version":3,"attributes":{"default_port":22},"authorized_actions":["read","update"],"description":null}`),
+	}
+	got, err := YamlFormatter{}.Format(output)
+	require.NoError(t, err)
+	want := `status_code: 200
+item:
+  id: ttcp_1234567890
+  name: "123"
+  version: 3
+  attributes:
+    default_port: 22
+  authorized_actions:
+    - read
+    - update
+  description: null`
+	assert.Equal(t, want, string(got))
+}
+
+// trimLines removes the padding the tabwriter adds to the empty trailing cells
+// of a row.
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestColumnsValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "single",
+			args: []string{"id"},
+			want: []string{"id"},
+		},
+		{
+			name: "comma-separated",
+			args: []string{"id, name,scope.name", "attributes.default_port"},
+			want: []string{"id", "name", "scope.name", "attributes.default_port"},
+		},
+		{
+			name:    "unknown-field",
+			args:    []string{"id,nmae"},
+			wantErr: `unknown field "nmae"`,
+		},
+		{
+			name:    "unknown-nested-field",
+			args:    []string{"scoep.id"},
+			wantErr: `unknown field "scoep"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			v := newColumnsValue(&got)
+			var err error
+			for _, a := range tt.args {
+				if err = v.Set(a); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestColumnsFlag(t *testing.T) {
+	c := NewCommand(nil)
+	f := c.FlagSet(FlagSetOutputFormat)
+	require.NoError(t, f.Parse([]string{"-columns", "id,name"}))
+	assert.Equal(t, []string{"id", "name"}, c.FlagColumns)
+
+	c = NewCommand(nil)
+	f = c.FlagSet(FlagSetOutputFormat)
+	err := f.Parse([]string{"-columns", "id,nmae"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "nmae"`)
+}
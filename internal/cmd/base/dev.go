@@ -3,6 +3,7 @@ package base
 import (
 	"context"
 	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/boundary/testing/dbtest"
 	capoidc "github.com/hashicorp/cap/oidc"
 	"github.com/hashicorp/go-multierror"
@@ -214,10 +216,9 @@ func (b *Server) CreateDevOidcAuthMethod(ctx context.Context) error {
 			if purpose != "api" {
 				continue
 			}
-			b.DevOidcSetup.hostAddr, b.DevOidcSetup.callbackPort, err = net.SplitHostPort(ln.Config.Address)
+			b.DevOidcSetup.hostAddr, b.DevOidcSetup.callbackPort, err = util.SplitHostPort(ln.Config.Address)
 			if err != nil {
-				if strings.Contains(err.Error(), "missing port") {
-					b.DevOidcSetup.hostAddr = ln.Config.Address
+				if errors.Is(err, util.ErrMissingPort) {
 					// Use the default API port in the callback
 					b.DevOidcSetup.callbackPort = "9200"
 				} else {
@@ -1,6 +1,7 @@
 package base
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/go-wordwrap"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 )
 
 // This is adapted from the code in the strings package for TrimSpace
@@ -179,8 +181,8 @@ func WrapMap(prefixSpaces, maxLengthOverride int, input map[string]interface{})
 // used, all other options are ignored.
 func (c *Command) PrintApiError(in *api.Error, contextStr string, opt ...Option) {
 	opts := getOpts(opt...)
-	switch Format(c.UI) {
-	case "json":
+	switch format := Format(c.UI); format {
+	case "json", "yaml":
 		output := struct {
 			Context  string          `json:"context,omitempty"`
 			Status   int             `json:"status"`
@@ -190,7 +192,12 @@ func (c *Command) PrintApiError(in *api.Error, contextStr string, opt ...Option)
 			Status:   in.Response().StatusCode(),
 			ApiError: in.Response().Body.Bytes(),
 		}
-		b, _ := JsonFormatter{}.Format(output)
+		var b []byte
+		if format == "yaml" {
+			b, _ = YamlFormatter{}.Format(output)
+		} else {
+			b, _ = JsonFormatter{}.Format(output)
+		}
 		c.UI.Error(string(b))
 
 	default:
@@ -273,6 +280,14 @@ func (c *Command) PrintCliError(err error) {
 		}
 		b, _ := JsonFormatter{}.Format(output)
 		c.UI.Error(string(b))
+	case "yaml":
+		output := struct {
+			Error string `json:"error"`
+		}{
+			Error: err.Error(),
+		}
+		b, _ := YamlFormatter{}.Format(output)
+		c.UI.Error(string(b))
 	}
 }
 
@@ -309,10 +324,73 @@ func (c *Command) PrintJson(input json.RawMessage, opt ...Option) bool {
 
 // PrintJsonItems prints the given items to the UI in JSON format
 func (c *Command) PrintJsonItems(resp *api.Response) bool {
+	output, err := itemsOutput(resp)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+		return false
+	}
+	b, err := JsonFormatter{}.Format(output)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+		return false
+	}
+	c.UI.Output(string(b))
+	return true
+}
+
+// PrintYamlItem prints the given item to the UI in YAML format
+func (c *Command) PrintYamlItem(resp *api.Response, opt ...Option) bool {
 	if resp == nil {
-		c.PrintCliError(errors.New("Error formatting as JSON: no response given to items formatter"))
+		c.PrintCliError(errors.New("Error formatting as YAML: no response given to item formatter"))
 		return false
 	}
+	if r := resp.HttpResponse(); r != nil {
+		opt = append(opt, WithStatusCode(r.StatusCode))
+	}
+	return c.PrintYaml(resp.Body.Bytes(), opt...)
+}
+
+// PrintYaml prints the given raw JSON in our common format, converted to YAML
+func (c *Command) PrintYaml(input json.RawMessage, opt ...Option) bool {
+	opts := getOpts(opt...)
+	output := struct {
+		StatusCode int             `json:"status_code,omitempty"`
+		Item       json.RawMessage `json:"item,omitempty"`
+	}{
+		StatusCode: opts.withStatusCode,
+		Item:       input,
+	}
+	b, err := YamlFormatter{}.Format(output)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error formatting as YAML: %w", err))
+		return false
+	}
+	c.UI.Output(string(b))
+	return true
+}
+
+// PrintYamlItems prints the given items to the UI in YAML format
+func (c *Command) PrintYamlItems(resp *api.Response) bool {
+	output, err := itemsOutput(resp)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error formatting as YAML: %w", err))
+		return false
+	}
+	b, err := YamlFormatter{}.Format(output)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error formatting as YAML: %w", err))
+		return false
+	}
+	c.UI.Output(string(b))
+	return true
+}
+
+// itemsOutput builds the common output of a list response: its status code
+// and items.
+func itemsOutput(resp *api.Response) (interface{}, error) {
+	if resp == nil {
+		return nil, errors.New("no response given to items formatter")
+	}
 	// First we need to grab the items out. The reason is that if we simply
 	// embed the raw message as with PrintJsonItem above, it will have {"items":
 	// {"items": []}}. However, we decode into a RawMessage which makes it much
@@ -323,24 +401,16 @@ func (c *Command) PrintJsonItems(resp *api.Response) bool {
 	var input inMsg
 	if resp.Body.Bytes() != nil {
 		if err := json.Unmarshal(resp.Body.Bytes(), &input); err != nil {
-			c.PrintCliError(fmt.Errorf("Error unmarshaling response body at format time: %w", err))
-			return false
+			return nil, fmt.Errorf("error unmarshaling response body at format time: %w", err)
 		}
 	}
-	output := struct {
+	return struct {
 		StatusCode int             `json:"status_code"`
 		Items      json.RawMessage `json:"items"`
 	}{
 		StatusCode: resp.HttpResponse().StatusCode,
 		Items:      input.Items,
-	}
-	b, err := JsonFormatter{}.Format(output)
-	if err != nil {
-		c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
-		return false
-	}
-	c.UI.Output(string(b))
-	return true
+	}, nil
 }
 
 // An output formatter for json output of an object
@@ -350,6 +420,44 @@ func (j JsonFormatter) Format(data interface{}) ([]byte, error) {
 	return json.Marshal(data)
 }
 
+// An output formatter for yaml output of an object. The object is first
+// marshaled to JSON so that the output uses the same field names and ordering
+// as the JSON format.
+type YamlFormatter struct{}
+
+func (y YamlFormatter) Format(data interface{}) ([]byte, error) {
+	j, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, so decoding it into a node keeps the key order
+	// and the exact representation of numbers.
+	var node yaml.Node
+	if err := yaml.Unmarshal(j, &node); err != nil {
+		return nil, err
+	}
+	resetYamlStyle(&node)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// resetYamlStyle clears the flow and quoting styles of the decoded JSON so
+// the node is encoded in block style.
+func resetYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetYamlStyle(n)
+	}
+}
+
 func Format(ui cli.Ui) string {
 	switch t := ui.(type) {
 	case *BoundaryUI:
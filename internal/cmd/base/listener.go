@@ -3,16 +3,19 @@ package base
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	// We must import sha512 so that it registers with the runtime so that
 	// certificates that use it can be parsed.
 	_ "crypto/sha512"
 	"crypto/tls"
+	"crypto/x509"
 
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/hashicorp/go-secure-stdlib/reloadutil"
 	"github.com/mitchellh/cli"
@@ -28,6 +31,10 @@ type ServerListener struct {
 	ClusterListener net.Listener
 	ProxyListener   net.Listener
 	OpsListener     net.Listener
+
+	// ClientAuth is the client certificate configuration of an api
+	// listener, if any.
+	ClientAuth *config.ListenerClientAuth
 }
 
 type WorkerAuthInfo struct {
@@ -50,7 +57,9 @@ var BuiltinListeners = map[string]ListenerFactory{
 
 // New creates a new listener of the given type with the given
 // configuration. The type is looked up in the BuiltinListeners map.
-func NewListener(l *listenerutil.ListenerConfig, ui cli.Ui) (net.Listener, map[string]string, reloadutil.ReloadFunc, error) {
+//
+// Supported options: WithClientAuth
+func NewListener(l *listenerutil.ListenerConfig, ui cli.Ui, opt ...Option) (net.Listener, map[string]string, reloadutil.ReloadFunc, error) {
 	f, ok := BuiltinListeners[l.Type]
 	if !ok {
 		return nil, nil, nil, fmt.Errorf("unknown listener type: %q", l.Type)
@@ -114,9 +123,39 @@ func NewListener(l *listenerutil.ListenerConfig, ui cli.Ui) (net.Listener, map[s
 		return nil, nil, nil, err
 	}
 
+	if ca := getOpts(opt...).withClientAuth; ca != nil {
+		if err := configureClientAuth(tlsConfig, ca); err != nil {
+			return nil, nil, nil, fmt.Errorf("error configuring client authentication for listener at address %q: %w", finalAddr, err)
+		}
+		props["tls_client_auth"] = ca.Mode
+	}
+
 	return tls.NewListener(ln, tlsConfig), props, reloadFunc, nil
 }
 
+// configureClientAuth sets up the verification of client certificates against
+// the configured CA bundle.
+func configureClientAuth(tlsConfig *tls.Config, ca *config.ListenerClientAuth) error {
+	switch ca.Mode {
+	case config.ClientAuthRequired:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	case config.ClientAuthOptional:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil
+	}
+	pem, err := ioutil.ReadFile(ca.ClientCaFile)
+	if err != nil {
+		return fmt.Errorf("error reading client ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no certificates found in client ca file %q", ca.ClientCaFile)
+	}
+	tlsConfig.ClientCAs = pool
+	return nil
+}
+
 func tcpListenerFactory(purpose string, l *listenerutil.ListenerConfig, ui cli.Ui) (string, net.Listener, error) {
 	if l.Address == "" {
 		switch purpose {
@@ -133,9 +172,9 @@ func tcpListenerFactory(purpose string, l *listenerutil.ListenerConfig, ui cli.U
 		}
 	}
 
-	host, port, err := net.SplitHostPort(l.Address)
+	host, port, err := util.SplitHostPort(l.Address)
 	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
+		if errors.Is(err, util.ErrMissingPort) {
 			switch purpose {
 			case "api":
 				port = "9200"
@@ -148,7 +187,6 @@ func tcpListenerFactory(purpose string, l *listenerutil.ListenerConfig, ui cli.U
 			default:
 				return "", nil, errors.New("no purpose provided for listener and no port discoverable")
 			}
-			host = l.Address
 		} else {
 			return "", nil, fmt.Errorf("error splitting host/port: %w", err)
 		}
@@ -164,8 +202,9 @@ func tcpListenerFactory(purpose string, l *listenerutil.ListenerConfig, ui cli.U
 	bindProto := "tcp"
 
 	// If they've passed 0.0.0.0, we only want to bind on IPv4
-	// rather than golang's dual stack default
-	if strings.HasPrefix(l.Address, "0.0.0.0:") || l.Address == "0.0.0.0" {
+	// rather than golang's dual stack default. Passing :: binds on both IPv4
+	// and IPv6.
+	if host == "0.0.0.0" {
 		bindProto = "tcp4"
 	}
 
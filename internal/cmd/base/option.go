@@ -1,6 +1,7 @@
 package base
 
 import (
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/sdk/pbs/plugin"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
@@ -41,6 +42,8 @@ type Options struct {
 	withHostPlugin                     func() (string, plugin.HostPluginServiceClient)
 	withEventGating                    bool
 	withSkipWorkerAuthKmsInstantiation bool
+	withListenerClientAuth             []*config.ListenerClientAuth
+	withClientAuth                     *config.ListenerClientAuth
 }
 
 func getDefaultOptions() Options {
@@ -197,3 +200,19 @@ func WithSkipWorkerAuthKmsInstantiation(with bool) Option {
 		o.withSkipWorkerAuthKmsInstantiation = with
 	}
 }
+
+// WithListenerClientAuth provides the client certificate configuration of the
+// listeners being set up, in the same order as the listeners.
+func WithListenerClientAuth(ca []*config.ListenerClientAuth) Option {
+	return func(o *Options) {
+		o.withListenerClientAuth = ca
+	}
+}
+
+// WithClientAuth provides the client certificate configuration of a single
+// listener.
+func WithClientAuth(ca *config.ListenerClientAuth) Option {
+	return func(o *Options) {
+		o.withClientAuth = ca
+	}
+}
@@ -3,6 +3,7 @@ package base
 import (
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/stretchr/testify/assert"
 )
@@ -124,4 +125,20 @@ func Test_GetOpts(t *testing.T) {
 		opts := getOpts(WithEventGating(true))
 		assert.True(opts.withEventGating)
 	})
+	t.Run("WithListenerClientAuth", func(t *testing.T) {
+		assert := assert.New(t)
+		ca := []*config.ListenerClientAuth{nil, {Mode: config.ClientAuthRequired}}
+		opts := getOpts(WithListenerClientAuth(ca))
+		testOpts := getDefaultOptions()
+		testOpts.withListenerClientAuth = ca
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithClientAuth", func(t *testing.T) {
+		assert := assert.New(t)
+		ca := &config.ListenerClientAuth{Mode: config.ClientAuthOptional}
+		opts := getOpts(WithClientAuth(ca))
+		testOpts := getDefaultOptions()
+		testOpts.withClientAuth = ca
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
@@ -434,6 +435,59 @@ func TestSetupWorkerPublicAddress(t *testing.T) {
 			expErrStr:        "",
 			expPublicAddress: "127.0.0.1:8080",
 		},
+		{
+			name: "using flag value with bare ipv6",
+			inputConfig: &config.Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Worker: &config.Worker{},
+			},
+			inputFlagValue:   "2001:db8::1",
+			expErr:           false,
+			expErrStr:        "",
+			expPublicAddress: "[2001:db8::1]:9202",
+		},
+		{
+			name: "using flag value with bracketed ipv6",
+			inputConfig: &config.Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Worker: &config.Worker{},
+			},
+			inputFlagValue:   "[2001:db8::1]",
+			expErr:           false,
+			expErrStr:        "",
+			expPublicAddress: "[2001:db8::1]:9202",
+		},
+		{
+			name: "using flag value with ipv6:port",
+			inputConfig: &config.Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Worker: &config.Worker{},
+			},
+			inputFlagValue:   "[2001:db8::1]:8080",
+			expErr:           false,
+			expErrStr:        "",
+			expPublicAddress: "[2001:db8::1]:8080",
+		},
+		{
+			name: "read address from listeners ipv6 only",
+			inputConfig: &config.Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{
+						{Purpose: []string{"proxy"}, Address: "[::]"},
+					},
+				},
+				Worker: &config.Worker{},
+			},
+			expErr:           false,
+			expErrStr:        "",
+			expPublicAddress: "[::]:9202",
+		},
 		{
 			name: "using flag value to point to env var with ip only",
 			inputConfig: &config.Config{
@@ -557,9 +611,9 @@ func TestSetupWorkerPublicAddress(t *testing.T) {
 				},
 				Worker: &config.Worker{},
 			},
-			inputFlagValue:   "abc::123",
+			inputFlagValue:   "abc::xyz",
 			expErr:           true,
-			expErrStr:        "Error splitting public adddress host/port: address abc::123: too many colons in address",
+			expErrStr:        "Error splitting public adddress host/port: address abc::xyz: too many colons in address",
 			expPublicAddress: "",
 		},
 		{
@@ -595,3 +649,19 @@ func TestSetupWorkerPublicAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_RegisterCredentialPlugin(t *testing.T) {
+	t.Parallel()
+	type testClient struct {
+		plgpb.CredentialPluginServiceClient
+	}
+
+	s := Server{}
+	require.NoError(t, s.RegisterCredentialPlugin("example", testClient{}))
+	require.NoError(t, s.RegisterCredentialPlugin("other", testClient{}))
+	assert.Len(t, s.CredentialPlugins, 2)
+
+	err := s.RegisterCredentialPlugin("example", testClient{})
+	require.EqualError(t, err, `credential plugin "example" is already registered`)
+	assert.Len(t, s.CredentialPlugins, 2)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util"
 	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
 	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
 	"github.com/hashicorp/boundary/version"
@@ -133,6 +134,12 @@ type Server struct {
 
 	EnabledPlugins []EnabledPlugin
 	HostPlugins    map[string]plgpb.HostPluginServiceClient
+	// RotationPlugins are the static credential rotation plugins keyed by
+	// the rotator name used in rotation configs
+	RotationPlugins map[string]plgpb.RotationPluginServiceClient
+	// CredentialPlugins are the credential store plugins keyed by plugin
+	// name
+	CredentialPlugins map[string]plgpb.CredentialPluginServiceClient
 
 	DevOidcSetup oidcSetup
 
@@ -141,9 +148,19 @@ type Server struct {
 	DatabaseMaxIdleConnections      *int
 	DatabaseConnMaxIdleTimeDuration *time.Duration
 
+	// The url and connection pool settings of the read replica of the
+	// database, if any
+	DatabaseReadUrl                     string
+	DatabaseReadMaxOpenConnections      int
+	DatabaseReadMaxIdleConnections      *int
+	DatabaseReadConnMaxIdleTimeDuration *time.Duration
+
 	DevDatabaseCleanupFunc func() error
 
 	Database *db.DB
+	// ReadDatabase is the read replica of the database. It is nil unless a
+	// read url is configured.
+	ReadDatabase *db.DB
 
 	// StatusGracePeriodDuration represents the period of time (as a
 	// duration) that the controller will wait before marking
@@ -424,7 +441,12 @@ func (b *Server) PrintInfo(ui cli.Ui) {
 	}
 }
 
-func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allowedPurposes []string) error {
+// SetupListeners creates the listeners of the given configuration.
+//
+// Supported options: WithListenerClientAuth
+func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allowedPurposes []string, opt ...Option) error {
+	opts := getOpts(opt...)
+
 	// Initialize the listeners
 	b.Listeners = make([]*ServerListener, 0, len(config.Listeners))
 	// Make sure we close everything before we exit
@@ -476,7 +498,8 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 			}
 		}
 
-		ln, props, reloadFunc, err := NewListener(lnConfig, ui)
+		clientAuth := opts.listenerClientAuth(i)
+		ln, props, reloadFunc, err := NewListener(lnConfig, ui, WithClientAuth(clientAuth))
 		if err != nil {
 			return fmt.Errorf("Error initializing listener of type %s: %w", lnConfig.Type, err)
 		}
@@ -523,7 +546,8 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 		props["max_request_duration"] = lnConfig.MaxRequestDuration.String()
 
 		serverListener := &ServerListener{
-			Config: lnConfig,
+			Config:     lnConfig,
+			ClientAuth: clientAuth,
 		}
 
 		switch purpose {
@@ -689,20 +713,40 @@ func (b *Server) OpenAndSetServerDatabase(ctx context.Context, dialect string) e
 	return nil
 }
 
+// OpenAndSetServerReadDatabase opens a database connection to the read
+// replica of the database and sets it to the Server's `ReadDatabase` field. The
+// connection pool settings of the replica must be set on the Server object
+// beforehand.
+func (b *Server) OpenAndSetServerReadDatabase(ctx context.Context, dialect string) error {
+	dbase, err := b.openDatabase(ctx, dialect, b.DatabaseReadUrl,
+		db.WithMaxOpenConnections(b.DatabaseReadMaxOpenConnections),
+		db.WithMaxIdleConnections(b.DatabaseReadMaxIdleConnections),
+		db.WithConnMaxIdleTimeDuration(b.DatabaseReadConnMaxIdleTimeDuration),
+	)
+	if err != nil {
+		return err
+	}
+	b.ReadDatabase = dbase
+	return nil
+}
+
 // OpenDatabase creates a database connection with the given URL and returns it to the caller.
 // It supports various configuration options - The values must be set on the Server object
 // beforehand.
 func (b *Server) OpenDatabase(ctx context.Context, dialect, url string) (*db.DB, error) {
+	return b.openDatabase(ctx, dialect, url,
+		db.WithMaxOpenConnections(b.DatabaseMaxOpenConnections),
+		db.WithMaxIdleConnections(b.DatabaseMaxIdleConnections),
+		db.WithConnMaxIdleTimeDuration(b.DatabaseConnMaxIdleTimeDuration),
+	)
+}
+
+func (b *Server) openDatabase(ctx context.Context, dialect, url string, opts ...db.Option) (*db.DB, error) {
 	dbType, err := db.StringToDbType(dialect)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db object with dialect %s: %w", dialect, err)
 	}
 
-	opts := []db.Option{
-		db.WithMaxOpenConnections(b.DatabaseMaxOpenConnections),
-		db.WithMaxIdleConnections(b.DatabaseMaxIdleConnections),
-		db.WithConnMaxIdleTimeDuration(b.DatabaseConnMaxIdleTimeDuration),
-	}
 	if os.Getenv("BOUNDARY_DISABLE_GORM_FORMATTER") == "" {
 		opts = append(opts, db.WithGormFormatter(b.Logger))
 	}
@@ -778,11 +822,10 @@ func (b *Server) SetupWorkerPublicAddress(conf *config.Config, flagValue string)
 		}
 	}
 
-	host, port, err := net.SplitHostPort(conf.Worker.PublicAddr)
+	host, port, err := util.SplitHostPort(conf.Worker.PublicAddr)
 	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
+		if errors.Is(err, util.ErrMissingPort) {
 			port = "9202"
-			host = conf.Worker.PublicAddr
 		} else {
 			return fmt.Errorf("Error splitting public adddress host/port: %w", err)
 		}
@@ -852,3 +895,40 @@ func (s *Server) SetStatusGracePeriodDuration(value time.Duration) {
 
 	s.StatusGracePeriodDuration = result
 }
+
+// RegisterRotationPlugin makes the static credential rotation plugin
+// available under the provided name, which rotation configs use as their
+// rotator. It is an error to register two plugins under the same name.
+func (b *Server) RegisterRotationPlugin(name string, plg plgpb.RotationPluginServiceClient) error {
+	if b.RotationPlugins == nil {
+		b.RotationPlugins = make(map[string]plgpb.RotationPluginServiceClient)
+	}
+	if _, ok := b.RotationPlugins[name]; ok {
+		return fmt.Errorf("rotation plugin %q is already registered", name)
+	}
+	b.RotationPlugins[name] = plg
+	return nil
+}
+
+// listenerClientAuth returns the client certificate configuration of the i-th
+// listener, if any.
+func (o Options) listenerClientAuth(i int) *config.ListenerClientAuth {
+	if i < len(o.withListenerClientAuth) {
+		return o.withListenerClientAuth[i]
+	}
+	return nil
+}
+
+// RegisterCredentialPlugin makes the credential store plugin available under
+// the provided name. It is an error to register two plugins under the same
+// name.
+func (b *Server) RegisterCredentialPlugin(name string, plg plgpb.CredentialPluginServiceClient) error {
+	if b.CredentialPlugins == nil {
+		b.CredentialPlugins = make(map[string]plgpb.CredentialPluginServiceClient)
+	}
+	if _, ok := b.CredentialPlugins[name]; ok {
+		return fmt.Errorf("credential plugin %q is already registered", name)
+	}
+	b.CredentialPlugins[name] = plg
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/aliasescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -144,6 +145,42 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"aliases": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"aliases create": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"aliases update": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"aliases read": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"aliases delete": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"aliases list": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -328,6 +365,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database maintenance": func() (cli.Command, error) {
+			return &database.MaintenanceCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
 
 		"credential-libraries": func() (cli.Command, error) {
 			return &credentiallibrariescmd.Command{
@@ -478,6 +520,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"credentials create ssh-certificate": func() (cli.Command, error) {
+			return &credentialscmd.SshCertificateCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"credentials create json": func() (cli.Command, error) {
 			return &credentialscmd.JsonCommand{
 				Command: base.NewCommand(ui),
@@ -502,12 +550,36 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"credentials update ssh-certificate": func() (cli.Command, error) {
+			return &credentialscmd.SshCertificateCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"credentials update json": func() (cli.Command, error) {
 			return &credentialscmd.JsonCommand{
 				Command: base.NewCommand(ui),
 				Func:    "update",
 			}, nil
 		},
+		"credentials set-rotation": func() (cli.Command, error) {
+			return &credentialscmd.RotationCommand{
+				Command: base.NewCommand(ui),
+				Func:    "set-rotation",
+			}, nil
+		},
+		"credentials read-rotation": func() (cli.Command, error) {
+			return &credentialscmd.RotationCommand{
+				Command: base.NewCommand(ui),
+				Func:    "read-rotation",
+			}, nil
+		},
+		"credentials remove-rotation": func() (cli.Command, error) {
+			return &credentialscmd.RotationCommand{
+				Command: base.NewCommand(ui),
+				Func:    "remove-rotation",
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
@@ -910,6 +982,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes rotate-keys": func() (cli.Command, error) {
+			return &scopescmd.KeysCommand{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-keys",
+			}, nil
+		},
+		"scopes read-key-rewrap-status": func() (cli.Command, error) {
+			return &scopescmd.KeysCommand{
+				Command: base.NewCommand(ui),
+				Func:    "read-key-rewrap-status",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
@@ -982,6 +1066,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"targets create rdp": func() (cli.Command, error) {
+			return &targetscmd.RdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets update": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
@@ -1000,6 +1090,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update rdp": func() (cli.Command, error) {
+			return &targetscmd.RdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets add-host-sources": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
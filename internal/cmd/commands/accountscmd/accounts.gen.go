@@ -278,6 +278,11 @@ func (c *Command) Run(args []string) int {
 				return base.CommandCliError
 			}
 
+		case "yaml":
+			if ok := c.PrintYamlItem(resp); !ok {
+				return base.CommandCliError
+			}
+
 		case "table":
 			c.UI.Output("The delete operation completed successfully.")
 		}
@@ -291,7 +296,18 @@ func (c *Command) Run(args []string) int {
 				return base.CommandCliError
 			}
 
+		case "yaml":
+			if ok := c.PrintYamlItems(resp); !ok {
+				return base.CommandCliError
+			}
+
 		case "table":
+			if len(c.FlagColumns) > 0 {
+				if ok := c.PrintColumnItems(resp); !ok {
+					return base.CommandCliError
+				}
+				break
+			}
 			c.UI.Output(c.printListTable(items))
 		}
 
@@ -301,12 +317,23 @@ func (c *Command) Run(args []string) int {
 
 	switch base.Format(c.UI) {
 	case "table":
+		if len(c.FlagColumns) > 0 {
+			if ok := c.PrintColumnItem(resp); !ok {
+				return base.CommandCliError
+			}
+			break
+		}
 		c.UI.Output(printItemTable(item, resp))
 
 	case "json":
 		if ok := c.PrintJsonItem(resp); !ok {
 			return base.CommandCliError
 		}
+
+	case "yaml":
+		if ok := c.PrintYamlItem(resp); !ok {
+			return base.CommandCliError
+		}
 	}
 
 	return base.CommandSuccess
@@ -0,0 +1,213 @@
+package aliasescmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+}
+
+type extraCmdVars struct {
+	flagValue         string
+	flagDestinationId string
+	flagHostId        string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"value", "destination-id", "host-id"},
+		"update": {"value", "destination-id", "host-id"},
+	}
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "value":
+			f.StringVar(&base.StringVar{
+				Name:   "value",
+				Target: &c.flagValue,
+				Usage:  `The value of the alias, a DNS name such as "prod.postgres.primary" which can be used in place of the target ID when connecting.`,
+			})
+		case "destination-id":
+			f.StringVar(&base.StringVar{
+				Name:   "destination-id",
+				Target: &c.flagDestinationId,
+				Usage:  "The ID of the target the alias refers to.",
+			})
+		case "host-id":
+			f.StringVar(&base.StringVar{
+				Name:   "host-id",
+				Target: &c.flagHostId,
+				Usage:  "The ID of the host of the destination target to connect to when the alias is used. Requires a destination ID.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]aliases.Option) bool {
+	if c.Func == "create" && c.flagValue == "" {
+		c.UI.Error("Value must be passed in via -value")
+		return false
+	}
+
+	switch c.flagValue {
+	case "":
+	case "null":
+		c.UI.Error("Value cannot be cleared")
+		return false
+	default:
+		*opts = append(*opts, aliases.WithValue(c.flagValue))
+	}
+
+	switch c.flagDestinationId {
+	case "":
+	case "null":
+		*opts = append(*opts, aliases.DefaultDestinationId())
+	default:
+		*opts = append(*opts, aliases.WithDestinationId(c.flagDestinationId))
+	}
+
+	switch c.flagHostId {
+	case "":
+	case "null":
+		*opts = append(*opts, aliases.DefaultHostId())
+	default:
+		*opts = append(*opts, aliases.WithHostId(c.flagHostId))
+	}
+
+	return true
+}
+
+func (c *Command) printListTable(items []*aliases.Alias) string {
+	if len(items) == 0 {
+		return "No aliases found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Alias information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if item.Value != "" {
+			output = append(output,
+				fmt.Sprintf("    Value:               %s", item.Value),
+			)
+		}
+		if item.DestinationId != "" {
+			output = append(output,
+				fmt.Sprintf("    Destination ID:      %s", item.DestinationId),
+			)
+		}
+		if item.HostId != "" {
+			output = append(output,
+				fmt.Sprintf("    Host ID:             %s", item.HostId),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *aliases.Alias, resp *api.Response) string {
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Value != "" {
+		nonAttributeMap["Value"] = item.Value
+	}
+	if item.DestinationId != "" {
+		nonAttributeMap["Destination ID"] = item.DestinationId
+	}
+	if item.HostId != "" {
+		nonAttributeMap["Host ID"] = item.HostId
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Alias information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
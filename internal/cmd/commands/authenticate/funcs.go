@@ -36,6 +36,12 @@ func saveAndOrPrintToken(c *base.Command, result *authmethods.AuthenticateResult
 			return base.CommandCliError
 		}
 		return base.CommandSuccess
+
+	case "yaml":
+		if ok := c.PrintYamlItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+		return base.CommandSuccess
 	}
 
 	var gotErr bool
@@ -253,12 +253,23 @@ func (c *OidcCommand) Run(args []string) int {
 
 	switch base.Format(c.UI) {
 	case "table":
+		if len(c.FlagColumns) > 0 {
+			if ok := c.PrintColumnItem(resp); !ok {
+				return base.CommandCliError
+			}
+			break
+		}
 		c.UI.Output(printItemTable(item, resp))
 
 	case "json":
 		if ok := c.PrintJsonItem(resp); !ok {
 			return base.CommandCliError
 		}
+
+	case "yaml":
+		if ok := c.PrintYamlItem(resp); !ok {
+			return base.CommandCliError
+		}
 	}
 
 	return base.CommandSuccess
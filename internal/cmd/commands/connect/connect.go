@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/util"
 	targetspb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-secure-stdlib/base62"
@@ -139,6 +140,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary connect -target-id ttcp_1234567890"`,
 			"",
+			"  An alias of the target can be used in place of its ID:",
+			"",
+			`      $ boundary connect -target-id prod.postgres.primary`,
+			"",
 			"",
 		}) + c.Flags().Help()
 
@@ -172,7 +177,7 @@ func (c *Command) Flags() *base.FlagSets {
 	f.StringVar(&base.StringVar{
 		Name:   "target-id",
 		Target: &c.flagTargetId,
-		Usage:  "The ID of the target to authorize against. Cannot be used with -authz-token.",
+		Usage:  "The ID or alias of the target to authorize against. Cannot be used with -authz-token.",
 	})
 
 	f.StringVar(&base.StringVar{
@@ -425,14 +430,10 @@ func (c *Command) Run(args []string) (retCode int) {
 
 	c.connectionsLeft.Store(c.sessionAuthzData.ConnectionLimit)
 	workerAddr := c.sessionAuthzData.GetWorkerInfo()[0].GetAddress()
-	workerHost, _, err := net.SplitHostPort(workerAddr)
-	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
-			workerHost = workerAddr
-		} else {
-			c.PrintCliError(fmt.Errorf("Error splitting worker adddress host/port: %w", err))
-			return base.CommandUserError
-		}
+	workerHost, _, err := util.SplitHostPort(workerAddr)
+	if err != nil && !errors.Is(err, util.ErrMissingPort) {
+		c.PrintCliError(fmt.Errorf("Error splitting worker adddress host/port: %w", err))
+		return base.CommandUserError
 	}
 
 	tlsConf, err := ClientTlsConfig(c.sessionAuthzData, workerHost)
@@ -25,14 +25,25 @@ type sshPrivateKey struct {
 	consumed bool
 }
 
+type sshCertificate struct {
+	Username    string `mapstructure:"username"`
+	Certificate string `mapstructure:"certificate"`
+	PrivateKey  string `mapstructure:"private_key"`
+	Passphrase  string `mapstructure:"private_key_passphrase"`
+
+	raw      *targets.SessionCredential
+	consumed bool
+}
+
 type credentials struct {
 	usernamePassword []usernamePassword
 	sshPrivateKey    []sshPrivateKey
+	sshCertificate   []sshCertificate
 	unspecified      []*targets.SessionCredential
 }
 
 func (c credentials) unconsumedSessionCredentials() []*targets.SessionCredential {
-	out := make([]*targets.SessionCredential, 0, len(c.sshPrivateKey)+len(c.usernamePassword)+len(c.unspecified))
+	out := make([]*targets.SessionCredential, 0, len(c.sshPrivateKey)+len(c.sshCertificate)+len(c.usernamePassword)+len(c.unspecified))
 
 	// Unspecified credentials cannot be consumed
 	out = append(out, c.unspecified...)
@@ -42,6 +53,11 @@ func (c credentials) unconsumedSessionCredentials() []*targets.SessionCredential
 			out = append(out, c.raw)
 		}
 	}
+	for _, c := range c.sshCertificate {
+		if !c.consumed {
+			out = append(out, c.raw)
+		}
+	}
 	for _, c := range c.usernamePassword {
 		if !c.consumed {
 			out = append(out, c.raw)
@@ -62,6 +78,7 @@ func parseCredentials(creds []*targets.SessionCredential) (credentials, error) {
 
 		var upCred usernamePassword
 		var spkCred sshPrivateKey
+		var sshcCred sshCertificate
 		switch credential.Type(cred.CredentialSource.CredentialType) {
 		case credential.UsernamePasswordType:
 			// Decode attributes from credential struct
@@ -86,6 +103,18 @@ func parseCredentials(creds []*targets.SessionCredential) (credentials, error) {
 				out.sshPrivateKey = append(out.sshPrivateKey, spkCred)
 				continue
 			}
+
+		case credential.SshCertificateType:
+			// Decode attributes from credential struct
+			if err := mapstructure.Decode(cred.Credential, &sshcCred); err != nil {
+				return credentials{}, err
+			}
+
+			if sshcCred.Username != "" && sshcCred.Certificate != "" && sshcCred.PrivateKey != "" {
+				sshcCred.raw = cred
+				out.sshCertificate = append(out.sshCertificate, sshcCred)
+				continue
+			}
 		}
 
 		// Credential type is unspecified, make a best effort attempt to parse
@@ -103,6 +132,18 @@ func parseCredentials(creds []*targets.SessionCredential) (credentials, error) {
 					continue
 				}
 
+				// Attempt unmarshaling into ssh certificate creds, this has to
+				// happen before ssh private key creds since an ssh certificate
+				// secret also contains a username and private key
+				if err := mapstructure.Decode(cred.Secret.Decoded, &sshcCred); err != nil {
+					return credentials{}, err
+				}
+				if sshcCred.Username != "" && sshcCred.Certificate != "" && sshcCred.PrivateKey != "" {
+					sshcCred.raw = cred
+					out.sshCertificate = append(out.sshCertificate, sshcCred)
+					continue
+				}
+
 				// Attempt unmarshaling into ssh private key creds
 				if err := mapstructure.Decode(cred.Secret.Decoded, &spkCred); err != nil {
 					return credentials{}, err
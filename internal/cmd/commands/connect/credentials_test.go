@@ -30,6 +30,17 @@ var (
 		},
 	}
 
+	typedSshCertificate = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			CredentialType: string(credential.SshCertificateType),
+		},
+		Credential: map[string]interface{}{
+			"username":    "user",
+			"certificate": "my-cert",
+			"private_key": "my-pk",
+		},
+	}
+
 	vaultUsernamePassword = &targets.SessionCredential{
 		CredentialSource: &targets.CredentialSource{
 			Type: "vault",
@@ -78,6 +89,19 @@ var (
 		},
 	}
 
+	staticSshCertificate = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			Type: "static",
+		},
+		Secret: &targets.SessionSecret{
+			Decoded: map[string]interface{}{
+				"username":    "static-decoded-user",
+				"certificate": "static-decoded-cert",
+				"private_key": "static-decoded-pk",
+			},
+		},
+	}
+
 	staticKv = &targets.SessionCredential{
 		CredentialSource: &targets.CredentialSource{
 			Type:              "static",
@@ -178,6 +202,23 @@ func Test_parseCredentials(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ssh-certificate-typed",
+			creds: []*targets.SessionCredential{
+				typedSshCertificate,
+			},
+			wantCreds: credentials{
+				sshCertificate: []sshCertificate{
+					{
+						Username:    "user",
+						Certificate: "my-cert",
+						PrivateKey:  "my-pk",
+						raw:         typedSshCertificate,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "vault-username-password-decoded",
 			creds: []*targets.SessionCredential{
@@ -242,6 +283,23 @@ func Test_parseCredentials(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "static-ssh-certificate-decoded",
+			creds: []*targets.SessionCredential{
+				staticSshCertificate,
+			},
+			wantCreds: credentials{
+				sshCertificate: []sshCertificate{
+					{
+						Username:    "static-decoded-user",
+						Certificate: "static-decoded-cert",
+						PrivateKey:  "static-decoded-pk",
+						raw:         staticSshCertificate,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "unspecified",
 			creds: []*targets.SessionCredential{
@@ -329,6 +387,7 @@ func Test_parseCredentials(t *testing.T) {
 
 			assert.ElementsMatch(tt.wantCreds.usernamePassword, creds.usernamePassword)
 			assert.ElementsMatch(tt.wantCreds.sshPrivateKey, creds.sshPrivateKey)
+			assert.ElementsMatch(tt.wantCreds.sshCertificate, creds.sshCertificate)
 			assert.ElementsMatch(tt.wantCreds.unspecified, creds.unspecified)
 		})
 	}
@@ -110,13 +110,39 @@ func (s *sshFlags) buildArgs(c *Command, port, ip, _ string, creds credentials)
 		case !tryConsume:
 			// Do nothing
 
-		// If we want to consume check if we have a private key available first
+		// If we want to consume check if we have an ssh certificate available
+		// first, only ssh supports certificates from a file
+		case strings.ToLower(s.flagSshStyle) == "ssh" && len(creds.sshCertificate) > 0:
+			// For now just grab the first ssh certificate credential brokered
+			cred := retCreds.sshCertificate[0]
+
+			username = cred.Username
+			cred.consumed = true
+			if cred.Passphrase != "" {
+				// Don't re-print everything, but print the passphrase they'll need
+				cred.consumed = false
+				delete(cred.raw.Credential, "username")
+				delete(cred.raw.Credential, "certificate")
+				delete(cred.raw.Credential, "private_key")
+			}
+			retCreds.sshCertificate[0] = cred
+
+			pkFileName, err := writeTempFile(c, "ssh private key", cred.PrivateKey)
+			if err != nil {
+				return nil, nil, credentials{}, err
+			}
+			certFileName, err := writeTempFile(c, "ssh certificate", cred.Certificate)
+			if err != nil {
+				return nil, nil, credentials{}, err
+			}
+			args = append(args, "-i", pkFileName, "-o", fmt.Sprintf("CertificateFile=%s", certFileName))
+
+		// Next check if we have a private key available
 		case len(creds.sshPrivateKey) > 0:
 			// For now just grab the first ssh private key credential brokered
 			cred := retCreds.sshPrivateKey[0]
 
 			username = cred.Username
-			privateKey := cred.PrivateKey
 			cred.consumed = true
 			if cred.Passphrase != "" {
 				// Don't re-print everything, but print the passphrase they'll need
@@ -126,30 +152,11 @@ func (s *sshFlags) buildArgs(c *Command, port, ip, _ string, creds credentials)
 			}
 			retCreds.sshPrivateKey[0] = cred
 
-			pkFile, err := os.CreateTemp("", "*")
-			if err != nil {
-				return nil, nil, credentials{}, fmt.Errorf("Error saving ssh private key to tmp file: %w", err)
-			}
-			c.cleanupFuncs = append(c.cleanupFuncs, func() error {
-				if err := os.Remove(pkFile.Name()); err != nil {
-					return fmt.Errorf("Error removing temporary ssh private key file; consider removing %s manually: %w", pkFile.Name(), err)
-				}
-				return nil
-			})
-			// SSH requires the private key file to end with a newline.
-			// When ingesting an ssh_private_key from a file:// Boundary calls strings.TrimSpace
-			// which will also trim newlines.
-			if !strings.HasSuffix(privateKey, "\n") {
-				privateKey = fmt.Sprintln(privateKey)
-			}
-			_, err = pkFile.WriteString(privateKey)
+			pkFileName, err := writeTempFile(c, "ssh private key", cred.PrivateKey)
 			if err != nil {
-				return nil, nil, credentials{}, fmt.Errorf("Error writing private key file to %s: %w", pkFile.Name(), err)
+				return nil, nil, credentials{}, err
 			}
-			if err := pkFile.Close(); err != nil {
-				return nil, nil, credentials{}, fmt.Errorf("Error closing private key file after writing to %s: %w", pkFile.Name(), err)
-			}
-			args = append(args, "-i", pkFile.Name())
+			args = append(args, "-i", pkFileName)
 
 		// Next check if we have a username password credential
 		case len(creds.usernamePassword) > 0:
@@ -175,3 +182,32 @@ func (s *sshFlags) buildArgs(c *Command, port, ip, _ string, creds credentials)
 
 	return args, envs, retCreds, nil
 }
+
+// writeTempFile writes contents to a temporary file that is removed when the
+// command is cleaned up and returns the name of the file. desc is used to
+// describe the contents in errors.
+func writeTempFile(c *Command, desc, contents string) (string, error) {
+	f, err := os.CreateTemp("", "*")
+	if err != nil {
+		return "", fmt.Errorf("Error saving %s to tmp file: %w", desc, err)
+	}
+	c.cleanupFuncs = append(c.cleanupFuncs, func() error {
+		if err := os.Remove(f.Name()); err != nil {
+			return fmt.Errorf("Error removing temporary %s file; consider removing %s manually: %w", desc, f.Name(), err)
+		}
+		return nil
+	})
+	// SSH requires the private key and certificate files to end with a
+	// newline. When ingesting an ssh_private_key from a file:// Boundary
+	// calls strings.TrimSpace which will also trim newlines.
+	if !strings.HasSuffix(contents, "\n") {
+		contents = fmt.Sprintln(contents)
+	}
+	if _, err := f.WriteString(contents); err != nil {
+		return "", fmt.Errorf("Error writing %s file to %s: %w", desc, f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("Error closing %s file after writing to %s: %w", desc, f.Name(), err)
+	}
+	return f.Name(), nil
+}
@@ -193,4 +193,5 @@ var keySubstMap = map[string]string{
 	"path":              "Path",
 	"http_method":       "HTTP Method",
 	"http_request_body": "HTTP Request Body",
+	"secret_version":    "Secret Version",
 }
@@ -1,6 +1,9 @@
 package credentiallibrariescmd
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/boundary/api/credentiallibraries"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
@@ -16,6 +19,7 @@ const (
 	pathFlagName              = "vault-path"
 	httpMethodFlagName        = "vault-http-method"
 	httpRequestBodyFlagName   = "vault-http-request-body"
+	secretVersionFlagName     = "vault-secret-version"
 	credentialTypeFlagName    = "credential-type"
 	credentialMappingFlagName = "credential-mapping-override"
 )
@@ -24,6 +28,7 @@ type extraVaultCmdVars struct {
 	flagPath              string
 	flagHttpMethod        string
 	flagHttpRequestBody   string
+	flagSecretVersion     string
 	flagCredentialType    string
 	flagCredentialMapping []base.CombinedSliceFlagValue
 }
@@ -34,6 +39,7 @@ func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
 			pathFlagName,
 			httpMethodFlagName,
 			httpRequestBodyFlagName,
+			secretVersionFlagName,
 			credentialTypeFlagName,
 			credentialMappingFlagName,
 		},
@@ -41,6 +47,7 @@ func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
 			pathFlagName,
 			httpMethodFlagName,
 			httpRequestBodyFlagName,
+			secretVersionFlagName,
 			credentialMappingFlagName,
 		},
 	}
@@ -70,6 +77,12 @@ func extraVaultFlagsFuncImpl(c *VaultCommand, set *base.FlagSets, _ *base.FlagSe
 				Target: &c.flagHttpRequestBody,
 				Usage:  "The http request body the library uses to communicate with vault. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read.",
 			})
+		case secretVersionFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   secretVersionFlagName,
+				Target: &c.flagSecretVersion,
+				Usage:  "The version of a KV version 2 secret the library reads from vault. If not set, the latest version is read. Can only be used with the GET http method.",
+			})
 		case credentialTypeFlagName:
 			f.StringVar(&base.StringVar{
 				Name:   credentialTypeFlagName,
@@ -108,6 +121,18 @@ func extraVaultFlagHandlingFuncImpl(c *VaultCommand, _ *base.FlagSets, opts *[]c
 		rb, _ := parseutil.ParsePath(c.flagHttpRequestBody)
 		*opts = append(*opts, credentiallibraries.WithVaultCredentialLibraryHttpRequestBody(rb))
 	}
+	switch c.flagSecretVersion {
+	case "":
+	case "null":
+		*opts = append(*opts, credentiallibraries.DefaultVaultCredentialLibrarySecretVersion())
+	default:
+		val, err := strconv.ParseUint(c.flagSecretVersion, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSecretVersion, err))
+			return false
+		}
+		*opts = append(*opts, credentiallibraries.WithVaultCredentialLibrarySecretVersion(uint32(val)))
+	}
 	switch c.flagCredentialType {
 	case "":
 	case "null":
@@ -12,6 +12,7 @@ import (
 const (
 	usernameFlagName             = "username"
 	passwordFlagName             = "password"
+	certificateFlagName          = "certificate"
 	privateKeyFlagName           = "private-key"
 	privateKeyPassphraseFlagName = "private-key-passphrase"
 	secretFlagName               = "secret"
@@ -180,6 +181,7 @@ func printItemTable(item *credentials.Credential, resp *api.Response) string {
 
 var keySubstMap = map[string]string{
 	"username":                    "Username",
+	"certificate":                 "Certificate",
 	"password_hmac":               "Password HMAC",
 	"private_key_hmac":            "Private Key HMAC",
 	"private_key_passphrase_hmac": "Private Key Passphrase HMAC",
@@ -0,0 +1,321 @@
+package credentialscmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*RotationCommand)(nil)
+	_ cli.CommandAutocomplete = (*RotationCommand)(nil)
+)
+
+const (
+	flagRotatorName          = "rotator"
+	flagRotationIntervalName = "rotation-interval"
+	flagGracePeriodName      = "grace-period"
+)
+
+// RotationCommand sets, reads and removes the rotation configuration of a
+// static credential.
+type RotationCommand struct {
+	*base.Command
+
+	Func string
+
+	flagRotator          string
+	flagRotationInterval time.Duration
+	flagGracePeriod      time.Duration
+}
+
+func (c *RotationCommand) Synopsis() string {
+	switch c.Func {
+	case "set-rotation":
+		return wordwrap.WrapString("Set the rotation configuration of a static credential", base.TermWidth)
+	case "read-rotation":
+		return wordwrap.WrapString("Read the rotation configuration of a static credential", base.TermWidth)
+	case "remove-rotation":
+		return wordwrap.WrapString("Remove the rotation configuration of a static credential", base.TermWidth)
+	}
+	return ""
+}
+
+var flagsRotation = map[string][]string{
+	"set-rotation":    {"id", "version", flagRotatorName, flagRotationIntervalName, flagGracePeriodName, "attributes", "attr", "string-attr", "bool-attr", "num-attr"},
+	"read-rotation":   {"id"},
+	"remove-rotation": {"id"},
+}
+
+func (c *RotationCommand) Help() string {
+	switch c.Func {
+	case "set-rotation":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary credentials set-rotation [options] [args]",
+			"",
+			"  Set the rotator that rotates the secret of a static credential and how often it is rotated. If the credential already has a rotation configuration it is replaced. Example:",
+			"",
+			`    $ boundary credentials set-rotation -id credup_1234567890 -rotator ldap -rotation-interval 24h -grace-period 1h`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	case "read-rotation":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary credentials read-rotation [options] [args]",
+			"",
+			"  Read the rotation configuration of a static credential. Example:",
+			"",
+			`    $ boundary credentials read-rotation -id credup_1234567890`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	case "remove-rotation":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary credentials remove-rotation [options] [args]",
+			"",
+			"  Remove the rotation configuration of a static credential, which stops its secret from being rotated. Example:",
+			"",
+			`    $ boundary credentials remove-rotation -id credup_1234567890`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	}
+	return ""
+}
+
+func (c *RotationCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "credential", flagsRotation, c.Func)
+
+	for _, name := range flagsRotation[c.Func] {
+		switch name {
+		case flagRotatorName:
+			f.StringVar(&base.StringVar{
+				Name:   flagRotatorName,
+				Target: &c.flagRotator,
+				Usage:  "The name of the rotation plugin that rotates the secret of the credential.",
+			})
+		case flagRotationIntervalName:
+			f.DurationVar(&base.DurationVar{
+				Name:   flagRotationIntervalName,
+				Target: &c.flagRotationInterval,
+				Usage:  "How often the secret of the credential is rotated.",
+			})
+		case flagGracePeriodName:
+			f.DurationVar(&base.DurationVar{
+				Name:   flagGracePeriodName,
+				Target: &c.flagGracePeriod,
+				Usage:  "How long the previous secret of the credential stays valid after a rotation.",
+			})
+		}
+	}
+
+	if c.Func == "set-rotation" {
+		f = set.NewFlagSet("Attribute Options")
+		common.PopulateAttributeFlags(c.Command, f, flagsRotation, c.Func)
+	}
+
+	return set
+}
+
+func (c *RotationCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *RotationCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RotationCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch c.Func {
+	case "":
+		return cli.RunResultHelp
+	}
+
+	if c.FlagId == "" {
+		c.PrintCliError(errors.New("ID is required but not passed in via -id"))
+		return base.CommandUserError
+	}
+
+	var opts []credentials.Option
+	if c.Func == "set-rotation" {
+		switch {
+		case c.flagRotator == "":
+			c.PrintCliError(errors.New("Rotator must be passed in via -rotator"))
+			return base.CommandUserError
+		case c.flagRotationInterval < time.Second:
+			c.PrintCliError(errors.New("Rotation interval of at least one second must be passed in via -rotation-interval"))
+			return base.CommandUserError
+		case c.flagGracePeriod < 0:
+			c.PrintCliError(errors.New("Grace period must not be negative"))
+			return base.CommandUserError
+		}
+		if err := common.HandleAttributeFlags(
+			c.Command,
+			"attr",
+			c.FlagAttributes,
+			c.FlagAttrs,
+			func() {},
+			func(in map[string]interface{}) {
+				opts = append(opts, credentials.WithAttributes(in))
+			}); err != nil {
+			c.PrintCliError(fmt.Errorf("Error evaluating attribute flags to: %s", err.Error()))
+			return base.CommandCliError
+		}
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+	credentialsClient := credentials.NewClient(client)
+
+	var resp *api.Response
+	var item *credentials.RotationConfig
+
+	switch c.Func {
+	case "set-rotation":
+		version := uint32(c.FlagVersion)
+		if version == 0 {
+			// Perform the check-and-set automatically: a credential without a
+			// rotation configuration is set with version zero.
+			existing, err := credentialsClient.ReadRotation(c.Context, c.FlagId)
+			switch {
+			case err == nil:
+				version = existing.GetItem().Version
+			case isNotFound(err):
+			default:
+				if exitCode := c.checkFuncError(err); exitCode > 0 {
+					return exitCode
+				}
+			}
+		}
+		result, err := credentialsClient.SetRotation(c.Context, c.FlagId, version, c.flagRotator,
+			uint32(c.flagRotationInterval/time.Second), uint32(c.flagGracePeriod/time.Second), opts...)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = result.GetResponse()
+		item = result.GetItem()
+	case "read-rotation":
+		result, err := credentialsClient.ReadRotation(c.Context, c.FlagId)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = result.GetResponse()
+		item = result.GetItem()
+	case "remove-rotation":
+		result, err := credentialsClient.RemoveRotation(c.Context, c.FlagId)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = result.GetResponse()
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		if item == nil {
+			c.UI.Output("The rotation configuration of the credential has been removed.")
+			return base.CommandSuccess
+		}
+		c.UI.Output(printRotationConfigTable(item))
+
+	case "json":
+		if item == nil {
+			c.UI.Output("{}")
+			return base.CommandSuccess
+		}
+		if ok := c.PrintJsonItem(resp); !ok {
+			return base.CommandCliError
+		}
+
+	case "yaml":
+		if item == nil {
+			return base.CommandSuccess
+		}
+		if ok := c.PrintYamlItem(resp); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *RotationCommand) checkFuncError(err error) int {
+	if err == nil {
+		return 0
+	}
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing %s on credential", c.Func))
+		return base.CommandApiError
+	}
+	c.PrintCliError(fmt.Errorf("Error trying to %s on credential: %s", c.Func, err.Error()))
+	return base.CommandCliError
+}
+
+func isNotFound(err error) bool {
+	apiErr := api.AsServerError(err)
+	return apiErr != nil && apiErr.Response() != nil && apiErr.Response().StatusCode() == http.StatusNotFound
+}
+
+func printRotationConfigTable(item *credentials.RotationConfig) string {
+	nonAttributeMap := map[string]interface{}{
+		"Credential ID":     item.CredentialId,
+		"Rotator":           item.Rotator,
+		"Rotation Interval": (time.Duration(item.RotationIntervalSeconds) * time.Second).String(),
+		"Grace Period":      (time.Duration(item.GracePeriodSeconds) * time.Second).String(),
+		"Secret Version":    item.SecretVersion,
+		"Version":           item.Version,
+		"Created Time":      item.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":      item.UpdatedTime.Local().Format(time.RFC1123),
+	}
+	if !item.LastRotationTime.IsZero() {
+		nonAttributeMap["Last Rotation Time"] = item.LastRotationTime.Local().Format(time.RFC1123)
+	}
+	if !item.NextRotationTime.IsZero() {
+		nonAttributeMap["Next Rotation Time"] = item.NextRotationTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, nil)
+
+	ret := []string{
+		"",
+		"Rotation configuration:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(item.Attributes) > 0 {
+		ret = append(ret,
+			"",
+			"  Attributes:",
+			base.WrapMap(4, maxLength, item.Attributes),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -3,7 +3,10 @@ package database
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/db/common"
@@ -17,7 +20,9 @@ import (
 // We expect the database already to be initialized iff initialized is set to true.
 // Returns a cleanup function which must be called even if an error is returned and
 // an error code where a non-zero value indicates an error happened.
-func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, maxOpenConns int, selectedRepairs schema.RepairMigrations) (func(), int) {
+// WithDestructiveCheck is supported, all other options are ignored.
+func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, maxOpenConns int, selectedRepairs schema.RepairMigrations, opt ...Option) (func(), int) {
+	opts := getOpts(opt...)
 	noop := func() {}
 	// This database is used to keep an exclusive lock on the database for the
 	// remainder of the command
@@ -63,6 +68,25 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 		ui.Output(base.WrapAtLength("Database has already been initialized. Please use 'boundary database migrate' for any upgrade needs."))
 		return unlock, -1
 	}
+	if opts.withDestructiveCheck {
+		plan, err := man.PlanMigrations(ctx)
+		if err != nil {
+			ui.Error(fmt.Errorf("Error planning database migrations: %w", err).Error())
+			return unlock, 2
+		}
+		var unacknowledged []string
+		for _, m := range plan {
+			if m.Destructive && !opts.withSkippedMigrations.IsSet(m.Edition, m.Version) {
+				unacknowledged = append(unacknowledged, fmt.Sprintf("%s:%d", m.Edition, m.Version))
+			}
+		}
+		if len(unacknowledged) > 0 {
+			ui.Error(base.WrapAtLength(fmt.Sprintf("The following migrations drop tables or columns, or truncate tables, and may result in a loss of data: %s.", strings.Join(unacknowledged, ", "))))
+			ui.Error(base.WrapAtLength(fmt.Sprintf("Review them using 'boundary database migrate -plan' and acknowledge them using 'boundary database migrate -skip=%s'.", strings.Join(unacknowledged, " -skip="))))
+			return unlock, 2
+		}
+	}
+
 	repairLogs, err := man.ApplyMigrations(ctx)
 	if err != nil {
 		ui.Error(fmt.Errorf("Error running database migrations: %w", err).Error())
@@ -99,6 +123,146 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 	return unlock, 0
 }
 
+// planDatabaseMigrations reports the migrations which would be applied to
+// the database by migrateDatabase, without applying them. If output is set, the
+// sql statements of the migrations are written to the file at that path.
+// It owns the reporting to the UI any errors.
+// We expect the database already to be initialized iff initialized is set to true.
+// Returns an error code where a non-zero value indicates an error happened.
+func planDatabaseMigrations(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, output string) int {
+	dBase, err := common.SqlOpen(dialect, u)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return 2
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(ctx); err != nil {
+		ui.Error(fmt.Sprintf("Unable to connect to the database at %q", u))
+		return 2
+	}
+	// Planning does not take a lock on the database, so it can be done while
+	// controllers are running.
+	man, err := schema.NewManager(ctx, schema.Dialect(dialect), dBase)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return 2
+	}
+
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return 2
+	}
+	if initialized && !st.Initialized {
+		ui.Output(base.WrapAtLength("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database."))
+		return -1
+	}
+	if !initialized && st.Initialized {
+		ui.Output(base.WrapAtLength("Database has already been initialized. Please use 'boundary database migrate' for any upgrade needs."))
+		return -1
+	}
+
+	plan, err := man.PlanMigrations(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error planning database migrations: %w", err).Error())
+		return 2
+	}
+
+	if output != "" {
+		f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			ui.Error(fmt.Errorf("Error opening migration plan output file: %w", err).Error())
+			return 2
+		}
+		if err := writeMigrationPlan(f, plan); err != nil {
+			_ = f.Close()
+			ui.Error(fmt.Errorf("Error writing migration plan: %w", err).Error())
+			return 2
+		}
+		if err := f.Close(); err != nil {
+			ui.Error(fmt.Errorf("Error writing migration plan: %w", err).Error())
+			return 2
+		}
+	}
+
+	switch format := base.Format(ui); format {
+	case "json", "yaml":
+		type plannedMigration struct {
+			Edition     string `json:"edition"`
+			Version     int    `json:"version"`
+			Destructive bool   `json:"destructive"`
+		}
+		out := struct {
+			Migrations []plannedMigration `json:"migrations"`
+			Output     string             `json:"output,omitempty"`
+		}{
+			Migrations: make([]plannedMigration, 0, len(plan)),
+			Output:     output,
+		}
+		for _, m := range plan {
+			out.Migrations = append(out.Migrations, plannedMigration{
+				Edition:     m.Edition,
+				Version:     m.Version,
+				Destructive: m.Destructive,
+			})
+		}
+		var b []byte
+		if format == "yaml" {
+			b, err = base.YamlFormatter{}.Format(out)
+		} else {
+			b, err = base.JsonFormatter{}.Format(out)
+		}
+		if err != nil {
+			ui.Error(fmt.Errorf("Error formatting migration plan: %w", err).Error())
+			return 2
+		}
+		ui.Output(string(b))
+
+	default:
+		if len(plan) == 0 {
+			ui.Output("No migrations to apply.")
+		} else {
+			ret := []string{"Migrations to apply:"}
+			for _, m := range plan {
+				l := fmt.Sprintf("  %s:%d", m.Edition, m.Version)
+				if m.Destructive {
+					l += " (destructive, requires -skip)"
+				}
+				ret = append(ret, l)
+			}
+			ui.Output(strings.Join(ret, "\n"))
+		}
+		if output != "" {
+			ui.Output(fmt.Sprintf("Migration sql written to %s.", output))
+		}
+	}
+	return 0
+}
+
+// writeMigrationPlan writes the sql statements of the planned migrations to
+// w, in the order in which they would be applied.
+func writeMigrationPlan(w io.Writer, plan []schema.PlannedMigration) error {
+	if _, err := fmt.Fprintln(w, "-- Boundary database migration plan."); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "-- This file is meant for review: apply the migrations using 'boundary database migrate',"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "-- which also records the schema version of each applied migration."); err != nil {
+		return err
+	}
+	for _, m := range plan {
+		header := fmt.Sprintf("\n-- Migration %s:%d", m.Edition, m.Version)
+		if m.Destructive {
+			header += " (destructive)"
+		}
+		if _, err := fmt.Fprintf(w, "%s\n%s\n", header, m.Statements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type RoleInfo struct {
 	RoleId string `json:"scope_id"`
 	Name   string `json:"name"`
@@ -267,3 +431,38 @@ func generateInitialTargetTableOutput(in *TargetInfo) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+type MaintenanceInfo struct {
+	Enabled           bool      `json:"enabled"`
+	FreezeSessions    bool      `json:"freeze_sessions"`
+	RetryAfterSeconds int64     `json:"retry_after_seconds"`
+	Message           string    `json:"message,omitempty"`
+	UpdatedTime       time.Time `json:"updated_time"`
+}
+
+func generateMaintenanceTableOutput(in *MaintenanceInfo) string {
+	nonAttributeMap := map[string]interface{}{
+		"Enabled":             in.Enabled,
+		"Freeze Sessions":     in.FreezeSessions,
+		"Retry After Seconds": in.RetryAfterSeconds,
+		"Updated Time":        in.UpdatedTime.Local().Format(time.RFC1123),
+	}
+	if in.Message != "" {
+		nonAttributeMap["Message"] = in.Message
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		"Maintenance mode information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -2,6 +2,10 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -204,6 +208,92 @@ func TestMigrateDatabase(t *testing.T) {
 	}
 }
 
+func TestMigrateDatabase_DestructiveCheck(t *testing.T) {
+	ctx := context.Background()
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+	dBase, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	t.Cleanup(func() { dBase.Close() })
+
+	earlyMigrationVersion := 2000
+	man, err := schema.NewManager(ctx, schema.Dialect(dialect), dBase, schema.WithEditions(
+		schema.TestCreatePartialEditions(schema.Dialect(dialect), schema.PartialEditions{"oss": earlyMigrationVersion}),
+	))
+	require.NoError(t, err)
+	_, err = man.ApplyMigrations(ctx)
+	require.NoError(t, err)
+
+	man, err = schema.NewManager(ctx, schema.Dialect(dialect), dBase)
+	require.NoError(t, err)
+	plan, err := man.PlanMigrations(ctx)
+	require.NoError(t, err)
+	skipped := make(schema.RepairMigrations)
+	for _, m := range plan {
+		if m.Destructive {
+			skipped.Add(m.Edition, m.Version)
+		}
+	}
+	require.NotEmpty(t, skipped, "expected the migrations after version %d to contain destructive migrations", earlyMigrationVersion)
+
+	ui := cli.NewMockUi()
+	clean, errCode := migrateDatabase(ctx, ui, dialect, u, true, 10, nil, WithDestructiveCheck(nil))
+	clean()
+	assert.EqualValues(t, 2, errCode)
+	assert.Contains(t, ui.ErrorWriter.String(), "may result in a loss of data")
+	assert.Empty(t, ui.OutputWriter.String())
+
+	ui = cli.NewMockUi()
+	clean, errCode = migrateDatabase(ctx, ui, dialect, u, true, 10, nil, WithDestructiveCheck(skipped))
+	clean()
+	assert.EqualValues(t, 0, errCode)
+	assert.Equal(t, "Migrations successfully run.\n", ui.OutputWriter.String())
+	assert.Empty(t, ui.ErrorWriter.String())
+}
+
+func TestPlanDatabaseMigrations(t *testing.T) {
+	ctx := context.Background()
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+
+	ui := cli.NewMockUi()
+	errCode := planDatabaseMigrations(ctx, ui, dialect, u, true, "")
+	assert.EqualValues(t, -1, errCode)
+	assert.Equal(t, "Database has not been initialized. Please use 'boundary database init' to\ninitialize the boundary database.\n", ui.OutputWriter.String())
+
+	output := filepath.Join(t.TempDir(), "plan.sql")
+	ui = cli.NewMockUi()
+	errCode = planDatabaseMigrations(ctx, ui, dialect, u, false, output)
+	require.EqualValues(t, 0, errCode, ui.ErrorWriter.String())
+	assert.True(t, strings.HasPrefix(ui.OutputWriter.String(), "Migrations to apply:\n  oss:1\n"))
+	assert.Contains(t, ui.OutputWriter.String(), fmt.Sprintf("Migration sql written to %s.", output))
+
+	sql, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(sql), "-- Boundary database migration plan.\n"))
+	assert.Contains(t, string(sql), "\n-- Migration oss:1\n")
+
+	// Planning does not migrate the database.
+	dBase, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	t.Cleanup(func() { dBase.Close() })
+	man, err := schema.NewManager(ctx, schema.Dialect(dialect), dBase)
+	require.NoError(t, err)
+	st, err := man.CurrentState(ctx)
+	require.NoError(t, err)
+	assert.False(t, st.Initialized)
+}
+
 func TestVerifyOplogIsEmpty(t *testing.T) {
 	dialect := "postgres"
 	ctx := context.Background()
@@ -46,6 +46,8 @@ type InitCommand struct {
 	flagLogLevel                     string
 	flagLogFormat                    string
 	flagMigrationUrl                 string
+	flagPlan                         bool
+	flagPlanOutput                   string
 	flagSkipInitialLoginRoleCreation bool
 	flagSkipAuthMethodCreation       bool
 	flagSkipScopesCreation           bool
@@ -161,6 +163,19 @@ func (c *InitCommand) Flags() *base.FlagSets {
 		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for initialization. This can allow different permissions for the user running initialization vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "plan",
+		Target: &c.flagPlan,
+		Usage:  `If set, print the migrations which would be applied to initialize the database instead of applying them. No initial resources are created.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "plan-output",
+		Target:     &c.flagPlanOutput,
+		Completion: complete.PredictFiles("*.sql"),
+		Usage:      `If set, write the SQL of the migrations which would be applied to initialize the database to the given file, for review. Implies "plan".`,
+	})
+
 	return set
 }
 
@@ -266,6 +281,13 @@ func (c *InitCommand) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
+	if c.flagPlan || c.flagPlanOutput != "" {
+		if errCode := planDatabaseMigrations(c.Context, c.UI, dialect, migrationUrl, false, c.flagPlanOutput); errCode > 0 {
+			return errCode
+		}
+		return base.CommandSuccess
+	}
+
 	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, false, c.DatabaseMaxOpenConnections, nil)
 	defer clean()
 	switch errCode {
@@ -0,0 +1,350 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/go-secure-stdlib/pluginutil/v2"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*MaintenanceCommand)(nil)
+	_ cli.CommandAutocomplete = (*MaintenanceCommand)(nil)
+)
+
+type MaintenanceCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	// This will be intialized, if needed, in ParseFlagsAndConfig when
+	// instantiating a config wrapper, if requested. It's then called as a
+	// deferred function on the Run method.
+	configWrapperCleanupFunc func() error
+
+	flagConfig         string
+	flagConfigKms      string
+	flagLogLevel       string
+	flagLogFormat      string
+	flagEnable         bool
+	flagDisable        bool
+	flagFreezeSessions bool
+	flagRetryAfter     time.Duration
+	flagMessage        string
+}
+
+func (c *MaintenanceCommand) Synopsis() string {
+	return "Read or set the maintenance mode of Boundary's controllers"
+}
+
+func (c *MaintenanceCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database maintenance [options]",
+		"",
+		"  Read or set the maintenance mode of Boundary's controllers. The mode is stored in the database and applied by all the controllers within a few seconds. In maintenance mode, the controllers reject the requests which modify resources with a 503 status and a Retry-After header; session authorization, authentication and the requests of workers are still served.",
+		"",
+		"  Read the maintenance mode:",
+		"",
+		"    $ boundary database maintenance -config=/etc/boundary/controller.hcl",
+		"",
+		"  Enable maintenance mode before a schema migration:",
+		"",
+		`    $ boundary database maintenance -config=/etc/boundary/controller.hcl -enable -retry-after=5m -message="Upgrading Boundary."`,
+		"",
+		"  Enable maintenance mode, also rejecting session authorization, authentication and the requests of workers:",
+		"",
+		"    $ boundary database maintenance -config=/etc/boundary/controller.hcl -enable -freeze-sessions",
+		"",
+		"  Disable maintenance mode:",
+		"",
+		"    $ boundary database maintenance -config=/etc/boundary/controller.hcl -disable",
+	}) + c.Flags().Help()
+}
+
+func (c *MaintenanceCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Maintenance Options")
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "enable",
+		Target: &c.flagEnable,
+		Usage:  "If set, enable maintenance mode.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "disable",
+		Target: &c.flagDisable,
+		Usage:  "If set, disable maintenance mode.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "freeze-sessions",
+		Target: &c.flagFreezeSessions,
+		Usage:  `If set with "enable", session authorization, authentication and the requests of workers are rejected as well.`,
+	})
+
+	f.DurationVar(&base.DurationVar{
+		Name:   "retry-after",
+		Target: &c.flagRetryAfter,
+		Usage:  fmt.Sprintf(`The time clients are asked to wait before retrying a rejected request, returned in the Retry-After header. Used with "enable", defaults to %s.`, server.DefaultMaintenanceRetryAfter),
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "message",
+		Target: &c.flagMessage,
+		Usage:  `A message returned with the rejected requests. Used with "enable".`,
+	})
+
+	return set
+}
+
+func (c *MaintenanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *MaintenanceCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *MaintenanceCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	defer func() {
+		if err := c.RunShutdownFuncs(); err != nil {
+			c.UI.Error(fmt.Errorf("Error running shutdown tasks: %w", err).Error())
+		}
+	}()
+
+	if c.configWrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.configWrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error finalizing config kms: %w", err))
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	if err := c.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	serverName, err := os.Hostname()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return base.CommandCliError
+	}
+	serverName = fmt.Sprintf("%s/boundary-database-maintenance", serverName)
+	if err := c.SetupEventing(c.Logger, c.StderrLock, serverName, base.WithEventerConfig(c.Config.Eventing)); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	urlToParse := c.Config.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return base.CommandUserError
+	}
+	c.DatabaseUrl, err = parseutil.ParsePath(urlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return base.CommandUserError
+	}
+	if err := c.OpenAndSetServerDatabase(c.Context, dialect); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	rw := db.New(c.Database)
+	kmsCache, err := kms.New(c.Context, rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return base.CommandCliError
+	}
+	repo, err := server.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating servers repository: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	var m *server.Maintenance
+	switch {
+	case c.flagEnable:
+		m, err = repo.SetMaintenance(c.Context, &server.Maintenance{
+			Enabled:        true,
+			FreezeSessions: c.flagFreezeSessions,
+			RetryAfter:     c.flagRetryAfter,
+			Message:        c.flagMessage,
+		})
+	case c.flagDisable:
+		m, err = repo.SetMaintenance(c.Context, &server.Maintenance{})
+	default:
+		m, err = repo.LookupMaintenance(c.Context)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error setting maintenance mode: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	info := &MaintenanceInfo{
+		Enabled:           m.Enabled,
+		FreezeSessions:    m.FreezeSessions,
+		RetryAfterSeconds: int64(m.RetryAfter / time.Second),
+		Message:           m.Message,
+		UpdatedTime:       m.UpdateTime,
+	}
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateMaintenanceTableOutput(info))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *MaintenanceCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case c.flagEnable && c.flagDisable:
+		c.UI.Error(`"enable" and "disable" cannot both be set`)
+		return base.CommandUserError
+	case !c.flagEnable && (c.flagFreezeSessions || c.flagRetryAfter != 0 || c.flagMessage != ""):
+		c.UI.Error(`"freeze-sessions", "retry-after" and "message" can only be used with "enable"`)
+		return base.CommandUserError
+	case c.flagRetryAfter < 0:
+		c.UI.Error(`"retry-after" must not be negative`)
+		return base.CommandUserError
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, cleanupFunc, err := wrapper.GetWrapperFromPath(
+		c.Context,
+		wrapperPath,
+		globals.KmsPurposeConfig,
+		configutil.WithPluginOptions(
+			pluginutil.WithPluginsMap(kms_plugin_assets.BuiltinKmsPlugins()),
+			pluginutil.WithPluginsFilesystem(kms_plugin_assets.KmsPluginPrefix, kms_plugin_assets.FileSystem()),
+		),
+		configutil.WithLogger(hclog.NewNullLogger()),
+	)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if wrapper != nil {
+		c.configWrapperCleanupFunc = cleanupFunc
+		if ifWrapper, ok := wrapper.(wrapping.InitFinalizer); ok {
+			if err := ifWrapper.Init(c.Context); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+				c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+				return base.CommandUserError
+			}
+			c.configWrapperCleanupFunc = func() error {
+				if err := ifWrapper.Finalize(context.Background()); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+					c.UI.Warn(fmt.Errorf("Could not finalize kms: %w", err).Error())
+				}
+				if cleanupFunc != nil {
+					return cleanupFunc()
+				}
+				return nil
+			}
+		}
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
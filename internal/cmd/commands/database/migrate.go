@@ -47,7 +47,8 @@ type MigrateCommand struct {
 	// deferred function on the Run method.
 	configWrapperCleanupFunc func() error
 
-	selectedRepairs schema.RepairMigrations
+	selectedRepairs   schema.RepairMigrations
+	skippedMigrations schema.RepairMigrations
 
 	flagConfig             string
 	flagConfigKms          string
@@ -55,6 +56,9 @@ type MigrateCommand struct {
 	flagLogFormat          string
 	flagMigrationUrl       string
 	flagRepairMigrations   []string
+	flagSkipMigrations     []string
+	flagPlan               bool
+	flagPlanOutput         string
 	flagAllowDevMigrations bool
 }
 
@@ -70,6 +74,14 @@ func (c *MigrateCommand) Help() string {
 		"",
 		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
 		"",
+		"  Print the migrations which would be applied and write their SQL to a file for review, without migrating the database:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -plan -plan-output=/tmp/boundary-migrations.sql",
+		"",
+		"  Migrations which drop tables or columns, or truncate tables, may result in a loss of data and are only applied when acknowledged using the \"skip\" flag:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -skip=oss:34002",
+		"",
 		"  For a full list of examples, please see the documentation.",
 	}) + c.Flags().Help()
 }
@@ -129,6 +141,25 @@ func (c *MigrateCommand) Flags() *base.FlagSets {
 		Usage:  `Run the repair function for the provided migration version.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "plan",
+		Target: &c.flagPlan,
+		Usage:  `If set, print the migrations which would be applied to the database instead of applying them.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "plan-output",
+		Target:     &c.flagPlanOutput,
+		Completion: complete.PredictFiles("*.sql"),
+		Usage:      `If set, write the SQL of the migrations which would be applied to the database to the given file, for review. Implies "plan".`,
+	})
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "skip",
+		Target: &c.flagSkipMigrations,
+		Usage:  `Skip the destructive migration check for the provided migration version, acknowledging that applying it may result in a loss of data. The migrations which require it are listed by "plan".`,
+	})
+
 	return set
 }
 
@@ -268,6 +299,10 @@ plugins {
 		return base.CommandUserError
 	}
 
+	if c.flagPlan || c.flagPlanOutput != "" {
+		return planDatabaseMigrations(c.Context, c.UI, dialect, migrationUrl, true, c.flagPlanOutput)
+	}
+
 	clean, errCode := migrateDatabase(
 		c.Context,
 		c.UI,
@@ -276,6 +311,7 @@ plugins {
 		true,
 		c.Config.Controller.Database.MaxOpenConnections,
 		c.selectedRepairs,
+		WithDestructiveCheck(c.skippedMigrations),
 	)
 	defer clean()
 	if errCode != 0 {
@@ -313,6 +349,24 @@ func (c *MigrateCommand) ParseFlagsAndConfig(args []string) int {
 		c.selectedRepairs.Add(edition, version)
 	}
 
+	c.skippedMigrations = make(schema.RepairMigrations)
+	for _, r := range c.flagSkipMigrations {
+		parts := strings.SplitN(r, ":", 2)
+		if len(parts) != 2 {
+			c.UI.Error(fmt.Sprintf("Error parsing skip option, invalid format: %s", r))
+			return base.CommandUserError
+		}
+
+		edition := parts[0]
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing skip option %s, %s", r, err.Error()))
+			return base.CommandUserError
+		}
+
+		c.skippedMigrations.Add(edition, version)
+	}
+
 	// Validation
 	switch {
 	case len(c.flagConfig) == 0:
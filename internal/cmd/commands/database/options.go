@@ -0,0 +1,35 @@
+package database
+
+import "github.com/hashicorp/boundary/internal/db/schema"
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withDestructiveCheck  bool
+	withSkippedMigrations schema.RepairMigrations
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithDestructiveCheck provides an option to refuse to apply migrations which
+// may result in a loss of data unless they are contained in the skipped set of
+// migrations.
+func WithDestructiveCheck(skipped schema.RepairMigrations) Option {
+	return func(o *options) {
+		o.withDestructiveCheck = true
+		o.withSkippedMigrations = skipped
+	}
+}
@@ -308,6 +308,26 @@ func printItemTable(item *hostsets.HostSet, resp *api.Response) string {
 		)
 	}
 
+	if item.HealthCheck != nil {
+		healthCheckMap := map[string]interface{}{
+			"Protocol":            item.HealthCheck.Protocol,
+			"Port":                item.HealthCheck.Port,
+			"Interval":            fmt.Sprintf("%d seconds", item.HealthCheck.IntervalSeconds),
+			"Unhealthy Threshold": item.HealthCheck.UnhealthyThreshold,
+		}
+		if item.HealthCheck.Path != "" {
+			healthCheckMap["Path"] = item.HealthCheck.Path
+		}
+		if item.HealthCheck.WorkerFilter != "" {
+			healthCheckMap["Worker Filter"] = item.HealthCheck.WorkerFilter
+		}
+		ret = append(ret,
+			"",
+			"  Health Check:",
+			base.WrapMap(4, maxLength, healthCheckMap),
+		)
+	}
+
 	if len(item.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
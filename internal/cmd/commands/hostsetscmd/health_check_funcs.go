@@ -0,0 +1,121 @@
+package hostsetscmd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+var healthCheckFlags = []string{
+	"health-check-protocol",
+	"health-check-port",
+	"health-check-path",
+	"health-check-interval",
+	"health-check-unhealthy-threshold",
+	"health-check-worker-filter",
+}
+
+type healthCheckCmdVars struct {
+	flagHealthCheckProtocol           string
+	flagHealthCheckPort               string
+	flagHealthCheckPath               string
+	flagHealthCheckInterval           string
+	flagHealthCheckUnhealthyThreshold string
+	flagHealthCheckWorkerFilter       string
+}
+
+func (v *healthCheckCmdVars) addHealthCheckFlag(fs *base.FlagSet, name string) {
+	switch name {
+	case "health-check-protocol":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-protocol",
+			Target: &v.flagHealthCheckProtocol,
+			Usage: `The protocol used to probe the hosts of the set, either "tcp" or "http". ` +
+				"Setting to null removes the health check from the host set. When updating, " +
+				"the health check is replaced as a whole so all of its values must be given again.",
+		})
+	case "health-check-port":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-port",
+			Target: &v.flagHealthCheckPort,
+			Usage:  "The port on the hosts to probe.",
+		})
+	case "health-check-path":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-path",
+			Target: &v.flagHealthCheckPath,
+			Usage:  `The path requested by http probes. Defaults to "/".`,
+		})
+	case "health-check-interval":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-interval",
+			Target: &v.flagHealthCheckInterval,
+			Usage: `An integer number of seconds, or a string such as "30s" or "5m", ` +
+				"indicating the amount of time between probes of a host. Defaults to 30 seconds.",
+		})
+	case "health-check-unhealthy-threshold":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-unhealthy-threshold",
+			Target: &v.flagHealthCheckUnhealthyThreshold,
+			Usage: "The number of consecutive failed probes after which a host is " +
+				"considered unhealthy and excluded from new sessions. Defaults to 3.",
+		})
+	case "health-check-worker-filter":
+		fs.StringVar(&base.StringVar{
+			Name:   "health-check-worker-filter",
+			Target: &v.flagHealthCheckWorkerFilter,
+			Usage:  "A boolean expression to filter which workers probe the hosts of the set.",
+		})
+	}
+}
+
+// healthCheckOptions converts the health check flags into host set options.
+// It returns false if the flags could not be parsed.
+func (v *healthCheckCmdVars) healthCheckOptions(c *base.Command, opts *[]hostsets.Option) bool {
+	if v.flagHealthCheckProtocol == "null" {
+		*opts = append(*opts, hostsets.DefaultHealthCheck())
+		return true
+	}
+	if v.flagHealthCheckProtocol == "" &&
+		v.flagHealthCheckPort == "" &&
+		v.flagHealthCheckPath == "" &&
+		v.flagHealthCheckInterval == "" &&
+		v.flagHealthCheckUnhealthyThreshold == "" &&
+		v.flagHealthCheckWorkerFilter == "" {
+		return true
+	}
+
+	hc := &hostsets.HealthCheck{
+		Protocol:     v.flagHealthCheckProtocol,
+		Path:         v.flagHealthCheckPath,
+		WorkerFilter: v.flagHealthCheckWorkerFilter,
+	}
+	if v.flagHealthCheckPort != "" {
+		port, err := parseutil.SafeParseInt(v.flagHealthCheckPort)
+		if err != nil || port < 0 {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse given health check port %q", v.flagHealthCheckPort))
+			return false
+		}
+		hc.Port = uint32(port)
+	}
+	if v.flagHealthCheckInterval != "" {
+		interval, err := parseutil.ParseDurationSecond(v.flagHealthCheckInterval)
+		if err != nil || interval < 0 {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse given health check interval %q", v.flagHealthCheckInterval))
+			return false
+		}
+		hc.IntervalSeconds = uint32(interval.Seconds())
+	}
+	if v.flagHealthCheckUnhealthyThreshold != "" {
+		threshold, err := parseutil.SafeParseInt(v.flagHealthCheckUnhealthyThreshold)
+		if err != nil || threshold < 0 {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse given health check unhealthy threshold %q", v.flagHealthCheckUnhealthyThreshold))
+			return false
+		}
+		hc.UnhealthyThreshold = uint32(threshold)
+	}
+	*opts = append(*opts, hostsets.WithHealthCheck(hc))
+	return true
+}
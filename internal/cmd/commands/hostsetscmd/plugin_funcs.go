@@ -18,12 +18,14 @@ func init() {
 type extraPluginCmdVars struct {
 	flagPreferredEndpoints []string
 	flagSyncInterval       string
+
+	healthCheckCmdVars
 }
 
 func extraPluginActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"preferred-endpoint", "sync-interval"},
-		"update": {"preferred-endpoint", "sync-interval"},
+		"create": append([]string{"preferred-endpoint", "sync-interval"}, healthCheckFlags...),
+		"update": append([]string{"preferred-endpoint", "sync-interval"}, healthCheckFlags...),
 	}
 }
 
@@ -84,6 +86,8 @@ func extraPluginFlagsFuncImpl(c *PluginCommand, set *base.FlagSets, f *base.Flag
 					"Setting to any negative value will disable syncing for that host set; setting to null " +
 					"will cause the set to use Boundary's default. The default may change between releases.",
 			})
+		default:
+			c.addHealthCheckFlag(fs, name)
 		}
 	}
 }
@@ -120,5 +124,5 @@ func extraPluginFlagsHandlingFuncImpl(c *PluginCommand, _ *base.FlagSets, opts *
 		*opts = append(*opts, hostsets.WithSyncIntervalSeconds(int32(interval.Seconds())))
 	}
 
-	return true
+	return c.healthCheckOptions(c.Command, opts)
 }
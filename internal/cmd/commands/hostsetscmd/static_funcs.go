@@ -1,9 +1,27 @@
 package hostsetscmd
 
 import (
+	"github.com/hashicorp/boundary/api/hostsets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
+func init() {
+	extraStaticActionsFlagsMapFunc = extraStaticActionsFlagsMapFuncImpl
+	extraStaticFlagsFunc = extraStaticFlagsFuncImpl
+	extraStaticFlagsHandlingFunc = extraStaticFlagsHandlingFuncImpl
+}
+
+type extraStaticCmdVars struct {
+	healthCheckCmdVars
+}
+
+func extraStaticActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": healthCheckFlags,
+		"update": healthCheckFlags,
+	}
+}
+
 func (c *StaticCommand) extraStaticHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -33,3 +51,15 @@ func (c *StaticCommand) extraStaticHelpFunc(helpMap map[string]func() string) st
 	}
 	return helpStr + c.Flags().Help()
 }
+
+func extraStaticFlagsFuncImpl(c *StaticCommand, set *base.FlagSets, f *base.FlagSet) {
+	fs := set.NewFlagSet("Static Host-Set Options")
+
+	for _, name := range flagsStaticMap[c.Func] {
+		c.addHealthCheckFlag(fs, name)
+	}
+}
+
+func extraStaticFlagsHandlingFuncImpl(c *StaticCommand, _ *base.FlagSets, opts *[]hostsets.Option) bool {
+	return c.healthCheckOptions(c.Command, opts)
+}
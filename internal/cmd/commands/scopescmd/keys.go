@@ -0,0 +1,210 @@
+package scopescmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*KeysCommand)(nil)
+	_ cli.CommandAutocomplete = (*KeysCommand)(nil)
+)
+
+const flagRewrapName = "rewrap"
+
+// KeysCommand rotates the keys of a scope and reports the progress of
+// re-encrypting the values encrypted with their previous versions.
+type KeysCommand struct {
+	*base.Command
+
+	Func string
+
+	flagRewrap bool
+}
+
+func (c *KeysCommand) Synopsis() string {
+	switch c.Func {
+	case "rotate-keys":
+		return wordwrap.WrapString("Rotate the keys of a scope", base.TermWidth)
+	case "read-key-rewrap-status":
+		return wordwrap.WrapString("Read the progress of re-encrypting values after rotating the keys of a scope", base.TermWidth)
+	}
+	return ""
+}
+
+var flagsKeys = map[string][]string{
+	"rotate-keys":            {"scope-id", flagRewrapName},
+	"read-key-rewrap-status": {"scope-id"},
+}
+
+func (c *KeysCommand) Help() string {
+	switch c.Func {
+	case "rotate-keys":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary scopes rotate-keys [options] [args]",
+			"",
+			"  Create new versions of the root key and the data keys of a scope. New values are encrypted with the new versions; existing values can still be decrypted. If -rewrap is set, values encrypted with the previous versions are re-encrypted in the background. Example:",
+			"",
+			`    $ boundary scopes rotate-keys -scope-id o_1234567890 -rewrap`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	case "read-key-rewrap-status":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary scopes read-key-rewrap-status [options] [args]",
+			"",
+			"  Read the progress of the last re-encryption started by rotating the keys of a scope. Example:",
+			"",
+			`    $ boundary scopes read-key-rewrap-status -scope-id o_1234567890`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	}
+	return ""
+}
+
+func (c *KeysCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "scope", flagsKeys, c.Func)
+
+	for _, name := range flagsKeys[c.Func] {
+		switch name {
+		case flagRewrapName:
+			f.BoolVar(&base.BoolVar{
+				Name:   flagRewrapName,
+				Target: &c.flagRewrap,
+				Usage:  "If set, values encrypted with the previous versions of the keys are re-encrypted with the new versions in the background",
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *KeysCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *KeysCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *KeysCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch c.Func {
+	case "":
+		return cli.RunResultHelp
+	}
+
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+	scopesClient := scopes.NewClient(client)
+
+	var resp *api.Response
+	var item *scopes.KeyRewrapStatus
+
+	switch c.Func {
+	case "rotate-keys":
+		result, err := scopesClient.RotateKeys(c.Context, c.FlagScopeId, c.flagRewrap)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = result.GetResponse()
+		item = result.GetItem()
+	case "read-key-rewrap-status":
+		result, err := scopesClient.ReadKeyRewrapStatus(c.Context, c.FlagScopeId)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = result.GetResponse()
+		item = result.GetItem()
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		if item == nil {
+			c.UI.Output("The keys of the scope have been rotated.")
+			return base.CommandSuccess
+		}
+		c.UI.Output(printKeyRewrapStatusTable(item))
+
+	case "json":
+		if ok := c.PrintJsonItem(resp); !ok {
+			return base.CommandCliError
+		}
+
+	case "yaml":
+		if ok := c.PrintYamlItem(resp); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *KeysCommand) checkFuncError(err error) int {
+	if err == nil {
+		return 0
+	}
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing %s on scope", c.Func))
+		return base.CommandApiError
+	}
+	c.PrintCliError(fmt.Errorf("Error trying to %s on scope: %s", c.Func, err.Error()))
+	return base.CommandCliError
+}
+
+func printKeyRewrapStatusTable(item *scopes.KeyRewrapStatus) string {
+	nonAttributeMap := map[string]interface{}{
+		"Scope ID":        item.ScopeId,
+		"Total Count":     item.TotalCount,
+		"Completed Count": item.CompletedCount,
+		"Completed":       item.Completed,
+		"Created Time":    item.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":    item.UpdatedTime.Local().Format(time.RFC1123),
+	}
+	if !item.CompletedTime.IsZero() {
+		nonAttributeMap["Completed Time"] = item.CompletedTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Key rewrap status:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
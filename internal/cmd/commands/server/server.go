@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/util"
 	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
 	"github.com/hashicorp/boundary/sdk/wrapper"
 	"github.com/hashicorp/go-hclog"
@@ -199,10 +200,15 @@ func (c *Command) Run(args []string) int {
 	if c.Config.Worker != nil {
 		switch c.WorkerAuthKms {
 		case nil:
-			if c.Config.Worker.AuthStoragePath == "" {
-				c.UI.Error("No worker auth KMS specified and no worker auth storage path specified.")
+			if c.Config.Worker.AuthStoragePath == "" && c.Config.Worker.AuthStorageVault == nil {
+				c.UI.Error("No worker auth KMS specified and no worker auth storage path or vault specified.")
 				return base.CommandUserError
 			}
+			if c.Config.Worker.AuthStorageVault == nil && c.WorkerAuthStorageKms == nil {
+				c.UI.Warn(base.WrapAtLength(
+					"WARNING! No worker-auth-storage KMS specified; the worker auth " +
+						"credentials are stored unencrypted in the worker auth storage path."))
+			}
 			if c.Config.Worker.Name != "" || c.Config.Worker.Description != "" {
 				c.UI.Error("Worker config cannot contain name or description when using PKI-based worker authentication; it must be set via the API.")
 				return base.CommandUserError
@@ -312,11 +318,9 @@ func (c *Command) Run(args []string) int {
 			}
 		}
 		for _, upstream := range c.Config.Worker.InitialUpstreams {
-			host, _, err := net.SplitHostPort(upstream)
+			host, _, err := util.SplitHostPort(upstream)
 			if err != nil {
-				if strings.Contains(err.Error(), "missing port in address") {
-					host = upstream
-				} else {
+				if !errors.Is(err, util.ErrMissingPort) {
 					c.UI.Error(fmt.Errorf("Invalid worker upstream address %q: %w", upstream, err).Error())
 					return base.CommandUserError
 				}
@@ -345,7 +349,7 @@ func (c *Command) Run(args []string) int {
 			}
 		}
 	}
-	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy", "ops"}); err != nil {
+	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy", "ops"}, base.WithListenerClientAuth(c.Config.ListenerClientAuth)); err != nil {
 		c.UI.Error(err.Error())
 		return base.CommandUserError
 	}
@@ -356,11 +360,9 @@ func (c *Command) Run(args []string) int {
 				if purpose != "cluster" {
 					continue
 				}
-				host, _, err := net.SplitHostPort(ln.Address)
+				host, _, err := util.SplitHostPort(ln.Address)
 				if err != nil {
-					if strings.Contains(err.Error(), "missing port in address") {
-						host = ln.Address
-					} else {
+					if !errors.Is(err, util.ErrMissingPort) {
 						c.UI.Error(fmt.Errorf("Invalid cluster listener address %q: %w", ln.Address, err).Error())
 						return base.CommandUserError
 					}
@@ -402,6 +404,25 @@ func (c *Command) Run(args []string) int {
 			return base.CommandCliError
 		}
 
+		if c.Config.Controller.Database.ReadUrl != "" {
+			c.DatabaseReadUrl, err = parseutil.ParsePath(c.Config.Controller.Database.ReadUrl)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				c.UI.Error(fmt.Errorf("Error parsing database read url: %w", err).Error())
+				return base.CommandUserError
+			}
+			c.DatabaseReadMaxOpenConnections = c.Config.Controller.Database.ReadMaxOpenConnections
+			c.DatabaseReadMaxIdleConnections = c.Config.Controller.Database.ReadMaxIdleConnections
+			c.DatabaseReadConnMaxIdleTimeDuration = c.Config.Controller.Database.ReadConnMaxIdleTimeDuration
+
+			if err := c.OpenAndSetServerReadDatabase(c.Context, "postgres"); err != nil {
+				c.UI.Error(fmt.Errorf("Error connecting to database read replica: %w", err).Error())
+				return base.CommandCliError
+			}
+			c.ShutdownFuncs = append(c.ShutdownFuncs, func() error {
+				return c.ReadDatabase.Close(context.Background())
+			})
+		}
+
 		sm, err := acquireSchemaManager(c.Context, c.Server.Database, c.Config.Controller.Database.SkipSharedLockAcquisition)
 		if err != nil {
 			c.UI.Error(fmt.Errorf("Failed to acquire database shared lock: %w", err).Error())
@@ -443,7 +464,7 @@ func (c *Command) Run(args []string) int {
 	}
 
 	if c.Config.Controller != nil {
-		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginHostAws, base.EnabledPluginHostAzure)
+		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginHostAws, base.EnabledPluginHostAzure, base.EnabledPluginHostGcp)
 		if err := c.StartController(c.Context); err != nil {
 			c.UI.Error(err.Error())
 			return base.CommandCliError
@@ -492,7 +513,7 @@ func (c *Command) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	opsServer, err := ops.NewServer(c.Logger, c.controller, c.worker, c.Listeners...)
+	opsServer, err := ops.NewServer(c.Logger, c.controller, c.worker, c.Config.Metrics, c.Listeners...)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return base.CommandCliError
@@ -658,8 +679,15 @@ func (c *Command) StartWorker() error {
 		if c.worker.WorkerAuthStorage == nil {
 			return fmt.Errorf("No worker auth storage found")
 		}
-		c.InfoKeys = append(c.InfoKeys, "worker auth storage path")
-		c.Info["worker auth storage path"] = c.worker.WorkerAuthStorage.BaseDir()
+		switch {
+		case c.Config.Worker.AuthStorageVault != nil:
+			v := c.Config.Worker.AuthStorageVault
+			c.InfoKeys = append(c.InfoKeys, "worker auth storage vault")
+			c.Info["worker auth storage vault"] = fmt.Sprintf("%s (%s/%s)", v.Address, v.Mount, v.Path)
+		default:
+			c.InfoKeys = append(c.InfoKeys, "worker auth storage path")
+			c.Info["worker auth storage path"] = c.worker.WorkerAuthStorage.BaseDir()
+		}
 	}
 
 	return nil
@@ -209,6 +209,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary targets authorize-session -scope-id o_1234567890 -name prod-ssh`,
 			"",
+			"    Request an authorized session using an alias of the target:",
+			"",
+			`      $ boundary targets authorize-session -id prod.postgres.primary`,
+			"",
 			"",
 		})
 	}
@@ -526,6 +530,9 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		if resp.Map[globals.SessionMaxSecondsField] != nil {
 			nonAttributeMap["Session Max Seconds"] = item.SessionMaxSeconds
 		}
+		if resp.Map[globals.SessionIdleTimeoutSecondsField] != nil {
+			nonAttributeMap["Session Idle Timeout Seconds"] = item.SessionIdleTimeoutSeconds
+		}
 	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
@@ -765,6 +772,12 @@ func printCustomActionOutputImpl(c *Command) (bool, error) {
 				return false, fmt.Errorf("Error formatting as JSON")
 			}
 			return true, nil
+
+		case "yaml":
+			if ok := c.PrintYamlItem(c.sar.GetResponse()); !ok {
+				return false, fmt.Errorf("Error formatting as YAML")
+			}
+			return true, nil
 		}
 	}
 
@@ -0,0 +1,291 @@
+// Code generated by "make cli"; DO NOT EDIT.
+package targetscmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+func initRdpFlags() {
+	flagsOnce.Do(func() {
+		extraFlags := extraRdpActionsFlagsMapFunc()
+		for k, v := range extraFlags {
+			flagsRdpMap[k] = append(flagsRdpMap[k], v...)
+		}
+	})
+}
+
+var (
+	_ cli.Command             = (*RdpCommand)(nil)
+	_ cli.CommandAutocomplete = (*RdpCommand)(nil)
+)
+
+type RdpCommand struct {
+	*base.Command
+
+	Func string
+
+	plural string
+
+	extraRdpCmdVars
+}
+
+func (c *RdpCommand) AutocompleteArgs() complete.Predictor {
+	initRdpFlags()
+	return complete.PredictAnything
+}
+
+func (c *RdpCommand) AutocompleteFlags() complete.Flags {
+	initRdpFlags()
+	return c.Flags().Completions()
+}
+
+func (c *RdpCommand) Synopsis() string {
+	if extra := extraRdpSynopsisFunc(c); extra != "" {
+		return extra
+	}
+
+	synopsisStr := "target"
+
+	synopsisStr = fmt.Sprintf("%s %s", "rdp-type", synopsisStr)
+
+	return common.SynopsisFunc(c.Func, synopsisStr)
+}
+
+func (c *RdpCommand) Help() string {
+	initRdpFlags()
+
+	var helpStr string
+	helpMap := common.HelpMap("target")
+
+	switch c.Func {
+
+	default:
+
+		helpStr = c.extraRdpHelpFunc(helpMap)
+
+	}
+
+	// Keep linter from complaining if we don't actually generate code using it
+	_ = helpMap
+	return helpStr
+}
+
+var flagsRdpMap = map[string][]string{
+
+	"create": {"scope-id", "name", "description"},
+
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *RdpCommand) Flags() *base.FlagSets {
+	if len(flagsRdpMap[c.Func]) == 0 {
+		return c.FlagSet(base.FlagSetNone)
+	}
+
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "rdp-type target", flagsRdpMap, c.Func)
+
+	extraRdpFlagsFunc(c, set, f)
+
+	return set
+}
+
+func (c *RdpCommand) Run(args []string) int {
+	initRdpFlags()
+
+	switch c.Func {
+	case "":
+		return cli.RunResultHelp
+
+	}
+
+	c.plural = "rdp-type target"
+	switch c.Func {
+	case "list":
+		c.plural = "rdp-type targets"
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if strutil.StrListContains(flagsRdpMap[c.Func], "id") && c.FlagId == "" {
+		c.PrintCliError(errors.New("ID is required but not passed in via -id"))
+		return base.CommandUserError
+	}
+
+	var opts []targets.Option
+
+	if strutil.StrListContains(flagsRdpMap[c.Func], "scope-id") {
+		switch c.Func {
+
+		case "create":
+			if c.FlagScopeId == "" {
+				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+				return base.CommandUserError
+			}
+
+		}
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+	targetsClient := targets.NewClient(client)
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultName())
+	default:
+		opts = append(opts, targets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultDescription())
+	default:
+		opts = append(opts, targets.WithDescription(c.FlagDescription))
+	}
+
+	switch c.FlagRecursive {
+	case true:
+		opts = append(opts, targets.WithRecursive(true))
+	}
+
+	if c.FlagFilter != "" {
+		opts = append(opts, targets.WithFilter(c.FlagFilter))
+	}
+
+	var version uint32
+
+	switch c.Func {
+
+	case "update":
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, targets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+
+	}
+
+	if ok := extraRdpFlagsHandlingFunc(c, f, &opts); !ok {
+		return base.CommandUserError
+	}
+
+	var resp *api.Response
+	var item *targets.Target
+
+	var createResult *targets.TargetCreateResult
+
+	var updateResult *targets.TargetUpdateResult
+
+	switch c.Func {
+
+	case "create":
+		createResult, err = targetsClient.Create(c.Context, "rdp", c.FlagScopeId, opts...)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = createResult.GetResponse()
+		item = createResult.GetItem()
+
+	case "update":
+		updateResult, err = targetsClient.Update(c.Context, c.FlagId, version, opts...)
+		if exitCode := c.checkFuncError(err); exitCode > 0 {
+			return exitCode
+		}
+		resp = updateResult.GetResponse()
+		item = updateResult.GetItem()
+
+	}
+
+	resp, item, err = executeExtraRdpActions(c, resp, item, err, targetsClient, version, opts)
+	if exitCode := c.checkFuncError(err); exitCode > 0 {
+		return exitCode
+	}
+
+	output, err := printCustomRdpActionOutput(c)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if output {
+		return base.CommandSuccess
+	}
+
+	switch c.Func {
+
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		if len(c.FlagColumns) > 0 {
+			if ok := c.PrintColumnItem(resp); !ok {
+				return base.CommandCliError
+			}
+			break
+		}
+		c.UI.Output(printItemTable(item, resp))
+
+	case "json":
+		if ok := c.PrintJsonItem(resp); !ok {
+			return base.CommandCliError
+		}
+
+	case "yaml":
+		if ok := c.PrintYamlItem(resp); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *RdpCommand) checkFuncError(err error) int {
+	if err == nil {
+		return 0
+	}
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing %s on %s", c.Func, c.plural))
+		return base.CommandApiError
+	}
+	c.PrintCliError(fmt.Errorf("Error trying to %s %s: %s", c.Func, c.plural, err.Error()))
+	return base.CommandCliError
+}
+
+var (
+	extraRdpActionsFlagsMapFunc = func() map[string][]string { return nil }
+	extraRdpSynopsisFunc        = func(*RdpCommand) string { return "" }
+	extraRdpFlagsFunc           = func(*RdpCommand, *base.FlagSets, *base.FlagSet) {}
+	extraRdpFlagsHandlingFunc   = func(*RdpCommand, *base.FlagSets, *[]targets.Option) bool { return true }
+	executeExtraRdpActions      = func(_ *RdpCommand, inResp *api.Response, inItem *targets.Target, inErr error, _ *targets.Client, _ uint32, _ []targets.Option) (*api.Response, *targets.Target, error) {
+		return inResp, inItem, inErr
+	}
+	printCustomRdpActionOutput = func(*RdpCommand) (bool, error) { return false, nil }
+)
@@ -12,7 +12,7 @@ import (
 
 func SynopsisFunc(inFunc, resType string) string {
 	if inFunc == "" {
-		return wordwrap.WrapString(fmt.Sprintf("Manage Boundary %ss", resType), base.TermWidth)
+		return wordwrap.WrapString(fmt.Sprintf("Manage Boundary %s", plural(resType)), base.TermWidth)
 	}
 	articleType := resType
 	switch resType[0] {
@@ -39,6 +39,7 @@ func HelpMap(resType string) map[string]func() string {
 		resource.Session.String():     "s",
 		resource.Target.String():      "t",
 		resource.Worker.String():      "w",
+		resource.Alias.String():       "alt",
 	}
 	return map[string]func() string{
 		"base": func() string {
@@ -127,6 +128,10 @@ func subtype(in []string, resType string, prefixMap map[string]string) []string
 		articleType = fmt.Sprintf("a %s", articleType)
 	}
 	for i, v := range in {
+		// Plural forms are substituted first so the types which are not
+		// pluralized by appending an "s" are handled.
+		v = strings.Replace(v, "{{hyphentype}}s", plural(hyphenType), -1)
+		v = strings.Replace(v, "{{type}}s", plural(resType), -1)
 		in[i] = strings.Replace(
 			strings.Replace(
 				strings.Replace(
@@ -140,3 +145,11 @@ func subtype(in []string, resType string, prefixMap map[string]string) []string
 	}
 	return in
 }
+
+// plural returns the plural form of the resource type.
+func plural(resType string) string {
+	if strings.HasSuffix(resType, "s") {
+		return resType + "es"
+	}
+	return resType + "s"
+}
@@ -11,12 +11,14 @@ import (
 	"io/ioutil"
 	"net"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/util"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	configutil "github.com/hashicorp/go-secure-stdlib/configutil/v2"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
@@ -127,8 +129,18 @@ type Config struct {
 	// Plugin-related options
 	Plugins Plugins `hcl:"plugins"`
 
-	// Internal field for use with HCP deployments. Used if controllers/ initial_upstreams is not set
+	// Metrics configures the prometheus metrics served on the ops listeners
+	Metrics *Metrics `hcl:"metrics"`
+
+	// Internal field for use with HCP deployments. Used if controllers/ initial_upstreams is not set.
+	// It can be a path to a file or env var holding the cluster id, e.g.
+	// "env://HCP_BOUNDARY_CLUSTER_ID".
 	HcpbClusterId string `hcl:"hcp_boundary_cluster_id"`
+
+	// ListenerClientAuth contains the client certificate settings of each
+	// listener in Listeners, in the same order. Entries are nil for listeners
+	// that do not authenticate clients with certificates.
+	ListenerClientAuth []*ListenerClientAuth `hcl:"-"`
 }
 
 type Controller struct {
@@ -153,12 +165,23 @@ type Controller struct {
 	GracefulShutdownWait         interface{} `hcl:"graceful_shutdown_wait_duration"`
 	GracefulShutdownWaitDuration time.Duration
 
+	// SessionIdleTimeout is the default amount of time a session can go
+	// without any traffic on its connections before it is canceled by the
+	// worker proxying it. It applies to the targets which do not set their own
+	// session idle timeout. Zero, the default, disables the idle timeout.
+	SessionIdleTimeout         interface{} `hcl:"session_idle_timeout"`
+	SessionIdleTimeoutDuration time.Duration
+
 	// StatusGracePeriod represents the period of time (as a duration) that the
 	// controller will wait before marking connections from a disconnected worker
 	// as invalid.
 	//
 	// TODO: This field is currently internal.
 	StatusGracePeriodDuration time.Duration `hcl:"-"`
+
+	// Webhooks are the endpoints receiving notifications of session and
+	// worker lifecycle events.
+	Webhooks []*Webhook `hcl:"webhook"`
 }
 
 func (c *Controller) InitNameIfEmpty() error {
@@ -210,12 +233,40 @@ type Worker struct {
 	// AuthStoragePath represents the location a worker stores its node credentials, if set
 	AuthStoragePath string `hcl:"auth_storage_path"`
 
+	// AuthStorageVault, if set, stores the node credentials of the worker in
+	// Vault instead of files. Credentials found in AuthStoragePath, if also
+	// set, are moved to Vault at startup.
+	AuthStorageVault *WorkerAuthStorageVault `hcl:"auth_storage_vault"`
+
 	// ControllerGeneratedActivationToken is a controller-generated activation
 	// token used to register this worker to the cluster. It can be a path, env
 	// var, or direct value.
 	ControllerGeneratedActivationToken string `hcl:"controller_generated_activation_token"`
 }
 
+// WorkerAuthStorageVault is the configuration block of the Vault KV version 2
+// secrets engine storing the node credentials of a worker
+type WorkerAuthStorageVault struct {
+	Address string `hcl:"address"`
+
+	// Token can be given as a string pointing to an env var or file.
+	Token     string `hcl:"token"`
+	Namespace string `hcl:"namespace"`
+
+	// Mount is the path the KV secrets engine is mounted at. It defaults to
+	// "secret".
+	Mount string `hcl:"mount"`
+
+	// Path is the path of the credentials of the worker within the secrets
+	// engine. It must be unique to the worker.
+	Path string `hcl:"path"`
+
+	// TlsCaCert is the PEM encoded CA certificate used to verify the Vault
+	// server. It can be given as a string pointing to an env var or file.
+	TlsCaCert     string `hcl:"tls_ca_cert"`
+	TlsSkipVerify bool   `hcl:"tls_skip_verify"`
+}
+
 type Database struct {
 	Url                     string         `hcl:"url"`
 	MigrationUrl            string         `hcl:"migration_url"`
@@ -226,6 +277,17 @@ type Database struct {
 	ConnMaxIdleTime         interface{}    `hcl:"max_idle_time"`
 	ConnMaxIdleTimeDuration *time.Duration `hcl:"-"`
 
+	// ReadUrl is the url of a read replica of the database. When set, queries
+	// which do not need to see the latest writes, such as listing resources,
+	// are run on the replica, with its own connection pool settings.
+	ReadUrl                     string         `hcl:"read_url"`
+	ReadMaxOpenConnections      int            `hcl:"-"`
+	ReadMaxOpenConnectionsRaw   interface{}    `hcl:"read_max_open_connections"`
+	ReadMaxIdleConnections      *int           `hcl:"-"`
+	ReadMaxIdleConnectionsRaw   interface{}    `hcl:"read_max_idle_connections"`
+	ReadConnMaxIdleTime         interface{}    `hcl:"read_max_idle_time"`
+	ReadConnMaxIdleTimeDuration *time.Duration `hcl:"-"`
+
 	// SkipSharedLockAcquisition allows skipping grabbing the database shared
 	// lock. This is dangerous unless you know what you're doing, and you should
 	// not set it unless you are the reason it's here in the first place, as not
@@ -248,8 +310,120 @@ type Scheduler struct {
 	MonitorIntervalDuration time.Duration
 }
 
+// Webhook is the configuration block of an endpoint receiving notifications
+// of the session and worker lifecycle events in a scope
+type Webhook struct {
+	Name string `hcl:",key"`
+
+	// ScopeId is the scope whose events are delivered. A webhook in an org
+	// also receives the events of the projects of the org.
+	ScopeId string `hcl:"scope_id"`
+
+	// Url and Secret can be given as a string pointing to an env var or
+	// file.
+	Url    string `hcl:"url"`
+	Secret string `hcl:"secret"`
+
+	// Events are the types of events delivered. All events are delivered if
+	// empty.
+	Events []string `hcl:"events"`
+
+	// MaxAttempts is the maximum number of delivery attempts of an event
+	// before it is dead-lettered.
+	MaxAttempts int `hcl:"max_attempts"`
+
+	// Timeout is the timeout of a single delivery attempt.
+	Timeout         interface{} `hcl:"timeout"`
+	TimeoutDuration time.Duration
+}
+
+// Values of the tls_client_auth listener field.
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequired = "required"
+)
+
+// Client certificate attributes which can be mapped to accounts.
+const (
+	ClientCertAttributeCommonName = "common_name"
+	ClientCertAttributeDnsSan     = "dns_san"
+	ClientCertAttributeEmailSan   = "email_san"
+	ClientCertAttributeUriSan     = "uri_san"
+)
+
+// ListenerClientAuth is the mutual TLS configuration of an api or ops
+// listener. It is read from the same listener block as the shared listener
+// configuration.
+type ListenerClientAuth struct {
+	// Mode is "required" to refuse connections without a client certificate
+	// signed by ClientCaFile, or "optional" to only verify client
+	// certificates when presented.
+	Mode string `hcl:"tls_client_auth"`
+
+	// ClientCaFile is the path to the PEM bundle of the CAs used to verify
+	// client certificates.
+	ClientCaFile string `hcl:"tls_client_ca_file"`
+
+	// CertAuth, when set, authenticates requests without an auth token
+	// as the account mapped to their client certificate. It is only
+	// supported on api listeners.
+	CertAuth *ClientCertAuth `hcl:"client_cert_auth"`
+}
+
+// ClientCertAuth maps a verified client certificate to an account of an auth
+// method.
+type ClientCertAuth struct {
+	// AuthMethodId is the id of a password or OIDC auth method.
+	AuthMethodId string `hcl:"auth_method_id"`
+
+	// Attribute is the certificate attribute matched against the login name
+	// of password accounts or the subject of OIDC accounts: one of
+	// "common_name", "dns_san", "email_san" or "uri_san".
+	Attribute string `hcl:"attribute"`
+}
+
+// Metrics is the configuration block of the prometheus metrics served on the
+// ops listeners
+type Metrics struct {
+	// BearerToken, if set, must be sent as a bearer token in the
+	// Authorization header of the requests for the metrics. It can be given
+	// as a string pointing to an env var or file.
+	BearerToken string `hcl:"bearer_token"`
+
+	// Username and Password, if set, must be sent as the basic
+	// authentication credentials of the requests for the metrics. They are
+	// mutually exclusive with BearerToken. Password can be given as a string
+	// pointing to an env var or file.
+	Username string `hcl:"username"`
+	Password string `hcl:"password"`
+
+	// Labels are static labels added to all the metrics registered by the
+	// controller and worker, e.g. the name or region of the cluster, so that
+	// the metrics of several clusters can be told apart.
+	Labels map[string]string `hcl:"labels"`
+}
+
 type Plugins struct {
 	ExecutionDir string `hcl:"execution_dir"`
+
+	// RotationPluginDir is the directory external static credential rotation
+	// plugins are loaded from. The file of each plugin is named with the
+	// "boundary-plugin-rotation-" prefix followed by the plugin name.
+	RotationPluginDir string `hcl:"rotation_plugin_dir"`
+
+	// RotationPlugins are the names of the external rotation plugins to load
+	// from RotationPluginDir. Rotation configs refer to a plugin by its name.
+	RotationPlugins []string `hcl:"rotation_plugins"`
+
+	// CredentialPluginDir is the directory external credential store plugins
+	// are loaded from. The file of each plugin is named with the
+	// "boundary-plugin-credential-" prefix followed by the plugin name.
+	CredentialPluginDir string `hcl:"credential_plugin_dir"`
+
+	// CredentialPlugins are the names of the external credential store
+	// plugins to load from CredentialPluginDir.
+	CredentialPlugins []string `hcl:"credential_plugins"`
 }
 
 // DevWorker is a Config that is used for dev mode of Boundary
@@ -354,6 +528,12 @@ func Parse(d string) (*Config, error) {
 		return nil, err
 	}
 
+	result.HcpbClusterId, err = parseutil.ParsePath(result.HcpbClusterId)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return nil, fmt.Errorf("Error parsing HCP Boundary cluster id: %w", err)
+	}
+	result.HcpbClusterId = strings.TrimSpace(result.HcpbClusterId)
+
 	// Perform controller configuration overrides for auth token settings
 	if result.Controller != nil {
 		result.Controller.Name, err = parseutil.ParsePath(result.Controller.Name)
@@ -397,6 +577,17 @@ func Parse(d string) (*Config, error) {
 			result.Controller.GracefulShutdownWaitDuration = t
 		}
 
+		if result.Controller.SessionIdleTimeout != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.SessionIdleTimeout)
+			if err != nil {
+				return result, err
+			}
+			if t < 0 {
+				return nil, errors.New("Controller session idle timeout must not be negative")
+			}
+			result.Controller.SessionIdleTimeoutDuration = t
+		}
+
 		if result.Controller.Scheduler != nil {
 			if result.Controller.Scheduler.JobRunInterval != "" {
 				t, err := parseutil.ParseDurationSecond(result.Controller.Scheduler.JobRunInterval)
@@ -415,6 +606,32 @@ func Parse(d string) (*Config, error) {
 			}
 		}
 
+		webhookNames := make(map[string]bool, len(result.Controller.Webhooks))
+		for _, wh := range result.Controller.Webhooks {
+			if wh.Name == "" {
+				return nil, errors.New("Webhook name must not be empty")
+			}
+			if webhookNames[wh.Name] {
+				return nil, fmt.Errorf("Webhook %q is defined more than once", wh.Name)
+			}
+			webhookNames[wh.Name] = true
+			wh.Url, err = parseutil.ParsePath(wh.Url)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				return nil, fmt.Errorf("Error parsing url of webhook %q: %w", wh.Name, err)
+			}
+			wh.Secret, err = parseutil.ParsePath(wh.Secret)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				return nil, fmt.Errorf("Error parsing secret of webhook %q: %w", wh.Name, err)
+			}
+			if wh.Timeout != nil {
+				t, err := parseutil.ParseDurationSecond(wh.Timeout)
+				if err != nil {
+					return nil, fmt.Errorf("Error parsing timeout of webhook %q: %w", wh.Name, err)
+				}
+				wh.TimeoutDuration = t
+			}
+		}
+
 		if result.Controller.Database != nil {
 			if result.Controller.Database.MaxOpenConnectionsRaw != nil {
 				switch t := result.Controller.Database.MaxOpenConnectionsRaw.(type) {
@@ -470,6 +687,60 @@ func Parse(d string) (*Config, error) {
 						reflect.TypeOf(t).String())
 				}
 			}
+			if result.Controller.Database.ReadMaxOpenConnectionsRaw != nil {
+				switch t := result.Controller.Database.ReadMaxOpenConnectionsRaw.(type) {
+				case string:
+					maxOpenConnectionsString, err := parseutil.ParsePath(t)
+					if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+						return nil, fmt.Errorf("Error parsing database read max open connections: %w", err)
+					}
+					result.Controller.Database.ReadMaxOpenConnections, err = strconv.Atoi(maxOpenConnectionsString)
+					if err != nil {
+						return nil, fmt.Errorf("Database read max open connections value is not an int: %w", err)
+					}
+				case int:
+					result.Controller.Database.ReadMaxOpenConnections = t
+				default:
+					return nil, fmt.Errorf("Database read max open connections: unsupported type %q",
+						reflect.TypeOf(t).String())
+				}
+			}
+			if result.Controller.Database.ReadMaxIdleConnectionsRaw != nil {
+				switch t := result.Controller.Database.ReadMaxIdleConnectionsRaw.(type) {
+				case string:
+					maxIdleConnectionsString, err := parseutil.ParsePath(t)
+					if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+						return nil, fmt.Errorf("Error parsing database read max idle connections: %w", err)
+					}
+					idleConns, err := strconv.Atoi(maxIdleConnectionsString)
+					if err != nil {
+						return nil, fmt.Errorf("Database read max idle connections value is not an int: %w", err)
+					}
+					result.Controller.Database.ReadMaxIdleConnections = &idleConns
+				case int:
+					result.Controller.Database.ReadMaxIdleConnections = &t
+				default:
+					return nil, fmt.Errorf("Database read max idle connections: unsupported type %q",
+						reflect.TypeOf(t).String())
+				}
+			}
+			if result.Controller.Database.ReadConnMaxIdleTime != nil {
+				switch t := result.Controller.Database.ReadConnMaxIdleTime.(type) {
+				case string:
+					durationString, err := parseutil.ParsePath(t)
+					if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+						return nil, fmt.Errorf("Error parsing read connection max idle time: %w", err)
+					}
+					connMaxIdleTime, err := parseutil.ParseDurationSecond(durationString)
+					if err != nil {
+						return nil, fmt.Errorf("Read connection max idle time is not a duration: %w", err)
+					}
+					result.Controller.Database.ReadConnMaxIdleTimeDuration = &connMaxIdleTime
+				default:
+					return nil, fmt.Errorf("Database read connection max idle time: unsupported type %q",
+						reflect.TypeOf(t).String())
+				}
+			}
 
 		}
 	}
@@ -500,6 +771,29 @@ func Parse(d string) (*Config, error) {
 			return nil, fmt.Errorf("Error parsing worker activation token: %w", err)
 		}
 
+		if v := result.Worker.AuthStorageVault; v != nil {
+			v.Token, err = parseutil.ParsePath(v.Token)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				return nil, fmt.Errorf("Error parsing worker auth storage vault token: %w", err)
+			}
+			v.TlsCaCert, err = parseutil.ParsePath(v.TlsCaCert)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				return nil, fmt.Errorf("Error parsing worker auth storage vault CA certificate: %w", err)
+			}
+			switch {
+			case v.Address == "":
+				return nil, errors.New("Worker auth storage vault address is required")
+			case v.Token == "":
+				return nil, errors.New("Worker auth storage vault token is required")
+			case strings.Trim(v.Path, "/") == "":
+				return nil, errors.New("Worker auth storage vault path is required")
+			}
+			if v.Mount == "" {
+				v.Mount = "secret"
+			}
+			v.Mount, v.Path = strings.Trim(v.Mount, "/"), strings.Trim(v.Path, "/")
+		}
+
 		if result.Worker.TagsRaw != nil {
 			switch t := result.Worker.TagsRaw.(type) {
 			// We allow `tags` to be a simple string containing a URL with schema.
@@ -648,6 +942,10 @@ func Parse(d string) (*Config, error) {
 		return nil, fmt.Errorf("error parsing: file doesn't contain a root object")
 	}
 
+	if result.ListenerClientAuth, err = parseListenerClientAuth(list.Filter("listener"), result.SharedConfig.Listeners); err != nil {
+		return nil, err
+	}
+
 	eventList := list.Filter("events")
 	switch len(eventList.Items) {
 	case 0:
@@ -666,10 +964,145 @@ func Parse(d string) (*Config, error) {
 			return nil, fmt.Errorf("Error parsing plugins execution dir: %w", err)
 		}
 	}
+	if result.Plugins.RotationPluginDir != "" {
+		result.Plugins.RotationPluginDir, err = parseutil.ParsePath(result.Plugins.RotationPluginDir)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return nil, fmt.Errorf("Error parsing plugins rotation plugin dir: %w", err)
+		}
+	}
+	if len(result.Plugins.RotationPlugins) > 0 && result.Plugins.RotationPluginDir == "" {
+		return nil, errors.New("Rotation plugins require a rotation plugin dir")
+	}
+	for i, name := range result.Plugins.RotationPlugins {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, errors.New("Rotation plugin names must not be empty")
+		}
+		result.Plugins.RotationPlugins[i] = name
+	}
+	if result.Plugins.CredentialPluginDir != "" {
+		result.Plugins.CredentialPluginDir, err = parseutil.ParsePath(result.Plugins.CredentialPluginDir)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return nil, fmt.Errorf("Error parsing plugins credential plugin dir: %w", err)
+		}
+	}
+	if len(result.Plugins.CredentialPlugins) > 0 && result.Plugins.CredentialPluginDir == "" {
+		return nil, errors.New("Credential plugins require a credential plugin dir")
+	}
+	for i, name := range result.Plugins.CredentialPlugins {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, errors.New("Credential plugin names must not be empty")
+		}
+		result.Plugins.CredentialPlugins[i] = name
+	}
+
+	if result.Metrics != nil {
+		if err := parseMetrics(result.Metrics); err != nil {
+			return nil, err
+		}
+	}
 
 	return result, nil
 }
 
+// metricsLabelNameRegex matches the valid prometheus label names.
+var metricsLabelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// parseMetrics resolves the secrets of the metrics configuration and
+// validates it.
+func parseMetrics(m *Metrics) error {
+	var err error
+	m.BearerToken, err = parseutil.ParsePath(m.BearerToken)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return fmt.Errorf("Error parsing metrics bearer token: %w", err)
+	}
+	m.Password, err = parseutil.ParsePath(m.Password)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return fmt.Errorf("Error parsing metrics password: %w", err)
+	}
+	switch {
+	case m.BearerToken != "" && (m.Username != "" || m.Password != ""):
+		return errors.New("Metrics bearer_token and username/password are mutually exclusive")
+	case (m.Username == "") != (m.Password == ""):
+		return errors.New("Metrics basic authentication requires both username and password")
+	}
+	for k, v := range m.Labels {
+		if !metricsLabelNameRegex.MatchString(k) || strings.HasPrefix(k, "__") {
+			return fmt.Errorf("Metrics label name %q is invalid", k)
+		}
+		if v == "" {
+			return fmt.Errorf("Metrics label %q has an empty value", k)
+		}
+	}
+	return nil
+}
+
+// parseListenerClientAuth reads the mutual TLS configuration of each listener.
+// The items are in the same order as the parsed listeners. It returns nil if
+// no listener authenticates clients with certificates.
+func parseListenerClientAuth(list *ast.ObjectList, listeners []*listenerutil.ListenerConfig) ([]*ListenerClientAuth, error) {
+	if len(list.Items) != len(listeners) {
+		return nil, fmt.Errorf("found %d listener blocks but %d parsed listeners", len(list.Items), len(listeners))
+	}
+	var found bool
+	ret := make([]*ListenerClientAuth, 0, len(listeners))
+	for i, item := range list.Items {
+		var ca ListenerClientAuth
+		if err := hcl.DecodeObject(&ca, item.Val); err != nil {
+			return nil, fmt.Errorf("error decoding listener client auth: %w", err)
+		}
+		switch ca.Mode {
+		case "", ClientAuthNone:
+			if ca.CertAuth != nil {
+				return nil, fmt.Errorf("listener %d: client_cert_auth requires tls_client_auth to be %q or %q", i+1, ClientAuthOptional, ClientAuthRequired)
+			}
+			ret = append(ret, nil)
+			continue
+		case ClientAuthOptional, ClientAuthRequired:
+		default:
+			return nil, fmt.Errorf("listener %d: unknown tls_client_auth value %q", i+1, ca.Mode)
+		}
+		isApi := strutil.StrListContains(listeners[i].Purpose, "api")
+		if !isApi && !strutil.StrListContains(listeners[i].Purpose, "ops") {
+			return nil, fmt.Errorf("listener %d: tls_client_auth is only supported on api and ops listeners", i+1)
+		}
+		if listeners[i].TLSDisable {
+			return nil, fmt.Errorf("listener %d: tls_client_auth requires tls to be enabled", i+1)
+		}
+		if ca.ClientCaFile == "" {
+			return nil, fmt.Errorf("listener %d: tls_client_auth requires tls_client_ca_file", i+1)
+		}
+		if ca.Mode == ClientAuthOptional && listeners[i].TLSRequireAndVerifyClientCert {
+			return nil, fmt.Errorf("listener %d: tls_client_auth %q conflicts with tls_require_and_verify_client_cert", i+1, ClientAuthOptional)
+		}
+		if ca.CertAuth != nil {
+			if !isApi {
+				return nil, fmt.Errorf("listener %d: client_cert_auth is only supported on api listeners", i+1)
+			}
+			switch {
+			case strings.HasPrefix(ca.CertAuth.AuthMethodId, "ampw_"), strings.HasPrefix(ca.CertAuth.AuthMethodId, "amoidc_"):
+			default:
+				return nil, fmt.Errorf("listener %d: client_cert_auth auth_method_id must be the id of a password or oidc auth method", i+1)
+			}
+			if ca.CertAuth.Attribute == "" {
+				ca.CertAuth.Attribute = ClientCertAttributeCommonName
+			}
+			switch ca.CertAuth.Attribute {
+			case ClientCertAttributeCommonName, ClientCertAttributeDnsSan, ClientCertAttributeEmailSan, ClientCertAttributeUriSan:
+			default:
+				return nil, fmt.Errorf("listener %d: unknown client_cert_auth attribute %q", i+1, ca.CertAuth.Attribute)
+			}
+		}
+		ret = append(ret, &ca)
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return ret, nil
+}
+
 // supportControllersRawConfig returns either initialUpstreamsRaw or controllersRaw depending on which is populated. Errors when both fields are populated.
 func supportControllersRawConfig(initialUpstreamsRaw, controllersRaw any) (any, error) {
 	switch {
@@ -694,14 +1127,13 @@ func parseWorkerUpstreams(c *Config) ([]string, error) {
 		return nil, err
 	}
 
+	var upstreams []string
 	switch t := rawUpstreams.(type) {
 	case []interface{}: // An array was configured directly in Boundary's HCL Config file.
-		var upstreams []string
 		err := mapstructure.WeakDecode(rawUpstreams, &upstreams)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode worker initial_upstreams block into config field: %w", err)
 		}
-		return upstreams, nil
 
 	case string:
 		upstreamsStr, err := parseutil.ParsePath(t)
@@ -709,17 +1141,27 @@ func parseWorkerUpstreams(c *Config) ([]string, error) {
 			return nil, fmt.Errorf("bad env var or file pointer: %w", err)
 		}
 
-		var upstreams []string
 		err = json.Unmarshal([]byte(upstreamsStr), &upstreams)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal env/file contents: %w", err)
 		}
-		return upstreams, nil
 
 	default:
 		typ := reflect.TypeOf(t)
 		return nil, fmt.Errorf("unexpected type %q", typ.String())
 	}
+
+	for _, upstream := range upstreams {
+		// Unix socket paths are used as-is
+		if strings.HasPrefix(upstream, "/") {
+			continue
+		}
+		// IPv6 literals must be bracketed when a port is given
+		if _, _, err := util.SplitHostPort(upstream); err != nil && !errors.Is(err, util.ErrMissingPort) {
+			return nil, fmt.Errorf("invalid upstream address %q: %w", upstream, err)
+		}
+	}
+	return upstreams, nil
 }
 
 func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
@@ -856,11 +1298,10 @@ func (c *Config) SetupControllerPublicClusterAddress(flagValue string) error {
 		}
 	}
 
-	host, port, err := net.SplitHostPort(c.Controller.PublicClusterAddr)
+	host, port, err := util.SplitHostPort(c.Controller.PublicClusterAddr)
 	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
+		if errors.Is(err, util.ErrMissingPort) {
 			port = "9201"
-			host = c.Controller.PublicClusterAddr
 		} else {
 			return fmt.Errorf("Error splitting public cluster adddress host/port: %w", err)
 		}
@@ -918,10 +1359,9 @@ func (c *Config) SetupWorkerInitialUpstreams() error {
 			break
 		}
 		// Best effort see if it's a domain name and if not assume it must match
-		host, _, err := net.SplitHostPort(c.Worker.InitialUpstreams[0])
-		if err != nil && strings.Contains(err.Error(), "missing port in address") {
+		host, _, err := util.SplitHostPort(c.Worker.InitialUpstreams[0])
+		if errors.Is(err, util.ErrMissingPort) {
 			err = nil
-			host = c.Worker.InitialUpstreams[0]
 		}
 		if err == nil {
 			ip := net.ParseIP(host)
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -477,6 +478,75 @@ func TestDevWorkerCredentialStorageDir(t *testing.T) {
 	}
 }
 
+func TestWorkerAuthStorageVault(t *testing.T) {
+	t.Setenv("BOUNDARY_TEST_VAULT_TOKEN", "s.token")
+	tests := []struct {
+		name       string
+		config     string
+		want       *WorkerAuthStorageVault
+		wantErrMsg string
+	}{
+		{
+			name: "valid",
+			config: `
+			worker {
+				name = "w_1234567890"
+				auth_storage_vault {
+					address = "https://vault.example.com:8200"
+					token = "env://BOUNDARY_TEST_VAULT_TOKEN"
+					path = "/boundary/workers/w_1234567890/"
+				}
+			}
+			`,
+			want: &WorkerAuthStorageVault{
+				Address: "https://vault.example.com:8200",
+				Token:   "s.token",
+				Mount:   "secret",
+				Path:    "boundary/workers/w_1234567890",
+			},
+		},
+		{
+			name: "missing-token",
+			config: `
+			worker {
+				name = "w_1234567890"
+				auth_storage_vault {
+					address = "https://vault.example.com:8200"
+					path = "boundary/workers/w_1234567890"
+				}
+			}
+			`,
+			wantErrMsg: "Worker auth storage vault token is required",
+		},
+		{
+			name: "missing-path",
+			config: `
+			worker {
+				name = "w_1234567890"
+				auth_storage_vault {
+					address = "https://vault.example.com:8200"
+					token = "s.token"
+					mount = "kv"
+				}
+			}
+			`,
+			wantErrMsg: "Worker auth storage vault path is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.config)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parsed.Worker.AuthStorageVault)
+		})
+	}
+}
+
 func TestDevKeyGeneration(t *testing.T) {
 	t.Parallel()
 	dk := DevKeyGeneration()
@@ -629,6 +699,54 @@ controller {
 	}
 }
 
+func TestControllerSessionIdleTimeout(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name    string
+		config  string
+		wantErr bool
+		want    time.Duration
+	}{
+		{
+			name:   "undefined",
+			config: `controller {}`,
+			want:   0,
+		},
+		{
+			name:   "duration",
+			config: `controller { session_idle_timeout = "30m" }`,
+			want:   30 * time.Minute,
+		},
+		{
+			name:   "seconds",
+			config: `controller { session_idle_timeout = 90 }`,
+			want:   90 * time.Second,
+		},
+		{
+			name:    "invalid",
+			config:  `controller { session_idle_timeout = "hello" }`,
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			config:  `controller { session_idle_timeout = "-5m" }`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Parse(tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Controller.SessionIdleTimeoutDuration)
+		})
+	}
+}
+
 func TestWorkerTags(t *testing.T) {
 	defaultStateFn := func(t *testing.T, tags string) {
 		t.Setenv("BOUNDARY_WORKER_TAGS", tags)
@@ -1213,6 +1331,29 @@ func TestWorkerUpstreams(t *testing.T) {
 			expWorkerUpstreams: []string{"127.0.0.1", "127.0.0.2", "127.0.0.3"},
 			expErr:             false,
 		},
+		{
+			name: "IPv6 Upstreams",
+			in: `
+			worker {
+				name = "test"
+				initial_upstreams = ["2001:db8::1", "[2001:db8::2]", "[2001:db8::3]:9201"]
+			}
+			`,
+			expWorkerUpstreams: []string{"2001:db8::1", "[2001:db8::2]", "[2001:db8::3]:9201"},
+			expErr:             false,
+		},
+		{
+			name: "Invalid Upstream",
+			in: `
+			worker {
+				name = "test"
+				initial_upstreams = ["127.0.0.1", "foo.test:9201:9202"]
+			}
+			`,
+			expWorkerUpstreams: nil,
+			expErr:             true,
+			expErrStr:          "Failed to parse worker upstreams: invalid upstream address \"foo.test:9201:9202\": address foo.test:9201:9202: too many colons in address",
+		},
 		{
 			name: "Using env var",
 			in: `
@@ -1345,6 +1486,61 @@ func TestWorkerUpstreams(t *testing.T) {
 	}
 }
 
+func TestHcpbClusterId(t *testing.T) {
+	clusterIdFile := filepath.Join(t.TempDir(), "cluster_id")
+	require.NoError(t, os.WriteFile(clusterIdFile, []byte("c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d\n"), 0o600))
+
+	tests := []struct {
+		name         string
+		in           string
+		envClusterId string
+		expClusterId string
+		expErr       bool
+		expErrStr    string
+	}{
+		{
+			name:         "literal",
+			in:           `hcp_boundary_cluster_id = "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d"`,
+			expClusterId: "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d",
+		},
+		{
+			name:         "env var",
+			in:           `hcp_boundary_cluster_id = "env://HCP_BOUNDARY_CLUSTER_ID"`,
+			envClusterId: "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d",
+			expClusterId: "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d",
+		},
+		{
+			name:         "file",
+			in:           fmt.Sprintf(`hcp_boundary_cluster_id = "file://%s"`, clusterIdFile),
+			expClusterId: "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d",
+		},
+		{
+			name:      "missing file",
+			in:        fmt.Sprintf(`hcp_boundary_cluster_id = "file://%s"`, filepath.Join(t.TempDir(), "missing")),
+			expErr:    true,
+			expErrStr: "Error parsing HCP Boundary cluster id",
+		},
+		{
+			name: "unset",
+			in:   `worker {}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HCP_BOUNDARY_CLUSTER_ID", tt.envClusterId)
+			c, err := Parse(tt.in)
+			if tt.expErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expClusterId, c.HcpbClusterId)
+		})
+	}
+}
+
 func TestControllerDescription(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1500,6 +1696,132 @@ func TestPluginExecutionDir(t *testing.T) {
 	}
 }
 
+func TestRotationPlugins(t *testing.T) {
+	tests := []struct {
+		name                   string
+		in                     string
+		envRotationPluginDir   string
+		expRotationPluginDir   string
+		expRotationPluginNames []string
+		expErr                 bool
+		expErrStr              string
+	}{
+		{
+			name: "Valid rotation plugins",
+			in: `
+			plugins {
+				rotation_plugin_dir = "env://ROTATION_PLUGIN_DIR"
+				rotation_plugins    = ["Example", " other "]
+			}`,
+			envRotationPluginDir:   `/usr/local/lib/boundary/plugins`,
+			expRotationPluginDir:   `/usr/local/lib/boundary/plugins`,
+			expRotationPluginNames: []string{"example", "other"},
+		}, {
+			name: "No rotation plugins",
+			in: `
+			plugins {
+				execution_dir = "/tmp"
+			}`,
+		}, {
+			name: "Missing rotation plugin dir",
+			in: `
+			plugins {
+				rotation_plugins = ["example"]
+			}`,
+			expErr:    true,
+			expErrStr: "Rotation plugins require a rotation plugin dir",
+		}, {
+			name: "Empty rotation plugin name",
+			in: `
+			plugins {
+				rotation_plugin_dir = "/usr/local/lib/boundary/plugins"
+				rotation_plugins    = ["example", ""]
+			}`,
+			expErr:    true,
+			expErrStr: "Rotation plugin names must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ROTATION_PLUGIN_DIR", tt.envRotationPluginDir)
+			p, err := Parse(tt.in)
+			if tt.expErr {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, p)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, p)
+			require.Equal(t, tt.expRotationPluginDir, p.Plugins.RotationPluginDir)
+			require.Equal(t, tt.expRotationPluginNames, p.Plugins.RotationPlugins)
+		})
+	}
+}
+
+func TestCredentialPlugins(t *testing.T) {
+	tests := []struct {
+		name                     string
+		in                       string
+		envCredentialPluginDir   string
+		expCredentialPluginDir   string
+		expCredentialPluginNames []string
+		expErr                   bool
+		expErrStr                string
+	}{
+		{
+			name: "Valid credential plugins",
+			in: `
+			plugins {
+				credential_plugin_dir = "env://CREDENTIAL_PLUGIN_DIR"
+				credential_plugins    = ["Example", " other "]
+			}`,
+			envCredentialPluginDir:   `/usr/local/lib/boundary/plugins`,
+			expCredentialPluginDir:   `/usr/local/lib/boundary/plugins`,
+			expCredentialPluginNames: []string{"example", "other"},
+		}, {
+			name: "No credential plugins",
+			in: `
+			plugins {
+				execution_dir = "/tmp"
+			}`,
+		}, {
+			name: "Missing credential plugin dir",
+			in: `
+			plugins {
+				credential_plugins = ["example"]
+			}`,
+			expErr:    true,
+			expErrStr: "Credential plugins require a credential plugin dir",
+		}, {
+			name: "Empty credential plugin name",
+			in: `
+			plugins {
+				credential_plugin_dir = "/usr/local/lib/boundary/plugins"
+				credential_plugins    = ["example", ""]
+			}`,
+			expErr:    true,
+			expErrStr: "Credential plugin names must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CREDENTIAL_PLUGIN_DIR", tt.envCredentialPluginDir)
+			p, err := Parse(tt.in)
+			if tt.expErr {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, p)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, p)
+			require.Equal(t, tt.expCredentialPluginDir, p.Plugins.CredentialPluginDir)
+			require.Equal(t, tt.expCredentialPluginNames, p.Plugins.CredentialPlugins)
+		})
+	}
+}
+
 func TestDatabaseMaxConnections(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -1777,6 +2099,93 @@ func TestDatabaseConnMaxIdleTimeDuration(t *testing.T) {
 	}
 }
 
+func TestDatabaseReadReplica(t *testing.T) {
+	tests := []struct {
+		name                      string
+		in                        string
+		envReadUrl                string
+		expReadUrl                string
+		expReadMaxOpenConnections int
+		expReadMaxIdleConnections *int
+		expReadConnMaxIdleTime    *time.Duration
+		expErr                    bool
+		expErrStr                 string
+	}{
+		{
+			name: "not set",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+			  	}
+			}`,
+		},
+		{
+			name: "all set",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					read_url = "postgres://replica"
+					read_max_open_connections = 10
+					read_max_idle_connections = "5"
+					read_max_idle_time = "5m"
+			  	}
+			}`,
+			expReadUrl:                "postgres://replica",
+			expReadMaxOpenConnections: 10,
+			expReadMaxIdleConnections: func() *int { i := 5; return &i }(),
+			expReadConnMaxIdleTime:    func() *time.Duration { d := 5 * time.Minute; return &d }(),
+		},
+		{
+			name:       "env read url",
+			envReadUrl: "postgres://replica",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					read_url = "env://ENV_READ_URL"
+			  	}
+			}`,
+			// The url is resolved when the server starts, not when parsing
+			expReadUrl: "env://ENV_READ_URL",
+		},
+		{
+			name: "invalid read max open connections",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					read_max_open_connections = "bogus value"
+			  	}
+			}`,
+			expErr: true,
+			expErrStr: "Database read max open connections value is not an int: " +
+				"strconv.Atoi: parsing \"bogus value\": invalid syntax",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV_READ_URL", tt.envReadUrl)
+			c, err := Parse(tt.in)
+			if tt.expErr {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.NotNil(t, c.Controller.Database)
+			require.Equal(t, tt.expReadUrl, c.Controller.Database.ReadUrl)
+			require.Equal(t, tt.expReadMaxOpenConnections, c.Controller.Database.ReadMaxOpenConnections)
+			require.Equal(t, tt.expReadMaxIdleConnections, c.Controller.Database.ReadMaxIdleConnections)
+			require.Equal(t, tt.expReadConnMaxIdleTime, c.Controller.Database.ReadConnMaxIdleTimeDuration)
+		})
+	}
+}
+
 func TestDatabaseSkipSharedLockAcquisition(t *testing.T) {
 	tests := []struct {
 		name                         string
@@ -1871,6 +2280,51 @@ func TestSetupControllerPublicClusterAddress(t *testing.T) {
 			expErrStr:               "",
 			expPublicClusterAddress: "127.0.0.1:8080",
 		},
+		{
+			name: "setting public cluster address directly with bare ipv6",
+			inputConfig: &Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Controller: &Controller{
+					PublicClusterAddr: "2001:db8::1",
+				},
+			},
+			inputFlagValue:          "",
+			expErr:                  false,
+			expErrStr:               "",
+			expPublicClusterAddress: "[2001:db8::1]:9201",
+		},
+		{
+			name: "setting public cluster address directly with bracketed ipv6",
+			inputConfig: &Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Controller: &Controller{
+					PublicClusterAddr: "[2001:db8::1]",
+				},
+			},
+			inputFlagValue:          "",
+			expErr:                  false,
+			expErrStr:               "",
+			expPublicClusterAddress: "[2001:db8::1]:9201",
+		},
+		{
+			name: "setting public cluster address directly with ipv6:port",
+			inputConfig: &Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{},
+				},
+				Controller: &Controller{
+					PublicClusterAddr: "[2001:db8::1]:8080",
+				},
+			},
+			inputFlagValue:          "",
+			expErr:                  false,
+			expErrStr:               "",
+			expPublicClusterAddress: "[2001:db8::1]:8080",
+		},
 		{
 			name: "setting public cluster address to env var",
 			inputConfig: &Config{
@@ -2053,6 +2507,34 @@ func TestSetupControllerPublicClusterAddress(t *testing.T) {
 			expErrStr:               "",
 			expPublicClusterAddress: "127.0.0.1:8080",
 		},
+		{
+			name: "read address from listeners ipv6 only",
+			inputConfig: &Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{
+						{Purpose: []string{"cluster"}, Address: "[2001:db8::1]"},
+					},
+				},
+				Controller: &Controller{},
+			},
+			expErr:                  false,
+			expErrStr:               "",
+			expPublicClusterAddress: "[2001:db8::1]:9201",
+		},
+		{
+			name: "read address from listeners ipv6:port",
+			inputConfig: &Config{
+				SharedConfig: &configutil.SharedConfig{
+					Listeners: []*listenerutil.ListenerConfig{
+						{Purpose: []string{"cluster"}, Address: "[2001:db8::1]:8080"},
+					},
+				},
+				Controller: &Controller{},
+			},
+			expErr:                  false,
+			expErrStr:               "",
+			expPublicClusterAddress: "[2001:db8::1]:8080",
+		},
 		{
 			name: "read address from listeners is ignored on different purpose",
 			inputConfig: &Config{
@@ -2088,9 +2570,9 @@ func TestSetupControllerPublicClusterAddress(t *testing.T) {
 				},
 				Controller: &Controller{},
 			},
-			inputFlagValue:          "abc::123",
+			inputFlagValue:          "abc::xyz",
 			expErr:                  true,
-			expErrStr:               "Error splitting public cluster adddress host/port: address abc::123: too many colons in address",
+			expErrStr:               "Error splitting public cluster adddress host/port: address abc::xyz: too many colons in address",
 			expPublicClusterAddress: "",
 		},
 		{
@@ -2327,3 +2809,400 @@ func TestSetupWorkerInitialUpstreams(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerWebhooks(t *testing.T) {
+	t.Setenv("BOUNDARY_TEST_WEBHOOK_SECRET", "env-secret")
+	cases := []struct {
+		name    string
+		config  string
+		want    []*Webhook
+		wantErr string
+	}{
+		{
+			name:   "none",
+			config: `controller {}`,
+		},
+		{
+			name: "full",
+			config: `
+controller {
+  webhook "chatops" {
+    scope_id     = "o_1234567890"
+    url          = "https://hooks.example.com/boundary"
+    secret       = "env://BOUNDARY_TEST_WEBHOOK_SECRET"
+    events       = ["session.authorized", "session.terminated"]
+    max_attempts = 5
+    timeout      = "5s"
+  }
+  webhook "tickets" {
+    scope_id = "global"
+    url      = "https://tickets.example.com/hook"
+    secret   = "s3cr3t"
+  }
+}
+`,
+			want: []*Webhook{
+				{
+					Name:            "chatops",
+					ScopeId:         "o_1234567890",
+					Url:             "https://hooks.example.com/boundary",
+					Secret:          "env-secret",
+					Events:          []string{"session.authorized", "session.terminated"},
+					MaxAttempts:     5,
+					Timeout:         "5s",
+					TimeoutDuration: 5 * time.Second,
+				},
+				{
+					Name:    "tickets",
+					ScopeId: "global",
+					Url:     "https://tickets.example.com/hook",
+					Secret:  "s3cr3t",
+				},
+			},
+		},
+		{
+			name: "duplicate-name",
+			config: `
+controller {
+  webhook "chatops" {
+    scope_id = "global"
+  }
+  webhook "chatops" {
+    scope_id = "global"
+  }
+}
+`,
+			wantErr: `Webhook "chatops" is defined more than once`,
+		},
+		{
+			name: "invalid-timeout",
+			config: `
+controller {
+  webhook "chatops" {
+    timeout = "soon"
+  }
+}
+`,
+			wantErr: `Error parsing timeout of webhook "chatops"`,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Parse(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Controller.Webhooks)
+		})
+	}
+}
+
+func TestListenerClientAuth(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  string
+		want    []*ListenerClientAuth
+		wantErr string
+	}{
+		{
+			name: "none",
+			config: `
+listener "tcp" {
+  purpose = "api"
+}
+`,
+		},
+		{
+			name: "full",
+			config: `
+listener "tcp" {
+  purpose = "cluster"
+}
+listener "tcp" {
+  purpose            = "api"
+  tls_cert_file      = "/etc/boundary/api.pem"
+  tls_key_file       = "/etc/boundary/api.key"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+  client_cert_auth {
+    auth_method_id = "amoidc_1234567890"
+    attribute      = "uri_san"
+  }
+}
+listener "tcp" {
+  purpose            = "api"
+  tls_cert_file      = "/etc/boundary/api.pem"
+  tls_key_file       = "/etc/boundary/api.key"
+  tls_client_auth    = "optional"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+}
+`,
+			want: []*ListenerClientAuth{
+				nil,
+				{
+					Mode:         ClientAuthRequired,
+					ClientCaFile: "/etc/boundary/mesh-ca.pem",
+					CertAuth: &ClientCertAuth{
+						AuthMethodId: "amoidc_1234567890",
+						Attribute:    ClientCertAttributeUriSan,
+					},
+				},
+				{
+					Mode:         ClientAuthOptional,
+					ClientCaFile: "/etc/boundary/mesh-ca.pem",
+				},
+			},
+		},
+		{
+			name: "unknown-mode",
+			config: `
+listener "tcp" {
+  purpose            = "api"
+  tls_client_auth    = "sometimes"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+}
+`,
+			wantErr: `unknown tls_client_auth value "sometimes"`,
+		},
+		{
+			name: "ops",
+			config: `
+listener "tcp" {
+  purpose            = "ops"
+  tls_cert_file      = "/etc/boundary/ops.pem"
+  tls_key_file       = "/etc/boundary/ops.key"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/scraper-ca.pem"
+}
+`,
+			want: []*ListenerClientAuth{
+				{
+					Mode:         ClientAuthRequired,
+					ClientCaFile: "/etc/boundary/scraper-ca.pem",
+				},
+			},
+		},
+		{
+			name: "not-api-or-ops",
+			config: `
+listener "tcp" {
+  purpose            = "cluster"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+}
+`,
+			wantErr: "tls_client_auth is only supported on api and ops listeners",
+		},
+		{
+			name: "cert-auth-on-ops",
+			config: `
+listener "tcp" {
+  purpose            = "ops"
+  tls_cert_file      = "/etc/boundary/ops.pem"
+  tls_key_file       = "/etc/boundary/ops.key"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/scraper-ca.pem"
+  client_cert_auth {
+    auth_method_id = "ampw_1234567890"
+  }
+}
+`,
+			wantErr: "client_cert_auth is only supported on api listeners",
+		},
+		{
+			name: "tls-disabled",
+			config: `
+listener "tcp" {
+  purpose            = "api"
+  tls_disable        = true
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+}
+`,
+			wantErr: "tls_client_auth requires tls to be enabled",
+		},
+		{
+			name: "missing-ca",
+			config: `
+listener "tcp" {
+  purpose         = "api"
+  tls_client_auth = "optional"
+}
+`,
+			wantErr: "tls_client_auth requires tls_client_ca_file",
+		},
+		{
+			name: "cert-auth-without-mode",
+			config: `
+listener "tcp" {
+  purpose = "api"
+  client_cert_auth {
+    auth_method_id = "ampw_1234567890"
+    attribute      = "common_name"
+  }
+}
+`,
+			wantErr: "client_cert_auth requires tls_client_auth",
+		},
+		{
+			name: "cert-auth-bad-auth-method",
+			config: `
+listener "tcp" {
+  purpose            = "api"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+  client_cert_auth {
+    auth_method_id = "u_1234567890"
+    attribute      = "common_name"
+  }
+}
+`,
+			wantErr: "must be the id of a password or oidc auth method",
+		},
+		{
+			name: "cert-auth-bad-attribute",
+			config: `
+listener "tcp" {
+  purpose            = "api"
+  tls_client_auth    = "required"
+  tls_client_ca_file = "/etc/boundary/mesh-ca.pem"
+  client_cert_auth {
+    auth_method_id = "ampw_1234567890"
+    attribute      = "serial"
+  }
+}
+`,
+			wantErr: `unknown client_cert_auth attribute "serial"`,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Parse(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.ListenerClientAuth)
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	t.Setenv("BOUNDARY_METRICS_TOKEN", "s3cr3t")
+	cases := []struct {
+		name    string
+		config  string
+		want    *Metrics
+		wantErr string
+	}{
+		{
+			name:   "none",
+			config: ``,
+		},
+		{
+			name: "bearer-token",
+			config: `
+metrics {
+  bearer_token = "env://BOUNDARY_METRICS_TOKEN"
+  labels {
+    cluster = "us-east-prod"
+    region  = "us-east-1"
+  }
+}
+`,
+			want: &Metrics{
+				BearerToken: "s3cr3t",
+				Labels: map[string]string{
+					"cluster": "us-east-prod",
+					"region":  "us-east-1",
+				},
+			},
+		},
+		{
+			name: "basic-auth",
+			config: `
+metrics {
+  username = "prometheus"
+  password = "env://BOUNDARY_METRICS_TOKEN"
+}
+`,
+			want: &Metrics{
+				Username: "prometheus",
+				Password: "s3cr3t",
+			},
+		},
+		{
+			name: "bearer-token-and-basic-auth",
+			config: `
+metrics {
+  bearer_token = "token"
+  username     = "prometheus"
+  password     = "password"
+}
+`,
+			wantErr: "mutually exclusive",
+		},
+		{
+			name: "username-without-password",
+			config: `
+metrics {
+  username = "prometheus"
+}
+`,
+			wantErr: "requires both username and password",
+		},
+		{
+			name: "invalid-label-name",
+			config: `
+metrics {
+  labels {
+    "cluster-name" = "prod"
+  }
+}
+`,
+			wantErr: `Metrics label name "cluster-name" is invalid`,
+		},
+		{
+			name: "reserved-label-name",
+			config: `
+metrics {
+  labels {
+    __name__ = "prod"
+  }
+}
+`,
+			wantErr: `Metrics label name "__name__" is invalid`,
+		},
+		{
+			name: "empty-label-value",
+			config: `
+metrics {
+  labels {
+    region = ""
+  }
+}
+`,
+			wantErr: `Metrics label "region" has an empty value`,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Parse(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Metrics)
+		})
+	}
+}
@@ -160,7 +160,7 @@ func RunCustom(args []string, runOpts *RunOptions) int {
 	}
 
 	switch format {
-	case "table", "json":
+	case "table", "json", "yaml":
 	default:
 		ui.Error(fmt.Sprintf("Invalid output format: %s", format))
 		return 1
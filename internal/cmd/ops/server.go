@@ -4,13 +4,17 @@ package ops
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/daemon/controller"
 	"github.com/hashicorp/boundary/internal/daemon/worker"
 	"github.com/hashicorp/go-cleanhttp"
@@ -19,7 +23,10 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/mitchellh/cli"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
 )
 
 // Server is a collection of all state required to serve
@@ -37,7 +44,8 @@ type opsBundle struct {
 
 // NewServer iterates through all the listeners and sets up HTTP Servers for each, along with individual handlers.
 // If Controller is set-up, NewServer will set-up a health endpoint for it.
-func NewServer(l hclog.Logger, c *controller.Controller, w *worker.Worker, listeners ...*base.ServerListener) (*Server, error) {
+// The metrics endpoint requires the credentials configured in metrics, if any.
+func NewServer(l hclog.Logger, c *controller.Controller, w *worker.Worker, metrics *config.Metrics, listeners ...*base.ServerListener) (*Server, error) {
 	const op = "ops.NewServer()"
 	if l == nil {
 		return nil, fmt.Errorf("%s: missing logger", op)
@@ -55,7 +63,7 @@ func NewServer(l hclog.Logger, c *controller.Controller, w *worker.Worker, liste
 			return nil, fmt.Errorf("%s: missing ops listener", op)
 		}
 
-		h, err := createOpsHandler(ln.Config, c, w)
+		h, err := createOpsHandler(ln.Config, c, w, metrics)
 		if err != nil {
 			return nil, err
 		}
@@ -129,7 +137,7 @@ func (s *Server) WaitIfHealthExists(d time.Duration, ui cli.Ui) {
 	<-time.After(d)
 }
 
-func createOpsHandler(lncfg *listenerutil.ListenerConfig, c *controller.Controller, w *worker.Worker) (http.Handler, error) {
+func createOpsHandler(lncfg *listenerutil.ListenerConfig, c *controller.Controller, w *worker.Worker, metrics *config.Metrics) (http.Handler, error) {
 	mux := http.NewServeMux()
 	var h http.Handler
 	var err error
@@ -153,10 +161,105 @@ func createOpsHandler(lncfg *listenerutil.ListenerConfig, c *controller.Controll
 		// either a controller or worker is starting up, but just to be safe.
 		mux.Handle("/health", h)
 	}
-	mux.Handle("/metrics", promhttp.Handler())
+	metricsHandler := promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(metricsGatherer(prometheus.DefaultGatherer, metrics), promhttp.HandlerOpts{}),
+	)
+	mux.Handle("/metrics", wrapHandlerWithMetricsAuth(metricsHandler, metrics))
 	return cleanhttp.PrintablePathCheckHandler(mux, nil), nil
 }
 
+// metricsGatherer returns a gatherer adding the static labels configured in
+// metrics, if any, to all the metrics gathered by g.
+func metricsGatherer(g prometheus.Gatherer, metrics *config.Metrics) prometheus.Gatherer {
+	if metrics == nil || len(metrics.Labels) == 0 {
+		return g
+	}
+	labels := make([]*dto.LabelPair, 0, len(metrics.Labels))
+	for k, v := range metrics.Labels {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(k), Value: proto.String(v)})
+	}
+	return &labeledGatherer{Gatherer: g, labels: labels}
+}
+
+// labeledGatherer adds static labels to the metrics of a gatherer. A metric
+// keeps its own value of a label it already has.
+type labeledGatherer struct {
+	prometheus.Gatherer
+	labels []*dto.LabelPair
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *labeledGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.Gatherer.Gather()
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			existing := make(map[string]bool, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				existing[l.GetName()] = true
+			}
+			for _, l := range g.labels {
+				if !existing[l.GetName()] {
+					m.Label = append(m.Label, l)
+				}
+			}
+			sort.Slice(m.Label, func(i, j int) bool { return m.Label[i].GetName() < m.Label[j].GetName() })
+		}
+	}
+	return mfs, err
+}
+
+// wrapHandlerWithMetricsAuth rejects the requests without the bearer token or
+// basic authentication credentials configured in metrics. It returns h as is
+// if no credentials are configured.
+func wrapHandlerWithMetricsAuth(h http.Handler, metrics *config.Metrics) http.Handler {
+	switch {
+	case metrics == nil:
+		return h
+	case metrics.BearerToken != "":
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok || !secureCompare(token, metrics.BearerToken) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	case metrics.Username != "":
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			// Both are compared so that the time taken does not reveal which
+			// one is wrong.
+			usernameOk := secureCompare(username, metrics.Username)
+			passwordOk := secureCompare(password, metrics.Password)
+			if !ok || !usernameOk || !passwordOk {
+				w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	default:
+		return h
+	}
+}
+
+// bearerToken returns the bearer token of the Authorization header of r.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "bearer "
+	v := r.Header.Get("Authorization")
+	if len(v) <= len(prefix) || !strings.EqualFold(v[:len(prefix)], prefix) {
+		return "", false
+	}
+	return v[len(prefix):], true
+}
+
+// secureCompare compares the strings in constant time.
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
 func createHttpServer(l hclog.Logger, h http.Handler, lncfg *listenerutil.ListenerConfig) *http.Server {
 	s := &http.Server{
 		Handler:           h,
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/daemon/controller"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/health"
 	"github.com/hashicorp/boundary/internal/daemon/worker"
@@ -33,6 +35,7 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/hashicorp/go-secure-stdlib/reloadutil"
 	"github.com/mitchellh/cli"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -104,7 +107,7 @@ func TestNewServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, err := NewServer(tt.logger, tt.c, tt.w, tt.listeners...)
+			s, err := NewServer(tt.logger, tt.c, tt.w, nil, tt.listeners...)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, s)
@@ -276,7 +279,7 @@ func TestNewServerIntegration(t *testing.T) {
 			err := bs.SetupListeners(nil, &configutil.SharedConfig{Listeners: tt.listeners}, []string{"ops"})
 			require.NoError(t, err)
 
-			s, err := NewServer(hclog.Default(), nil, nil, bs.Listeners...)
+			s, err := NewServer(hclog.Default(), nil, nil, nil, bs.Listeners...)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, s)
@@ -573,7 +576,7 @@ func TestHealthEndpointLifecycle(t *testing.T) {
 	t.Cleanup(tc.Shutdown)
 
 	// Controller has started and is set onto our Command object, start ops.
-	opsServer, err := NewServer(hclog.Default(), tc.Controller(), nil, tc.Config().Listeners...)
+	opsServer, err := NewServer(hclog.Default(), tc.Controller(), nil, nil, tc.Config().Listeners...)
 	require.NoError(t, err)
 	opsServer.Start()
 
@@ -775,7 +778,7 @@ func TestCreateOpsHandler(t *testing.T) {
 				w = tc.Worker()
 			}
 
-			h, err := createOpsHandler(tt.lncfg, c, w)
+			h, err := createOpsHandler(tt.lncfg, c, w, nil)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, h)
@@ -798,6 +801,146 @@ func TestCreateOpsHandler(t *testing.T) {
 	}
 }
 
+func TestMetricsGatherer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGoCollector())
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_counter",
+		Help: "A counter for testing.",
+	}, []string{"cluster"})
+	reg.MustRegister(counter)
+	counter.WithLabelValues("staging").Inc()
+
+	t.Run("no-labels", func(t *testing.T) {
+		assert.Equal(t, prometheus.Gatherer(reg), metricsGatherer(reg, nil))
+		assert.Equal(t, prometheus.Gatherer(reg), metricsGatherer(reg, &config.Metrics{}))
+	})
+	t.Run("labels", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		g := metricsGatherer(reg, &config.Metrics{Labels: map[string]string{
+			"cluster": "prod",
+			"region":  "us-east-1",
+		}})
+		mfs, err := g.Gather()
+		require.NoError(err)
+		require.NotEmpty(mfs)
+		for _, mf := range mfs {
+			for _, m := range mf.GetMetric() {
+				got := map[string]string{}
+				for _, l := range m.GetLabel() {
+					got[l.GetName()] = l.GetValue()
+				}
+				assert.Equal("us-east-1", got["region"], mf.GetName())
+				if mf.GetName() == "test_counter" {
+					// A metric keeps its own value of the label
+					assert.Equal("staging", got["cluster"])
+				} else {
+					assert.Equal("prod", got["cluster"], mf.GetName())
+				}
+			}
+		}
+	})
+}
+
+func TestWrapHandlerWithMetricsAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name       string
+		metrics    *config.Metrics
+		setAuth    func(r *http.Request)
+		wantStatus int
+		wantHeader string
+	}{
+		{
+			name:       "no metrics config",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no credentials configured",
+			metrics:    &config.Metrics{Labels: map[string]string{"region": "us-east-1"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "bearer token",
+			metrics: &config.Metrics{BearerToken: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer s3cr3t")
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing bearer token",
+			metrics:    &config.Metrics{BearerToken: "s3cr3t"},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Bearer realm="metrics"`,
+		},
+		{
+			name:    "wrong bearer token",
+			metrics: &config.Metrics{BearerToken: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer guess")
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Bearer realm="metrics"`,
+		},
+		{
+			name:    "basic auth instead of bearer token",
+			metrics: &config.Metrics{BearerToken: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.SetBasicAuth("prometheus", "s3cr3t")
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Bearer realm="metrics"`,
+		},
+		{
+			name:    "basic auth",
+			metrics: &config.Metrics{Username: "prometheus", Password: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.SetBasicAuth("prometheus", "s3cr3t")
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing basic auth",
+			metrics:    &config.Metrics{Username: "prometheus", Password: "s3cr3t"},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Basic realm="metrics"`,
+		},
+		{
+			name:    "wrong username",
+			metrics: &config.Metrics{Username: "prometheus", Password: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.SetBasicAuth("grafana", "s3cr3t")
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Basic realm="metrics"`,
+		},
+		{
+			name:    "wrong password",
+			metrics: &config.Metrics{Username: "prometheus", Password: "s3cr3t"},
+			setAuth: func(r *http.Request) {
+				r.SetBasicAuth("prometheus", "guess")
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Basic realm="metrics"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.setAuth != nil {
+				tt.setAuth(r)
+			}
+			rec := httptest.NewRecorder()
+			wrapHandlerWithMetricsAuth(ok, tt.metrics).ServeHTTP(rec, r)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantHeader, rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
 func TestCreateHttpServer(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"context"
+
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/grpc"
+)
+
+var _ plgpb.CredentialPluginServiceClient = (*WrappingPluginClient)(nil)
+
+// WrappingPluginClient provides a wrapper around a Server implementation that
+// can be used when loading a plugin in-memory
+type WrappingPluginClient struct {
+	Server plgpb.CredentialPluginServiceServer
+}
+
+func NewWrappingPluginClient(s plgpb.CredentialPluginServiceServer) *WrappingPluginClient {
+	return &WrappingPluginClient{Server: s}
+}
+
+func (tpc *WrappingPluginClient) NormalizeStoreData(ctx context.Context, req *plgpb.NormalizeStoreDataRequest, opts ...grpc.CallOption) (*plgpb.NormalizeStoreDataResponse, error) {
+	return tpc.Server.NormalizeStoreData(ctx, req)
+}
+
+func (tpc *WrappingPluginClient) OnCreateStore(ctx context.Context, req *plgpb.OnCreateStoreRequest, opts ...grpc.CallOption) (*plgpb.OnCreateStoreResponse, error) {
+	return tpc.Server.OnCreateStore(ctx, req)
+}
+
+func (tpc *WrappingPluginClient) OnUpdateStore(ctx context.Context, req *plgpb.OnUpdateStoreRequest, opts ...grpc.CallOption) (*plgpb.OnUpdateStoreResponse, error) {
+	return tpc.Server.OnUpdateStore(ctx, req)
+}
+
+func (tpc *WrappingPluginClient) OnDeleteStore(ctx context.Context, req *plgpb.OnDeleteStoreRequest, opts ...grpc.CallOption) (*plgpb.OnDeleteStoreResponse, error) {
+	return tpc.Server.OnDeleteStore(ctx, req)
+}
+
+func (tpc *WrappingPluginClient) IssueCredentials(ctx context.Context, req *plgpb.IssueCredentialsRequest, opts ...grpc.CallOption) (*plgpb.IssueCredentialsResponse, error) {
+	return tpc.Server.IssueCredentials(ctx, req)
+}
+
+func (tpc *WrappingPluginClient) RevokeCredentials(ctx context.Context, req *plgpb.RevokeCredentialsRequest, opts ...grpc.CallOption) (*plgpb.RevokeCredentialsResponse, error) {
+	return tpc.Server.RevokeCredentials(ctx, req)
+}
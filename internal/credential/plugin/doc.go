@@ -0,0 +1,5 @@
+// Package plugin provides the in-memory support for credential store plugins,
+// which broker credentials from external systems over the
+// CredentialPluginService gRPC interface. External credential plugins are
+// served with and loaded by the sdk/plugins/credential package.
+package plugin
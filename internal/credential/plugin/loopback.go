@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const loopbackPluginCredentialsAttrField = "credentials"
+
+var _ plgpb.CredentialPluginServiceServer = (*loopbackPlugin)(nil)
+
+// loopbackPlugin provides a credential plugin that keeps everything in memory
+// and is useful for testing and as a reference for plugin authors. The
+// credentials a library issues are defined in the "credentials" attribute of
+// the library, either as a single object or a list of objects.
+type loopbackPlugin struct {
+	*TestPluginServer
+
+	l sync.Mutex
+	// issued maps the external ids of the issued revocable credentials to the
+	// id of the session they were issued for.
+	issued map[string]string
+}
+
+type loopbackPluginCredentialInfo struct {
+	Secret               map[string]interface{} `mapstructure:"secret"`
+	LeaseDurationSeconds uint32                 `mapstructure:"lease_duration_seconds"`
+	Revocable            bool                   `mapstructure:"revocable"`
+}
+
+// NewLoopbackPlugin returns a new loopback plugin
+func NewLoopbackPlugin() plgpb.CredentialPluginServiceServer {
+	ret := &loopbackPlugin{
+		TestPluginServer: new(TestPluginServer),
+		issued:           make(map[string]string),
+	}
+	ret.OnCreateStoreFn = ret.onCreateStore
+	ret.OnUpdateStoreFn = ret.onUpdateStore
+	ret.OnDeleteStoreFn = ret.onDeleteStore
+	ret.IssueCredentialsFn = ret.issueCredentials
+	ret.RevokeCredentialsFn = ret.revokeCredentials
+	return ret
+}
+
+func (l *loopbackPlugin) onCreateStore(ctx context.Context, req *plgpb.OnCreateStoreRequest) (*plgpb.OnCreateStoreResponse, error) {
+	const op = "plugin.(loopbackPlugin).onCreateStore"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "req is nil")
+	}
+	if secrets := req.GetSecrets(); secrets != nil {
+		return &plgpb.OnCreateStoreResponse{
+			Persisted: &plgpb.CredentialStorePersisted{
+				Secrets: secrets,
+			},
+		}, nil
+	}
+	return &plgpb.OnCreateStoreResponse{}, nil
+}
+
+func (l *loopbackPlugin) onUpdateStore(ctx context.Context, req *plgpb.OnUpdateStoreRequest) (*plgpb.OnUpdateStoreResponse, error) {
+	const op = "plugin.(loopbackPlugin).onUpdateStore"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "req is nil")
+	}
+	if secrets := req.GetSecrets(); secrets != nil {
+		return &plgpb.OnUpdateStoreResponse{
+			Persisted: &plgpb.CredentialStorePersisted{
+				Secrets: secrets,
+			},
+		}, nil
+	}
+	return &plgpb.OnUpdateStoreResponse{}, nil
+}
+
+func (l *loopbackPlugin) onDeleteStore(ctx context.Context, req *plgpb.OnDeleteStoreRequest) (*plgpb.OnDeleteStoreResponse, error) {
+	const op = "plugin.(loopbackPlugin).onDeleteStore"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "req is nil")
+	}
+	return &plgpb.OnDeleteStoreResponse{}, nil
+}
+
+func (l *loopbackPlugin) issueCredentials(ctx context.Context, req *plgpb.IssueCredentialsRequest) (*plgpb.IssueCredentialsResponse, error) {
+	const op = "plugin.(loopbackPlugin).issueCredentials"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "req is nil")
+	}
+	lib := req.GetLibrary()
+	if lib == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "library is nil")
+	}
+	if req.GetSessionId() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+
+	var infos []*loopbackPluginCredentialInfo
+	if attrs := lib.GetAttributes(); attrs != nil {
+		switch t := attrs.AsMap()[loopbackPluginCredentialsAttrField].(type) {
+		case nil:
+		case []interface{}:
+			for _, c := range t {
+				info := new(loopbackPluginCredentialInfo)
+				if err := mapstructure.Decode(c, info); err != nil {
+					return nil, errors.Wrap(ctx, err, op)
+				}
+				infos = append(infos, info)
+			}
+		case map[string]interface{}:
+			info := new(loopbackPluginCredentialInfo)
+			if err := mapstructure.Decode(t, info); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			infos = append(infos, info)
+		default:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown credential info type %T", t))
+		}
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+	resp := new(plgpb.IssueCredentialsResponse)
+	for i, info := range infos {
+		secret, err := structpb.NewStruct(info.Secret)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		externalId := fmt.Sprintf("%s_%s_%d", lib.GetId(), req.GetSessionId(), i)
+		if info.Revocable {
+			l.issued[externalId] = req.GetSessionId()
+		}
+		resp.Credentials = append(resp.Credentials, &plgpb.IssuedCredential{
+			ExternalId:           externalId,
+			Secret:               secret,
+			LeaseDurationSeconds: info.LeaseDurationSeconds,
+			Revocable:            info.Revocable,
+		})
+	}
+	return resp, nil
+}
+
+func (l *loopbackPlugin) revokeCredentials(ctx context.Context, req *plgpb.RevokeCredentialsRequest) (*plgpb.RevokeCredentialsResponse, error) {
+	const op = "plugin.(loopbackPlugin).revokeCredentials"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "req is nil")
+	}
+	l.l.Lock()
+	defer l.l.Unlock()
+	for _, id := range req.GetExternalIds() {
+		if sessionId, ok := l.issued[id]; !ok || sessionId != req.GetSessionId() {
+			return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential %q was not issued for session %q", id, req.GetSessionId()))
+		}
+	}
+	for _, id := range req.GetExternalIds() {
+		delete(l.issued, id)
+	}
+	return &plgpb.RevokeCredentialsResponse{}, nil
+}
@@ -0,0 +1,162 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentialstores"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	external_credential_plugins "github.com/hashicorp/boundary/sdk/plugins/credential"
+	goplugin "github.com/hashicorp/go-plugin"
+	ta "github.com/stretchr/testify/assert"
+	tr "github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestLoopbackPlugin is a quick test of basic functionality.
+func TestLoopbackPlugin(t *testing.T) {
+	testLoopbackPlugin(t, NewWrappingPluginClient(NewLoopbackPlugin()))
+}
+
+// TestLoopbackPlugin_Grpc runs the same test against the loopback plugin
+// served and dispensed over the go-plugin gRPC interface used by external
+// credential plugins.
+func TestLoopbackPlugin_Grpc(t *testing.T) {
+	require := tr.New(t)
+	srv, err := external_credential_plugins.NewCredentialPluginServiceServer(NewLoopbackPlugin())
+	require.NoError(err)
+	client, server := goplugin.TestPluginGRPCConn(t, map[string]goplugin.Plugin{
+		"credential-plugin": srv,
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	raw, err := client.Dispense("credential-plugin")
+	require.NoError(err)
+	plg, ok := raw.(plgpb.CredentialPluginServiceClient)
+	require.True(ok)
+	testLoopbackPlugin(t, plg)
+}
+
+func testLoopbackPlugin(t *testing.T, plg plgpb.CredentialPluginServiceClient) {
+	t.Helper()
+	require, assert := tr.New(t), ta.New(t)
+	ctx := context.Background()
+
+	secretsMap := map[string]interface{}{
+		"token": "secret-token",
+		"baz":   true,
+	}
+	secrets, err := structpb.NewStruct(secretsMap)
+	require.NoError(err)
+
+	// Secrets given on creation come back as persisted data
+	createResp, err := plg.OnCreateStore(ctx, &plgpb.OnCreateStoreRequest{
+		Store:   &credentialstores.CredentialStore{Id: "store1"},
+		Secrets: secrets,
+	})
+	require.NoError(err)
+	require.NotNil(createResp.GetPersisted())
+	assert.EqualValues(secretsMap, createResp.GetPersisted().GetSecrets().AsMap())
+
+	newSecretsMap := map[string]interface{}{
+		"token": "new-secret-token",
+	}
+	newSecrets, err := structpb.NewStruct(newSecretsMap)
+	require.NoError(err)
+	updateResp, err := plg.OnUpdateStore(ctx, &plgpb.OnUpdateStoreRequest{
+		CurrentStore: &credentialstores.CredentialStore{Id: "store1"},
+		NewStore:     &credentialstores.CredentialStore{Id: "store1"},
+		Secrets:      newSecrets,
+		Persisted:    createResp.GetPersisted(),
+	})
+	require.NoError(err)
+	require.NotNil(updateResp.GetPersisted())
+	assert.EqualValues(newSecretsMap, updateResp.GetPersisted().GetSecrets().AsMap())
+	persisted := updateResp.GetPersisted()
+
+	// Issue the credentials defined in the library attributes
+	attrs, err := structpb.NewStruct(map[string]interface{}{
+		loopbackPluginCredentialsAttrField: []interface{}{
+			map[string]interface{}{
+				"secret": map[string]interface{}{
+					"username": "user",
+					"password": "pass",
+				},
+				"lease_duration_seconds": 300,
+				"revocable":              true,
+			},
+			map[string]interface{}{
+				"secret": map[string]interface{}{
+					"api_key": "key",
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	lib := &credentiallibraries.CredentialLibrary{
+		Id:    "lib1",
+		Attrs: &credentiallibraries.CredentialLibrary_Attributes{Attributes: attrs},
+	}
+	issueResp, err := plg.IssueCredentials(ctx, &plgpb.IssueCredentialsRequest{
+		Store:     &credentialstores.CredentialStore{Id: "store1"},
+		Library:   lib,
+		Persisted: persisted,
+		SessionId: "s_1",
+	})
+	require.NoError(err)
+	require.Len(issueResp.GetCredentials(), 2)
+	revocable, static := issueResp.GetCredentials()[0], issueResp.GetCredentials()[1]
+	assert.Equal("lib1_s_1_0", revocable.GetExternalId())
+	assert.EqualValues(map[string]interface{}{"username": "user", "password": "pass"}, revocable.GetSecret().AsMap())
+	assert.Equal(uint32(300), revocable.GetLeaseDurationSeconds())
+	assert.True(revocable.GetRevocable())
+	assert.Equal("lib1_s_1_1", static.GetExternalId())
+	assert.EqualValues(map[string]interface{}{"api_key": "key"}, static.GetSecret().AsMap())
+	assert.Zero(static.GetLeaseDurationSeconds())
+	assert.False(static.GetRevocable())
+
+	// A session id is required
+	_, err = plg.IssueCredentials(ctx, &plgpb.IssueCredentialsRequest{Library: lib})
+	require.Error(err)
+
+	// Only the revocable credentials of the session can be revoked
+	_, err = plg.RevokeCredentials(ctx, &plgpb.RevokeCredentialsRequest{
+		Store:       &credentialstores.CredentialStore{Id: "store1"},
+		Persisted:   persisted,
+		SessionId:   "s_2",
+		ExternalIds: []string{revocable.GetExternalId()},
+	})
+	require.Error(err)
+	_, err = plg.RevokeCredentials(ctx, &plgpb.RevokeCredentialsRequest{
+		Store:       &credentialstores.CredentialStore{Id: "store1"},
+		Persisted:   persisted,
+		SessionId:   "s_1",
+		ExternalIds: []string{static.GetExternalId()},
+	})
+	require.Error(err)
+	_, err = plg.RevokeCredentials(ctx, &plgpb.RevokeCredentialsRequest{
+		Store:       &credentialstores.CredentialStore{Id: "store1"},
+		Persisted:   persisted,
+		SessionId:   "s_1",
+		ExternalIds: []string{revocable.GetExternalId()},
+	})
+	require.NoError(err)
+
+	// Revoked credentials can not be revoked again
+	_, err = plg.RevokeCredentials(ctx, &plgpb.RevokeCredentialsRequest{
+		Store:       &credentialstores.CredentialStore{Id: "store1"},
+		Persisted:   persisted,
+		SessionId:   "s_1",
+		ExternalIds: []string{revocable.GetExternalId()},
+	})
+	require.Error(err)
+
+	_, err = plg.OnDeleteStore(ctx, &plgpb.OnDeleteStoreRequest{
+		Store:     &credentialstores.CredentialStore{Id: "store1"},
+		Persisted: persisted,
+	})
+	require.NoError(err)
+}
@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"context"
+
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+var _ plgpb.CredentialPluginServiceServer = (*TestPluginServer)(nil)
+
+// TestPluginServer provides a credential plugin service server where each
+// method can be overwritten for tests.
+type TestPluginServer struct {
+	NormalizeStoreDataFn func(context.Context, *plgpb.NormalizeStoreDataRequest) (*plgpb.NormalizeStoreDataResponse, error)
+	OnCreateStoreFn      func(context.Context, *plgpb.OnCreateStoreRequest) (*plgpb.OnCreateStoreResponse, error)
+	OnUpdateStoreFn      func(context.Context, *plgpb.OnUpdateStoreRequest) (*plgpb.OnUpdateStoreResponse, error)
+	OnDeleteStoreFn      func(context.Context, *plgpb.OnDeleteStoreRequest) (*plgpb.OnDeleteStoreResponse, error)
+	IssueCredentialsFn   func(context.Context, *plgpb.IssueCredentialsRequest) (*plgpb.IssueCredentialsResponse, error)
+	RevokeCredentialsFn  func(context.Context, *plgpb.RevokeCredentialsRequest) (*plgpb.RevokeCredentialsResponse, error)
+	plgpb.UnimplementedCredentialPluginServiceServer
+}
+
+func (t TestPluginServer) NormalizeStoreData(ctx context.Context, req *plgpb.NormalizeStoreDataRequest) (*plgpb.NormalizeStoreDataResponse, error) {
+	if t.NormalizeStoreDataFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.NormalizeStoreData(ctx, req)
+	}
+	return t.NormalizeStoreDataFn(ctx, req)
+}
+
+func (t TestPluginServer) OnCreateStore(ctx context.Context, req *plgpb.OnCreateStoreRequest) (*plgpb.OnCreateStoreResponse, error) {
+	if t.OnCreateStoreFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.OnCreateStore(ctx, req)
+	}
+	return t.OnCreateStoreFn(ctx, req)
+}
+
+func (t TestPluginServer) OnUpdateStore(ctx context.Context, req *plgpb.OnUpdateStoreRequest) (*plgpb.OnUpdateStoreResponse, error) {
+	if t.OnUpdateStoreFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.OnUpdateStore(ctx, req)
+	}
+	return t.OnUpdateStoreFn(ctx, req)
+}
+
+func (t TestPluginServer) OnDeleteStore(ctx context.Context, req *plgpb.OnDeleteStoreRequest) (*plgpb.OnDeleteStoreResponse, error) {
+	if t.OnDeleteStoreFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.OnDeleteStore(ctx, req)
+	}
+	return t.OnDeleteStoreFn(ctx, req)
+}
+
+func (t TestPluginServer) IssueCredentials(ctx context.Context, req *plgpb.IssueCredentialsRequest) (*plgpb.IssueCredentialsResponse, error) {
+	if t.IssueCredentialsFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.IssueCredentials(ctx, req)
+	}
+	return t.IssueCredentialsFn(ctx, req)
+}
+
+func (t TestPluginServer) RevokeCredentials(ctx context.Context, req *plgpb.RevokeCredentialsRequest) (*plgpb.RevokeCredentialsResponse, error) {
+	if t.RevokeCredentialsFn == nil {
+		return t.UnimplementedCredentialPluginServiceServer.RevokeCredentials(ctx, req)
+	}
+	return t.RevokeCredentialsFn(ctx, req)
+}
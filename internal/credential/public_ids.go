@@ -18,6 +18,9 @@ func init() {
 	if err := subtypes.Register(Domain, JsonSubtype, JsonCredentialPrefix); err != nil {
 		panic(err)
 	}
+	if err := subtypes.Register(Domain, SshCertificateSubtype, SshCertificateCredentialPrefix); err != nil {
+		panic(err)
+	}
 }
 
 const (
@@ -30,6 +33,9 @@ const (
 
 	JsonCredentialPrefix = "credjson"
 	JsonSubtype          = subtypes.Subtype("json")
+
+	SshCertificateCredentialPrefix = "credsshc"
+	SshCertificateSubtype          = subtypes.Subtype("ssh_certificate")
 )
 
 func NewUsernamePasswordCredentialId(ctx context.Context) (string, error) {
@@ -55,3 +61,11 @@ func NewJsonCredentialId(ctx context.Context) (string, error) {
 	}
 	return id, nil
 }
+
+func NewSshCertificateCredentialId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(SshCertificateCredentialPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "credential.NewSshCertificateCredentialId")
+	}
+	return id, nil
+}
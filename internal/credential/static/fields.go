@@ -6,7 +6,16 @@ const (
 	descriptionField          = "Description"
 	usernameField             = "Username"
 	passwordField             = "Password"
+	certificateField          = "Certificate"
 	privateKeyField           = "PrivateKey"
 	PrivateKeyPassphraseField = "PrivateKeyPassphrase"
 	objectField               = "Object"
 )
+
+// These constants are the field names used in the rotation config field masks.
+const (
+	rotatorField                 = "Rotator"
+	rotationAttributesField      = "Attributes"
+	rotationIntervalSecondsField = "RotationIntervalSeconds"
+	gracePeriodSecondsField      = "GracePeriodSeconds"
+)
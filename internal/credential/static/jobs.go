@@ -0,0 +1,501 @@
+package static
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	credentialRotationJobName      = "static_credential_rotation"
+	retiredSecretRetirementJobName = "static_credential_retired_secret_retirement"
+
+	defaultNextRunIn = 5 * time.Minute
+)
+
+// RegisterJobs registers the static credential related jobs with the
+// provided scheduler. rotators contains the registered rotation plugins
+// keyed by name, the built-in rotators are always available.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, kms *kms.Kms, rotators map[string]plgpb.RotationPluginServiceClient) error {
+	const op = "static.RegisterJobs"
+	all := BuiltinRotators()
+	for name, plg := range rotators {
+		if _, ok := all[name]; ok {
+			return errors.New(ctx, errors.InvalidParameter, op, "rotation plugin name conflicts with a built-in rotator: "+name)
+		}
+		all[name] = plg
+	}
+
+	rotation, err := newCredentialRotationJob(ctx, r, w, kms, all)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, rotation); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("credential rotation job"))
+	}
+	retirement, err := newRetiredSecretRetirementJob(ctx, r, w, kms, all)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, retirement); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("retired secret retirement job"))
+	}
+	return nil
+}
+
+// CredentialRotationJob is the recurring job that rotates the secrets of
+// static credentials that have a rotation config and are due for rotation.
+// The CredentialRotationJob is not thread safe, an attempt to Run the job
+// concurrently will result in an JobAlreadyRunning error.
+type CredentialRotationJob struct {
+	reader   db.Reader
+	writer   db.Writer
+	kms      *kms.Kms
+	rotators map[string]plgpb.RotationPluginServiceClient
+	limit    int
+
+	running      ua.Bool
+	numCreds     int
+	numProcessed int
+	numFailed    int
+}
+
+// newCredentialRotationJob creates a new in-memory CredentialRotationJob.
+//
+// WithLimit is the only supported option.
+func newCredentialRotationJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms, rotators map[string]plgpb.RotationPluginServiceClient, opt ...Option) (*CredentialRotationJob, error) {
+	const op = "static.newCredentialRotationJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &CredentialRotationJob{
+		reader:   r,
+		writer:   w,
+		kms:      kms,
+		rotators: rotators,
+		limit:    opts.withLimit,
+	}, nil
+}
+
+// Status returns the current status of the credential rotation job. Total is
+// the total number of credentials that are due for rotation. Completed is
+// the number of credentials already processed.
+func (j *CredentialRotationJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numProcessed,
+		Total:     j.numCreds,
+	}
+}
+
+// Run rotates the secrets of all static credentials that are due for
+// rotation. The pending secrets left by previous runs, on this or any other
+// controller, are stored first, and a credential is not rotated again while
+// it has a pending secret. Can not be run in parallel, if Run is invoked
+// while already running an error with code JobAlreadyRunning will be
+// returned.
+func (j *CredentialRotationJob) Run(ctx context.Context) error {
+	const op = "static.(CredentialRotationJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	j.numProcessed, j.numFailed, j.numCreds = 0, 0, 0
+	if err := j.storePending(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var configs []*RotationConfig
+	err := j.reader.SearchWhere(ctx, &configs, rotationDueWhereClause, nil, db.WithLimit(j.limit))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numProcessed and numCreds for status report
+	j.numCreds = len(configs)
+	for _, c := range configs {
+		// Verify context is not done before rotating next credential
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := j.rotate(ctx, c); err != nil {
+			j.numFailed++
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error rotating credential", "credential id", c.CredentialId, "rotator", c.Rotator))
+		}
+		j.numProcessed++
+	}
+
+	return nil
+}
+
+func (j *CredentialRotationJob) rotate(ctx context.Context, c *RotationConfig) error {
+	const op = "static.(CredentialRotationJob).rotate"
+	rotator, ok := j.rotators[c.Rotator]
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "unknown rotator")
+	}
+	attrs, err := c.RotationAttributes()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+	}
+
+	repo, err := NewRepository(ctx, j.reader, j.writer, j.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	creds, err := repo.Retrieve(ctx, c.ProjectId, []string{c.CredentialId})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	current := creds[0]
+	secret, err := secretFromCredential(ctx, current)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	resp, err := rotator.RotateCredential(ctx, &plgpb.RotateCredentialRequest{
+		CredentialId:   c.CredentialId,
+		CredentialType: string(staticCredentialType(current)),
+		Attributes:     attrs,
+		Secret:         secret,
+		SecretVersion:  c.SecretVersion,
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("rotator failed to rotate credential"))
+	}
+	rotated, err := credentialWithSecret(ctx, current, resp.GetSecret())
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("rotator returned an invalid secret"))
+	}
+	// The rotator has already applied the new secret, so it is stored as
+	// the pending secret of the credential before the credential is
+	// updated. If the update fails, the pending secret is stored by the
+	// next run instead of rotating again.
+	if err := repo.storePendingSecret(ctx, c, rotated); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to store rotated secret"))
+	}
+	if err := repo.rotateCredential(ctx, c, current, rotated); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to store rotated secret, it is kept as pending secret"))
+	}
+	return nil
+}
+
+// storePending stores the pending secrets that were applied by a rotator
+// but could not replace the secret of their credential. A pending secret is
+// dropped if the credential was rotated in the meantime, and it is deleted
+// along with the rotation config of its credential.
+func (j *CredentialRotationJob) storePending(ctx context.Context) error {
+	const op = "static.(CredentialRotationJob).storePending"
+	var pending []*PendingSecret
+	if err := j.reader.SearchWhere(ctx, &pending, "", nil, db.WithLimit(j.limit)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	repo, err := NewRepository(ctx, j.reader, j.writer, j.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, p := range pending {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		err := j.storePendingSecret(ctx, repo, p)
+		switch {
+		case err == nil:
+		case errors.Match(errors.T(errors.NotSpecificIntegrity), err):
+			event.WriteError(ctx, op, err, event.WithInfoMsg("dropping pending secret of a credential that changed", "credential id", p.CredentialId))
+			if err := repo.deletePendingSecret(ctx, p.CredentialId); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error deleting pending secret", "credential id", p.CredentialId))
+			}
+		default:
+			j.numFailed++
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error storing pending secret", "credential id", p.CredentialId))
+		}
+	}
+	return nil
+}
+
+// storePendingSecret replaces the secret of the credential of the pending
+// secret p with it.
+func (j *CredentialRotationJob) storePendingSecret(ctx context.Context, repo *Repository, p *PendingSecret) error {
+	const op = "static.(CredentialRotationJob).storePendingSecret"
+	c, err := repo.LookupRotationConfig(ctx, p.CredentialId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case c == nil:
+		// The rotation config was deleted, which deletes the pending secret.
+		return nil
+	case c.SecretVersion != p.SecretVersion:
+		return errors.New(ctx, errors.NotSpecificIntegrity, op, "credential was rotated after the pending secret was applied")
+	}
+
+	databaseWrapper, err := j.kms.GetWrapper(ctx, c.ProjectId, kms.KeyPurposeDatabase, kms.WithKeyId(p.KeyId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := p.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	secret, err := p.secretStruct()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+	}
+
+	creds, err := repo.Retrieve(ctx, c.ProjectId, []string{c.CredentialId})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	current := creds[0]
+	rotated, err := credentialWithSecret(ctx, current, secret)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := repo.rotateCredential(ctx, c, current, rotated); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// NextRunIn queries the rotation configs to determine when the next
+// credential is due for rotation. The job runs at least every
+// defaultNextRunIn, and if the last run failed to rotate a credential, it is
+// not run again before defaultNextRunIn.
+func (j *CredentialRotationJob) NextRunIn(ctx context.Context) (time.Duration, error) {
+	const op = "static.(CredentialRotationJob).NextRunIn"
+	rows, err := j.reader.Query(ctx, rotationNextRunInQuery, nil)
+	if err != nil {
+		return defaultNextRunIn, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	next := defaultNextRunIn
+	for rows.Next() {
+		type NextRotation struct {
+			RotationIn time.Duration
+		}
+		var n NextRotation
+		if err := j.reader.ScanRows(ctx, rows, &n); err != nil {
+			return defaultNextRunIn, errors.Wrap(ctx, err, op)
+		}
+		next = n.RotationIn * time.Second
+		if next < 0 {
+			// If we are past the next rotation time, return 0 to schedule immediately
+			next = 0
+		}
+	}
+	if next > defaultNextRunIn || j.numFailed > 0 {
+		// Check at least every defaultNextRunIn for new rotation configs, but
+		// do not retry failed rotations more often than that.
+		next = defaultNextRunIn
+	}
+	return next, nil
+}
+
+// Name is the unique name of the job.
+func (j *CredentialRotationJob) Name() string {
+	return credentialRotationJobName
+}
+
+// Description is the human readable description of the job.
+func (j *CredentialRotationJob) Description() string {
+	return "Periodically rotates the secrets of static credentials that have a rotation config."
+}
+
+// RetiredSecretRetirementJob is the recurring job that retires the previous
+// secrets of rotated static credentials once their grace period has passed
+// and no session created before the rotation is still active. The
+// RetiredSecretRetirementJob is not thread safe, an attempt to Run the job
+// concurrently will result in an JobAlreadyRunning error.
+type RetiredSecretRetirementJob struct {
+	reader   db.Reader
+	writer   db.Writer
+	kms      *kms.Kms
+	rotators map[string]plgpb.RotationPluginServiceClient
+	limit    int
+
+	running      ua.Bool
+	numSecrets   int
+	numProcessed int
+}
+
+// newRetiredSecretRetirementJob creates a new in-memory
+// RetiredSecretRetirementJob.
+//
+// WithLimit is the only supported option.
+func newRetiredSecretRetirementJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms, rotators map[string]plgpb.RotationPluginServiceClient, opt ...Option) (*RetiredSecretRetirementJob, error) {
+	const op = "static.newRetiredSecretRetirementJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &RetiredSecretRetirementJob{
+		reader:   r,
+		writer:   w,
+		kms:      kms,
+		rotators: rotators,
+		limit:    opts.withLimit,
+	}, nil
+}
+
+// Status returns the current status of the retired secret retirement job.
+// Total is the total number of retired secrets that can be retired.
+// Completed is the number of retired secrets already processed.
+func (j *RetiredSecretRetirementJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numProcessed,
+		Total:     j.numSecrets,
+	}
+}
+
+// Run retires all retired secrets that are no longer used by any session
+// and deletes them from the repository. Can not be run in parallel, if Run
+// is invoked while already running an error with code JobAlreadyRunning will
+// be returned.
+func (j *RetiredSecretRetirementJob) Run(ctx context.Context) error {
+	const op = "static.(RetiredSecretRetirementJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var secrets []*retirableSecret
+	err := j.reader.SearchWhere(ctx, &secrets, "", nil, db.WithLimit(j.limit))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numProcessed and numSecrets for status report
+	j.numProcessed, j.numSecrets = 0, len(secrets)
+	for _, s := range secrets {
+		// Verify context is not done before retiring next secret
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := j.retire(ctx, s); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error retiring secret", "credential id", s.CredentialId, "secret version", s.SecretVersion))
+		}
+		j.numProcessed++
+	}
+
+	return nil
+}
+
+func (j *RetiredSecretRetirementJob) retire(ctx context.Context, s *retirableSecret) error {
+	const op = "static.(RetiredSecretRetirementJob).retire"
+	repo, err := NewRepository(ctx, j.reader, j.writer, j.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c, err := repo.LookupRotationConfig(ctx, s.CredentialId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if c == nil {
+		// The rotation config was deleted, which deletes the retired secret.
+		return nil
+	}
+	rotator, ok := j.rotators[c.Rotator]
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "unknown rotator")
+	}
+	attrs, err := c.RotationAttributes()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+	}
+
+	databaseWrapper, err := j.kms.GetWrapper(ctx, s.ProjectId, kms.KeyPurposeDatabase, kms.WithKeyId(s.KeyId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	retired := &RetiredSecret{RetiredSecret: s.RetiredSecret}
+	if err := retired.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	retiredSecret, err := retired.secretStruct()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+	}
+
+	creds, err := repo.Retrieve(ctx, s.ProjectId, []string{s.CredentialId})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	current := creds[0]
+	secret, err := secretFromCredential(ctx, current)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	if _, err := rotator.RetireCredential(ctx, &plgpb.RetireCredentialRequest{
+		CredentialId:         s.CredentialId,
+		CredentialType:       string(staticCredentialType(current)),
+		Attributes:           attrs,
+		Secret:               secret,
+		RetiredSecret:        retiredSecret,
+		RetiredSecretVersion: s.SecretVersion,
+	}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("rotator failed to retire secret"))
+	}
+	if err := repo.deleteRetiredSecret(ctx, retired); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// NextRunIn returns the default run frequency of the retired secret
+// retirement job.
+func (j *RetiredSecretRetirementJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return defaultNextRunIn, nil
+}
+
+// Name is the unique name of the job.
+func (j *RetiredSecretRetirementJob) Name() string {
+	return retiredSecretRetirementJobName
+}
+
+// Description is the human readable description of the job.
+func (j *RetiredSecretRetirementJob) Description() string {
+	return "Periodically retires the previous secrets of rotated static credentials that are no longer used by any session."
+}
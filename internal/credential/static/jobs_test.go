@@ -0,0 +1,297 @@
+package static
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const testRotatorName = "test"
+
+// testRotator is a rotation plugin that sets the password of a
+// username_password credential to the next value in passwords and records
+// the retired passwords.
+type testRotator struct {
+	plgpb.UnimplementedRotationPluginServiceServer
+	passwords []string
+	retired   []string
+}
+
+func (r *testRotator) RotateCredential(_ context.Context, req *plgpb.RotateCredentialRequest) (*plgpb.RotateCredentialResponse, error) {
+	next := r.passwords[0]
+	r.passwords = r.passwords[1:]
+	secret, err := structpb.NewStruct(map[string]interface{}{
+		secretUsernameField: req.GetSecret().GetFields()[secretUsernameField].GetStringValue(),
+		secretPasswordField: next,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plgpb.RotateCredentialResponse{Secret: secret}, nil
+}
+
+func (r *testRotator) RetireCredential(_ context.Context, req *plgpb.RetireCredentialRequest) (*plgpb.RetireCredentialResponse, error) {
+	r.retired = append(r.retired, req.GetRetiredSecret().GetFields()[secretPasswordField].GetStringValue())
+	return &plgpb.RetireCredentialResponse{}, nil
+}
+
+// failingWriter is a db.Writer whose transactions fail while fail is set.
+type failingWriter struct {
+	db.Writer
+	fail bool
+}
+
+func (w *failingWriter) DoTx(ctx context.Context, retries uint, backOff db.Backoff, handler db.TxHandler) (db.RetryInfo, error) {
+	if w.fail {
+		return db.RetryInfo{}, errors.New(ctx, errors.Internal, "failingWriter.DoTx", "transaction failed")
+	}
+	return w.Writer.DoTx(ctx, retries, backOff, handler)
+}
+
+func TestRegisterJobs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	err := RegisterJobs(ctx, sche, rw, rw, kkms, map[string]plgpb.RotationPluginServiceClient{
+		PasswordRotator: NewWrappingRotationPluginClient(&testRotator{}),
+	})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+
+	err = RegisterJobs(ctx, sche, rw, rw, kkms, map[string]plgpb.RotationPluginServiceClient{
+		testRotatorName: NewWrappingRotationPluginClient(&testRotator{}),
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialRotationJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+	other := TestUsernamePasswordCredential(t, conn, wrapper, "other", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+	for _, id := range []string{cred.PublicId, other.PublicId} {
+		in, err := NewRotationConfig(ctx, id, testRotatorName, time.Hour)
+		require.NoError(t, err)
+		_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+		require.NoError(t, err)
+	}
+
+	rotator := &testRotator{passwords: []string{"first", "second"}}
+	rotators := map[string]plgpb.RotationPluginServiceClient{testRotatorName: NewWrappingRotationPluginClient(rotator)}
+	rotationJob, err := newCredentialRotationJob(ctx, rw, rw, kkms, rotators)
+	require.NoError(t, err)
+	retirementJob, err := newRetiredSecretRetirementJob(ctx, rw, rw, kkms, rotators)
+	require.NoError(t, err)
+
+	// No credential is due for rotation yet
+	require.NoError(t, rotationJob.Run(ctx))
+	assert.Equal(t, 0, rotationJob.numCreds)
+	next, err := rotationJob.NextRunIn(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, defaultNextRunIn, next)
+
+	_, err = rw.Exec(ctx, "update credential_static_rotation set next_rotation_time = now() where credential_id = ?", []interface{}{cred.PublicId})
+	require.NoError(t, err)
+	next, err = rotationJob.NextRunIn(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), next)
+
+	require.NoError(t, rotationJob.Run(ctx))
+	assert.Equal(t, 1, rotationJob.numCreds)
+	assert.Equal(t, 1, rotationJob.numProcessed)
+	assert.Equal(t, 0, rotationJob.numFailed)
+
+	creds, err := repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId, other.PublicId})
+	require.NoError(t, err)
+	for _, c := range creds {
+		switch c.GetPublicId() {
+		case cred.PublicId:
+			assert.Equal(t, []byte("first"), c.(*UsernamePasswordCredential).GetPassword())
+		case other.PublicId:
+			assert.Equal(t, []byte("pass"), c.(*UsernamePasswordCredential).GetPassword())
+		}
+	}
+	cfg, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), cfg.SecretVersion)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cfg.NextRotationTime.AsTime(), time.Minute)
+
+	// The grace period of the retired secret has passed, it is retired
+	require.NoError(t, retirementJob.Run(ctx))
+	assert.Equal(t, 1, retirementJob.numSecrets)
+	assert.Equal(t, []string{"pass"}, rotator.retired)
+
+	var retired []*RetiredSecret
+	require.NoError(t, rw.SearchWhere(ctx, &retired, "credential_id = ?", []interface{}{cred.PublicId}))
+	assert.Empty(t, retired)
+}
+
+func TestCredentialRotationJob_StoreFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+	in, err := NewRotationConfig(ctx, cred.PublicId, testRotatorName, time.Hour)
+	require.NoError(t, err)
+	_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	require.NoError(t, err)
+	_, err = rw.Exec(ctx, "update credential_static_rotation set next_rotation_time = now() where credential_id = ?", []interface{}{cred.PublicId})
+	require.NoError(t, err)
+
+	w := &failingWriter{Writer: rw, fail: true}
+	rotator := &testRotator{passwords: []string{"first", "second"}}
+	rotators := map[string]plgpb.RotationPluginServiceClient{testRotatorName: NewWrappingRotationPluginClient(rotator)}
+	job, err := newCredentialRotationJob(ctx, rw, w, kkms, rotators)
+	require.NoError(t, err)
+
+	// The rotated secret cannot replace the secret of the credential, it is
+	// kept as pending secret
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numFailed)
+	assert.Equal(t, []string{"second"}, rotator.passwords)
+	var pending []*PendingSecret
+	require.NoError(t, rw.SearchWhere(ctx, &pending, "credential_id = ?", []interface{}{cred.PublicId}))
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint64(1), pending[0].SecretVersion)
+	assert.NotEmpty(t, pending[0].CtSecret)
+	assert.Empty(t, pending[0].Secret)
+
+	// The credential is not rotated again while its secret is pending
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numFailed)
+	assert.Equal(t, 0, job.numCreds)
+	assert.Equal(t, []string{"second"}, rotator.passwords)
+
+	// The pending secret is stored once the database is available, also by
+	// a new job as after a restart of the controller
+	job, err = newCredentialRotationJob(ctx, rw, rw, kkms, rotators)
+	require.NoError(t, err)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.numFailed)
+	assert.Equal(t, []string{"second"}, rotator.passwords)
+	pending = nil
+	require.NoError(t, rw.SearchWhere(ctx, &pending, "credential_id = ?", []interface{}{cred.PublicId}))
+	assert.Empty(t, pending)
+
+	creds, err := repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId})
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	assert.Equal(t, []byte("first"), creds[0].(*UsernamePasswordCredential).GetPassword())
+	cfg, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), cfg.SecretVersion)
+}
+
+func TestCredentialRotationJob_StalePendingSecret(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+	in, err := NewRotationConfig(ctx, cred.PublicId, testRotatorName, time.Hour)
+	require.NoError(t, err)
+	_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	require.NoError(t, err)
+	cfg, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+
+	// A pending secret applied before the credential was rotated again is
+	// dropped
+	creds, err := repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId})
+	require.NoError(t, err)
+	stale := creds[0].(*UsernamePasswordCredential).clone()
+	stale.Password = []byte("stale")
+	require.NoError(t, repo.storePendingSecret(ctx, cfg, stale))
+	_, err = rw.Exec(ctx, "update credential_static_rotation set secret_version = 2 where credential_id = ?", []interface{}{cred.PublicId})
+	require.NoError(t, err)
+
+	rotators := map[string]plgpb.RotationPluginServiceClient{testRotatorName: NewWrappingRotationPluginClient(&testRotator{})}
+	job, err := newCredentialRotationJob(ctx, rw, rw, kkms, rotators)
+	require.NoError(t, err)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.numFailed)
+
+	var pending []*PendingSecret
+	require.NoError(t, rw.SearchWhere(ctx, &pending, "credential_id = ?", []interface{}{cred.PublicId}))
+	assert.Empty(t, pending)
+	creds, err = repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("pass"), creds[0].(*UsernamePasswordCredential).GetPassword())
+}
+
+func TestCredentialRotationJob_UnknownRotator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+	in, err := NewRotationConfig(ctx, cred.PublicId, "unknown", time.Hour)
+	require.NoError(t, err)
+	_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	require.NoError(t, err)
+	_, err = rw.Exec(ctx, "update credential_static_rotation set next_rotation_time = now() where credential_id = ?", []interface{}{cred.PublicId})
+	require.NoError(t, err)
+
+	job, err := newCredentialRotationJob(ctx, rw, rw, kkms, BuiltinRotators())
+	require.NoError(t, err)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numFailed)
+
+	// A failed rotation is not retried before the default interval
+	next, err := job.NextRunIn(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, defaultNextRunIn, next)
+
+	cfg, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), cfg.SecretVersion)
+}
@@ -1,5 +1,11 @@
 package static
 
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -19,6 +25,8 @@ type options struct {
 	withLimit                int
 	withPublicId             string
 	withPrivateKeyPassphrase []byte
+	withRotationAttributes   *structpb.Struct
+	withGracePeriod          time.Duration
 }
 
 func getDefaultOptions() options {
@@ -61,3 +69,19 @@ func WithPrivateKeyPassphrase(with []byte) Option {
 		o.withPrivateKeyPassphrase = with
 	}
 }
+
+// WithRotationAttributes provides optional attributes that are passed to the
+// rotator of a rotation config.
+func WithRotationAttributes(with *structpb.Struct) Option {
+	return func(o *options) {
+		o.withRotationAttributes = with
+	}
+}
+
+// WithGracePeriod provides an optional minimum duration a previous secret is
+// kept valid after a credential is rotated.
+func WithGracePeriod(with time.Duration) Option {
+	return func(o *options) {
+		o.withGracePeriod = with
+	}
+}
@@ -2,8 +2,10 @@ package static
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func Test_GetOpts(t *testing.T) {
@@ -33,4 +35,17 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrivateKeyPassphrase = []byte("my-pass")
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithRotationAttributes", func(t *testing.T) {
+		attrs := &structpb.Struct{Fields: map[string]*structpb.Value{"address": structpb.NewStringValue("localhost:22")}}
+		opts := getOpts(WithRotationAttributes(attrs))
+		testOpts := getDefaultOptions()
+		testOpts.withRotationAttributes = attrs
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithGracePeriod", func(t *testing.T) {
+		opts := getOpts(WithGracePeriod(time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withGracePeriod = time.Hour
+		assert.Equal(t, opts, testOpts)
+	})
 }
@@ -0,0 +1,35 @@
+package static
+
+const (
+	rotateCredentialQuery = `
+update credential_static_rotation
+   set secret_version     = secret_version + 1,
+       last_rotation_time = current_timestamp
+ where credential_id  = ?
+   and secret_version = ?;
+`
+
+	deleteRetiredSecretQuery = `
+delete from credential_static_retired_secret
+ where credential_id  = ?
+   and secret_version = ?;
+`
+
+	deletePendingSecretQuery = `
+delete from credential_static_pending_secret
+ where credential_id = ?;
+`
+
+	// rotationDueWhereClause selects the rotation configs that are due for
+	// rotation and have no pending secret left to store.
+	rotationDueWhereClause = `
+next_rotation_time <= current_timestamp
+and credential_id not in (select credential_id from credential_static_pending_secret)
+`
+
+	rotationNextRunInQuery = `
+select extract(epoch from (min(next_rotation_time) - now()))::int as rotation_in
+  from credential_static_rotation
+having min(next_rotation_time) is not null;
+`
+)
@@ -192,6 +192,101 @@ func (r *Repository) CreateSshPrivateKeyCredential(
 	return newCred, nil
 }
 
+// CreateSshCertificateCredential inserts c into the repository and returns a
+// new SshCertificateCredential containing the credential's PublicId. c is not
+// changed. c must not contain a PublicId. The PublicId is generated and
+// assigned by this method. c must contain a valid StoreId.
+//
+// The certificate is stored in plain text. The private key is encrypted and a
+// HmacSha256 of the private key is calculated. If a passphrase is supplied, it
+// is also encrypted and an HmacSha256 of passphrase is calculated. Only the
+// PrivateKeyHmac (and PrivateKeyPassphraseHmac) is returned, the plain-text
+// and encrypted private key and passphrase are not returned.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ProjectId. Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateSshCertificateCredential(
+	ctx context.Context,
+	projectId string,
+	c *SshCertificateCredential,
+	_ ...Option,
+) (*SshCertificateCredential, error) {
+	const op = "static.(Repository).CreateSshCertificateCredential"
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.SshCertificateCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if projectId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if c.Username == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing username")
+	}
+	if c.Certificate == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing certificate")
+	}
+	if c.PrivateKey == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing private key")
+	}
+	if c.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	if c.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+
+	c = c.clone()
+	id, err := credential.NewSshCertificateCredentialId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.PublicId = id
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	// encrypt
+	databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var newCred *SshCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCred = c.clone()
+			if err := w.Create(ctx, newCred,
+				db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s: name %s already exists", c.StoreId, c.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s", c.StoreId)))
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	newCred.PrivateKeyEncrypted = nil
+	newCred.PrivateKey = nil
+
+	// Clear passphrase fields, only PrivateKeyPassphraseHmac should be returned if it exists
+	newCred.PrivateKeyPassphraseEncrypted = nil
+	newCred.PrivateKeyPassphrase = nil
+
+	return newCred, nil
+}
+
 // CreateJsonCredential inserts c into the repository and returns a new
 // JsonCredential containing the credential's PublicId. c is not
 // changed. c must not contain a PublicId. The PublicId is generated and
@@ -318,6 +413,23 @@ func (r *Repository) LookupCredential(ctx context.Context, publicId string, _ ..
 		spkCred.PrivateKeyPassphrase = nil
 		cred = spkCred
 
+	case credential.SshCertificateSubtype:
+		sshcCred := allocSshCertificateCredential()
+		sshcCred.PublicId = publicId
+		if err := r.reader.LookupByPublicId(ctx, sshcCred); err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+		}
+		// Clear private key fields, only privateKeyHmac should be returned
+		sshcCred.PrivateKeyEncrypted = nil
+		sshcCred.PrivateKey = nil
+		// Clear passphrase fields, only PrivateKeyPassphraseHmac should be returned if it exists
+		sshcCred.PrivateKeyPassphraseEncrypted = nil
+		sshcCred.PrivateKeyPassphrase = nil
+		cred = sshcCred
+
 	case credential.JsonSubtype:
 		jsonCred := allocJsonCredential()
 		jsonCred.PublicId = publicId
@@ -582,6 +694,207 @@ func (r *Repository) UpdateSshPrivateKeyCredential(ctx context.Context,
 	return returnedCredential, rowsUpdated, nil
 }
 
+// UpdateSshCertificateCredential updates the repository entry for c.PublicId
+// with the values in c for the fields listed in fieldMaskPaths. It returns a
+// new SshCertificateCredential containing the updated values and a count of
+// the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only Name, Description, Username,
+// Certificate, PrivateKey and PrivateKeyPassphrase can be changed. If c.Name
+// is set to a non-empty string, it must be unique within c.ProjectId. If only
+// one of Certificate and PrivateKey is changed, the certificate must be
+// issued for the public key of the private key, like when both are changed.
+//
+// An attribute of c will be set to NULL in the database if the attribute in c
+// is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateSshCertificateCredential(ctx context.Context,
+	projectId string,
+	c *SshCertificateCredential,
+	version uint32,
+	fieldMaskPaths []string,
+	_ ...Option,
+) (*SshCertificateCredential, int, error) {
+	const op = "static.(Repository).UpdateSshCertificateCredential"
+	if c == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.SshCertificateCredential == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if projectId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if c.StoreId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	c = c.clone()
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(usernameField, f):
+		case strings.EqualFold(certificateField, f):
+		case strings.EqualFold(privateKeyField, f):
+		case strings.EqualFold(PrivateKeyPassphraseField, f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]interface{}{
+			nameField:                 c.Name,
+			descriptionField:          c.Description,
+			usernameField:             c.Username,
+			certificateField:          c.Certificate,
+			privateKeyField:           c.PrivateKey,
+			PrivateKeyPassphraseField: c.PrivateKeyPassphrase,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	if err := r.checkSshCertificateKeyUpdate(ctx, projectId, c, fieldMaskPaths); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	var performedEncryption bool
+	for _, f := range fieldMaskPaths {
+		if strings.EqualFold(privateKeyField, f) || strings.EqualFold(PrivateKeyPassphraseField, f) {
+			if !performedEncryption {
+				// We don't need to encrypt twice so keep track
+				performedEncryption = true
+
+				databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+				if err != nil {
+					return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+				}
+				if err := c.encrypt(ctx, databaseWrapper); err != nil {
+					return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+				}
+			}
+
+			if strings.EqualFold(privateKeyField, f) {
+				// Set PrivateKeyHmac and PrivateKeyEncrypted masks for update.
+				dbMask = append(dbMask, "PrivateKeyHmac", "PrivateKeyEncrypted")
+			}
+			if strings.EqualFold(PrivateKeyPassphraseField, f) {
+				// Set PassphraseHmac and PassphraseEncrypted masks for update.
+				dbMask = append(dbMask, "PrivateKeyPassphraseHmac", "PrivateKeyPassphraseEncrypted")
+			}
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected,
+			errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredential *SshCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCredential = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCredential,
+				dbMask, nullFields,
+				db.WithOplog(oplogWrapper, returnedCredential.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, err
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	returnedCredential.PrivateKeyEncrypted = nil
+	returnedCredential.PrivateKey = nil
+
+	// Clear passphrase fields, only PrivateKeyPassphraseHmac should be returned if it exists
+	returnedCredential.PrivateKeyPassphraseEncrypted = nil
+	returnedCredential.PrivateKeyPassphrase = nil
+
+	return returnedCredential, rowsUpdated, nil
+}
+
+// checkSshCertificateKeyUpdate checks that the certificate of c is issued for
+// its private key when only one of them is updated, by checking the updated
+// one against the one stored. When both are updated, they were already
+// checked by NewSshCertificateCredential.
+func (r *Repository) checkSshCertificateKeyUpdate(ctx context.Context, projectId string, c *SshCertificateCredential, fieldMaskPaths []string) error {
+	const op = "static.(Repository).checkSshCertificateKeyUpdate"
+	var certificateUpdated, privateKeyUpdated, passphraseUpdated bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(certificateField, f):
+			certificateUpdated = true
+		case strings.EqualFold(privateKeyField, f):
+			privateKeyUpdated = true
+		case strings.EqualFold(PrivateKeyPassphraseField, f):
+			passphraseUpdated = true
+		}
+	}
+	if certificateUpdated == privateKeyUpdated {
+		return nil
+	}
+
+	stored := allocSshCertificateCredential()
+	stored.PublicId = c.PublicId
+	if err := r.reader.LookupByPublicId(ctx, stored); err != nil {
+		if errors.IsNotFoundError(err) {
+			// The update reports that the credential does not exist
+			return nil
+		}
+		return errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := stored.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	certificate, privateKey, passphrase := stored.Certificate, stored.PrivateKey, stored.PrivateKeyPassphrase
+	switch {
+	case certificateUpdated:
+		certificate = c.Certificate
+	case privateKeyUpdated:
+		privateKey = c.PrivateKey
+		if passphraseUpdated {
+			passphrase = c.PrivateKeyPassphrase
+		}
+	}
+	if len(certificate) == 0 || len(privateKey) == 0 {
+		return nil
+	}
+	cert, err := parseSshCertificate(certificate)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+	}
+	if err := checkSshCertificateKey(ctx, cert, privateKey, passphrase); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
 // UpdateJsonCredential updates the repository entry for c.PublicId
 // with the values in c for the fields listed in fieldMaskPaths. It returns a
 // new JsonCredential containing the updated values and a count of the
@@ -711,7 +1024,8 @@ func (r *Repository) UpdateJsonCredential(ctx context.Context,
 	return returnedCredential, rowsUpdated, nil
 }
 
-// ListCredentials returns a slice of UsernamePasswordCredentials, SshPrivateKeyCredentials, and JsonCredentials
+// ListCredentials returns a slice of UsernamePasswordCredentials,
+// SshPrivateKeyCredentials, SshCertificateCredentials, and JsonCredentials
 // for the storeId. WithLimit is the only option supported.
 // TODO: This should hit a view and return the interface type...
 func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ...Option) ([]credential.Static, error) {
@@ -738,13 +1052,19 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
+	var sshcCreds []*SshCertificateCredential
+	err = r.reader.SearchWhere(ctx, &sshcCreds, "store_id = ?", []interface{}{storeId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
 	var jsonCreds []*JsonCredential
 	err = r.reader.SearchWhere(ctx, &jsonCreds, "store_id = ?", []interface{}{storeId}, db.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	ret := make([]credential.Static, 0, len(upCreds)+len(spkCreds)+len(jsonCreds))
+	ret := make([]credential.Static, 0, len(upCreds)+len(spkCreds)+len(sshcCreds)+len(jsonCreds))
 
 	for _, c := range upCreds {
 		// Clear password fields, only PasswordHmac should be returned
@@ -764,6 +1084,17 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		ret = append(ret, c)
 	}
 
+	for _, c := range sshcCreds {
+		// Clear private key fields, only PrivateKeyHmac should be returned
+		c.PrivateKeyEncrypted = nil
+		c.PrivateKey = nil
+
+		// Clear passphrase fields, only PrivateKeyPassphraseHmac should be returned if it exists
+		c.PrivateKeyPassphraseEncrypted = nil
+		c.PrivateKeyPassphrase = nil
+		ret = append(ret, c)
+	}
+
 	for _, c := range jsonCreds {
 		// Clear the object fields, only ObjectHmac should be returned
 		c.ObjectEncrypted = nil
@@ -799,6 +1130,11 @@ func (r *Repository) DeleteCredential(ctx context.Context, projectId, id string,
 		c.PublicId = id
 		input = c
 		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case credential.SshCertificateSubtype:
+		c := allocSshCertificateCredential()
+		c.PublicId = id
+		input = c
+		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
 	case credential.JsonSubtype:
 		c := allocJsonCredential()
 		c.PublicId = id
@@ -2,6 +2,8 @@ package static
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"testing"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/testdata"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -2401,3 +2404,92 @@ func TestRepository_UpdateJsonCredential(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_UpdateSshCertificateCredential_keyMismatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+
+	// largeCert is issued for the public key of TestLargeSshPrivateKeyPem
+	largeKey, err := ssh.ParsePrivateKey([]byte(TestLargeSshPrivateKeyPem))
+	require.NoError(t, err)
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	cert := &ssh.Certificate{
+		Key:             largeKey.PublicKey(),
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"username"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+	largeCert := string(ssh.MarshalAuthorizedKey(cert))
+
+	tests := []struct {
+		name        string
+		certificate string
+		privateKey  string
+		masks       []string
+		wantErr     bool
+	}{
+		{
+			name:        "certificate-issued-for-stored-key",
+			certificate: TestSshCertificate,
+			masks:       []string{certificateField},
+		},
+		{
+			name:        "certificate-not-issued-for-stored-key",
+			certificate: largeCert,
+			masks:       []string{certificateField},
+			wantErr:     true,
+		},
+		{
+			name:       "stored-certificate-not-issued-for-key",
+			privateKey: TestLargeSshPrivateKeyPem,
+			masks:      []string{privateKeyField, PrivateKeyPassphraseField},
+			wantErr:    true,
+		},
+		{
+			name:        "certificate-and-key",
+			certificate: largeCert,
+			privateKey:  TestLargeSshPrivateKeyPem,
+			masks:       []string{certificateField, privateKeyField, PrivateKeyPassphraseField},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestSshCertificateCredential(t, conn, wrapper, "username", TestSshCertificate, TestSshPrivateKeyPem, cs.PublicId, prj.PublicId)
+
+			in := allocSshCertificateCredential()
+			in.PublicId = orig.PublicId
+			in.StoreId = cs.PublicId
+			in.Certificate = []byte(tt.certificate)
+			in.PrivateKey = credential.PrivateKey(tt.privateKey)
+			got, rowsUpdated, err := repo.UpdateSshCertificateCredential(ctx, prj.PublicId, in, orig.Version, tt.masks)
+			if tt.wantErr {
+				assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+				assert.Nil(got)
+				assert.Zero(rowsUpdated)
+
+				stored := allocSshCertificateCredential()
+				stored.PublicId = orig.PublicId
+				require.NoError(rw.LookupByPublicId(ctx, stored))
+				assert.Equal(orig.Version, stored.Version)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, rowsUpdated)
+			assert.Equal([]byte(tt.certificate), got.Certificate)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-dbw"
+)
+
+// CreateRotationConfig inserts c into the repository and returns a new
+// RotationConfig. c is not changed. c must contain a valid CredentialId of a
+// static credential in projectId. Only one rotation configuration can exist
+// for a credential.
+//
+// The StoreId, ProjectId and NextRotationTime are set by the database.
+func (r *Repository) CreateRotationConfig(ctx context.Context, projectId string, c *RotationConfig, _ ...Option) (*RotationConfig, error) {
+	const op = "static.(Repository).CreateRotationConfig"
+	switch {
+	case c == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing rotation config")
+	case c.RotationConfig == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded rotation config")
+	case projectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	case c.CredentialId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	case c.Rotator == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing rotator")
+	case c.RotationIntervalSeconds == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing rotation interval")
+	}
+
+	c = c.clone()
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newConfig *RotationConfig
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newConfig = c.clone()
+			if err := w.Create(ctx, newConfig,
+				db.WithOplog(oplogWrapper, newConfig.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("credential %s already has a rotation config", c.CredentialId)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for credential: %s", c.CredentialId)))
+	}
+	return newConfig, nil
+}
+
+// LookupRotationConfig returns the rotation configuration of the static
+// credential credentialId. Returns nil, nil if the credential has no rotation
+// configuration.
+func (r *Repository) LookupRotationConfig(ctx context.Context, credentialId string, _ ...Option) (*RotationConfig, error) {
+	const op = "static.(Repository).LookupRotationConfig"
+	if credentialId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	}
+	c := allocRotationConfig()
+	if err := r.reader.LookupWhere(ctx, c, "credential_id = ?", []interface{}{credentialId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", credentialId)))
+	}
+	return c, nil
+}
+
+// UpdateRotationConfig updates the repository entry for c.CredentialId with
+// the values in c for the fields listed in fieldMaskPaths. It returns a new
+// RotationConfig containing the updated values and a count of the number of
+// records updated. c is not changed.
+//
+// c must contain a valid CredentialId. Only Rotator, Attributes,
+// RotationIntervalSeconds and GracePeriodSeconds can be changed. Changing the
+// rotation interval reschedules the next rotation.
+func (r *Repository) UpdateRotationConfig(ctx context.Context, projectId string, c *RotationConfig, version uint32, fieldMaskPaths []string, _ ...Option) (*RotationConfig, int, error) {
+	const op = "static.(Repository).UpdateRotationConfig"
+	switch {
+	case c == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing rotation config")
+	case c.RotationConfig == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded rotation config")
+	case c.CredentialId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	case projectId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	c = c.clone()
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(rotatorField, f):
+		case strings.EqualFold(rotationAttributesField, f):
+		case strings.EqualFold(rotationIntervalSecondsField, f):
+		case strings.EqualFold(gracePeriodSecondsField, f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]interface{}{
+			rotatorField:                 c.Rotator,
+			rotationAttributesField:      c.Attributes,
+			rotationIntervalSecondsField: c.RotationIntervalSeconds,
+			gracePeriodSecondsField:      c.GracePeriodSeconds,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected,
+			errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedConfig *RotationConfig
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedConfig = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedConfig,
+				dbMask, nullFields,
+				db.WithOplog(oplogWrapper, returnedConfig.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(c.CredentialId))
+	}
+	return returnedConfig, rowsUpdated, nil
+}
+
+// DeleteRotationConfig deletes the rotation configuration of the static
+// credential credentialId, which stops the credential from being rotated.
+// Previous secrets of the credential are deleted without being retired.
+func (r *Repository) DeleteRotationConfig(ctx context.Context, projectId, credentialId string, _ ...Option) (int, error) {
+	const op = "static.(Repository).DeleteRotationConfig"
+	if credentialId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	}
+	if projectId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	}
+
+	c := allocRotationConfig()
+	c.CredentialId = credentialId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			rowsDeleted, err = w.Delete(ctx, c, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(credentialId))
+	}
+	return rowsDeleted, nil
+}
+
+// storePendingSecret stores the secret of the rotated static credential as
+// the pending secret of the credential until rotateCredential replaces the
+// secret of the credential with it. c must be the rotation configuration
+// that was used to rotate the credential.
+func (r *Repository) storePendingSecret(ctx context.Context, c *RotationConfig, rotated credential.Static) error {
+	const op = "static.(Repository).storePendingSecret"
+	switch {
+	case c == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing rotation config")
+	case rotated == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing rotated credential")
+	case rotated.GetPublicId() != c.CredentialId:
+		return errors.New(ctx, errors.InvalidParameter, op, "credential does not match rotation config")
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, c.ProjectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	secret, err := secretFromCredential(ctx, rotated)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	pending := allocPendingSecret()
+	pending.CredentialId = c.CredentialId
+	pending.SecretVersion = c.SecretVersion
+	if pending.Secret, err = secret.MarshalJSON(); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	if err := pending.encrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := r.writer.Create(ctx, pending); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(c.CredentialId))
+	}
+	return nil
+}
+
+// deletePendingSecret deletes the pending secret of the static credential
+// credentialId, if any.
+func (r *Repository) deletePendingSecret(ctx context.Context, credentialId string) error {
+	const op = "static.(Repository).deletePendingSecret"
+	if credentialId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	}
+	if _, err := r.writer.Exec(ctx, deletePendingSecretQuery, []interface{}{credentialId}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(credentialId))
+	}
+	return nil
+}
+
+// rotateCredential replaces the secret of the decrypted static credential
+// current with the secret of rotated. The secret of current is stored as a
+// retired secret with the current secret version of c, the secret version
+// of c is incremented and the pending secret of the credential, if any, is
+// deleted. c must be the rotation configuration that was used to rotate
+// current.
+func (r *Repository) rotateCredential(ctx context.Context, c *RotationConfig, current, rotated credential.Static) error {
+	const op = "static.(Repository).rotateCredential"
+	switch {
+	case c == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing rotation config")
+	case current == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing current credential")
+	case rotated == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing rotated credential")
+	case current.GetPublicId() != c.CredentialId || rotated.GetPublicId() != c.CredentialId:
+		return errors.New(ctx, errors.InvalidParameter, op, "credential does not match rotation config")
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, c.ProjectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	secret, err := secretFromCredential(ctx, current)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	retired := allocRetiredSecret()
+	retired.CredentialId = c.CredentialId
+	retired.SecretVersion = c.SecretVersion
+	if retired.Secret, err = secret.MarshalJSON(); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	if err := retired.encrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var input interface{}
+	var dbMask, nullFields []string
+	var md oplog.Metadata
+	switch v := rotated.(type) {
+	case *UsernamePasswordCredential:
+		v = v.clone()
+		if err := v.encrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		input, md = v, v.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		dbMask = []string{usernameField, "PasswordHmac", "CtPassword"}
+	case *SshPrivateKeyCredential:
+		v = v.clone()
+		if err := v.encrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		input, md = v, v.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		dbMask = []string{usernameField, "PrivateKeyHmac", "PrivateKeyEncrypted"}
+		if len(v.PrivateKeyPassphrase) > 0 {
+			dbMask = append(dbMask, "PrivateKeyPassphraseHmac", "PrivateKeyPassphraseEncrypted")
+		} else {
+			nullFields = []string{"PrivateKeyPassphraseHmac", "PrivateKeyPassphraseEncrypted"}
+		}
+	case *SshCertificateCredential:
+		v = v.clone()
+		if err := v.encrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		input, md = v, v.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		dbMask = []string{usernameField, certificateField, "PrivateKeyHmac", "PrivateKeyEncrypted"}
+		if len(v.PrivateKeyPassphrase) > 0 {
+			dbMask = append(dbMask, "PrivateKeyPassphraseHmac", "PrivateKeyPassphraseEncrypted")
+		} else {
+			nullFields = []string{"PrivateKeyPassphraseHmac", "PrivateKeyPassphraseEncrypted"}
+		}
+	case *JsonCredential:
+		v = v.clone()
+		if err := v.encrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		input, md = v, v.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		dbMask = []string{"ObjectHmac", "ObjectEncrypted"}
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, "unsupported credential type")
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			// Bumping the secret version first guards against the credential
+			// being rotated concurrently.
+			rowsUpdated, err := w.Exec(ctx, rotateCredentialQuery, []interface{}{c.CredentialId, c.SecretVersion})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.NotSpecificIntegrity, op, "credential was rotated concurrently")
+			}
+			if err := w.Create(ctx, retired.clone()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			rowsUpdated, err = w.Update(ctx, input, dbMask, nullFields, db.WithOplog(oplogWrapper, md))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "expected to update exactly 1 credential")
+			}
+			if _, err := w.Exec(ctx, deletePendingSecretQuery, []interface{}{c.CredentialId}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(c.CredentialId))
+	}
+	return nil
+}
+
+// deleteRetiredSecret deletes the retired secret s.
+func (r *Repository) deleteRetiredSecret(ctx context.Context, s *RetiredSecret) error {
+	const op = "static.(Repository).deleteRetiredSecret"
+	if s == nil || s.RetiredSecret == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing retired secret")
+	}
+	rowsDeleted, err := r.writer.Exec(ctx, deleteRetiredSecretQuery, []interface{}{s.CredentialId, s.SecretVersion})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if rowsDeleted > 1 {
+		return errors.New(ctx, errors.MultipleRecords, op, "more than 1 retired secret would have been deleted")
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package static
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("credential_static_username_password_credential", usernamePasswordCredentialRewrapFn)
+	kms.RegisterTableRewrapFn("credential_static_ssh_private_key_credential", sshPrivateKeyCredentialRewrapFn)
+	kms.RegisterTableRewrapFn("credential_static_ssh_certificate_credential", sshCertificateCredentialRewrapFn)
+	kms.RegisterTableRewrapFn("credential_static_json_credential", jsonCredentialRewrapFn)
+	kms.RegisterTableRewrapFn("credential_static_retired_secret", retiredSecretRewrapFn)
+	kms.RegisterTableRewrapFn("credential_static_pending_secret", pendingSecretRewrapFn)
+}
+
+func usernamePasswordCredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.usernamePasswordCredentialRewrapFn"
+	var creds []*UsernamePasswordCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list credentials to rewrap"))
+	}
+	if len(creds) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range creds {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, c, []string{"CtPassword", "PasswordHmac", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func sshPrivateKeyCredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.sshPrivateKeyCredentialRewrapFn"
+	var creds []*SshPrivateKeyCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list credentials to rewrap"))
+	}
+	if len(creds) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range creds {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		dbMask := []string{"PrivateKeyEncrypted", "PrivateKeyHmac", "KeyId"}
+		if len(c.PrivateKeyPassphrase) > 0 {
+			dbMask = append(dbMask, "PrivateKeyPassphraseEncrypted", "PrivateKeyPassphraseHmac")
+		}
+		n, err := writer.Update(ctx, c, dbMask, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func sshCertificateCredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.sshCertificateCredentialRewrapFn"
+	var creds []*SshCertificateCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list credentials to rewrap"))
+	}
+	if len(creds) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range creds {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		dbMask := []string{"PrivateKeyEncrypted", "PrivateKeyHmac", "KeyId"}
+		if len(c.PrivateKeyPassphrase) > 0 {
+			dbMask = append(dbMask, "PrivateKeyPassphraseEncrypted", "PrivateKeyPassphraseHmac")
+		}
+		n, err := writer.Update(ctx, c, dbMask, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func jsonCredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.jsonCredentialRewrapFn"
+	var creds []*JsonCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list credentials to rewrap"))
+	}
+	if len(creds) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range creds {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, c, []string{"ObjectEncrypted", "ObjectHmac", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func retiredSecretRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.retiredSecretRewrapFn"
+	var secrets []*RetiredSecret
+	if err := reader.SearchWhere(ctx, &secrets, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list retired secrets to rewrap"))
+	}
+	if len(secrets) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, s := range secrets {
+		if err := s.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := s.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, s, []string{"CtSecret", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("%s version %d", s.CredentialId, s.SecretVersion)))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func pendingSecretRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "static.pendingSecretRewrapFn"
+	var secrets []*PendingSecret
+	if err := reader.SearchWhere(ctx, &secrets, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list pending secrets to rewrap"))
+	}
+	if len(secrets) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, s := range secrets {
+		if err := s.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := s.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, s, []string{"CtSecret", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(s.CredentialId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -0,0 +1,384 @@
+package static
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// A RotationConfig contains the configuration for rotating the secret of a
+// static credential on a schedule. The rotator is either the name of a
+// built-in rotator or the name of a registered rotation plugin.
+type RotationConfig struct {
+	*store.RotationConfig
+	tableName string `gorm:"-"`
+}
+
+// NewRotationConfig creates a new in memory RotationConfig for the static
+// credential credentialId. The credential is rotated by rotator every
+// interval. WithRotationAttributes and WithGracePeriod are the only valid
+// options. All other options are ignored.
+func NewRotationConfig(ctx context.Context, credentialId string, rotator string, interval time.Duration, opt ...Option) (*RotationConfig, error) {
+	const op = "static.NewRotationConfig"
+	switch {
+	case credentialId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	case rotator == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing rotator")
+	case interval < time.Second:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "rotation interval must be at least one second")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withGracePeriod < 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "grace period must not be negative")
+	}
+	attrs := []byte("{}")
+	if opts.withRotationAttributes != nil {
+		var err error
+		if attrs, err = json.Marshal(opts.withRotationAttributes.AsMap()); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid rotation attributes"))
+		}
+	}
+
+	return &RotationConfig{
+		RotationConfig: &store.RotationConfig{
+			CredentialId:            credentialId,
+			Rotator:                 rotator,
+			Attributes:              attrs,
+			RotationIntervalSeconds: uint32(interval / time.Second),
+			GracePeriodSeconds:      uint32(opts.withGracePeriod / time.Second),
+		},
+	}, nil
+}
+
+func allocRotationConfig() *RotationConfig {
+	return &RotationConfig{
+		RotationConfig: &store.RotationConfig{},
+	}
+}
+
+func (c *RotationConfig) clone() *RotationConfig {
+	cp := proto.Clone(c.RotationConfig)
+	return &RotationConfig{
+		RotationConfig: cp.(*store.RotationConfig),
+	}
+}
+
+// TableName returns the table name.
+func (c *RotationConfig) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_rotation"
+}
+
+// SetTableName sets the table name.
+func (c *RotationConfig) SetTableName(n string) {
+	c.tableName = n
+}
+
+// RotationAttributes returns the attributes of the rotation configuration
+// as a struct.
+func (c *RotationConfig) RotationAttributes() (*structpb.Struct, error) {
+	attrs := &structpb.Struct{}
+	if len(c.Attributes) == 0 {
+		return attrs, nil
+	}
+	if err := attrs.UnmarshalJSON(c.Attributes); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+func (c *RotationConfig) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.CredentialId},
+		"resource-type":      []string{"credential-static-rotation"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+// A RetiredSecret contains a previous secret of a rotated static credential.
+// It is kept until no session created before the rotation still uses it.
+type RetiredSecret struct {
+	*store.RetiredSecret
+	tableName string `gorm:"-"`
+}
+
+func allocRetiredSecret() *RetiredSecret {
+	return &RetiredSecret{
+		RetiredSecret: &store.RetiredSecret{},
+	}
+}
+
+func (s *RetiredSecret) clone() *RetiredSecret {
+	cp := proto.Clone(s.RetiredSecret)
+	return &RetiredSecret{
+		RetiredSecret: cp.(*store.RetiredSecret),
+	}
+}
+
+// TableName returns the table name.
+func (s *RetiredSecret) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "credential_static_retired_secret"
+}
+
+// SetTableName sets the table name.
+func (s *RetiredSecret) SetTableName(n string) {
+	s.tableName = n
+}
+
+func (s *RetiredSecret) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(RetiredSecret).encrypt"
+	if len(s.Secret) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no secret defined")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, s.RetiredSecret, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	s.KeyId = keyId
+	return nil
+}
+
+func (s *RetiredSecret) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(RetiredSecret).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, s.RetiredSecret, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// secretStruct returns the decrypted secret as a struct.
+func (s *RetiredSecret) secretStruct() (*structpb.Struct, error) {
+	secret := &structpb.Struct{}
+	if err := secret.UnmarshalJSON(s.Secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// A PendingSecret contains a secret that a rotator has applied to a static
+// credential but that has not been stored as the secret of the credential
+// yet. It is stored before the credential is updated so the secret is not
+// lost if the update fails, and it is deleted in the transaction that
+// updates the credential.
+type PendingSecret struct {
+	*store.PendingSecret
+	tableName string `gorm:"-"`
+}
+
+func allocPendingSecret() *PendingSecret {
+	return &PendingSecret{
+		PendingSecret: &store.PendingSecret{},
+	}
+}
+
+func (s *PendingSecret) clone() *PendingSecret {
+	cp := proto.Clone(s.PendingSecret)
+	return &PendingSecret{
+		PendingSecret: cp.(*store.PendingSecret),
+	}
+}
+
+// TableName returns the table name.
+func (s *PendingSecret) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "credential_static_pending_secret"
+}
+
+// SetTableName sets the table name.
+func (s *PendingSecret) SetTableName(n string) {
+	s.tableName = n
+}
+
+func (s *PendingSecret) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(PendingSecret).encrypt"
+	if len(s.Secret) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no secret defined")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, s.PendingSecret, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	s.KeyId = keyId
+	return nil
+}
+
+func (s *PendingSecret) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(PendingSecret).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, s.PendingSecret, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// secretStruct returns the decrypted secret as a struct.
+func (s *PendingSecret) secretStruct() (*structpb.Struct, error) {
+	secret := &structpb.Struct{}
+	if err := secret.UnmarshalJSON(s.Secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// retirableSecret is a retired secret that has passed its retire time and is
+// no longer used by any session. It is read from the
+// credential_static_retired_secret_retirable view.
+type retirableSecret struct {
+	*store.RetiredSecret
+	ProjectId string
+}
+
+// TableName returns the table name.
+func (s *retirableSecret) TableName() string {
+	return "credential_static_retired_secret_retirable"
+}
+
+// Secret field names used when passing the secret of a static credential to
+// a rotator.
+const (
+	secretUsernameField             = "username"
+	secretPasswordField             = "password"
+	secretCertificateField          = "certificate"
+	secretPrivateKeyField           = "private_key"
+	secretPrivateKeyPassphraseField = "private_key_passphrase"
+)
+
+// secretFromCredential returns the secret of the decrypted static credential
+// c as a struct.
+func secretFromCredential(ctx context.Context, c credential.Static) (*structpb.Struct, error) {
+	const op = "static.secretFromCredential"
+	m := map[string]interface{}{}
+	switch v := c.(type) {
+	case *UsernamePasswordCredential:
+		m[secretUsernameField] = v.GetUsername()
+		m[secretPasswordField] = string(v.GetPassword())
+	case *SshPrivateKeyCredential:
+		m[secretUsernameField] = v.GetUsername()
+		m[secretPrivateKeyField] = string(v.GetPrivateKey())
+		if len(v.GetPrivateKeyPassphrase()) > 0 {
+			m[secretPrivateKeyPassphraseField] = string(v.GetPrivateKeyPassphrase())
+		}
+	case *SshCertificateCredential:
+		m[secretUsernameField] = v.GetUsername()
+		m[secretCertificateField] = string(v.GetCertificate())
+		m[secretPrivateKeyField] = string(v.GetPrivateKey())
+		if len(v.GetPrivateKeyPassphrase()) > 0 {
+			m[secretPrivateKeyPassphraseField] = string(v.GetPrivateKeyPassphrase())
+		}
+	case *JsonCredential:
+		if err := json.Unmarshal(v.GetObject(), &m); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+		}
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported credential type")
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	return s, nil
+}
+
+// credentialWithSecret returns a copy of the static credential c with its
+// secret replaced by secret. The returned credential has not been
+// validated against the rules applied when creating a credential.
+func credentialWithSecret(ctx context.Context, c credential.Static, secret *structpb.Struct) (credential.Static, error) {
+	const op = "static.credentialWithSecret"
+	if secret == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing secret")
+	}
+	f := secret.GetFields()
+	str := func(name string) string { return f[name].GetStringValue() }
+	switch v := c.(type) {
+	case *UsernamePasswordCredential:
+		if str(secretPasswordField) == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "secret is missing password")
+		}
+		cp := v.clone()
+		if u := str(secretUsernameField); u != "" {
+			cp.Username = u
+		}
+		cp.Password = []byte(str(secretPasswordField))
+		return cp, nil
+	case *SshPrivateKeyCredential:
+		if str(secretPrivateKeyField) == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "secret is missing private key")
+		}
+		cp := v.clone()
+		if u := str(secretUsernameField); u != "" {
+			cp.Username = u
+		}
+		cp.PrivateKey = []byte(str(secretPrivateKeyField))
+		cp.PrivateKeyPassphrase = []byte(str(secretPrivateKeyPassphraseField))
+		return cp, nil
+	case *SshCertificateCredential:
+		if str(secretCertificateField) == "" || str(secretPrivateKeyField) == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "secret is missing certificate or private key")
+		}
+		cp := v.clone()
+		if u := str(secretUsernameField); u != "" {
+			cp.Username = u
+		}
+		cp.Certificate = []byte(str(secretCertificateField))
+		cp.PrivateKey = []byte(str(secretPrivateKeyField))
+		cp.PrivateKeyPassphrase = []byte(str(secretPrivateKeyPassphraseField))
+		return cp, nil
+	case *JsonCredential:
+		if len(f) == 0 {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "secret is empty")
+		}
+		b, err := json.Marshal(secret.AsMap())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+		}
+		cp := v.clone()
+		cp.Object = b
+		return cp, nil
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported credential type")
+	}
+}
+
+// staticCredentialType returns the credential type of the static credential
+// c.
+func staticCredentialType(c credential.Static) credential.Type {
+	switch c.(type) {
+	case *UsernamePasswordCredential:
+		return credential.UsernamePasswordType
+	case *SshPrivateKeyCredential:
+		return credential.SshPrivateKeyType
+	case *SshCertificateCredential:
+		return credential.SshCertificateType
+	case *JsonCredential:
+		return credential.JsonType
+	default:
+		return credential.UnspecifiedType
+	}
+}
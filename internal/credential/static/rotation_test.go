@@ -0,0 +1,259 @@
+package static
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRotationConfig_New(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	attrs, err := structpb.NewStruct(map[string]interface{}{"address": "localhost:22"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		credentialId string
+		rotator      string
+		interval     time.Duration
+		opts         []Option
+		wantAttrs    string
+		wantGrace    uint32
+		wantErr      errors.Code
+	}{
+		{
+			name:     "missing-credential-id",
+			rotator:  PasswordRotator,
+			interval: time.Hour,
+			wantErr:  errors.InvalidParameter,
+		},
+		{
+			name:         "missing-rotator",
+			credentialId: "credup_1234567890",
+			interval:     time.Hour,
+			wantErr:      errors.InvalidParameter,
+		},
+		{
+			name:         "interval-too-short",
+			credentialId: "credup_1234567890",
+			rotator:      PasswordRotator,
+			interval:     time.Millisecond,
+			wantErr:      errors.InvalidParameter,
+		},
+		{
+			name:         "negative-grace-period",
+			credentialId: "credup_1234567890",
+			rotator:      PasswordRotator,
+			interval:     time.Hour,
+			opts:         []Option{WithGracePeriod(-time.Second)},
+			wantErr:      errors.InvalidParameter,
+		},
+		{
+			name:         "valid",
+			credentialId: "credup_1234567890",
+			rotator:      PasswordRotator,
+			interval:     time.Hour,
+			wantAttrs:    "{}",
+		},
+		{
+			name:         "valid-with-options",
+			credentialId: "credup_1234567890",
+			rotator:      PasswordRotator,
+			interval:     time.Hour,
+			opts:         []Option{WithRotationAttributes(attrs), WithGracePeriod(time.Minute)},
+			wantAttrs:    `{"address":"localhost:22"}`,
+			wantGrace:    60,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRotationConfig(ctx, tt.credentialId, tt.rotator, tt.interval, tt.opts...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.credentialId, got.CredentialId)
+			assert.Equal(tt.rotator, got.Rotator)
+			assert.Equal(uint32(tt.interval/time.Second), got.RotationIntervalSeconds)
+			assert.Equal(tt.wantGrace, got.GracePeriodSeconds)
+			assert.JSONEq(tt.wantAttrs, string(got.Attributes))
+		})
+	}
+}
+
+func TestCredentialWithSecret(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	up, err := NewUsernamePasswordCredential("csst_1234567890", "user", credential.Password("pass"))
+	require.NoError(t, err)
+	spk, err := NewSshPrivateKeyCredential(ctx, "csst_1234567890", "user", credential.PrivateKey(TestSshPrivateKeyPem))
+	require.NoError(t, err)
+	obj, _, err := TestJsonObject()
+	require.NoError(t, err)
+	js, err := NewJsonCredential(ctx, "csst_1234567890", obj)
+	require.NoError(t, err)
+
+	for _, c := range []credential.Static{up, spk, js} {
+		c := c
+		t.Run(string(staticCredentialType(c)), func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			secret, err := secretFromCredential(ctx, c)
+			require.NoError(err)
+			got, err := credentialWithSecret(ctx, c, secret)
+			require.NoError(err)
+			gotSecret, err := secretFromCredential(ctx, got)
+			require.NoError(err)
+			assert.Equal(secret.AsMap(), gotSecret.AsMap())
+		})
+	}
+
+	t.Run("missing-password", func(t *testing.T) {
+		_, err := credentialWithSecret(ctx, up, &structpb.Struct{})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("missing-private-key", func(t *testing.T) {
+		_, err := credentialWithSecret(ctx, spk, &structpb.Struct{})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+}
+
+func TestRepository_RotationConfig(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+
+	got, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	in, err := NewRotationConfig(ctx, cred.PublicId, PasswordRotator, time.Hour)
+	require.NoError(t, err)
+	created, err := repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	require.NoError(t, err)
+	assert.Equal(t, cred.PublicId, created.CredentialId)
+
+	_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	assert.Error(t, err)
+
+	got, err = repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, cs.PublicId, got.StoreId)
+	assert.Equal(t, prj.PublicId, got.ProjectId)
+	assert.Equal(t, uint64(1), got.SecretVersion)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got.NextRotationTime.AsTime(), time.Minute)
+
+	got.RotationIntervalSeconds = 60
+	got.GracePeriodSeconds = 30
+	updated, n, err := repo.UpdateRotationConfig(ctx, prj.PublicId, got, got.Version, []string{rotationIntervalSecondsField, gracePeriodSecondsField})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, uint32(60), updated.RotationIntervalSeconds)
+	assert.Equal(t, uint32(30), updated.GracePeriodSeconds)
+
+	got, err = repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), got.NextRotationTime.AsTime(), 30*time.Second)
+
+	// The grace period can be removed
+	got.GracePeriodSeconds = 0
+	updated, n, err = repo.UpdateRotationConfig(ctx, prj.PublicId, got, got.Version, []string{gracePeriodSecondsField})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, uint32(0), updated.GracePeriodSeconds)
+	got, err = repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+
+	_, _, err = repo.UpdateRotationConfig(ctx, prj.PublicId, got, got.Version, []string{"SecretVersion"})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidFieldMask), err), "unexpected error: %v", err)
+
+	n, err = repo.DeleteRotationConfig(ctx, prj.PublicId, cred.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err = repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRepository_rotateCredential(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.PublicId, prj.PublicId)
+
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(t, err)
+	in, err := NewRotationConfig(ctx, cred.PublicId, PasswordRotator, time.Hour)
+	require.NoError(t, err)
+	_, err = repo.CreateRotationConfig(ctx, prj.PublicId, in)
+	require.NoError(t, err)
+	cfg, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+
+	creds, err := repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId})
+	require.NoError(t, err)
+	current := creds[0]
+	rotated, err := credentialWithSecret(ctx, current, &structpb.Struct{Fields: map[string]*structpb.Value{
+		secretPasswordField: structpb.NewStringValue("new-pass"),
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.rotateCredential(ctx, cfg, current, rotated))
+
+	creds, err = repo.Retrieve(ctx, prj.PublicId, []string{cred.PublicId})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new-pass"), creds[0].(*UsernamePasswordCredential).GetPassword())
+	assert.Equal(t, "user", creds[0].(*UsernamePasswordCredential).GetUsername())
+
+	got, err := repo.LookupRotationConfig(ctx, cred.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), got.SecretVersion)
+	assert.NotNil(t, got.LastRotationTime)
+
+	var retired []*RetiredSecret
+	require.NoError(t, rw.SearchWhere(ctx, &retired, "credential_id = ?", []interface{}{cred.PublicId}))
+	require.Len(t, retired, 1)
+	assert.Equal(t, uint64(1), retired[0].SecretVersion)
+	databaseWrapper, err := kkms.GetWrapper(ctx, prj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	require.NoError(t, retired[0].decrypt(ctx, databaseWrapper))
+	secret, err := retired[0].secretStruct()
+	require.NoError(t, err)
+	assert.Equal(t, "pass", secret.GetFields()[secretPasswordField].GetStringValue())
+
+	// Rotating with a stale config must fail.
+	err = repo.rotateCredential(ctx, cfg, creds[0], rotated)
+	assert.Error(t, err)
+}
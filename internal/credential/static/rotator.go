@@ -0,0 +1,46 @@
+package static
+
+import (
+	"context"
+
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/grpc"
+)
+
+// The names of the built-in rotators. A rotation plugin cannot be registered
+// using one of these names.
+const (
+	SshPrivateKeyRotator = "ssh_private_key"
+	PasswordRotator      = "password"
+)
+
+var _ plgpb.RotationPluginServiceClient = (*WrappingRotationPluginClient)(nil)
+
+// WrappingRotationPluginClient provides a wrapper around a Server
+// implementation that can be used when loading a rotation plugin in-memory.
+type WrappingRotationPluginClient struct {
+	Server plgpb.RotationPluginServiceServer
+}
+
+// NewWrappingRotationPluginClient returns a RotationPluginServiceClient that
+// calls s directly.
+func NewWrappingRotationPluginClient(s plgpb.RotationPluginServiceServer) *WrappingRotationPluginClient {
+	return &WrappingRotationPluginClient{Server: s}
+}
+
+func (c *WrappingRotationPluginClient) RotateCredential(ctx context.Context, req *plgpb.RotateCredentialRequest, opts ...grpc.CallOption) (*plgpb.RotateCredentialResponse, error) {
+	return c.Server.RotateCredential(ctx, req)
+}
+
+func (c *WrappingRotationPluginClient) RetireCredential(ctx context.Context, req *plgpb.RetireCredentialRequest, opts ...grpc.CallOption) (*plgpb.RetireCredentialResponse, error) {
+	return c.Server.RetireCredential(ctx, req)
+}
+
+// BuiltinRotators returns the rotators that are always available, keyed by
+// name.
+func BuiltinRotators() map[string]plgpb.RotationPluginServiceClient {
+	return map[string]plgpb.RotationPluginServiceClient{
+		SshPrivateKeyRotator: NewWrappingRotationPluginClient(&sshPrivateKeyRotator{}),
+		PasswordRotator:      NewWrappingRotationPluginClient(&passwordRotator{}),
+	}
+}
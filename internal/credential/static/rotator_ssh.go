@@ -0,0 +1,424 @@
+package static
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Attributes supported by the built-in rotators.
+const (
+	// rotatorAddressAttribute is the host:port of the SSH server the
+	// credential grants access to. The port defaults to 22.
+	rotatorAddressAttribute = "address"
+	// rotatorHostKeyAttribute is the public key of the SSH server in
+	// authorized_keys format.
+	rotatorHostKeyAttribute = "host_key"
+	// rotatorInsecureSkipHostKeyAttribute disables verification of the
+	// public key of the SSH server when set to true.
+	rotatorInsecureSkipHostKeyAttribute = "insecure_skip_host_key_verification"
+	// rotatorKeyTypeAttribute is the type of the generated SSH private key.
+	// It must be either "ecdsa" or "rsa" and defaults to "ecdsa".
+	rotatorKeyTypeAttribute = "key_type"
+
+	rotatorDialTimeout       = 30 * time.Second
+	rotatorCommandTimeout    = 2 * time.Minute
+	rotatorPasswordLength    = 32
+	rotatorRsaKeyBits        = 3072
+	rotatorAuthorizedKeyNote = "boundary-rotated"
+
+	// rotatorMaxOutput is the maximum number of bytes of the output of a
+	// command that is kept for error messages.
+	rotatorMaxOutput = 4096
+)
+
+// retireAuthorizedKeyCmd is formatted with a base64 encoded public key and
+// removes the lines containing it from the authorized_keys file. grep exits
+// with 1 when it selects no line, which only means that the retired key was
+// the last key of the file. The temporary file is removed if any step
+// fails.
+const retireAuthorizedKeyCmd = `umask 077 && f=~/.ssh/authorized_keys && if [ -e "$f" ]; then ` +
+	`{ grep -v -F '%s' "$f" > "$f.tmp" || [ $? -eq 1 ]; } && mv "$f.tmp" "$f" || { rm -f "$f.tmp"; exit 1; }; fi`
+
+// sshPrivateKeyRotator rotates ssh_private_key credentials by generating a
+// new key pair and adding the public key to the authorized_keys file of the
+// user on the SSH server. Retiring a key removes it from the authorized_keys
+// file.
+//
+// The built-in rotators run on the controllers, so the SSH server must be
+// reachable from every controller. They do not connect through workers.
+type sshPrivateKeyRotator struct {
+	plgpb.UnimplementedRotationPluginServiceServer
+}
+
+// RotateCredential generates a new private key and authorizes it on the SSH
+// server using the current private key.
+func (r *sshPrivateKeyRotator) RotateCredential(ctx context.Context, req *plgpb.RotateCredentialRequest) (*plgpb.RotateCredentialResponse, error) {
+	if req.GetCredentialType() != string(credential.SshPrivateKeyType) {
+		return nil, status.Errorf(codes.InvalidArgument, "the %s rotator does not support %q credentials", SshPrivateKeyRotator, req.GetCredentialType())
+	}
+	username, signer, err := sshSignerFromSecret(req.GetSecret())
+	if err != nil {
+		return nil, err
+	}
+
+	keyType := req.GetAttributes().GetFields()[rotatorKeyTypeAttribute].GetStringValue()
+	newKeyPem, newSigner, err := generateSshPrivateKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	authorizedKey := authorizedKeyLine(newSigner.PublicKey())
+
+	cmd := fmt.Sprintf("umask 077 && mkdir -p ~/.ssh && printf '%%s\\n' '%s' >> ~/.ssh/authorized_keys", authorizedKey)
+	if err := runSshCommand(ctx, req.GetAttributes(), username, ssh.PublicKeys(signer), cmd); err != nil {
+		return nil, sshCommandError(err, codes.Unavailable, "unable to authorize new key")
+	}
+	// Make sure the new key works before it replaces the current key.
+	if err := runSshCommand(ctx, req.GetAttributes(), username, ssh.PublicKeys(newSigner), "true"); err != nil {
+		return nil, sshCommandError(err, codes.FailedPrecondition, "unable to authenticate with new key")
+	}
+
+	secret, err := structpb.NewStruct(map[string]interface{}{
+		secretUsernameField:   username,
+		secretPrivateKeyField: string(newKeyPem),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to build secret: %s", err)
+	}
+	return &plgpb.RotateCredentialResponse{Secret: secret}, nil
+}
+
+// RetireCredential removes the public key of the retired private key from
+// the authorized_keys file using the current private key.
+func (r *sshPrivateKeyRotator) RetireCredential(ctx context.Context, req *plgpb.RetireCredentialRequest) (*plgpb.RetireCredentialResponse, error) {
+	if req.GetCredentialType() != string(credential.SshPrivateKeyType) {
+		return nil, status.Errorf(codes.InvalidArgument, "the %s rotator does not support %q credentials", SshPrivateKeyRotator, req.GetCredentialType())
+	}
+	username, signer, err := sshSignerFromSecret(req.GetSecret())
+	if err != nil {
+		return nil, err
+	}
+	_, retiredSigner, err := sshSignerFromSecret(req.GetRetiredSecret())
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(signer.PublicKey().Marshal(), retiredSigner.PublicKey().Marshal()) {
+		// The key was not changed by the rotation, there is nothing to remove.
+		return &plgpb.RetireCredentialResponse{}, nil
+	}
+
+	// The base64 encoded key only contains characters that are safe to use
+	// in a fixed string match.
+	key := base64.StdEncoding.EncodeToString(retiredSigner.PublicKey().Marshal())
+	cmd := fmt.Sprintf(retireAuthorizedKeyCmd, key)
+	if err := runSshCommand(ctx, req.GetAttributes(), username, ssh.PublicKeys(signer), cmd); err != nil {
+		return nil, sshCommandError(err, codes.Unavailable, "unable to remove retired key")
+	}
+	return &plgpb.RetireCredentialResponse{}, nil
+}
+
+// passwordRotator rotates username_password credentials by changing the
+// password of the user on an SSH server with passwd. The previous password
+// stops working as soon as the credential is rotated, sessions that have
+// already authenticated are not affected. passwd must prompt for the current
+// password, so the user cannot be root. Like sshPrivateKeyRotator, it
+// connects to the SSH server from the controller.
+type passwordRotator struct {
+	plgpb.UnimplementedRotationPluginServiceServer
+}
+
+// RotateCredential generates a new password and sets it on the SSH server
+// using the current password.
+func (r *passwordRotator) RotateCredential(ctx context.Context, req *plgpb.RotateCredentialRequest) (*plgpb.RotateCredentialResponse, error) {
+	if req.GetCredentialType() != string(credential.UsernamePasswordType) {
+		return nil, status.Errorf(codes.InvalidArgument, "the %s rotator does not support %q credentials", PasswordRotator, req.GetCredentialType())
+	}
+	fields := req.GetSecret().GetFields()
+	username := fields[secretUsernameField].GetStringValue()
+	password := fields[secretPasswordField].GetStringValue()
+	if username == "" || password == "" {
+		return nil, status.Error(codes.InvalidArgument, "secret is missing username or password")
+	}
+
+	newPassword, err := base62.Random(rotatorPasswordLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to generate password: %s", err)
+	}
+
+	// passwd prompts for the current password and twice for the new one.
+	answers := []string{password, newPassword, newPassword}
+	if err := runSshPrompts(ctx, req.GetAttributes(), username, ssh.Password(password), "passwd", answers); err != nil {
+		return nil, sshCommandError(err, codes.Unavailable, "unable to change password")
+	}
+
+	secret, err := structpb.NewStruct(map[string]interface{}{
+		secretUsernameField: username,
+		secretPasswordField: newPassword,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to build secret: %s", err)
+	}
+	return &plgpb.RotateCredentialResponse{Secret: secret}, nil
+}
+
+// RetireCredential is a no-op, the previous password was replaced when the
+// credential was rotated.
+func (r *passwordRotator) RetireCredential(ctx context.Context, req *plgpb.RetireCredentialRequest) (*plgpb.RetireCredentialResponse, error) {
+	return &plgpb.RetireCredentialResponse{}, nil
+}
+
+// sshSignerFromSecret returns the username and a signer for the private key
+// in an ssh_private_key secret.
+func sshSignerFromSecret(secret *structpb.Struct) (string, ssh.Signer, error) {
+	fields := secret.GetFields()
+	username := fields[secretUsernameField].GetStringValue()
+	privateKey := fields[secretPrivateKeyField].GetStringValue()
+	passphrase := fields[secretPrivateKeyPassphraseField].GetStringValue()
+	if username == "" || privateKey == "" {
+		return "", nil, status.Error(codes.InvalidArgument, "secret is missing username or private key")
+	}
+	var signer ssh.Signer
+	var err error
+	if passphrase == "" {
+		signer, err = ssh.ParsePrivateKey([]byte(privateKey))
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	}
+	if err != nil {
+		return "", nil, status.Errorf(codes.InvalidArgument, "unable to parse private key: %s", err)
+	}
+	return username, signer, nil
+}
+
+// generateSshPrivateKey generates a new private key of keyType and returns it
+// PEM encoded together with a signer for it.
+func generateSshPrivateKey(keyType string) ([]byte, ssh.Signer, error) {
+	var block *pem.Block
+	switch keyType {
+	case "", "ecdsa":
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "unable to generate key: %s", err)
+		}
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "unable to marshal key: %s", err)
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case "rsa":
+		k, err := rsa.GenerateKey(rand.Reader, rotatorRsaKeyBits)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "unable to generate key: %s", err)
+		}
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported key type %q", keyType)
+	}
+	keyPem := pem.EncodeToMemory(block)
+	signer, err := ssh.ParsePrivateKey(keyPem)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "unable to parse generated key: %s", err)
+	}
+	return keyPem, signer, nil
+}
+
+// authorizedKeyLine returns pk in authorized_keys format without a trailing
+// newline.
+func authorizedKeyLine(pk ssh.PublicKey) string {
+	return fmt.Sprintf("%s %s", strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pk))), rotatorAuthorizedKeyNote)
+}
+
+// sshCommandError returns err if it is a status error returned by
+// runSshCommand for an invalid configuration, otherwise it returns a status
+// error with code and msg.
+func sshCommandError(err error, code codes.Code, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(code, "%s: %s", msg, err)
+}
+
+// runSshCommand connects to the SSH server configured in attrs as username
+// using auth and runs cmd.
+func runSshCommand(ctx context.Context, attrs *structpb.Struct, username string, auth ssh.AuthMethod, cmd string) error {
+	client, err := dialSsh(ctx, attrs, username, auth)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	if out, err := session.CombinedOutput(cmd); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// runSshPrompts connects to the SSH server configured in attrs as username
+// using auth and runs cmd in a pseudo terminal. Each password prompt of cmd
+// is answered with the next of answers once it is read, and cmd fails if it
+// prompts more often than there are answers.
+func runSshPrompts(ctx context.Context, attrs *structpb.Struct, username string, auth ssh.AuthMethod, cmd string, answers []string) error {
+	client, err := dialSsh(ctx, attrs, username, auth)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	if err := session.RequestPty("dumb", 24, 80, ssh.TerminalModes{ssh.ECHO: 0}); err != nil {
+		return err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	// The pseudo terminal merges stderr into stdout.
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := session.Start(cmd); err != nil {
+		return err
+	}
+	out, err := answerPrompts(stdout, stdin, answers)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(out))
+	}
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// answerPrompts reads the output of a command from out until it is closed
+// and writes the next of answers to in each time the output ends with a
+// password prompt. It returns the output that was read, truncated to
+// rotatorMaxOutput bytes, and fails if more prompts are read than there are
+// answers, which happens when the command rejects an answer and prompts
+// again.
+func answerPrompts(out io.Reader, in io.Writer, answers []string) (string, error) {
+	var output strings.Builder
+	var line []byte
+	buf := make([]byte, 256)
+	for {
+		n, err := out.Read(buf)
+		for _, b := range buf[:n] {
+			if output.Len() < rotatorMaxOutput {
+				output.WriteByte(b)
+			}
+			if b == '\n' || b == '\r' {
+				line = line[:0]
+				continue
+			}
+			line = append(line, b)
+			if !isPasswordPrompt(line) {
+				continue
+			}
+			line = line[:0]
+			if len(answers) == 0 {
+				return output.String(), fmt.Errorf("unexpected password prompt")
+			}
+			if _, err := io.WriteString(in, answers[0]+"\n"); err != nil {
+				return output.String(), err
+			}
+			answers = answers[1:]
+		}
+		switch {
+		case err == io.EOF:
+			if len(answers) > 0 {
+				return output.String(), fmt.Errorf("command ended before all password prompts were answered")
+			}
+			return output.String(), nil
+		case err != nil:
+			return output.String(), err
+		}
+	}
+}
+
+// isPasswordPrompt reports whether line, the last line of the output of a
+// command, is a password prompt such as "Current password:".
+func isPasswordPrompt(line []byte) bool {
+	l := strings.ToLower(string(line))
+	return strings.HasSuffix(strings.TrimSpace(l), ":") && strings.Contains(l, "password")
+}
+
+// dialSsh connects to the SSH server configured in attrs as username using
+// auth. The connection is closed after rotatorCommandTimeout, or earlier if
+// ctx has an earlier deadline.
+func dialSsh(ctx context.Context, attrs *structpb.Struct, username string, auth ssh.AuthMethod) (*ssh.Client, error) {
+	fields := attrs.GetFields()
+	addr := fields[rotatorAddressAttribute].GetStringValue()
+	if addr == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing %q attribute", rotatorAddressAttribute)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case fields[rotatorHostKeyAttribute].GetStringValue() != "":
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(fields[rotatorHostKeyAttribute].GetStringValue()))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to parse %q attribute: %s", rotatorHostKeyAttribute, err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(hostKey)
+	case fields[rotatorInsecureSkipHostKeyAttribute].GetBoolValue():
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "missing %q attribute", rotatorHostKeyAttribute)
+	}
+
+	d := net.Dialer{Timeout: rotatorDialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(rotatorCommandTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User:            username,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         rotatorDialTimeout,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
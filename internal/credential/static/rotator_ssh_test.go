@@ -0,0 +1,175 @@
+package static
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestGenerateSshPrivateKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		keyType  string
+		wantType string
+		wantErr  bool
+	}{
+		{keyType: "", wantType: ssh.KeyAlgoECDSA256},
+		{keyType: "ecdsa", wantType: ssh.KeyAlgoECDSA256},
+		{keyType: "rsa", wantType: ssh.KeyAlgoRSA},
+		{keyType: "dsa", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.keyType, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			keyPem, signer, err := generateSshPrivateKey(tt.keyType)
+			if tt.wantErr {
+				assert.Equal(codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantType, signer.PublicKey().Type())
+
+			// The generated key must be usable as an ssh_private_key credential
+			_, err = NewSshPrivateKeyCredential(context.Background(), "csst_1234567890", "user", credential.PrivateKey(keyPem))
+			require.NoError(err)
+
+			line := authorizedKeyLine(signer.PublicKey())
+			assert.False(strings.ContainsAny(line, "'\n"))
+			pk, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+			require.NoError(err)
+			assert.Equal(signer.PublicKey().Marshal(), pk.Marshal())
+			assert.Equal(rotatorAuthorizedKeyNote, comment)
+		})
+	}
+}
+
+func TestBuiltinRotators_InvalidRequests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	rotators := BuiltinRotators()
+	require.Len(t, rotators, 2)
+
+	upSecret, err := structpb.NewStruct(map[string]interface{}{
+		secretUsernameField: "user",
+		secretPasswordField: "pass",
+	})
+	require.NoError(t, err)
+	spkSecret, err := structpb.NewStruct(map[string]interface{}{
+		secretUsernameField:   "user",
+		secretPrivateKeyField: TestSshPrivateKeyPem,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		rotator string
+		req     *plgpb.RotateCredentialRequest
+		want    codes.Code
+	}{
+		{
+			name:    "ssh-private-key-wrong-type",
+			rotator: SshPrivateKeyRotator,
+			req:     &plgpb.RotateCredentialRequest{CredentialType: string(credential.UsernamePasswordType), Secret: upSecret},
+			want:    codes.InvalidArgument,
+		},
+		{
+			name:    "ssh-private-key-missing-address",
+			rotator: SshPrivateKeyRotator,
+			req:     &plgpb.RotateCredentialRequest{CredentialType: string(credential.SshPrivateKeyType), Secret: spkSecret},
+			want:    codes.InvalidArgument,
+		},
+		{
+			name:    "password-wrong-type",
+			rotator: PasswordRotator,
+			req:     &plgpb.RotateCredentialRequest{CredentialType: string(credential.SshPrivateKeyType), Secret: spkSecret},
+			want:    codes.InvalidArgument,
+		},
+		{
+			name:    "password-missing-password",
+			rotator: PasswordRotator,
+			req:     &plgpb.RotateCredentialRequest{CredentialType: string(credential.UsernamePasswordType), Secret: &structpb.Struct{}},
+			want:    codes.InvalidArgument,
+		},
+		{
+			name:    "password-missing-host-key",
+			rotator: PasswordRotator,
+			req: &plgpb.RotateCredentialRequest{
+				CredentialType: string(credential.UsernamePasswordType),
+				Secret:         upSecret,
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					rotatorAddressAttribute: structpb.NewStringValue("localhost"),
+				}},
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := rotators[tt.rotator].RotateCredential(ctx, tt.req)
+			assert.Equal(t, tt.want, status.Code(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestAnswerPrompts(t *testing.T) {
+	t.Parallel()
+	answers := []string{"current", "new", "new"}
+	tests := []struct {
+		name      string
+		output    string
+		wantInput string
+		wantErr   bool
+	}{
+		{
+			name:      "passwd",
+			output:    "Changing password for user.\r\nCurrent password: \r\nNew password: \r\nRetype new password: \r\npasswd: password updated successfully\r\n",
+			wantInput: "current\nnew\nnew\n",
+		},
+		{
+			name:      "rejected-answer",
+			output:    "Current password: \r\nNew password: \r\nRetype new password: \r\nSorry, passwords do not match.\r\nNew password: ",
+			wantInput: "current\nnew\nnew\n",
+			wantErr:   true,
+		},
+		{
+			name:      "ended-early",
+			output:    "Current password: \r\npasswd: Authentication token manipulation error\r\n",
+			wantInput: "current\n",
+			wantErr:   true,
+		},
+		{
+			name:   "no-prompt",
+			output: "passwd: unrecognized option\r\n",
+			// The command ends before any prompt is answered.
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var in strings.Builder
+			out, err := answerPrompts(strings.NewReader(tt.output), &in, answers)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantInput, in.String())
+			assert.Equal(t, tt.output, out)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package static
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ credential.Static = (*SshCertificateCredential)(nil)
+
+// A SshCertificateCredential contains the credential with a username, an SSH
+// certificate and the private key the certificate was issued for. It is
+// owned by a credential store.
+type SshCertificateCredential struct {
+	*store.SshCertificateCredential
+	tableName          string `gorm:"-"`
+	PassphraseUnneeded bool   `gorm:"-"`
+}
+
+// NewSshCertificateCredential creates a new in memory static Credential
+// containing a username, an SSH certificate and the private key for the
+// certificate that is assigned to storeId. If both a certificate and a
+// private key are provided, the certificate must have been issued for the
+// public key of the private key. Name, description and private key
+// passphrase are the only valid options. All other options are ignored.
+func NewSshCertificateCredential(
+	ctx context.Context,
+	storeId string,
+	username string,
+	certificate []byte,
+	privateKey credential.PrivateKey,
+	opt ...Option,
+) (*SshCertificateCredential, error) {
+	const op = "static.NewSshCertificateCredential"
+
+	var cert *ssh.Certificate
+	if len(certificate) != 0 {
+		var err error
+		if cert, err = parseSshCertificate(certificate); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+		}
+	}
+
+	opts := getOpts(opt...)
+	if len(privateKey) != 0 {
+		if err := checkSshCertificateKey(ctx, cert, privateKey, opts.withPrivateKeyPassphrase); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	l := &SshCertificateCredential{
+		SshCertificateCredential: &store.SshCertificateCredential{
+			StoreId:              storeId,
+			Name:                 opts.withName,
+			Description:          opts.withDescription,
+			Username:             username,
+			Certificate:          certificate,
+			PrivateKey:           privateKey,
+			PrivateKeyPassphrase: opts.withPrivateKeyPassphrase,
+		},
+	}
+
+	// If a private key was given and no passphrase was given and everything is
+	// okay, we can nil out any passphrase we have, so set this as a hint
+	if len(privateKey) != 0 && len(opts.withPrivateKeyPassphrase) == 0 {
+		l.PassphraseUnneeded = true
+		// These shouldn't be set, but safety
+		l.PrivateKeyPassphrase = nil
+		l.PrivateKeyPassphraseEncrypted = nil
+		l.PrivateKeyPassphraseHmac = nil
+	}
+
+	return l, nil
+}
+
+// parseSshCertificate parses an SSH certificate in authorized keys format.
+func parseSshCertificate(certificate []byte) (*ssh.Certificate, error) {
+	pk, _, _, _, err := ssh.ParseAuthorizedKey(certificate)
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := pk.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, "static.parseSshCertificate", "public key is not an ssh certificate")
+	}
+	return cert, nil
+}
+
+// checkSshCertificateKey parses the private key and checks that cert, if not
+// nil, was issued for its public key. A private key which cannot be parsed
+// because its passphrase is missing is not checked.
+func checkSshCertificateKey(ctx context.Context, cert *ssh.Certificate, privateKey credential.PrivateKey, passphrase []byte) error {
+	const op = "static.checkSshCertificateKey"
+	var signer ssh.Signer
+	var err error
+	if len(passphrase) == 0 {
+		signer, err = ssh.ParsePrivateKey(privateKey)
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(privateKey, passphrase)
+	}
+	switch {
+	case err == nil:
+		if cert != nil && !bytes.Equal(cert.Key.Marshal(), signer.PublicKey().Marshal()) {
+			return errors.New(ctx, errors.InvalidParameter, op, "certificate was not issued for the public key of the private key")
+		}
+	case err.Error() == (&ssh.PassphraseMissingError{}).Error():
+		// This is okay, if it's brokered and the client can use it, no worries
+	default:
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+	}
+	return nil
+}
+
+func allocSshCertificateCredential() *SshCertificateCredential {
+	return &SshCertificateCredential{
+		SshCertificateCredential: &store.SshCertificateCredential{},
+	}
+}
+
+func (c *SshCertificateCredential) clone() *SshCertificateCredential {
+	cp := proto.Clone(c.SshCertificateCredential)
+	return &SshCertificateCredential{
+		SshCertificateCredential: cp.(*store.SshCertificateCredential),
+	}
+}
+
+// TableName returns the table name.
+func (c *SshCertificateCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_ssh_certificate_credential"
+}
+
+// SetTableName sets the table name.
+func (c *SshCertificateCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *SshCertificateCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"credential-static-ssh-certificate"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+func (c *SshCertificateCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshCertificateCredential).encrypt"
+
+	if len(c.PrivateKey) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no private key defined")
+	}
+
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	c.KeyId = keyId
+
+	// Encrypt private key
+	blobInfo, err := cipher.Encrypt(ctx, c.PrivateKey)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	protoBytes, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	c.PrivateKeyEncrypted = protoBytes
+	if err := c.hmacPrivateKey(ctx, cipher); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	if len(c.PrivateKeyPassphrase) > 0 {
+		// Encrypt passphrase
+		blobInfo, err := cipher.Encrypt(ctx, c.PrivateKeyPassphrase)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+		}
+		protoBytes, err := proto.Marshal(blobInfo)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+		}
+		c.PrivateKeyPassphraseEncrypted = protoBytes
+		if err := c.hmacPrivateKeyPassphrase(ctx, cipher); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	return nil
+}
+
+func (c *SshCertificateCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshCertificateCredential).decrypt"
+
+	if len(c.PrivateKeyEncrypted) > 0 {
+		dec := new(wrapping.BlobInfo)
+		if err := proto.Unmarshal(c.PrivateKeyEncrypted, dec); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+		}
+		pt, err := cipher.Decrypt(ctx, dec)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+		}
+		c.PrivateKey = pt
+	}
+
+	if len(c.PrivateKeyPassphraseEncrypted) > 0 {
+		dec := new(wrapping.BlobInfo)
+		if err := proto.Unmarshal(c.PrivateKeyPassphraseEncrypted, dec); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+		}
+		pt, err := cipher.Decrypt(ctx, dec)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+		}
+		c.PrivateKeyPassphrase = pt
+	}
+
+	return nil
+}
+
+func (c *SshCertificateCredential) hmacPrivateKey(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshCertificateCredential).hmacPrivateKey"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	hm, err := crypto.HmacSha256(ctx, c.PrivateKey, cipher, []byte(c.StoreId), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PrivateKeyHmac = []byte(hm)
+	return nil
+}
+
+func (c *SshCertificateCredential) hmacPrivateKeyPassphrase(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshCertificateCredential).hmacPrivateKeyPassphrase"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	hm, err := crypto.HmacSha256(ctx, c.PrivateKeyPassphrase, cipher, []byte(c.StoreId), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PrivateKeyPassphraseHmac = []byte(hm)
+	return nil
+}
@@ -0,0 +1,248 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestSshCertificateCredential_New(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	privKey := []byte(TestSshPrivateKeyPem)
+	cert := []byte(TestSshCertificate)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+
+	type args struct {
+		username      string
+		certificate   []byte
+		sshPrivateKey credential.PrivateKey
+		storeId       string
+		options       []Option
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		want           *SshCertificateCredential
+		wantCreateErr  bool
+		wantEncryptErr bool
+		wantAllocError bool
+	}{
+		{
+			name: "missing-private-key",
+			args: args{
+				username:    "test-user",
+				certificate: cert,
+				storeId:     cs.PublicId,
+			},
+			want:           allocSshCertificateCredential(),
+			wantEncryptErr: true,
+		},
+		{
+			name: "missing-certificate",
+			args: args{
+				username:      "test-user",
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+			},
+			want:          allocSshCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "missing-username",
+			args: args{
+				certificate:   cert,
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+			},
+			want:          allocSshCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "missing-store-id",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: privKey,
+			},
+			want:          allocSshCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "bad-certificate",
+			args: args{
+				username:      "test-user",
+				certificate:   []byte("foobar"),
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "not-a-certificate",
+			args: args{
+				username:      "test-user",
+				certificate:   []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPF/CEQBkquextDE6NUDc8WIHdtbe1Kk0rHxN0yIKqXN"),
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "bad-private-key",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: []byte("foobar"),
+				storeId:       cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "certificate-private-key-mismatch",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: []byte(TestLargeSshPrivateKeyPem),
+				storeId:       cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "valid-no-options",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+			},
+			want: &SshCertificateCredential{
+				SshCertificateCredential: &store.SshCertificateCredential{
+					Username:    "test-user",
+					Certificate: cert,
+					PrivateKey:  privKey,
+					StoreId:     cs.PublicId,
+				},
+			},
+		},
+		{
+			name: "valid-with-name",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+				options:       []Option{WithName("my-credential")},
+			},
+			want: &SshCertificateCredential{
+				SshCertificateCredential: &store.SshCertificateCredential{
+					Username:    "test-user",
+					Certificate: cert,
+					PrivateKey:  privKey,
+					StoreId:     cs.PublicId,
+					Name:        "my-credential",
+				},
+			},
+		},
+		{
+			name: "valid-with-description",
+			args: args{
+				username:      "test-user",
+				certificate:   cert,
+				sshPrivateKey: privKey,
+				storeId:       cs.PublicId,
+				options:       []Option{WithDescription("my-credential-description")},
+			},
+			want: &SshCertificateCredential{
+				SshCertificateCredential: &store.SshCertificateCredential{
+					Username:    "test-user",
+					Certificate: cert,
+					PrivateKey:  privKey,
+					StoreId:     cs.PublicId,
+					Description: "my-credential-description",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			got, err := NewSshCertificateCredential(ctx, tt.args.storeId, tt.args.username, tt.args.certificate, tt.args.sshPrivateKey, tt.args.options...)
+			if tt.wantAllocError {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Emptyf(got.PublicId, "PublicId set")
+
+			id, err := credential.NewSshCertificateCredentialId(ctx)
+			require.NoError(err)
+
+			tt.want.PublicId = id
+			got.PublicId = id
+
+			databaseWrapper, err := kkms.GetWrapper(context.Background(), prj.PublicId, kms.KeyPurposeDatabase)
+			require.NoError(err)
+
+			err = got.encrypt(ctx, databaseWrapper)
+			if tt.wantEncryptErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			err = rw.Create(context.Background(), got)
+			if tt.wantCreateErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			got2 := allocSshCertificateCredential()
+			got2.PublicId = id
+			assert.Equal(id, got2.GetPublicId())
+			require.NoError(rw.LookupById(ctx, got2))
+
+			err = got2.decrypt(ctx, databaseWrapper)
+			require.NoError(err)
+
+			// Timestamps and version are automatically set
+			tt.want.CreateTime = got2.CreateTime
+			tt.want.UpdateTime = got2.UpdateTime
+			tt.want.Version = got2.Version
+
+			// KeyId is allocated via kms no need to validate in this test
+			tt.want.KeyId = got2.KeyId
+			got2.PrivateKeyEncrypted = nil
+			got2.PrivateKeyPassphraseEncrypted = nil
+
+			// encrypt also calculates the hmac, validate it is correct
+			hm, err := crypto.HmacSha256(ctx, got.PrivateKey, databaseWrapper, []byte(got.StoreId), nil)
+			require.NoError(err)
+			tt.want.PrivateKeyHmac = []byte(hm)
+
+			assert.Empty(cmp.Diff(tt.want, got2.clone(), protocmp.Transform()))
+		})
+	}
+}
@@ -8,6 +8,7 @@ const (
 	vaultPathField       = "VaultPath"
 	httpMethodField      = "HttpMethod"
 	httpRequestBodyField = "HttpRequestBody"
+	secretVersionField   = "SecretVersion"
 
 	certificateField    = "Certificate"
 	certificateKeyField = "CertificateKey"
@@ -28,6 +28,7 @@ type options struct {
 	withMethod         Method
 	withRequestBody    []byte
 	withCredentialType credential.Type
+	withSecretVersion  uint32
 
 	withOverrideUsernameAttribute             string
 	withOverridePasswordAttribute             string
@@ -127,6 +128,15 @@ func WithRequestBody(b []byte) Option {
 	}
 }
 
+// WithSecretVersion provides an optional version of a KV version 2 secret
+// for a credential library to read. It can only be used with the GET
+// method.
+func WithSecretVersion(v uint32) Option {
+	return func(o *options) {
+		o.withSecretVersion = v
+	}
+}
+
 // WithCredentialType provides an optional credential type to associate
 // with a credential library.
 func WithCredentialType(t credential.Type) Option {
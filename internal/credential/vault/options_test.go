@@ -90,6 +90,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withRequestBody = []byte("body")
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithSecretVersion", func(t *testing.T) {
+		opts := getOpts(WithSecretVersion(2))
+		testOpts := getDefaultOptions()
+		testOpts.withSecretVersion = 2
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithCredentialType", func(t *testing.T) {
 		opts := getOpts(WithCredentialType(credential.UsernamePasswordType))
 		testOpts := getDefaultOptions()
@@ -146,6 +146,7 @@ type issueCredentialLibrary struct {
 	VaultPath                     string
 	HttpMethod                    string
 	HttpRequestBody               []byte
+	SecretVersion                 uint32
 	CredType                      string `gorm:"column:credential_type"`
 	ProjectId                     string
 	VaultAddress                  string
@@ -189,6 +190,7 @@ func (pl *issueCredentialLibrary) clone() *issueCredentialLibrary {
 		VaultPath:                     pl.VaultPath,
 		HttpMethod:                    pl.HttpMethod,
 		HttpRequestBody:               append(pl.HttpRequestBody[:0:0], pl.HttpRequestBody...),
+		SecretVersion:                 pl.SecretVersion,
 		VaultAddress:                  pl.VaultAddress,
 		Namespace:                     pl.Namespace,
 		CaCert:                        append(pl.CaCert[:0:0], pl.CaCert...),
@@ -305,7 +307,11 @@ func (pl *issueCredentialLibrary) retrieveCredential(ctx context.Context, op err
 	var secret *vault.Secret
 	switch Method(pl.HttpMethod) {
 	case MethodGet:
-		secret, err = client.get(ctx, pl.VaultPath)
+		var opts []Option
+		if pl.SecretVersion > 0 {
+			opts = append(opts, WithSecretVersion(pl.SecretVersion))
+		}
+		secret, err = client.get(ctx, pl.VaultPath, opts...)
 	case MethodPost:
 		secret, err = client.post(ctx, pl.VaultPath, pl.HttpRequestBody)
 	default:
@@ -115,14 +115,17 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, projectId stri
 // number of records updated. l is not changed.
 //
 // l must contain a valid PublicId. Only Name, Description, VaultPath,
-// HttpMethod, HttpRequestBody, and MappingOverride can be updated. If
-// l.Name is set to a non-empty string, it must be unique within l.StoreId.
+// HttpMethod, HttpRequestBody, SecretVersion, and MappingOverride can be
+// updated. If l.Name is set to a non-empty string, it must be unique within
+// l.StoreId.
 //
 // An attribute of l will be set to NULL in the database if the attribute
 // in l is the zero value and it is included in fieldMaskPaths except for
 // HttpMethod.  If HttpMethod is in the fieldMaskPath but l.HttpMethod
 // is not set it will be set to the value "GET".  If storage has a value
 // for HttpRequestBody when l.HttpMethod is set to GET the update will fail.
+// Likewise, if storage has a value for SecretVersion when l.HttpMethod is
+// set to POST the update will fail.
 func (r *Repository) UpdateCredentialLibrary(ctx context.Context, projectId string, l *CredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialLibrary, int, error) {
 	const op = "vault.(Repository).UpdateCredentialLibrary"
 	if l == nil {
@@ -150,6 +153,7 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, projectId stri
 		case strings.EqualFold(vaultPathField, f):
 		case strings.EqualFold(httpMethodField, f):
 		case strings.EqualFold(httpRequestBodyField, f):
+		case strings.EqualFold(secretVersionField, f):
 		case strings.EqualFold(MappingOverrideField, f):
 			updateMappingOverride = true
 		default:
@@ -164,6 +168,7 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, projectId stri
 			vaultPathField:       l.VaultPath,
 			httpMethodField:      l.HttpMethod,
 			httpRequestBodyField: l.HttpRequestBody,
+			secretVersionField:   l.SecretVersion,
 			MappingOverrideField: l.MappingOverride,
 		},
 		fieldMaskPaths,
@@ -355,6 +360,7 @@ type listLookupLibrary struct {
 	VaultPath                     string
 	HttpMethod                    string
 	HttpRequestBody               []byte
+	SecretVersion                 uint32
 	CredentialType                string
 	UsernameAttribute             string
 	PasswordAttribute             string
@@ -378,6 +384,7 @@ func (pl *listLookupLibrary) toCredentialLibrary() *CredentialLibrary {
 	cl.VaultPath = pl.VaultPath
 	cl.HttpMethod = pl.HttpMethod
 	cl.HttpRequestBody = pl.HttpRequestBody
+	cl.SecretVersion = pl.SecretVersion
 	cl.CredentialLibrary.CredentialType = pl.CredentialType
 
 	switch pl.CredentialType {
@@ -164,6 +164,37 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid-secret-version",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:       cs.GetPublicId(),
+					HttpMethod:    "GET",
+					VaultPath:     "/secret/data/my-secret",
+					SecretVersion: 3,
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:       cs.GetPublicId(),
+					HttpMethod:    "GET",
+					VaultPath:     "/secret/data/my-secret",
+					SecretVersion: 3,
+				},
+			},
+		},
+		{
+			name: "invalid-POST-secret-version",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:       cs.GetPublicId(),
+					HttpMethod:    "POST",
+					VaultPath:     "/some/path",
+					SecretVersion: 3,
+				},
+			},
+			wantErr: errors.CheckConstraint,
+		},
 		{
 			name: "valid-username-password-credential-type",
 			in: &CredentialLibrary{
@@ -459,6 +490,7 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 			assert.NotSame(tt.in, got)
 			assert.Equal(tt.want.Name, got.Name)
 			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.SecretVersion, got.SecretVersion)
 			assert.Equal(tt.want.CredentialType(), got.CredentialType())
 			assert.Equal(got.CreateTime, got.UpdateTime)
 
@@ -601,6 +633,13 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 		}
 	}
 
+	changeSecretVersion := func(v uint32) func(*CredentialLibrary) *CredentialLibrary {
+		return func(l *CredentialLibrary) *CredentialLibrary {
+			l.SecretVersion = v
+			return l
+		}
+	}
+
 	changeVaultPath := func(p string) func(*CredentialLibrary) *CredentialLibrary {
 		return func(l *CredentialLibrary) *CredentialLibrary {
 			l.VaultPath = p
@@ -1075,6 +1114,69 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 			},
 			wantCount: 1,
 		},
+		{
+			name: "add-secret-version",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod: "GET",
+					VaultPath:  "/secret/data/my-secret",
+				},
+			},
+			chgFn: changeSecretVersion(2),
+			masks: []string{secretVersionField},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:    "GET",
+					VaultPath:     "/secret/data/my-secret",
+					SecretVersion: 2,
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "delete-secret-version",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:    "GET",
+					VaultPath:     "/secret/data/my-secret",
+					SecretVersion: 2,
+				},
+			},
+			chgFn: changeSecretVersion(0),
+			masks: []string{secretVersionField},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod: "GET",
+					VaultPath:  "/secret/data/my-secret",
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "add-secret-version-to-POST-method",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod: "POST",
+					VaultPath:  "/some/path",
+				},
+			},
+			chgFn:   changeSecretVersion(2),
+			masks:   []string{secretVersionField},
+			wantErr: errors.CheckConstraint,
+		},
+		{
+			name: "change-method-to-POST-leave-secret-version",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:    "GET",
+					VaultPath:     "/secret/data/my-secret",
+					SecretVersion: 2,
+				},
+			},
+			chgFn:   changeHttpMethod(MethodPost),
+			masks:   []string{httpMethodField},
+			wantErr: errors.CheckConstraint,
+		},
 		{
 			name: "read-only-credential-type-in-field-mask",
 			orig: &CredentialLibrary{
@@ -1515,6 +1617,13 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 				assert.Equal(tt.want.Description, got.Description)
 			}
 
+			switch tt.want.SecretVersion {
+			case 0:
+				dbassert.IsNull(got, "secret_version")
+			default:
+				assert.Equal(tt.want.SecretVersion, got.SecretVersion)
+			}
+
 			if tt.wantCount > 0 {
 				assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
 			}
@@ -0,0 +1,74 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("credential_vault_token", tokenRewrapFn)
+	kms.RegisterTableRewrapFn("credential_vault_client_certificate", clientCertificateRewrapFn)
+}
+
+func tokenRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "vault.tokenRewrapFn"
+	var tokens []*Token
+	if err := reader.SearchWhere(ctx, &tokens, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list tokens to rewrap"))
+	}
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, t := range tokens {
+		if err := t.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := t.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, t, []string{"CtToken", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(t.StoreId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
+
+func clientCertificateRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) (int, error) {
+	const op = "vault.clientCertificateRewrapFn"
+	var certs []*ClientCertificate
+	if err := reader.SearchWhere(ctx, &certs, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list client certificates to rewrap"))
+	}
+	if len(certs) == 0 {
+		return 0, nil
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	var rewrapped int
+	for _, c := range certs {
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op)
+		}
+		n, err := writer.Update(ctx, c, []string{"CtCertificateKey", "CertificateKeyHmac", "KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+		if err != nil {
+			return rewrapped, errors.Wrap(ctx, err, op, errors.WithMsg(c.StoreId))
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
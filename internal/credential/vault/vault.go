@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,7 +23,7 @@ type vaultClient interface {
 	revokeLease(context.Context, string) error
 	lookupToken(context.Context) (*vault.Secret, error)
 	swapToken(context.Context, TokenSecret) (old TokenSecret)
-	get(context.Context, string) (*vault.Secret, error)
+	get(context.Context, string, ...Option) (*vault.Secret, error)
 	post(context.Context, string, []byte) (*vault.Secret, error)
 	capabilities(context.Context, []string) (pathCapabilities, error)
 }
@@ -204,9 +205,18 @@ func (c *client) swapToken(ctx context.Context, new TokenSecret) (old TokenSecre
 	return
 }
 
-func (c *client) get(ctx context.Context, path string) (*vault.Secret, error) {
+// get reads the secret at path. WithSecretVersion is the only valid option,
+// it requests a specific version of a KV version 2 secret.
+func (c *client) get(ctx context.Context, path string, opt ...Option) (*vault.Secret, error) {
 	const op = "vault.(client).get"
-	s, err := c.cl.Logical().Read(path)
+	opts := getOpts(opt...)
+	var data map[string][]string
+	if opts.withSecretVersion > 0 {
+		data = map[string][]string{
+			"version": {strconv.FormatUint(uint64(opts.withSecretVersion), 10)},
+		}
+	}
+	s, err := c.cl.Logical().ReadWithData(path, data)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.VaultCredentialRequest), errors.WithMsg(fmt.Sprintf("vault: %s", c.cl.Address())))
 	}
@@ -161,6 +161,34 @@ func TestClient_Get(t *testing.T) {
 	assert.NotNil(cred)
 }
 
+func TestClient_Get_SecretVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	v := NewTestVaultServer(t)
+	v.CreateKVSecret(t, "my-secret", []byte(`{"data": {"password": "first"}}`))
+	v.CreateKVSecret(t, "my-secret", []byte(`{"data": {"password": "second"}}`))
+
+	client := v.client(t)
+	secretPath := path.Join("secret", "data", "my-secret")
+
+	passwordFn := func(s *vault.Secret) interface{} {
+		data, ok := s.Data["data"].(map[string]interface{})
+		require.True(ok)
+		return data["password"]
+	}
+
+	latest, err := client.get(ctx, secretPath)
+	require.NoError(err)
+	require.NotNil(latest)
+	assert.Equal("second", passwordFn(latest))
+
+	pinned, err := client.get(ctx, secretPath, WithSecretVersion(1))
+	require.NoError(err)
+	require.NotNil(pinned)
+	assert.Equal("first", passwordFn(pinned))
+}
+
 func TestClient_Post(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -31,8 +31,13 @@ type workerServiceServer struct {
 	serversRepoFn    common.ServersRepoFactory
 	sessionRepoFn    session.RepositoryFactory
 	connectionRepoFn common.ConnectionRepoFactory
+	staticHostRepoFn common.StaticRepoFactory
+	pluginHostRepoFn common.PluginHostRepoFactory
+	hostHealthRepoFn common.HostHealthRepoFactory
 	updateTimes      *sync.Map
 	kms              *kms.Kms
+
+	healthAssignments hostHealthAssignments
 }
 
 var (
@@ -44,6 +49,9 @@ func NewWorkerServiceServer(
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn session.RepositoryFactory,
 	connectionRepoFn common.ConnectionRepoFactory,
+	staticHostRepoFn common.StaticRepoFactory,
+	pluginHostRepoFn common.PluginHostRepoFactory,
+	hostHealthRepoFn common.HostHealthRepoFactory,
 	updateTimes *sync.Map,
 	kms *kms.Kms,
 ) *workerServiceServer {
@@ -51,6 +59,9 @@ func NewWorkerServiceServer(
 		serversRepoFn:    serversRepoFn,
 		sessionRepoFn:    sessionRepoFn,
 		connectionRepoFn: connectionRepoFn,
+		staticHostRepoFn: staticHostRepoFn,
+		pluginHostRepoFn: pluginHostRepoFn,
+		hostHealthRepoFn: hostHealthRepoFn,
 		updateTimes:      updateTimes,
 		kms:              kms,
 	}
@@ -121,6 +132,12 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error storing worker status"))
 		return &pbs.StatusResponse{}, status.Errorf(codes.Internal, "Error storing worker status: %v", err)
 	}
+	// Failing to record the results of the host health checks must not fail
+	// the status of the worker. The results are dropped, the next probes of
+	// the worker report the health of the hosts again.
+	if err := ws.recordHostHealthResults(ctx, req.GetHostHealthCheckResults()); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error recording host health check results"))
+	}
 	controllers, err := serverRepo.ListControllers(ctx)
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error getting current controllers"))
@@ -212,6 +229,13 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		})
 	}
 
+	// Host health checks are best effort, a failure must not prevent the
+	// worker from getting the session changes above.
+	ret.HostHealthChecks, err = ws.hostHealthChecks(ctx, wrk.GetPublicId())
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error handling host health checks"))
+	}
+
 	return ret, nil
 }
 
@@ -330,18 +354,19 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			SessionId:   sessionInfo.GetPublicId(),
 			Certificate: sessionInfo.Certificate,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
-		Credentials:     workerCreds,
+		Status:             sessionInfo.States[0].Status.ProtoVal(),
+		Version:            sessionInfo.Version,
+		TofuToken:          string(sessionInfo.TofuToken),
+		Endpoint:           sessionInfo.Endpoint,
+		Expiration:         sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:    sessionInfo.ConnectionLimit,
+		ConnectionsLeft:    authzSummary.ConnectionLimit,
+		IdleTimeoutSeconds: sessionInfo.IdleTimeoutSeconds,
+		HostId:             sessionInfo.HostId,
+		HostSetId:          sessionInfo.HostSetId,
+		TargetId:           sessionInfo.TargetId,
+		UserId:             sessionInfo.UserId,
+		Credentials:        workerCreds,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -375,7 +400,14 @@ func (ws *workerServiceServer) CancelSession(ctx context.Context, req *pbs.Cance
 		return nil, err
 	}
 
-	ses, err = sessRepo.CancelSession(ctx, req.GetSessionId(), ses.Version)
+	switch req.GetReason() {
+	case "":
+		ses, err = sessRepo.CancelSession(ctx, req.GetSessionId(), ses.Version)
+	case session.IdleTimeout.String():
+		ses, err = sessRepo.CancelIdleSession(ctx, req.GetSessionId(), ses.Version)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported cancel reason %q", req.GetReason())
+	}
 	if err != nil {
 		return nil, err
 	}
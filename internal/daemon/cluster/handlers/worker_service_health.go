@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/health"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+)
+
+const hostDomain = "host"
+
+// hostHealthAssignmentsInterval is how long the assignments of the host health
+// checks to the workers are reused before being computed again.
+const hostHealthAssignmentsInterval = 10 * time.Second
+
+// hostHealthAssignments caches the probes assigned to each worker, so they are
+// not computed again on the status of every worker.
+type hostHealthAssignments struct {
+	sync.Mutex
+	computedAt time.Time
+	probes     map[string][]*health.Probe
+}
+
+// recordHostHealthResults records the results of the host health checks
+// reported by the worker.
+func (ws *workerServiceServer) recordHostHealthResults(ctx context.Context, reported []*pbs.HostHealthCheckResult) error {
+	const op = "workers.(workerServiceServer).recordHostHealthResults"
+	if len(reported) == 0 {
+		return nil
+	}
+	healthRepo, err := ws.hostHealthRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error getting host health repo"))
+	}
+	results := make([]*health.Result, 0, len(reported))
+	for _, r := range reported {
+		results = append(results, &health.Result{
+			HostSetId: r.GetHostSetId(),
+			HostId:    r.GetHostId(),
+			Healthy:   r.GetHealthy(),
+			Error:     r.GetError(),
+		})
+	}
+	if err := healthRepo.RecordResults(ctx, results); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error recording host health check results"))
+	}
+	return nil
+}
+
+// hostHealthChecks returns the health checks the worker is assigned to run.
+// The assignments of all the workers are computed at most once per
+// hostHealthAssignmentsInterval.
+func (ws *workerServiceServer) hostHealthChecks(ctx context.Context, workerId string) ([]*pbs.HostHealthCheck, error) {
+	const op = "workers.(workerServiceServer).hostHealthChecks"
+	ws.healthAssignments.Lock()
+	defer ws.healthAssignments.Unlock()
+	if time.Since(ws.healthAssignments.computedAt) >= hostHealthAssignmentsInterval {
+		probes, err := ws.assignHostHealthChecks(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ws.healthAssignments.probes = probes
+		ws.healthAssignments.computedAt = time.Now()
+	}
+
+	probes := ws.healthAssignments.probes[workerId]
+	ret := make([]*pbs.HostHealthCheck, 0, len(probes))
+	for _, p := range probes {
+		ret = append(ret, &pbs.HostHealthCheck{
+			HostSetId:       p.HostSetId,
+			HostId:          p.HostId,
+			Address:         p.Address,
+			Protocol:        p.Protocol,
+			Path:            p.Path,
+			IntervalSeconds: p.IntervalSeconds,
+		})
+	}
+	return ret, nil
+}
+
+// assignHostHealthChecks returns the probes of the host health checks keyed
+// by the id of the active worker they are assigned to.
+func (ws *workerServiceServer) assignHostHealthChecks(ctx context.Context) (map[string][]*health.Probe, error) {
+	const op = "workers.(workerServiceServer).assignHostHealthChecks"
+	healthRepo, err := ws.hostHealthRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error getting host health repo"))
+	}
+	checks, err := healthRepo.ListHealthChecks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error listing host health checks"))
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error getting servers repo"))
+	}
+	workers, err := serversRepo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithActiveWorkers(true))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error listing workers"))
+	}
+
+	endpoints, err := ws.hostSetEndpoints(ctx, checks)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	probes, err := health.AssignAllProbes(ctx, checks, endpoints, workers)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error assigning host health checks"))
+	}
+	return probes, nil
+}
+
+// hostSetEndpoints returns the endpoints of the host sets of the health
+// checks.
+func (ws *workerServiceServer) hostSetEndpoints(ctx context.Context, checks []*health.HealthCheck) ([]*host.Endpoint, error) {
+	const op = "workers.(workerServiceServer).hostSetEndpoints"
+	staticRepo, err := ws.staticHostRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error getting static host repo"))
+	}
+	pluginRepo, err := ws.pluginHostRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error getting plugin host repo"))
+	}
+
+	var endpoints []*host.Endpoint
+	var pluginSetIds []string
+	for _, hc := range checks {
+		switch subtypes.SubtypeFromId(hostDomain, hc.HostSetId) {
+		case static.Subtype:
+			eps, err := staticRepo.Endpoints(ctx, hc.HostSetId)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			endpoints = append(endpoints, eps...)
+		default:
+			pluginSetIds = append(pluginSetIds, hc.HostSetId)
+		}
+	}
+	if len(pluginSetIds) > 0 {
+		eps, err := pluginRepo.Endpoints(ctx, pluginSetIds)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		endpoints = append(endpoints, eps...)
+	}
+	return endpoints, nil
+}
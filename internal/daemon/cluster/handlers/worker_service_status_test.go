@@ -12,14 +12,19 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/servers"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/health"
+	pluginhost "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/server/store"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/target/tcp"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/registration"
 	"github.com/hashicorp/nodeenrollment/rotation"
@@ -29,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestStatus(t *testing.T) {
@@ -53,6 +59,16 @@ func TestStatus(t *testing.T) {
 	connRepoFn := func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, rw, rw, kms)
 	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	repo, err := sessionRepoFn()
 	require.NoError(t, err)
@@ -89,7 +105,7 @@ func TestStatus(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, err)
 
-	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, new(sync.Map), kms)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
 	require.NotNil(t, s)
 
 	connection, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker1.PublicId)
@@ -239,6 +255,16 @@ func TestStatusSessionClosed(t *testing.T) {
 	connRepoFn := func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, rw, rw, kms)
 	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	repo, err := sessionRepoFn()
 	require.NoError(t, err)
@@ -287,7 +313,7 @@ func TestStatusSessionClosed(t *testing.T) {
 	sess2, _, err = repo.ActivateSession(ctx, sess2.PublicId, sess2.Version, tofu2)
 	require.NoError(t, err)
 
-	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, new(sync.Map), kms)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
 	require.NotNil(t, s)
 
 	connection, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker1.PublicId)
@@ -420,6 +446,16 @@ func TestStatusDeadConnection(t *testing.T) {
 	connRepoFn := func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, rw, rw, kms, session.WithWorkerStateDelay(0))
 	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	repo, err := sessionRepoFn()
 	require.NoError(t, err)
@@ -466,7 +502,7 @@ func TestStatusDeadConnection(t *testing.T) {
 	sess2, _, err = repo.ActivateSession(ctx, sess2.PublicId, sess2.Version, tofu2)
 	require.NoError(t, err)
 
-	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, new(sync.Map), kms)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
 	require.NotNil(t, s)
 
 	connection, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker1.PublicId)
@@ -560,6 +596,16 @@ func TestStatusWorkerWithKeyId(t *testing.T) {
 	connRepoFn := func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, rw, rw, kms)
 	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	repo, err := sessionRepoFn()
 	require.NoError(t, err)
@@ -619,7 +665,7 @@ func TestStatusWorkerWithKeyId(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, err)
 
-	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, new(sync.Map), kms)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
 	require.NotNil(t, s)
 
 	connection, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker1.PublicId)
@@ -745,10 +791,20 @@ func TestWorkerOperationalStatus(t *testing.T) {
 	connRepoFn := func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, rw, rw, kms)
 	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	worker1 := server.TestKmsWorker(t, conn, wrapper)
 
-	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, new(sync.Map), kms)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
 	require.NotNil(t, s)
 
 	cases := []struct {
@@ -828,3 +884,94 @@ func TestWorkerOperationalStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusHostHealthChecks(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms)
+	}
+	connRepoFn := func() (*session.ConnectionRepository, error) {
+		return session.NewConnectionRepository(ctx, rw, rw, kms)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	pluginHostRepoFn := func() (*pluginhost.Repository, error) {
+		return pluginhost.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
+
+	hc := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+
+	healthRepo, err := hostHealthRepoFn()
+	require.NoError(t, err)
+	check, err := health.NewHealthCheck(ctx, hs.GetPublicId(), health.ProtocolHttp, 8080, health.WithUnhealthyThreshold(1))
+	require.NoError(t, err)
+	_, err = healthRepo.SetHealthCheck(ctx, check)
+	require.NoError(t, err)
+
+	worker1 := server.TestKmsWorker(t, conn, wrapper)
+	s := handlers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, connRepoFn, staticHostRepoFn, pluginHostRepoFn, hostHealthRepoFn, new(sync.Map), kms)
+	require.NotNil(t, s)
+
+	workerStatus := &pb.ServerWorkerStatus{
+		PublicId:         worker1.GetPublicId(),
+		Name:             worker1.GetName(),
+		Address:          worker1.GetAddress(),
+		OperationalState: server.ActiveOperationalState.String(),
+	}
+	got, err := s.Status(ctx, &pbs.StatusRequest{WorkerStatus: workerStatus})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(
+		[]*pbs.HostHealthCheck{
+			{
+				HostSetId:       hs.GetPublicId(),
+				HostId:          h.GetPublicId(),
+				Address:         h.GetAddress() + ":8080",
+				Protocol:        health.ProtocolHttp,
+				Path:            health.DefaultPath,
+				IntervalSeconds: health.DefaultIntervalSeconds,
+			},
+		},
+		got.GetHostHealthChecks(),
+		protocmp.Transform(),
+	))
+
+	_, err = s.Status(ctx, &pbs.StatusRequest{
+		WorkerStatus: workerStatus,
+		HostHealthCheckResults: []*pbs.HostHealthCheckResult{
+			{HostSetId: hs.GetPublicId(), HostId: h.GetPublicId(), Error: "connection refused"},
+		},
+	})
+	require.NoError(t, err)
+
+	eps, err := healthRepo.FilterHealthy(ctx, []*host.Endpoint{{SetId: hs.GetPublicId(), HostId: h.GetPublicId(), Address: h.GetAddress()}})
+	require.NoError(t, err)
+	assert.Empty(t, eps)
+
+	// Results which can't be recorded don't fail the status.
+	got, err = s.Status(ctx, &pbs.StatusRequest{
+		WorkerStatus: workerStatus,
+		HostHealthCheckResults: []*pbs.HostHealthCheckResult{
+			{HostSetId: hs.GetPublicId(), Healthy: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, worker1.GetPublicId(), got.GetWorkerId())
+	assert.Len(t, got.GetHostHealthChecks(), 1)
+}
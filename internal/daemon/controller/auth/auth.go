@@ -45,6 +45,10 @@ const (
 
 	// It's of recovery type
 	AuthTokenTypeRecoveryKms
+
+	// No token was given but the api listener mapped the verified client
+	// certificate to an account
+	AuthTokenTypeClientCert
 )
 
 const (
@@ -309,7 +313,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 func (v *verifier) decryptToken(ctx context.Context) {
 	const op = "auth.(verifier).decryptToken"
 	switch v.requestInfo.TokenFormat {
-	case uint32(AuthTokenTypeUnknown):
+	case uint32(AuthTokenTypeUnknown), uint32(AuthTokenTypeClientCert):
 		// Nothing to decrypt
 		return
 
@@ -461,6 +465,26 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		// nonces there, so just set the user
 		userId = "u_recovery"
 
+	case uint32(AuthTokenTypeClientCert):
+		if v.requestInfo.ClientCertAccountId == "" {
+			// This will end up staying as the anonymous user
+			break
+		}
+		iamRepo, err := v.iamRepoFn()
+		if err != nil {
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to get iam repo"))
+			return
+		}
+		u, err := iamRepo.LookupUserWithLogin(ctx, v.requestInfo.ClientCertAccountId)
+		if err != nil {
+			// Continue as the anonymous user as the account may not be
+			// associated with a user
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up user for client certificate account; continuing as anonymous user"), event.WithInfo("account_id", v.requestInfo.ClientCertAccountId))
+			break
+		}
+		accountId = v.requestInfo.ClientCertAccountId
+		userId = u.GetPublicId()
+
 	case uint32(AuthTokenTypeBearer), uint32(AuthTokenTypeSplitCookie):
 		if v.requestInfo.Token == "" {
 			// This will end up staying as the anonymous user
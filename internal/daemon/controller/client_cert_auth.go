@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// clientCertAccountId returns the id of the account of the configured auth
+// method mapped to the verified client certificate of the request. It returns
+// an empty string if the request has no verified client certificate or no
+// account matches it.
+func (c *Controller) clientCertAccountId(ctx context.Context, certAuth *config.ClientCertAuth, r *http.Request) (string, error) {
+	const op = "controller.(Controller).clientCertAccountId"
+	if certAuth == nil || r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", nil
+	}
+	values := clientCertAttributeValues(r.TLS.VerifiedChains[0][0], certAuth.Attribute)
+	switch {
+	case strings.HasPrefix(certAuth.AuthMethodId, password.AuthMethodPrefix+"_"):
+		repo, err := c.PasswordAuthRepoFn()
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		for _, v := range values {
+			acct, err := repo.LookupAccountByLoginName(ctx, certAuth.AuthMethodId, strings.ToLower(v))
+			if err != nil {
+				return "", errors.Wrap(ctx, err, op)
+			}
+			if acct != nil {
+				return acct.GetPublicId(), nil
+			}
+		}
+	case strings.HasPrefix(certAuth.AuthMethodId, oidc.AuthMethodPrefix+"_"):
+		repo, err := c.OidcRepoFn()
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		for _, v := range values {
+			acct, err := repo.LookupAccountBySubject(ctx, certAuth.AuthMethodId, v)
+			if err != nil {
+				return "", errors.Wrap(ctx, err, op)
+			}
+			if acct != nil {
+				return acct.GetPublicId(), nil
+			}
+		}
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported auth method %q", certAuth.AuthMethodId))
+	}
+	return "", nil
+}
+
+// clientCertAttributeValues returns the values of the attribute of the
+// certificate, in the order they appear in the certificate.
+func clientCertAttributeValues(cert *x509.Certificate, attribute string) []string {
+	switch attribute {
+	case config.ClientCertAttributeCommonName:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case config.ClientCertAttributeDnsSan:
+		return cert.DNSNames
+	case config.ClientCertAttributeEmailSan:
+		return cert.EmailAddresses
+	case config.ClientCertAttributeUriSan:
+		values := make([]string, 0, len(cert.URIs))
+		for _, u := range cert.URIs {
+			values = append(values, u.String())
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCertAttributeValues(t *testing.T) {
+	uri, err := url.Parse("spiffe://example.com/user/alice")
+	assert.NoError(t, err)
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "alice"},
+		DNSNames:       []string{"alice.example.com", "a.example.com"},
+		EmailAddresses: []string{"alice@example.com"},
+		URIs:           []*url.URL{uri},
+	}
+
+	tests := []struct {
+		attribute string
+		want      []string
+	}{
+		{attribute: config.ClientCertAttributeCommonName, want: []string{"alice"}},
+		{attribute: config.ClientCertAttributeDnsSan, want: []string{"alice.example.com", "a.example.com"}},
+		{attribute: config.ClientCertAttributeEmailSan, want: []string{"alice@example.com"}},
+		{attribute: config.ClientCertAttributeUriSan, want: []string{"spiffe://example.com/user/alice"}},
+		{attribute: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute, func(t *testing.T) {
+			assert.Equal(t, tt.want, clientCertAttributeValues(cert, tt.attribute))
+		})
+	}
+	assert.Empty(t, clientCertAttributeValues(&x509.Certificate{}, config.ClientCertAttributeCommonName))
+}
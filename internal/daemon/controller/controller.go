@@ -4,14 +4,18 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/hashicorp/boundary/internal/alias"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	plugincred "github.com/hashicorp/boundary/internal/credential/plugin"
 	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
@@ -19,11 +23,15 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/internal/metric"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	hosthealth "github.com/hashicorp/boundary/internal/host/health"
 	pluginhost "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/kms/rotation"
+	"github.com/hashicorp/boundary/internal/notification"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	paginationjob "github.com/hashicorp/boundary/internal/pagination/job"
 	"github.com/hashicorp/boundary/internal/plugin/host"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/scheduler"
@@ -33,9 +41,13 @@ import (
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	credential_plugin_assets "github.com/hashicorp/boundary/plugins/credential"
 	host_plugin_assets "github.com/hashicorp/boundary/plugins/host"
+	rotation_plugin_assets "github.com/hashicorp/boundary/plugins/rotation"
 	"github.com/hashicorp/boundary/sdk/pbs/plugin"
+	external_credential_plugins "github.com/hashicorp/boundary/sdk/plugins/credential"
 	external_host_plugins "github.com/hashicorp/boundary/sdk/plugins/host"
+	external_rotation_plugins "github.com/hashicorp/boundary/sdk/plugins/rotation"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/mlock"
 	"github.com/hashicorp/go-secure-stdlib/pluginutil/v2"
@@ -98,11 +110,15 @@ type Controller struct {
 	// Used for testing and tracking worker health
 	workerStatusUpdateTimes *sync.Map
 
+	// maintenance holds the *server.Maintenance last read from the database
+	maintenance *atomic.Value
+
 	apiGrpcServer         *grpc.Server
 	apiGrpcServerListener grpcServerListener
 	apiGrpcGatewayTicket  string
 
 	// Repo factory methods
+	AliasRepoFn             common.AliasRepoFactory
 	AuthTokenRepoFn         common.AuthTokenRepoFactory
 	VaultCredentialRepoFn   common.VaultCredentialRepoFactory
 	StaticCredentialRepoFn  common.StaticCredentialRepoFactory
@@ -115,8 +131,10 @@ type Controller struct {
 	StaticHostRepoFn        common.StaticRepoFactory
 	PluginHostRepoFn        common.PluginHostRepoFactory
 	HostPluginRepoFn        common.HostPluginRepoFactory
+	HostHealthRepoFn        common.HostHealthRepoFactory
 	TargetRepoFn            target.RepositoryFactory
 	WorkerAuthRepoStorageFn common.WorkerAuthRepoStorageFactory
+	KeyRotationRepoFn       common.KeyRotationRepoFactory
 
 	scheduler *scheduler.Scheduler
 
@@ -139,6 +157,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 		schedulerWg:             new(sync.WaitGroup),
 		workerAuthCache:         new(sync.Map),
 		workerStatusUpdateTimes: new(sync.Map),
+		maintenance:             new(atomic.Value),
 		enabledPlugins:          conf.Server.EnabledPlugins,
 		apiListeners:            make([]*base.ServerListener, 0),
 	}
@@ -222,7 +241,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 			if _, err = conf.RegisterHostPlugin(ctx, "loopback", plg, opts...); err != nil {
 				return nil, err
 			}
-		case base.EnabledPluginHostAzure, base.EnabledPluginHostAws:
+		case base.EnabledPluginHostAzure, base.EnabledPluginHostAws, base.EnabledPluginHostGcp:
 			pluginType := strings.ToLower(enabledPlugin.String())
 			client, cleanup, err := external_host_plugins.CreateHostPlugin(
 				ctx,
@@ -240,15 +259,94 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 			if _, err := conf.RegisterHostPlugin(ctx, pluginType, client, hostplugin.WithDescription(fmt.Sprintf("Built-in %s host plugin", enabledPlugin.String()))); err != nil {
 				return nil, fmt.Errorf("error registering %s host plugin: %w", pluginType, err)
 			}
+		case base.EnabledPluginCredentialLoopback:
+			if err := conf.RegisterCredentialPlugin("loopback", plugincred.NewWrappingPluginClient(plugincred.NewLoopbackPlugin())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// External credential plugins are loaded from the configured directory
+	// and run as separate processes for the lifetime of the controller
+	if conf.RawConfig != nil {
+		for _, pluginName := range conf.RawConfig.Plugins.CredentialPlugins {
+			if pluginLogger == nil {
+				pluginLogger, err = event.NewHclogLogger(ctx, c.conf.Server.Eventer)
+				if err != nil {
+					return nil, fmt.Errorf("error creating credential plugin logger: %w", err)
+				}
+			}
+			client, cleanup, err := external_credential_plugins.CreateCredentialPlugin(
+				ctx,
+				pluginName,
+				external_credential_plugins.WithPluginOptions(
+					pluginutil.WithPluginExecutionDirectory(conf.RawConfig.Plugins.ExecutionDir),
+					pluginutil.WithPluginsFilesystem(credential_plugin_assets.CredentialPluginPrefix, os.DirFS(conf.RawConfig.Plugins.CredentialPluginDir)),
+				),
+				external_credential_plugins.WithLogger(pluginLogger.Named(pluginName)),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error creating %s credential plugin: %w", pluginName, err)
+			}
+			conf.ShutdownFuncs = append(conf.ShutdownFuncs, cleanup)
+			if err := conf.RegisterCredentialPlugin(pluginName, client); err != nil {
+				return nil, fmt.Errorf("error registering %s credential plugin: %w", pluginName, err)
+			}
+		}
+	}
+
+	// External rotation plugins are loaded from the configured directory and
+	// run as separate processes for the lifetime of the controller. They
+	// rotate the static credentials whose rotation config uses their name as
+	// rotator
+	if conf.RawConfig != nil {
+		for _, pluginName := range conf.RawConfig.Plugins.RotationPlugins {
+			if pluginLogger == nil {
+				pluginLogger, err = event.NewHclogLogger(ctx, c.conf.Server.Eventer)
+				if err != nil {
+					return nil, fmt.Errorf("error creating rotation plugin logger: %w", err)
+				}
+			}
+			client, cleanup, err := external_rotation_plugins.CreateRotationPlugin(
+				ctx,
+				pluginName,
+				external_rotation_plugins.WithPluginOptions(
+					pluginutil.WithPluginExecutionDirectory(conf.RawConfig.Plugins.ExecutionDir),
+					pluginutil.WithPluginsFilesystem(rotation_plugin_assets.RotationPluginPrefix, os.DirFS(conf.RawConfig.Plugins.RotationPluginDir)),
+				),
+				external_rotation_plugins.WithLogger(pluginLogger.Named(pluginName)),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error creating %s rotation plugin: %w", pluginName, err)
+			}
+			conf.ShutdownFuncs = append(conf.ShutdownFuncs, cleanup)
+			if err := conf.RegisterRotationPlugin(pluginName, client); err != nil {
+				return nil, fmt.Errorf("error registering %s rotation plugin: %w", pluginName, err)
+			}
 		}
 	}
 
 	if conf.HostPlugins == nil {
 		conf.HostPlugins = make(map[string]plugin.HostPluginServiceClient)
 	}
+	if conf.CredentialPlugins == nil {
+		conf.CredentialPlugins = make(map[string]plugin.CredentialPluginServiceClient)
+	}
 
 	// Set up repo stuff
 	dbase := db.New(c.conf.Database)
+	// reader is used by the repositories serving the API. When a read replica
+	// of the database is configured, it serves the reads of the contexts
+	// returned by db.WithReplicaReads, which the list endpoints and the
+	// validation of auth tokens use, and every other read still goes to the
+	// primary.
+	var reader db.Reader = dbase
+	if c.conf.ReadDatabase != nil {
+		reader, err = db.NewReplicaReader(ctx, db.New(c.conf.ReadDatabase), dbase)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database read replica reader: %w", err)
+		}
+	}
 	c.kms, err = kms.New(ctx, dbase, dbase)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms cache: %w", err)
@@ -307,42 +405,48 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 		return nil, fmt.Errorf("error creating new scheduler: %w", err)
 	}
 	c.IamRepoFn = func() (*iam.Repository, error) {
-		return iam.NewRepository(dbase, dbase, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader))
+		return iam.NewRepository(reader, dbase, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader))
 	}
 	c.StaticHostRepoFn = func() (*static.Repository, error) {
-		return static.NewRepository(dbase, dbase, c.kms)
+		return static.NewRepository(reader, dbase, c.kms)
 	}
 	c.PluginHostRepoFn = func() (*pluginhost.Repository, error) {
-		return pluginhost.NewRepository(dbase, dbase, c.kms, c.scheduler, c.conf.HostPlugins)
+		return pluginhost.NewRepository(reader, dbase, c.kms, c.scheduler, c.conf.HostPlugins)
 	}
 	c.HostPluginRepoFn = func() (*host.Repository, error) {
-		return host.NewRepository(dbase, dbase, c.kms)
+		return host.NewRepository(reader, dbase, c.kms)
+	}
+	c.HostHealthRepoFn = func() (*hosthealth.Repository, error) {
+		return hosthealth.NewRepository(dbase, dbase)
+	}
+	c.AliasRepoFn = func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, reader, dbase, c.kms)
 	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
-		return authtoken.NewRepository(dbase, dbase, c.kms,
+		return authtoken.NewRepository(reader, dbase, c.kms,
 			authtoken.WithTokenTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenTimeToLiveDuration),
 			authtoken.WithTokenTimeToStaleDuration(c.conf.RawConfig.Controller.AuthTokenTimeToStaleDuration))
 	}
 	c.VaultCredentialRepoFn = func() (*vault.Repository, error) {
-		return vault.NewRepository(dbase, dbase, c.kms, c.scheduler)
+		return vault.NewRepository(reader, dbase, c.kms, c.scheduler)
 	}
 	c.StaticCredentialRepoFn = func() (*credstatic.Repository, error) {
-		return credstatic.NewRepository(ctx, dbase, dbase, c.kms)
+		return credstatic.NewRepository(ctx, reader, dbase, c.kms)
 	}
 	c.ServersRepoFn = func() (*server.Repository, error) {
 		return server.NewRepository(dbase, dbase, c.kms)
 	}
 	c.OidcRepoFn = func() (*oidc.Repository, error) {
-		return oidc.NewRepository(ctx, dbase, dbase, c.kms)
+		return oidc.NewRepository(ctx, reader, dbase, c.kms)
 	}
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
-		return password.NewRepository(dbase, dbase, c.kms)
+		return password.NewRepository(reader, dbase, c.kms)
 	}
 	c.TargetRepoFn = func(o ...target.Option) (*target.Repository, error) {
-		return target.NewRepository(ctx, dbase, dbase, c.kms, o...)
+		return target.NewRepository(ctx, reader, dbase, c.kms, o...)
 	}
 	c.SessionRepoFn = func(opt ...session.Option) (*session.Repository, error) {
-		return session.NewRepository(ctx, dbase, dbase, c.kms, opt...)
+		return session.NewRepository(ctx, reader, dbase, c.kms, opt...)
 	}
 	c.ConnectionRepoFn = func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, dbase, dbase, c.kms)
@@ -350,6 +454,9 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.WorkerAuthRepoStorageFn = func() (*server.WorkerAuthRepositoryStorage, error) {
 		return server.NewRepositoryStorage(ctx, dbase, dbase, c.kms)
 	}
+	c.KeyRotationRepoFn = func() (*rotation.Repository, error) {
+		return rotation.NewRepository(dbase, dbase, c.kms, c.scheduler)
+	}
 
 	// Check that credentials are available at startup, to avoid some harmless
 	// but nasty-looking errors
@@ -390,6 +497,10 @@ func (c *Controller) Start() error {
 	if err := c.registerJobs(); err != nil {
 		return fmt.Errorf("error registering jobs: %w", err)
 	}
+	// Read the maintenance mode before serving requests
+	if err := c.refreshMaintenance(c.baseContext); err != nil {
+		return fmt.Errorf("error reading maintenance mode: %w", err)
+	}
 	if err := c.startListeners(); err != nil {
 		return fmt.Errorf("error starting controller listeners: %w", err)
 	}
@@ -402,11 +513,15 @@ func (c *Controller) Start() error {
 		return fmt.Errorf("error starting scheduler: %w", err)
 	}
 
-	c.tickerWg.Add(5)
+	c.tickerWg.Add(7)
 	go func() {
 		defer c.tickerWg.Done()
 		c.startStatusTicking(c.baseContext)
 	}()
+	go func() {
+		defer c.tickerWg.Done()
+		c.startMaintenanceTicking(c.baseContext)
+	}()
 	go func() {
 		defer c.tickerWg.Done()
 		c.startNonceCleanupTicking(c.baseContext)
@@ -419,6 +534,10 @@ func (c *Controller) Start() error {
 		defer c.tickerWg.Done()
 		c.startCloseExpiredPendingTokens(c.baseContext)
 	}()
+	go func() {
+		defer c.tickerWg.Done()
+		c.startKmsReloadTicking(c.baseContext)
+	}()
 	go func() {
 		defer c.tickerWg.Done()
 		c.started.Store(true)
@@ -473,16 +592,60 @@ func (c *Controller) registerJobs() error {
 	if err := pluginhost.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.HostPlugins); err != nil {
 		return err
 	}
+	if err := credstatic.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.RotationPlugins); err != nil {
+		return err
+	}
 	if err := session.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.StatusGracePeriodDuration); err != nil {
 		return err
 	}
 	if err := serversjob.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
 		return err
 	}
+	if err := rotation.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
+		return err
+	}
+	if err := paginationjob.RegisterJobs(c.baseContext, c.scheduler, rw); err != nil {
+		return err
+	}
+	webhooks, err := c.webhooks()
+	if err != nil {
+		return err
+	}
+	if err := notification.RegisterJobs(c.baseContext, c.scheduler, rw, rw, webhooks, c.conf.StatusGracePeriodDuration); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// webhooks returns the webhooks configured on the controller.
+func (c *Controller) webhooks() ([]*notification.Webhook, error) {
+	const op = "controller.(Controller).webhooks"
+	var webhooks []*notification.Webhook
+	for _, wh := range c.conf.RawConfig.Controller.Webhooks {
+		var opts []notification.Option
+		if len(wh.Events) > 0 {
+			eventTypes := make([]notification.EventType, 0, len(wh.Events))
+			for _, e := range wh.Events {
+				eventTypes = append(eventTypes, notification.EventType(e))
+			}
+			opts = append(opts, notification.WithEventTypes(eventTypes))
+		}
+		if wh.MaxAttempts != 0 {
+			opts = append(opts, notification.WithMaxAttempts(wh.MaxAttempts))
+		}
+		if wh.TimeoutDuration != 0 {
+			opts = append(opts, notification.WithTimeout(wh.TimeoutDuration))
+		}
+		w, err := notification.NewWebhook(c.baseContext, wh.Name, wh.ScopeId, wh.Url, wh.Secret, opts...)
+		if err != nil {
+			return nil, errors.Wrap(c.baseContext, err, op, errors.WithMsg(fmt.Sprintf("invalid webhook %q", wh.Name)))
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
 func (c *Controller) Shutdown() error {
 	const op = "controller.(Controller).Shutdown"
 	if !c.started.Load() {
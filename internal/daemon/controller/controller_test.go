@@ -254,24 +254,102 @@ func TestController_NewPluginsConfig(t *testing.T) {
 	conf.EnabledPlugins = []base.EnabledPlugin{
 		base.EnabledPluginHostAws,
 		base.EnabledPluginHostAzure,
+		base.EnabledPluginHostGcp,
 	}
 
 	_, err = New(testCtx, conf)
 	require.NoError(err)
 
-	// Check that both plugins were written to the temp dir
+	// Check that all plugins were written to the temp dir
 	files, err := os.ReadDir(tmpDir)
 	require.NoError(err)
-	require.Len(files, 2)
+	require.Len(files, 3)
 	for _, file := range files {
 		name := filepath.Base(file.Name())
 		// Remove random chars and hyphen
 		name = name[0 : len(name)-6]
 		switch name {
 		case host_plugin_assets.HostPluginPrefix + "aws",
-			host_plugin_assets.HostPluginPrefix + "azure":
+			host_plugin_assets.HostPluginPrefix + "azure",
+			host_plugin_assets.HostPluginPrefix + "gcp":
 		default:
 			require.Fail("unexpected name", name)
 		}
 	}
 }
+
+func TestController_NewRotationPlugins(t *testing.T) {
+	newConf := func(t *testing.T, pluginNames ...string) *Config {
+		ctx, cancel := context.WithCancel(context.Background())
+		tc := &TestController{
+			t:              t,
+			ctx:            ctx,
+			cancel:         cancel,
+			opts:           nil,
+			shutdownDoneCh: make(chan struct{}),
+			shutdownOnce:   new(sync.Once),
+		}
+		initialConfig, err := config.DevController()
+		require.NoError(t, err)
+		initialConfig.Plugins.ExecutionDir = t.TempDir()
+		initialConfig.Plugins.RotationPluginDir = t.TempDir()
+		initialConfig.Plugins.RotationPlugins = pluginNames
+		return TestControllerConfig(t, ctx, tc, &TestControllerOpts{Config: initialConfig})
+	}
+
+	t.Run("no external plugins", func(t *testing.T) {
+		conf := newConf(t)
+		_, err := New(context.Background(), conf)
+		require.NoError(t, err)
+		assert.Empty(t, conf.RotationPlugins)
+	})
+	t.Run("missing external plugin", func(t *testing.T) {
+		conf := newConf(t, "missing")
+		_, err := New(context.Background(), conf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error creating missing rotation plugin")
+	})
+}
+
+func TestController_NewCredentialPlugins(t *testing.T) {
+	newConf := func(t *testing.T, pluginNames ...string) *Config {
+		ctx, cancel := context.WithCancel(context.Background())
+		tc := &TestController{
+			t:              t,
+			ctx:            ctx,
+			cancel:         cancel,
+			opts:           nil,
+			shutdownDoneCh: make(chan struct{}),
+			shutdownOnce:   new(sync.Once),
+		}
+		initialConfig, err := config.DevController()
+		require.NoError(t, err)
+		initialConfig.Plugins.ExecutionDir = t.TempDir()
+		initialConfig.Plugins.CredentialPluginDir = t.TempDir()
+		initialConfig.Plugins.CredentialPlugins = pluginNames
+		conf := TestControllerConfig(t, ctx, tc, &TestControllerOpts{Config: initialConfig})
+		conf.EnabledPlugins = []base.EnabledPlugin{base.EnabledPluginCredentialLoopback}
+		return conf
+	}
+
+	t.Run("loopback", func(t *testing.T) {
+		conf := newConf(t)
+		_, err := New(context.Background(), conf)
+		require.NoError(t, err)
+		assert.Contains(t, conf.CredentialPlugins, "loopback")
+	})
+	t.Run("no credential plugins", func(t *testing.T) {
+		conf := newConf(t)
+		conf.EnabledPlugins = nil
+		_, err := New(context.Background(), conf)
+		require.NoError(t, err)
+		assert.NotNil(t, conf.CredentialPlugins)
+		assert.Empty(t, conf.CredentialPlugins)
+	})
+	t.Run("missing external plugin", func(t *testing.T) {
+		conf := newConf(t, "missing")
+		_, err := New(context.Background(), conf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error creating missing credential plugin")
+	})
+}
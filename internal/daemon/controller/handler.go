@@ -16,9 +16,11 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/daemon/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/accounts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/aliases"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentiallibraries"
@@ -55,6 +57,10 @@ import (
 type HandlerProperties struct {
 	ListenerConfig *listenerutil.ListenerConfig
 	CancelCtx      context.Context
+
+	// ClientCertAuth maps verified client certificates to accounts when
+	// requests do not carry an auth token.
+	ClientCertAuth *config.ClientCertAuth
 }
 
 // apiHandler returns an http.Handler for the services. This can be used on
@@ -70,7 +76,8 @@ func (c *Controller) apiHandler(props HandlerProperties) (http.Handler, error) {
 	mux.Handle("/v1/", grpcGwMux)
 	mux.Handle("/", handleUi(c))
 
-	corsWrappedHandler := wrapHandlerWithCors(mux, props)
+	maintenanceWrappedHandler := wrapHandlerWithMaintenance(mux, c)
+	corsWrappedHandler := wrapHandlerWithCors(maintenanceWrappedHandler, props)
 	commonWrappedHandler := wrapHandlerWithCommonFuncs(corsWrappedHandler, c, props)
 	callbackInterceptingHandler := wrapHandlerWithCallbackInterceptor(commonWrappedHandler, c)
 	printablePathCheckHandler := cleanhttp.PrintablePathCheckHandler(callbackInterceptingHandler, nil)
@@ -97,7 +104,7 @@ func (c *Controller) GetHealthHandler(lcfg *listenerutil.ListenerConfig) (http.H
 		return nil, fmt.Errorf("%s: failed to register health service handler: %w", op, err)
 	}
 
-	wrapped := wrapHandlerWithCommonFuncs(healthGrpcGwMux, c, HandlerProperties{lcfg, c.baseContext})
+	wrapped := wrapHandlerWithCommonFuncs(healthGrpcGwMux, c, HandlerProperties{ListenerConfig: lcfg, CancelCtx: c.baseContext})
 	return common.WrapWithEventsHandler(wrapped, c.conf.Eventer, c.kms, lcfg)
 }
 
@@ -118,7 +125,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterHostCatalogServiceServer(s, hcs)
 	}
 	if _, ok := currentServices[services.HostSetService_ServiceDesc.ServiceName]; !ok {
-		hss, err := host_sets.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn)
+		hss, err := host_sets.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn, c.HostHealthRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create host set handler service: %w", err)
 		}
@@ -153,7 +160,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterAuthTokenServiceServer(s, authtoks)
 	}
 	if _, ok := currentServices[services.ScopeService_ServiceDesc.ServiceName]; !ok {
-		os, err := scopes.NewService(c.IamRepoFn)
+		os, err := scopes.NewService(c.IamRepoFn, c.KeyRotationRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create scope handler service: %w", err)
 		}
@@ -172,17 +179,27 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 			c.kms,
 			c.TargetRepoFn,
 			c.IamRepoFn,
+			c.AliasRepoFn,
 			c.ServersRepoFn,
 			c.SessionRepoFn,
 			c.PluginHostRepoFn,
 			c.StaticHostRepoFn,
+			c.HostHealthRepoFn,
 			c.VaultCredentialRepoFn,
-			c.StaticCredentialRepoFn)
+			c.StaticCredentialRepoFn,
+			c.conf.RawConfig.Controller.SessionIdleTimeoutDuration)
 		if err != nil {
 			return fmt.Errorf("failed to create target handler service: %w", err)
 		}
 		services.RegisterTargetServiceServer(s, ts)
 	}
+	if _, ok := currentServices[services.AliasService_ServiceDesc.ServiceName]; !ok {
+		as, err := aliases.NewService(c.baseContext, c.AliasRepoFn, c.IamRepoFn)
+		if err != nil {
+			return fmt.Errorf("failed to create alias handler service: %w", err)
+		}
+		services.RegisterAliasServiceServer(s, as)
+	}
 	if _, ok := currentServices[services.GroupService_ServiceDesc.ServiceName]; !ok {
 		gs, err := groups.NewService(c.IamRepoFn)
 		if err != nil {
@@ -278,6 +295,9 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterTargetServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register target service handler: %w", err)
 	}
+	if err := services.RegisterAliasServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register alias service handler: %w", err)
+	}
 	if err := services.RegisterGroupServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register group service handler: %w", err)
 	}
@@ -349,6 +369,19 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 
 		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(ctx, c.kms, r)
 
+		// Requests without a token are authenticated as the account mapped to
+		// their client certificate, if any
+		if requestInfo.TokenFormat == uint32(auth.AuthTokenTypeUnknown) && props.ClientCertAuth != nil {
+			accountId, err := c.clientCertAccountId(ctx, props.ClientCertAuth, r)
+			switch {
+			case err != nil:
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error mapping client certificate to an account; continuing as anonymous user"))
+			case accountId != "":
+				requestInfo.TokenFormat = uint32(auth.AuthTokenTypeClientCert)
+				requestInfo.ClientCertAccountId = accountId
+			}
+		}
+
 		if info, ok := event.RequestInfoFromContext(ctx); ok {
 			// piggyback some eventing fields with the auth info proto message
 			requestInfo.EventId = info.EventId
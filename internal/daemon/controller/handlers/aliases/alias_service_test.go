@@ -0,0 +1,267 @@
+package aliases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/aliases"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+
+func getTestService(t *testing.T) (aliases.Service, *db.DB, func() (*iam.Repository, error)) {
+	t.Helper()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	s, err := aliases.NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+	return s, conn, iamRepoFn
+}
+
+func TestGet(t *testing.T) {
+	s, conn, iamRepoFn := getTestService(t)
+	a := alias.TestAlias(t, conn, "get.alias", alias.WithName("default"), alias.WithDescription("default"))
+
+	want := &pb.Alias{
+		Id:                a.GetPublicId(),
+		ScopeId:           scope.Global.String(),
+		Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+		Name:              wrapperspb.String("default"),
+		Description:       wrapperspb.String("default"),
+		CreatedTime:       a.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       a.GetUpdateTime().GetTimestamp(),
+		Version:           1,
+		Value:             "get.alias",
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetAliasRequest
+		res  *pbs.GetAliasResponse
+		err  error
+	}{
+		{
+			name: "Get an existing alias",
+			req:  &pbs.GetAliasRequest{Id: a.GetPublicId()},
+			res:  &pbs.GetAliasResponse{Item: want},
+		},
+		{
+			name: "Get a non existing alias",
+			req:  &pbs.GetAliasRequest{Id: alias.AliasPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetAliasRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetAlias(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetAlias(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	s, conn, iamRepoFn := getTestService(t)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+
+	got, err := s.ListAliases(ctx, &pbs.ListAliasesRequest{ScopeId: scope.Global.String()})
+	require.NoError(t, err)
+	assert.Empty(t, got.GetItems())
+
+	alias.TestAlias(t, conn, "first.alias")
+	alias.TestAlias(t, conn, "second.alias")
+
+	got, err = s.ListAliases(ctx, &pbs.ListAliasesRequest{ScopeId: scope.Global.String()})
+	require.NoError(t, err)
+	assert.Len(t, got.GetItems(), 2)
+
+	got, err = s.ListAliases(ctx, &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Filter: `"/item/value"=="first.alias"`})
+	require.NoError(t, err)
+	require.Len(t, got.GetItems(), 1)
+	assert.Equal(t, "first.alias", got.GetItems()[0].GetValue())
+
+	_, err = s.ListAliases(ctx, &pbs.ListAliasesRequest{ScopeId: "o_1234567890"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
+func TestCreate(t *testing.T) {
+	s, conn, iamRepoFn := getTestService(t)
+	iamRepo, err := iamRepoFn()
+	require.NoError(t, err)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(context.Background(), t, conn, prj.GetPublicId(), "test target")
+
+	cases := []struct {
+		name string
+		item *pb.Alias
+		want *pb.Alias
+		err  error
+	}{
+		{
+			name: "Create a valid alias",
+			item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Value:         "Prod.Postgres.Primary",
+				Name:          wrapperspb.String("name"),
+				DestinationId: wrapperspb.String(tar.GetPublicId()),
+			},
+			want: &pb.Alias{
+				ScopeId:           scope.Global.String(),
+				Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+				Value:             "prod.postgres.primary",
+				Name:              wrapperspb.String("name"),
+				DestinationId:     wrapperspb.String(tar.GetPublicId()),
+				Version:           1,
+				AuthorizedActions: testAuthorizedActions,
+			},
+		},
+		{
+			name: "Not global scope",
+			item: &pb.Alias{
+				ScopeId: prj.GetPublicId(),
+				Value:   "project.alias",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid value",
+			item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Value:   "not_a_dns_name",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid destination id",
+			item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Value:         "bad.destination",
+				DestinationId: wrapperspb.String("hst_1234567890"),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid host id",
+			item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Value:         "bad.host",
+				DestinationId: wrapperspb.String(tar.GetPublicId()),
+				HostId:        wrapperspb.String(tar.GetPublicId()),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify id",
+			item: &pb.Alias{
+				Id:      alias.AliasPrefix + "_1234567890",
+				ScopeId: scope.Global.String(),
+				Value:   "with.id",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateAlias(auth.DisabledAuthTestContext(iamRepoFn, tc.item.GetScopeId()), &pbs.CreateAliasRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateAlias(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), "aliases/"+alias.AliasPrefix+"_")
+			assert.Equal(got.GetUri(), "aliases/"+got.GetItem().GetId())
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.Equal(got.GetItem().GetCreatedTime(), got.GetItem().GetUpdatedTime())
+			got.Item.Id, got.Item.CreatedTime, got.Item.UpdatedTime = "", nil, nil
+			assert.Empty(cmp.Diff(got.GetItem(), tc.want, protocmp.Transform()), "CreateAlias(%q) got response\n%q, wanted\n%q", tc.item, got.GetItem(), tc.want)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s, conn, iamRepoFn := getTestService(t)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+	a := alias.TestAlias(t, conn, "update.alias")
+
+	got, err := s.UpdateAlias(ctx, &pbs.UpdateAliasRequest{
+		Id:         a.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"value", "description"}},
+		Item: &pb.Alias{
+			Version:     a.GetVersion(),
+			Value:       "Updated.Alias",
+			Description: wrapperspb.String("updated"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "updated.alias", got.GetItem().GetValue())
+	assert.Equal(t, "updated", got.GetItem().GetDescription().GetValue())
+	assert.Equal(t, a.GetVersion()+1, got.GetItem().GetVersion())
+
+	_, err = s.UpdateAlias(ctx, &pbs.UpdateAliasRequest{
+		Id:         a.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"value"}},
+		Item: &pb.Alias{
+			Version: got.GetItem().GetVersion(),
+			Value:   "",
+		},
+	})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
+
+func TestDelete(t *testing.T) {
+	s, conn, iamRepoFn := getTestService(t)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+	a := alias.TestAlias(t, conn, "delete.alias")
+
+	_, err := s.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: a.GetPublicId()})
+	require.NoError(t, err)
+
+	_, err = s.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: a.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+
+	_, err = s.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: "j_1234567890"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
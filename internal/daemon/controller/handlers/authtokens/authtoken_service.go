@@ -3,6 +3,7 @@ package authtokens
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -10,8 +11,10 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -66,6 +69,11 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
+	tok, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.AuthToken,
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
 	if authResults.Error != nil {
 		// If it's forbidden, and it's a recursive request, and they're
@@ -79,22 +87,16 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 			return nil, authResults.Error
 		}
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
 		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.AuthToken, req.GetRecursive())
 	if err != nil {
 		return nil, err
 	}
+	paginated := req.GetPageSize() > 0 || req.GetListToken() != ""
 	// If no scopes match, return an empty response
-	if len(scopeIds) == 0 {
-		return &pbs.ListAuthTokensResponse{}, nil
-	}
-
-	ul, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
+	if len(scopeIds) == 0 && !paginated {
 		return &pbs.ListAuthTokensResponse{}, nil
 	}
 
@@ -102,20 +104,35 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthToken, 0, len(ul))
+	finalItems := make([]*pb.AuthToken, 0, req.GetPageSize())
 	res := perms.Resource{
 		Type: resource.AuthToken,
 	}
-	for _, at := range ul {
+	listFn := func(ctx context.Context, page *pagination.Page) ([]pagination.Item, error) {
+		if len(scopeIds) == 0 {
+			return nil, nil
+		}
+		ul, err := s.listFromRepo(ctx, scopeIds, page)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]pagination.Item, 0, len(ul))
+		for _, at := range ul {
+			items = append(items, at)
+		}
+		return items, nil
+	}
+	appendFn := func(ctx context.Context, i pagination.Item) (bool, error) {
+		at := i.(*authtoken.AuthToken)
 		res.Id = at.GetPublicId()
 		res.ScopeId = at.GetScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -130,15 +147,47 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 
 		item, err := toProto(ctx, at, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(item) {
+			return false, nil
 		}
+		finalItems = append(finalItems, item)
+		return true, nil
+	}
+	next, complete, err := pagination.FillPage(ctx, tok, int(req.GetPageSize()), listFn, appendFn)
+	if err != nil {
+		return nil, err
+	}
+	if !paginated {
+		if len(finalItems) == 0 {
+			return &pbs.ListAuthTokensResponse{}, nil
+		}
+		return &pbs.ListAuthTokensResponse{Items: finalItems}, nil
 	}
 
-	return &pbs.ListAuthTokensResponse{Items: finalItems}, nil
+	var removedIds []string
+	if tok.Refresh() && tok.FirstPage() {
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		removedIds, err = repo.ListDeletedIds(ctx, scopeIds, tok.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	listToken, err := next.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAuthTokensResponse{
+		Items:        finalItems,
+		ResponseType: pagination.ResponseType(complete),
+		ListToken:    listToken,
+		RemovedIds:   removedIds,
+	}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -258,13 +307,13 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*authtoken.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, page *pagination.Page) ([]*authtoken.AuthToken, error) {
 	repo, err := s.repoFn()
 	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, scopeIds)
+	ul, err := repo.ListAuthTokens(ctx, scopeIds, authtoken.WithPage(page))
 	if err != nil {
 		return nil, err
 	}
@@ -382,6 +431,7 @@ func validateListRequest(req *pbs.ListAuthTokensRequest) error {
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
+	handlers.ValidatePageSize(req.GetPageSize(), badFields)
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
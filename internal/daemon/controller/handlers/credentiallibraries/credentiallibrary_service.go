@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/perms"
@@ -32,6 +33,7 @@ const (
 	vaultPathField             = "attributes.path"
 	httpMethodField            = "attributes.http_method"
 	httpRequestBodyField       = "attributes.http_request_body"
+	secretVersionField         = "attributes.secret_version"
 	credentialMappingPathField = "credential_mapping_overrides"
 	domain                     = "credential"
 )
@@ -103,6 +105,7 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	csl, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
 	if err != nil {
@@ -564,6 +567,9 @@ func toProto(in credential.Library, opt ...handlers.Option) (*pb.CredentialLibra
 			if vaultIn.GetHttpRequestBody() != nil {
 				attrs.HttpRequestBody = wrapperspb.String(string(vaultIn.GetHttpRequestBody()))
 			}
+			if vaultIn.GetSecretVersion() > 0 {
+				attrs.SecretVersion = wrapperspb.UInt32(vaultIn.GetSecretVersion())
+			}
 			out.Attrs = &pb.CredentialLibrary_VaultCredentialLibraryAttributes{
 				VaultCredentialLibraryAttributes: attrs,
 			}
@@ -589,6 +595,9 @@ func toStorageVaultLibrary(storeId string, in *pb.CredentialLibrary) (out *vault
 	if attrs.GetHttpRequestBody() != nil {
 		opts = append(opts, vault.WithRequestBody([]byte(attrs.GetHttpRequestBody().GetValue())))
 	}
+	if attrs.GetSecretVersion() != nil {
+		opts = append(opts, vault.WithSecretVersion(attrs.GetSecretVersion().GetValue()))
+	}
 
 	credentialType := credential.Type(in.GetCredentialType())
 	switch credentialType {
@@ -661,6 +670,14 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 			if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) != "POST" {
 				badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 			}
+			if v := attrs.GetSecretVersion(); v != nil {
+				switch {
+				case v.GetValue() == 0:
+					badFields[secretVersionField] = "If set, value must be greater than zero."
+				case strings.ToUpper(attrs.GetHttpMethod().GetValue()) == "POST":
+					badFields[secretVersionField] = fmt.Sprintf("Field can only be set if %q is set to the value 'GET'.", httpMethodField)
+				}
+			}
 			validateMapping(badFields, credential.Type(req.GetItem().GetCredentialType()), req.GetItem().CredentialMappingOverrides.AsMap())
 		default:
 			badFields[globals.CredentialStoreIdField] = "This field must be a valid credential store id."
@@ -691,6 +708,14 @@ func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest, currentCrede
 				if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) == "GET" {
 					badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 				}
+				if v := attrs.GetSecretVersion(); v != nil {
+					switch {
+					case v.GetValue() == 0:
+						badFields[secretVersionField] = "If set, value must be greater than zero."
+					case strings.ToUpper(attrs.GetHttpMethod().GetValue()) == "POST":
+						badFields[secretVersionField] = fmt.Sprintf("Field can only be set if %q is set to the value 'GET'.", httpMethodField)
+					}
+				}
 				validateMapping(badFields, currentCredentialType, req.GetItem().CredentialMappingOverrides.AsMap())
 			}
 		}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/perms"
@@ -32,15 +34,26 @@ import (
 const (
 	usernameField             = "attributes.username"
 	passwordField             = "attributes.password"
+	certificateField          = "attributes.certificate"
 	privateKeyField           = "attributes.private_key"
 	privateKeyPassphraseField = "attributes.private_key_passphrase"
 	objectField               = "attributes.object"
 	domain                    = "credential"
 )
 
+// credentialPrefixes are the prefixes of the ids of static credentials.
+var credentialPrefixes = []string{
+	credential.UsernamePasswordCredentialPrefix,
+	credential.PreviousUsernamePasswordCredentialPrefix,
+	credential.SshPrivateKeyCredentialPrefix,
+	credential.SshCertificateCredentialPrefix,
+	credential.JsonCredentialPrefix,
+}
+
 var (
 	upMaskManager   handlers.MaskManager
 	spkMaskManager  handlers.MaskManager
+	sshcMaskManager handlers.MaskManager
 	jsonMaskManager handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
@@ -50,8 +63,15 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.SetRotation,
+		action.ReadRotation,
+		action.RemoveRotation,
 	}
 
+	// rotationConfigFields are the fields of a rotation config that are
+	// replaced when it is set.
+	rotationConfigFields = []string{"Rotator", "Attributes", "RotationIntervalSeconds", "GracePeriodSeconds"}
+
 	// CollectionActions contains the set of actions that can be performed on
 	// this collection
 	CollectionActions = action.ActionSet{
@@ -70,6 +90,10 @@ func init() {
 		handlers.MaskSource{&pb.Credential{}, &pb.SshPrivateKeyAttributes{}}); err != nil {
 		panic(err)
 	}
+	if sshcMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.SshCertificateCredential{}},
+		handlers.MaskSource{&pb.Credential{}, &pb.SshCertificateAttributes{}}); err != nil {
+		panic(err)
+	}
 	if jsonMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.JsonCredential{}},
 		handlers.MaskSource{&pb.Credential{}, &pb.JsonAttributes{}}); err != nil {
 		panic(err)
@@ -107,6 +131,7 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	creds, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
 	if err != nil {
@@ -298,6 +323,78 @@ func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredential
 	return nil, nil
 }
 
+// SetCredentialRotation implements the interface pbs.CredentialServiceServer.
+func (s Service) SetCredentialRotation(ctx context.Context, req *pbs.SetCredentialRotationRequest) (*pbs.SetCredentialRotationResponse, error) {
+	if err := validateSetRotationRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetRotation)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.setRotationInRepo(ctx, authResults.Scope.GetId(), req)
+	if err != nil {
+		return nil, err
+	}
+	item, err := rotationConfigToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.SetCredentialRotationResponse{Item: item}, nil
+}
+
+// ReadCredentialRotation implements the interface pbs.CredentialServiceServer.
+func (s Service) ReadCredentialRotation(ctx context.Context, req *pbs.ReadCredentialRotationRequest) (*pbs.ReadCredentialRotationResponse, error) {
+	const op = "credentials.(Service).ReadCredentialRotation"
+	if err := validateReadRotationRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadRotation)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c, err := repo.LookupRotationConfig(ctx, req.GetId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if c == nil {
+		return nil, handlers.NotFoundErrorf("Credential %q has no rotation config.", req.GetId())
+	}
+	item, err := rotationConfigToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ReadCredentialRotationResponse{Item: item}, nil
+}
+
+// RemoveCredentialRotation implements the interface pbs.CredentialServiceServer.
+func (s Service) RemoveCredentialRotation(ctx context.Context, req *pbs.RemoveCredentialRotationRequest) (*pbs.RemoveCredentialRotationResponse, error) {
+	const op = "credentials.(Service).RemoveCredentialRotation"
+	if err := validateRemoveRotationRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveRotation)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rows, err := repo.DeleteRotationConfig(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove rotation config"))
+	}
+	if rows == 0 {
+		return nil, handlers.NotFoundErrorf("Credential %q has no rotation config.", req.GetId())
+	}
+	return &pbs.RemoveCredentialRotationResponse{}, nil
+}
+
 func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Static, error) {
 	const op = "credentials.(Service).listFromRepo"
 	repo, err := s.repoFn()
@@ -368,6 +465,23 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Cred
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
 		}
 		return out, nil
+	case credential.SshCertificateSubtype.String():
+		cred, err := toSshCertificateStorageCredential(ctx, item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, err := repo.CreateSshCertificateCredential(ctx, scopeId, cred)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential"))
+		}
+		if out == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
+		}
+		return out, nil
 	case credential.JsonSubtype.String():
 		cred, err := toJsonStorageCredential(ctx, item.GetCredentialStoreId(), item)
 		if err != nil {
@@ -460,6 +574,34 @@ func (s Service) updateInRepo(
 		}
 		return out, nil
 
+	case credential.SshCertificateSubtype:
+		dbMasks = append(dbMasks, sshcMaskManager.Translate(masks)...)
+		if len(dbMasks) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+
+		cred, err := toSshCertificateStorageCredential(ctx, storeId, in)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert to ssh certificate storage credential"))
+		}
+		if cred.PassphraseUnneeded {
+			// See the comment for the ssh private key case above.
+			dbMasks = append(dbMasks, static.PrivateKeyPassphraseField)
+		}
+		cred.PublicId = id
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, rowsUpdated, err := repo.UpdateSshCertificateCredential(ctx, scopeId, cred, item.GetVersion(), dbMasks)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential"))
+		}
+		if rowsUpdated == 0 {
+			return nil, handlers.NotFoundErrorf("Credential %q doesn't exist or incorrect version provided.", id)
+		}
+		return out, nil
+
 	case credential.JsonSubtype:
 		dbMasks = append(dbMasks, jsonMaskManager.Translate(masks, "attributes", "object")...)
 		if len(dbMasks) == 0 {
@@ -507,6 +649,40 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
+func (s Service) setRotationInRepo(ctx context.Context, scopeId string, req *pbs.SetCredentialRotationRequest) (*static.RotationConfig, error) {
+	const op = "credentials.(Service).setRotationInRepo"
+	var opts []static.Option
+	if req.GetAttributes() != nil {
+		opts = append(opts, static.WithRotationAttributes(req.GetAttributes()))
+	}
+	if req.GetGracePeriodSeconds() > 0 {
+		opts = append(opts, static.WithGracePeriod(time.Duration(req.GetGracePeriodSeconds())*time.Second))
+	}
+	in, err := static.NewRotationConfig(ctx, req.GetId(), req.GetRotator(), time.Duration(req.GetRotationIntervalSeconds())*time.Second, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build rotation config: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if req.GetVersion() == 0 {
+		out, err := repo.CreateRotationConfig(ctx, scopeId, in)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create rotation config"))
+		}
+		return out, nil
+	}
+	out, rowsUpdated, err := repo.UpdateRotationConfig(ctx, scopeId, in, req.GetVersion(), rotationConfigFields)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update rotation config"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Rotation config of credential %q doesn't exist or incorrect version provided.", req.GetId())
+	}
+	return out, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	const op = "credentials.(Service).authResult"
 	res := auth.VerifyResults{}
@@ -576,6 +752,8 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 			out.Type = credential.UsernamePasswordSubtype.String()
 		case *static.SshPrivateKeyCredential:
 			out.Type = credential.SshPrivateKeySubtype.String()
+		case *static.SshCertificateCredential:
+			out.Type = credential.SshCertificateSubtype.String()
 		case *static.JsonCredential:
 			out.Type = credential.JsonSubtype.String()
 		}
@@ -622,6 +800,17 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 				},
 			}
 		}
+	case *static.SshCertificateCredential:
+		if outputFields.Has(globals.AttributesField) {
+			out.Attrs = &pb.Credential_SshCertificateAttributes{
+				SshCertificateAttributes: &pb.SshCertificateAttributes{
+					Username:                 wrapperspb.String(cred.GetUsername()),
+					Certificate:              wrapperspb.String(string(cred.GetCertificate())),
+					PrivateKeyHmac:           base64.RawURLEncoding.EncodeToString(cred.GetPrivateKeyHmac()),
+					PrivateKeyPassphraseHmac: base64.RawURLEncoding.EncodeToString(cred.GetPrivateKeyPassphraseHmac()),
+				},
+			}
+		}
 	case *static.JsonCredential:
 		if outputFields.Has(globals.AttributesField) {
 			out.Attrs = &pb.Credential_JsonAttributes{
@@ -634,6 +823,26 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 	return &out, nil
 }
 
+func rotationConfigToProto(in *static.RotationConfig) (*pb.RotationConfig, error) {
+	attrs, err := in.RotationAttributes()
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to read rotation attributes: %v.", err)
+	}
+	return &pb.RotationConfig{
+		CredentialId:            in.GetCredentialId(),
+		Rotator:                 in.GetRotator(),
+		Attributes:              attrs,
+		RotationIntervalSeconds: in.GetRotationIntervalSeconds(),
+		GracePeriodSeconds:      in.GetGracePeriodSeconds(),
+		SecretVersion:           in.GetSecretVersion(),
+		LastRotationTime:        in.GetLastRotationTime().GetTimestamp(),
+		NextRotationTime:        in.GetNextRotationTime().GetTimestamp(),
+		CreatedTime:             in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:             in.GetUpdateTime().GetTimestamp(),
+		Version:                 in.GetVersion(),
+	}, nil
+}
+
 func toUsernamePasswordStorageCredential(ctx context.Context, storeId string, in *pb.Credential) (out *static.UsernamePasswordCredential, err error) {
 	const op = "credentials.toUsernamePasswordStorageCredential"
 	var opts []static.Option
@@ -684,6 +893,38 @@ func toSshPrivateKeyStorageCredential(ctx context.Context, storeId string, in *p
 	return cs, err
 }
 
+func toSshCertificateStorageCredential(ctx context.Context, storeId string, in *pb.Credential) (out *static.SshCertificateCredential, err error) {
+	const op = "credentials.toSshCertificateStorageCredential"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := in.GetSshCertificateAttributes()
+	if attrs.GetPrivateKeyPassphrase() != nil {
+		opts = append(opts, static.WithPrivateKeyPassphrase([]byte(attrs.GetPrivateKeyPassphrase().GetValue())))
+	}
+	var certificate []byte
+	if attrs.GetCertificate() != nil {
+		certificate = []byte(attrs.GetCertificate().GetValue())
+	}
+	cs, err := static.NewSshCertificateCredential(
+		ctx,
+		storeId,
+		attrs.GetUsername().GetValue(),
+		certificate,
+		credential.PrivateKey(attrs.GetPrivateKey().GetValue()),
+		opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to build credential"))
+	}
+
+	return cs, err
+}
+
 func toJsonStorageCredential(ctx context.Context, storeId string, in *pb.Credential) (out *static.JsonCredential, err error) {
 	const op = "credentials.toJsonStorageCredential"
 	var opts []static.Option
@@ -728,6 +969,7 @@ func validateGetRequest(req *pbs.GetCredentialRequest) error {
 		credential.UsernamePasswordCredentialPrefix,
 		credential.PreviousUsernamePasswordCredentialPrefix,
 		credential.SshPrivateKeyCredentialPrefix,
+		credential.SshCertificateCredentialPrefix,
 		credential.JsonCredentialPrefix,
 	)
 }
@@ -777,6 +1019,22 @@ func validateCreateRequest(req *pbs.CreateCredentialRequest) error {
 				}
 			}
 
+		case credential.SshCertificateSubtype.String():
+			attrs := req.Item.GetSshCertificateAttributes()
+			if attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "Field required for creating an SSH certificate credential."
+			}
+			if attrs.GetCertificate().GetValue() == "" {
+				badFields[certificateField] = "Field required for creating an SSH certificate credential."
+			} else if err := validateSshCertificate(attrs.GetCertificate().GetValue()); err != nil {
+				badFields[certificateField] = fmt.Sprintf("Unable to parse given certificate value: %v.", err)
+			}
+			if attrs.GetPrivateKey().GetValue() == "" {
+				badFields[privateKeyField] = "Field required for creating an SSH certificate credential."
+			} else {
+				validateSshPrivateKey(attrs.GetPrivateKey().GetValue(), attrs.GetPrivateKeyPassphrase().GetValue(), badFields)
+			}
+
 		case credential.JsonSubtype.String():
 			object := req.GetItem().GetJsonAttributes().GetObject()
 			if object == nil && len(object.AsMap()) <= 0 {
@@ -842,6 +1100,26 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 				}
 			}
 
+		case credential.SshCertificateSubtype:
+			attrs := req.GetItem().GetSshCertificateAttributes()
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), usernameField) && attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "This is a required field and cannot be set to empty."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), certificateField) {
+				if attrs.GetCertificate().GetValue() == "" {
+					badFields[certificateField] = "This is a required field and cannot be set to empty."
+				} else if err := validateSshCertificate(attrs.GetCertificate().GetValue()); err != nil {
+					badFields[certificateField] = fmt.Sprintf("Unable to parse given certificate value: %v.", err)
+				}
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), privateKeyField) {
+				if attrs.GetPrivateKey().GetValue() == "" {
+					badFields[privateKeyField] = "This is a required field and cannot be set to empty."
+				} else {
+					validateSshPrivateKey(attrs.GetPrivateKey().GetValue(), attrs.GetPrivateKeyPassphrase().GetValue(), badFields)
+				}
+			}
+
 		case credential.JsonSubtype:
 			object := req.GetItem().GetJsonAttributes().GetObject()
 			if object != nil {
@@ -866,6 +1144,7 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 		credential.UsernamePasswordCredentialPrefix,
 		credential.PreviousUsernamePasswordCredentialPrefix,
 		credential.SshPrivateKeyCredentialPrefix,
+		credential.SshCertificateCredentialPrefix,
 		credential.JsonCredentialPrefix,
 	)
 }
@@ -877,10 +1156,36 @@ func validateDeleteRequest(req *pbs.DeleteCredentialRequest) error {
 		credential.UsernamePasswordCredentialPrefix,
 		credential.PreviousUsernamePasswordCredentialPrefix,
 		credential.SshPrivateKeyCredentialPrefix,
+		credential.SshCertificateCredentialPrefix,
 		credential.JsonCredentialPrefix,
 	)
 }
 
+func validateSetRotationRequest(req *pbs.SetCredentialRotationRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), credentialPrefixes...) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetRotator() == "" {
+		badFields["rotator"] = "This is a required field."
+	}
+	if req.GetRotationIntervalSeconds() == 0 {
+		badFields["rotation_interval_seconds"] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateReadRotationRequest(req *pbs.ReadCredentialRotationRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, credentialPrefixes...)
+}
+
+func validateRemoveRotationRequest(req *pbs.RemoveCredentialRotationRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, credentialPrefixes...)
+}
+
 func validateListRequest(req *pbs.ListCredentialsRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetCredentialStoreId()), static.CredentialStorePrefix, static.PreviousCredentialStorePrefix) {
@@ -894,3 +1199,39 @@ func validateListRequest(req *pbs.ListCredentialsRequest) error {
 	}
 	return nil
 }
+
+// validateSshCertificate returns an error if certificate is not an SSH
+// certificate in authorized keys format.
+func validateSshCertificate(certificate string) error {
+	pk, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return err
+	}
+	if _, ok := pk.(*ssh.Certificate); !ok {
+		return fmt.Errorf("public key is not an ssh certificate")
+	}
+	return nil
+}
+
+// validateSshPrivateKey adds an entry to badFields if privateKey cannot be
+// parsed with the provided passphrase.
+func validateSshPrivateKey(privateKey, passphrase string, badFields map[string]string) {
+	switch passphrase {
+	case "":
+		if _, err := ssh.ParsePrivateKey([]byte(privateKey)); err != nil {
+			badFields[privateKeyField] = fmt.Sprintf("Unable to parse given private key value: %v.", err)
+		}
+	default:
+		if _, err := ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase)); err != nil {
+			if errors.Is(err, x509.IncorrectPasswordError) {
+				badFields[privateKeyPassphraseField] = "Incorrect private key passphrase."
+			} else {
+				if _, err := ssh.ParsePrivateKey([]byte(privateKey)); err == nil {
+					badFields[privateKeyPassphraseField] = "Passphrase supplied for unencrypted key."
+				} else {
+					badFields[privateKeyField] = fmt.Sprintf("Unable to parse given private key value: %v.", err)
+				}
+			}
+		}
+	}
+}
@@ -36,7 +36,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "set-rotation", "read-rotation", "remove-rotation"}
 
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
@@ -712,6 +712,50 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid-ssh-certificate",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              credential.SshCertificateSubtype.String(),
+				Attrs: &pb.Credential_SshCertificateAttributes{
+					SshCertificateAttributes: &pb.SshCertificateAttributes{
+						Username:    wrapperspb.String("username"),
+						Certificate: wrapperspb.String(static.TestSshCertificate),
+						PrivateKey:  wrapperspb.String(static.TestSshPrivateKeyPem),
+					},
+				},
+			}},
+			idPrefix: credential.SshCertificateCredentialPrefix + "_",
+			res: &pbs.CreateCredentialResponse{
+				Uri: fmt.Sprintf("credentials/%s_", credential.SshCertificateCredentialPrefix),
+				Item: &pb.Credential{
+					Id:                store.GetPublicId(),
+					CredentialStoreId: store.GetPublicId(),
+					CreatedTime:       store.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       store.GetUpdateTime().GetTimestamp(),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              credential.SshCertificateSubtype.String(),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "invalid-ssh-certificate",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              credential.SshCertificateSubtype.String(),
+				Attrs: &pb.Credential_SshCertificateAttributes{
+					SshCertificateAttributes: &pb.SshCertificateAttributes{
+						Username:    wrapperspb.String("username"),
+						Certificate: wrapperspb.String("not-a-certificate"),
+						PrivateKey:  wrapperspb.String(static.TestSshPrivateKeyPem),
+					},
+				},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "valid-json",
 			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
@@ -803,6 +847,19 @@ func TestCreate(t *testing.T) {
 						assert.Empty(got.GetItem().GetSshPrivateKeyAttributes().GetPrivateKeyPassphrase())
 					}
 
+				case credential.SshCertificateSubtype.String():
+					pk := tc.req.GetItem().GetSshCertificateAttributes().GetPrivateKey().GetValue()
+					hm, err := crypto.HmacSha256(context.Background(), []byte(pk), databaseWrapper, []byte(store.GetPublicId()), nil)
+					require.NoError(err)
+
+					// Validate attributes equal
+					assert.Equal(tc.req.GetItem().GetSshCertificateAttributes().GetUsername().GetValue(),
+						got.GetItem().GetSshCertificateAttributes().GetUsername().GetValue())
+					assert.Equal(tc.req.GetItem().GetSshCertificateAttributes().GetCertificate().GetValue(),
+						got.GetItem().GetSshCertificateAttributes().GetCertificate().GetValue())
+					assert.Equal(base64.RawURLEncoding.EncodeToString([]byte(hm)), got.GetItem().GetSshCertificateAttributes().GetPrivateKeyHmac())
+					assert.Empty(got.GetItem().GetSshCertificateAttributes().GetPrivateKey())
+
 				case credential.JsonSubtype.String():
 					hm, err := crypto.HmacSha256(context.Background(), objBytes, databaseWrapper, []byte(store.GetPublicId()), nil)
 					require.NoError(err)
@@ -1325,3 +1382,108 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestRotation(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(context.Background(), rw, rw, kms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	s, err := NewService(staticRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cred := static.TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", store.GetPublicId(), prj.GetPublicId())
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId())
+
+	_, err = s.ReadCredentialRotation(ctx, &pbs.ReadCredentialRotationRequest{Id: cred.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.NotFoundError()))
+
+	invalid := []struct {
+		name string
+		req  *pbs.SetCredentialRotationRequest
+	}{
+		{
+			name: "bad-prefix",
+			req:  &pbs.SetCredentialRotationRequest{Id: fmt.Sprintf("%s_1234567890", static.CredentialStorePrefix), Rotator: static.PasswordRotator, RotationIntervalSeconds: 60},
+		},
+		{
+			name: "missing-rotator",
+			req:  &pbs.SetCredentialRotationRequest{Id: cred.GetPublicId(), RotationIntervalSeconds: 60},
+		},
+		{
+			name: "missing-interval",
+			req:  &pbs.SetCredentialRotationRequest{Id: cred.GetPublicId(), Rotator: static.PasswordRotator},
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.SetCredentialRotation(ctx, tc.req)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+		})
+	}
+
+	attrs, err := structpb.NewStruct(map[string]interface{}{"address": "127.0.0.1:22"})
+	require.NoError(t, err)
+	created, err := s.SetCredentialRotation(ctx, &pbs.SetCredentialRotationRequest{
+		Id:                      cred.GetPublicId(),
+		Rotator:                 static.PasswordRotator,
+		Attributes:              attrs,
+		RotationIntervalSeconds: 3600,
+		GracePeriodSeconds:      60,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, cred.GetPublicId(), created.GetItem().GetCredentialId())
+	assert.Equal(t, static.PasswordRotator, created.GetItem().GetRotator())
+	assert.Empty(t, cmp.Diff(attrs, created.GetItem().GetAttributes(), protocmp.Transform()))
+	assert.Equal(t, uint32(3600), created.GetItem().GetRotationIntervalSeconds())
+	assert.Equal(t, uint32(60), created.GetItem().GetGracePeriodSeconds())
+	assert.Equal(t, uint64(1), created.GetItem().GetSecretVersion())
+
+	// A second rotation config cannot be created
+	_, err = s.SetCredentialRotation(ctx, &pbs.SetCredentialRotationRequest{Id: cred.GetPublicId(), Rotator: static.PasswordRotator, RotationIntervalSeconds: 60})
+	assert.Error(t, err)
+
+	read, err := s.ReadCredentialRotation(ctx, &pbs.ReadCredentialRotationRequest{Id: cred.GetPublicId()})
+	require.NoError(t, err)
+	assert.Equal(t, created.GetItem().GetVersion(), read.GetItem().GetVersion())
+	assert.NotNil(t, read.GetItem().GetNextRotationTime())
+
+	// All the fields are replaced when the rotation config is updated
+	updated, err := s.SetCredentialRotation(ctx, &pbs.SetCredentialRotationRequest{
+		Id:                      cred.GetPublicId(),
+		Version:                 read.GetItem().GetVersion(),
+		Rotator:                 static.PasswordRotator,
+		RotationIntervalSeconds: 60,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(60), updated.GetItem().GetRotationIntervalSeconds())
+	assert.Equal(t, uint32(0), updated.GetItem().GetGracePeriodSeconds())
+	assert.Empty(t, updated.GetItem().GetAttributes().GetFields())
+	assert.Greater(t, updated.GetItem().GetVersion(), read.GetItem().GetVersion())
+
+	// The update fails with an outdated version
+	_, err = s.SetCredentialRotation(ctx, &pbs.SetCredentialRotationRequest{
+		Id:                      cred.GetPublicId(),
+		Version:                 read.GetItem().GetVersion(),
+		Rotator:                 static.PasswordRotator,
+		RotationIntervalSeconds: 60,
+	})
+	assert.Error(t, err)
+
+	_, err = s.RemoveCredentialRotation(ctx, &pbs.RemoveCredentialRotationRequest{Id: cred.GetPublicId()})
+	require.NoError(t, err)
+	_, err = s.ReadCredentialRotation(ctx, &pbs.ReadCredentialRotationRequest{Id: cred.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.NotFoundError()))
+	_, err = s.RemoveCredentialRotation(ctx, &pbs.RemoveCredentialRotationRequest{Id: cred.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.NotFoundError()))
+}
@@ -3,14 +3,18 @@ package host_sets
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/health"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	plugstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
@@ -24,6 +28,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
@@ -79,13 +84,14 @@ type Service struct {
 
 	staticRepoFn common.StaticRepoFactory
 	pluginRepoFn common.PluginHostRepoFactory
+	healthRepoFn common.HostHealthRepoFactory
 }
 
 var _ pbs.HostSetServiceServer = (*Service)(nil)
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
+func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, healthRepoFn common.HostHealthRepoFactory) (Service, error) {
 	const op = "host_sets.NewService"
 	if staticRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static repository")
@@ -93,7 +99,10 @@ func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.Plugi
 	if pluginRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing plugin repository")
 	}
-	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn}, nil
+	if healthRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing host health repository")
+	}
+	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn, healthRepoFn: healthRepoFn}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -108,6 +117,7 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = db.WithReplicaReads(ctx)
 	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), opt...)
 	if err != nil {
 		return nil, err
@@ -122,6 +132,11 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	}
 	finalItems := make([]*pb.HostSet, 0, len(hl))
 
+	healthChecks, err := s.listHealthChecks(ctx, hl)
+	if err != nil {
+		return nil, err
+	}
+
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.HostSet,
@@ -148,10 +163,14 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 			outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 		}
 
+		hc := healthChecks[item.GetPublicId()]
 		item, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
 			return nil, err
 		}
+		if outputFields.Has(globals.HealthCheckField) {
+			item.HealthCheck = toHealthCheckProto(hc)
+		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
@@ -204,6 +223,11 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 	if err != nil {
 		return nil, err
 	}
+	if outputFields.Has(globals.HealthCheckField) {
+		if item.HealthCheck, err = s.lookupHealthCheck(ctx, hs.GetPublicId()); err != nil {
+			return nil, err
+		}
+	}
 
 	return &pbs.GetHostSetResponse{Item: item}, nil
 }
@@ -223,6 +247,12 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 	if err != nil {
 		return nil, err
 	}
+	var hc *health.HealthCheck
+	if req.GetItem().GetHealthCheck() != nil {
+		if hc, err = s.setHealthCheckInRepo(ctx, hs.GetPublicId(), req.GetItem().GetHealthCheck()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
@@ -246,6 +276,9 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 	if err != nil {
 		return nil, err
 	}
+	if outputFields.Has(globals.HealthCheckField) {
+		item.HealthCheck = toHealthCheckProto(hc)
+	}
 
 	return &pbs.CreateHostSetResponse{
 		Item: item,
@@ -291,6 +324,11 @@ func (s Service) UpdateHostSet(ctx context.Context, req *pbs.UpdateHostSetReques
 	if err != nil {
 		return nil, err
 	}
+	if outputFields.Has(globals.HealthCheckField) {
+		if item.HealthCheck, err = s.lookupHealthCheck(ctx, hs.GetPublicId()); err != nil {
+			return nil, err
+		}
+	}
 
 	return &pbs.UpdateHostSetResponse{Item: item}, nil
 }
@@ -585,15 +623,105 @@ func (s Service) updatePluginInRepo(ctx context.Context, projectId string, req *
 
 func (s Service) updateInRepo(ctx context.Context, projectId, catalogId string, req *pbs.UpdateHostSetRequest) (hs host.Set, hosts []host.Host, plg *plugins.PluginInfo, err error) {
 	const op = "host_sets.(Service).updateInRepo"
-	switch subtypes.SubtypeFromId(domain, req.GetId()) {
-	case static.Subtype:
-		hs, hosts, err = s.updateStaticInRepo(ctx, projectId, catalogId, req)
-	case plugin.Subtype:
-		hs, hosts, plg, err = s.updatePluginInRepo(ctx, projectId, req)
+	// The health check is not stored with the host set, so the paths
+	// referring to it are handled separately from the other fields.
+	var paths []string
+	var healthCheckUpdated bool
+	for _, p := range req.GetUpdateMask().GetPaths() {
+		if p == globals.HealthCheckField || strings.HasPrefix(p, globals.HealthCheckField+".") {
+			healthCheckUpdated = true
+			continue
+		}
+		paths = append(paths, p)
+	}
+	if healthCheckUpdated {
+		req = proto.Clone(req).(*pbs.UpdateHostSetRequest)
+		req.UpdateMask.Paths = paths
 	}
+
+	switch {
+	case healthCheckUpdated && len(paths) == 0:
+		hs, hosts, plg, err = s.getFromRepo(ctx, req.GetId())
+		if err == nil && hs.GetVersion() != req.GetItem().GetVersion() {
+			err = handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", req.GetId())
+		}
+	default:
+		switch subtypes.SubtypeFromId(domain, req.GetId()) {
+		case static.Subtype:
+			hs, hosts, err = s.updateStaticInRepo(ctx, projectId, catalogId, req)
+		case plugin.Subtype:
+			hs, hosts, plg, err = s.updatePluginInRepo(ctx, projectId, req)
+		}
+	}
+	if err != nil || !healthCheckUpdated {
+		return
+	}
+
+	if req.GetItem().GetHealthCheck() == nil {
+		repo, repoErr := s.healthRepoFn()
+		if repoErr != nil {
+			return nil, nil, nil, repoErr
+		}
+		if _, delErr := repo.DeleteHealthCheck(ctx, req.GetId()); delErr != nil {
+			return nil, nil, nil, errors.Wrap(ctx, delErr, op, errors.WithMsg("unable to delete health check"))
+		}
+		return
+	}
+	_, err = s.setHealthCheckInRepo(ctx, req.GetId(), req.GetItem().GetHealthCheck())
 	return
 }
 
+func (s Service) setHealthCheckInRepo(ctx context.Context, setId string, item *pb.HealthCheck) (*health.HealthCheck, error) {
+	const op = "host_sets.(Service).setHealthCheckInRepo"
+	hc, err := toStorageHealthCheck(ctx, setId, item)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.healthRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.SetHealthCheck(ctx, hc)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to set health check"))
+	}
+	return out, nil
+}
+
+func (s Service) lookupHealthCheck(ctx context.Context, setId string) (*pb.HealthCheck, error) {
+	const op = "host_sets.(Service).lookupHealthCheck"
+	repo, err := s.healthRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	hc, err := repo.LookupHealthCheck(ctx, setId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return toHealthCheckProto(hc), nil
+}
+
+func (s Service) listHealthChecks(ctx context.Context, sets []host.Set) (map[string]*health.HealthCheck, error) {
+	const op = "host_sets.(Service).listHealthChecks"
+	ids := make([]string, 0, len(sets))
+	for _, hs := range sets {
+		ids = append(ids, hs.GetPublicId())
+	}
+	repo, err := s.healthRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	hcs, err := repo.ListHealthChecks(ctx, ids...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret := make(map[string]*health.HealthCheck, len(hcs))
+	for _, hc := range hcs {
+		ret[hc.HostSetId] = hc
+	}
+	return ret, nil
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, projectId, id string) (bool, error) {
 	const op = "host_sets.(Service).deleteFromRepo"
 	rows := 0
@@ -894,6 +1022,33 @@ func toProto(ctx context.Context, in host.Set, hosts []host.Host, opt ...handler
 	return &out, nil
 }
 
+func toHealthCheckProto(in *health.HealthCheck) *pb.HealthCheck {
+	if in == nil {
+		return nil
+	}
+	return &pb.HealthCheck{
+		Protocol:           in.Protocol,
+		Port:               in.Port,
+		Path:               in.Path,
+		IntervalSeconds:    in.IntervalSeconds,
+		UnhealthyThreshold: in.UnhealthyThreshold,
+		WorkerFilter:       in.WorkerFilter,
+	}
+}
+
+func toStorageHealthCheck(ctx context.Context, setId string, item *pb.HealthCheck) (*health.HealthCheck, error) {
+	const op = "host_set_service.toStorageHealthCheck"
+	hc, err := health.NewHealthCheck(ctx, setId, item.GetProtocol(), item.GetPort(),
+		health.WithPath(item.GetPath()),
+		health.WithIntervalSeconds(item.GetIntervalSeconds()),
+		health.WithUnhealthyThreshold(item.GetUnhealthyThreshold()),
+		health.WithWorkerFilter(item.GetWorkerFilter()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("Unable to build health check"))
+	}
+	return hc, nil
+}
+
 func toStorageStaticSet(ctx context.Context, catalogId string, item *pb.HostSet) (*static.HostSet, error) {
 	const op = "host_set_service.toStorageStaticSet"
 	var opts []static.Option
@@ -976,6 +1131,7 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateHostSetRequest) e
 				}
 			}
 		}
+		validateHealthCheck(req.GetItem().GetHealthCheck(), badFields)
 		return badFields
 	})
 }
@@ -1001,10 +1157,45 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateHostSetRequest) e
 				}
 			}
 		}
+		validateHealthCheck(req.GetItem().GetHealthCheck(), badFields)
 		return badFields
 	}, static.HostSetPrefix, plugin.HostSetPrefix, plugin.PreviousHostSetPrefix)
 }
 
+// validateHealthCheck adds the invalid fields of the health check, if any,
+// to badFields.
+func validateHealthCheck(hc *pb.HealthCheck, badFields map[string]string) {
+	if hc == nil {
+		return
+	}
+	field := func(name string) string {
+		return fmt.Sprintf("%s.%s", globals.HealthCheckField, name)
+	}
+	switch hc.GetProtocol() {
+	case health.ProtocolTcp:
+		if hc.GetPath() != "" {
+			badFields[field("path")] = "This field is only valid for the http protocol."
+		}
+	case health.ProtocolHttp:
+	default:
+		badFields[field("protocol")] = fmt.Sprintf("Must be %q or %q.", health.ProtocolTcp, health.ProtocolHttp)
+	}
+	if hc.GetPort() == 0 || hc.GetPort() > math.MaxUint16 {
+		badFields[field("port")] = "Must be between 1 and 65535."
+	}
+	if hc.GetIntervalSeconds() > math.MaxInt32 {
+		badFields[field("interval_seconds")] = "Value is too large."
+	}
+	if hc.GetUnhealthyThreshold() > math.MaxInt32 {
+		badFields[field("unhealthy_threshold")] = "Value is too large."
+	}
+	if hc.GetWorkerFilter() != "" {
+		if _, err := bexpr.CreateEvaluator(hc.GetWorkerFilter()); err != nil {
+			badFields[field(globals.WorkerFilterField)] = "Unable to successfully parse filter expression."
+		}
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteHostSetRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, static.HostSetPrefix, plugin.HostSetPrefix, plugin.PreviousHostSetPrefix)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/health"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -60,6 +61,9 @@ func TestGet_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -122,7 +126,7 @@ func TestGet_Static(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), req)
@@ -154,6 +158,9 @@ func TestGet_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -238,7 +245,7 @@ func TestGet_Plugin(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), req)
@@ -271,6 +278,9 @@ func TestList_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -329,7 +339,7 @@ func TestList_Static(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test with non-anon user
@@ -370,6 +380,9 @@ func TestList_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -442,7 +455,7 @@ func TestList_Plugin(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test with non-anon user
@@ -484,6 +497,9 @@ func TestDelete_Static(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -493,7 +509,7 @@ func TestDelete_Static(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -555,6 +571,9 @@ func TestDelete_Plugin(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -570,7 +589,7 @@ func TestDelete_Plugin(t *testing.T) {
 	hc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
 	h := plugin.TestSet(t, conn, kms, sche, hc, plgm)
 
-	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn, healthRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -633,6 +652,9 @@ func TestDelete_twice(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -642,7 +664,7 @@ func TestDelete_twice(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostSetRequest{
 		Id: h.GetPublicId(),
@@ -670,6 +692,9 @@ func TestCreate_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -779,7 +804,7 @@ func TestCreate_Static(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn, plgRepoFn)
+			s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -826,6 +851,9 @@ func TestCreate_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -1044,7 +1072,7 @@ func TestCreate_Plugin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn, plgRepoFn)
+			s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -1093,6 +1121,9 @@ func TestUpdate_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -1128,7 +1159,7 @@ func TestUpdate_Static(t *testing.T) {
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	tested, err := host_sets.NewService(repoFn, plgRepoFn)
+	tested, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -1468,6 +1499,9 @@ func TestUpdate_Plugin(t *testing.T) {
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 	_, proj := iam.TestScopes(t, iamRepo)
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 
 	name := "test"
 	plg := hostplugin.TestPlugin(t, conn, name)
@@ -1484,7 +1518,7 @@ func TestUpdate_Plugin(t *testing.T) {
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	tested, err := host_sets.NewService(repoFn, pluginHostRepo)
+	tested, err := host_sets.NewService(repoFn, pluginHostRepo, healthRepoFn)
 	require.NoError(t, err, "Failed to create a new host catalog service.")
 
 	hc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
@@ -1798,6 +1832,119 @@ func TestUpdate_Plugin(t *testing.T) {
 	}
 }
 
+func TestHealthCheck_Static(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	plgRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
+	require.NoError(t, err, "Error when getting new host set service.")
+
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, check := range []*pb.HealthCheck{
+			{Protocol: "udp", Port: 22},
+			{Protocol: health.ProtocolTcp},
+			{Protocol: health.ProtocolTcp, Port: 70000},
+			{Protocol: health.ProtocolTcp, Port: 22, Path: "/health"},
+			{Protocol: health.ProtocolTcp, Port: 22, WorkerFilter: `"dev" in`},
+		} {
+			_, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+				HostCatalogId: hc.GetPublicId(),
+				HealthCheck:   check,
+			}})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v for %v", err, check)
+		}
+	})
+
+	t.Run("lifecycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		created, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+			HostCatalogId: hc.GetPublicId(),
+			HealthCheck:   &pb.HealthCheck{Protocol: health.ProtocolHttp, Port: 8080},
+		}})
+		require.NoError(err)
+		id := created.GetItem().GetId()
+		assert.Empty(cmp.Diff(
+			&pb.HealthCheck{
+				Protocol:           health.ProtocolHttp,
+				Port:               8080,
+				Path:               health.DefaultPath,
+				IntervalSeconds:    health.DefaultIntervalSeconds,
+				UnhealthyThreshold: health.DefaultUnhealthyThreshold,
+			},
+			created.GetItem().GetHealthCheck(),
+			protocmp.Transform(),
+		))
+
+		got, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: id})
+		require.NoError(err)
+		assert.Empty(cmp.Diff(created.GetItem().GetHealthCheck(), got.GetItem().GetHealthCheck(), protocmp.Transform()))
+
+		list, err := s.ListHostSets(ctx, &pbs.ListHostSetsRequest{HostCatalogId: hc.GetPublicId()})
+		require.NoError(err)
+		require.Len(list.GetItems(), 1)
+		assert.Empty(cmp.Diff(created.GetItem().GetHealthCheck(), list.GetItems()[0].GetHealthCheck(), protocmp.Transform()))
+
+		// Only updating the health check leaves the version unchanged
+		updated, err := s.UpdateHostSet(ctx, &pbs.UpdateHostSetRequest{
+			Id:         id,
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"health_check"}},
+			Item: &pb.HostSet{
+				Version:     created.GetItem().GetVersion(),
+				HealthCheck: &pb.HealthCheck{Protocol: health.ProtocolTcp, Port: 22, UnhealthyThreshold: 5},
+			},
+		})
+		require.NoError(err)
+		assert.Equal(created.GetItem().GetVersion(), updated.GetItem().GetVersion())
+		assert.Equal(health.ProtocolTcp, updated.GetItem().GetHealthCheck().GetProtocol())
+		assert.Empty(updated.GetItem().GetHealthCheck().GetPath())
+		assert.EqualValues(5, updated.GetItem().GetHealthCheck().GetUnhealthyThreshold())
+
+		_, err = s.UpdateHostSet(ctx, &pbs.UpdateHostSetRequest{
+			Id:         id,
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"health_check"}},
+			Item:       &pb.HostSet{Version: created.GetItem().GetVersion() + 1},
+		})
+		require.Error(err)
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+
+		updated, err = s.UpdateHostSet(ctx, &pbs.UpdateHostSetRequest{
+			Id:         id,
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "health_check"}},
+			Item: &pb.HostSet{
+				Version: created.GetItem().GetVersion(),
+				Name:    wrapperspb.String("checked"),
+			},
+		})
+		require.NoError(err)
+		assert.Equal(created.GetItem().GetVersion()+1, updated.GetItem().GetVersion())
+		assert.Equal("checked", updated.GetItem().GetName().GetValue())
+		assert.Nil(updated.GetItem().GetHealthCheck())
+	})
+}
+
 func TestAddHostSetHosts(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
@@ -1812,13 +1959,16 @@ func TestAddHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -1933,13 +2083,16 @@ func TestSetHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -2050,13 +2203,16 @@ func TestRemoveHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	healthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, healthRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
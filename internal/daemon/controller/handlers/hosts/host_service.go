@@ -3,25 +3,28 @@ package hosts
 import (
 	"context"
 	"fmt"
-	"net"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
+	"github.com/hashicorp/boundary/internal/util"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
 	"google.golang.org/grpc/codes"
@@ -89,35 +92,49 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	_, authResults := s.parentAndAuthResult(ctx, req.GetHostCatalogId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
-	}
-	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	tok, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.Host, req.GetHostCatalogId(), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(hl) == 0 {
-		return &pbs.ListHostsResponse{}, nil
+	_, authResults := s.parentAndAuthResult(ctx, req.GetHostCatalogId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
 	}
-
+	ctx = db.WithReplicaReads(ctx)
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Host, 0, len(hl))
+	paginated := req.GetPageSize() > 0 || req.GetListToken() != ""
+	finalItems := make([]*pb.Host, 0, req.GetPageSize())
 
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.Host,
 		Pin:     req.GetHostCatalogId(),
 	}
-	for _, item := range hl {
+	var plg *plugins.PluginInfo
+	listFn := func(ctx context.Context, page *pagination.Page) ([]pagination.Item, error) {
+		hl, hlPlg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), page)
+		if err != nil {
+			return nil, err
+		}
+		if hlPlg != nil {
+			plg = hlPlg
+		}
+		items := make([]pagination.Item, 0, len(hl))
+		for _, item := range hl {
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	appendFn := func(ctx context.Context, i pagination.Item) (bool, error) {
+		item := i.(host.Host)
 		res.Id = item.GetPublicId()
 		idActions := idActionsTypeMap[subtypes.SubtypeFromId(domain, res.Id)]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), idActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -133,22 +150,51 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 		outputOpts = append(outputOpts, handlers.WithHostSetIds(item.GetSetIds()))
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		finalItems = append(finalItems, pbItem)
+		return true, nil
+	}
+	next, complete, err := pagination.FillPage(ctx, tok, int(req.GetPageSize()), listFn, appendFn)
+	if err != nil {
+		return nil, err
+	}
+	if !paginated {
+		if len(finalItems) == 0 {
+			return &pbs.ListHostsResponse{}, nil
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return &pbs.ListHostsResponse{Items: finalItems}, nil
+	}
+
+	var removedIds []string
+	if tok.Refresh() && tok.FirstPage() {
+		removedIds, err = s.listDeletedIdsFromRepo(ctx, req.GetHostCatalogId(), tok.UpdatedAfter)
+		if err != nil {
+			return nil, err
 		}
 	}
-	return &pbs.ListHostsResponse{Items: finalItems}, nil
+	listToken, err := next.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListHostsResponse{
+		Items:        finalItems,
+		ResponseType: pagination.ResponseType(complete),
+		ListToken:    listToken,
+		RemovedIds:   removedIds,
+	}, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -406,7 +452,7 @@ func (s Service) deleteFromRepo(ctx context.Context, projectId, id string) (bool
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Host, *plugins.PluginInfo, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, page *pagination.Page) ([]host.Host, *plugins.PluginInfo, error) {
 	var hosts []host.Host
 	var plg *plugins.PluginInfo
 	switch subtypes.SubtypeFromId(domain, catalogId) {
@@ -415,7 +461,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, err := repo.ListHosts(ctx, catalogId)
+		hl, err := repo.ListHosts(ctx, catalogId, static.WithPage(page))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -427,7 +473,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId)
+		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId, plugin.WithPage(page))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -439,6 +485,24 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 	return hosts, plg, nil
 }
 
+func (s Service) listDeletedIdsFromRepo(ctx context.Context, catalogId string, since time.Time) ([]string, error) {
+	switch subtypes.SubtypeFromId(domain, catalogId) {
+	case static.Subtype:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		return repo.ListDeletedIds(ctx, []string{catalogId}, since)
+	case plugin.Subtype:
+		repo, err := s.pluginRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		return repo.ListDeletedIds(ctx, []string{catalogId}, since)
+	}
+	return nil, nil
+}
+
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (host.Catalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
 	staticRepo, err := s.staticRepoFn()
@@ -644,12 +708,12 @@ func validateCreateRequest(req *pbs.CreateHostRequest) error {
 					len(attrs.GetAddress().GetValue()) > static.MaxHostAddressLength {
 					badFields["attributes.address"] = fmt.Sprintf("Address length must be between %d and %d characters.", static.MinHostAddressLength, static.MaxHostAddressLength)
 				}
-				_, _, err := net.SplitHostPort(attrs.GetAddress().GetValue())
+				_, _, err := util.SplitHostPort(attrs.GetAddress().GetValue())
 				switch {
 				case err == nil:
 					badFields["attributes.address"] = "Address for static hosts does not support a port."
-				case strings.Contains(err.Error(), "missing port in address"):
-					// Bare hostname, which we want
+				case errors.Is(err, util.ErrMissingPort):
+					// Bare hostname or IP address, which we want
 				default:
 					badFields["attributes.address"] = fmt.Sprintf("Error parsing address: %v.", err)
 				}
@@ -711,6 +775,7 @@ func validateListRequest(req *pbs.ListHostsRequest) error {
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
+	handlers.ValidatePageSize(req.GetPageSize(), badFields)
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
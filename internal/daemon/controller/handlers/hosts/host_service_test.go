@@ -673,6 +673,48 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create a valid IPv6 Host",
+			req: &pbs.CreateHostRequest{Item: &pb.Host{
+				HostCatalogId: hc.GetPublicId(),
+				Name:          &wrappers.StringValue{Value: "ipv6"},
+				Type:          "static",
+				Attrs: &pb.Host_StaticHostAttributes{
+					StaticHostAttributes: &pb.StaticHostAttributes{
+						Address: wrapperspb.String("2001:db8::1"),
+					},
+				},
+			}},
+			res: &pbs.CreateHostResponse{
+				Uri: fmt.Sprintf("hosts/%s_", static.HostPrefix),
+				Item: &pb.Host{
+					HostCatalogId: hc.GetPublicId(),
+					Scope:         &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: org.GetPublicId()},
+					Name:          &wrappers.StringValue{Value: "ipv6"},
+					Type:          "static",
+					Attrs: &pb.Host_StaticHostAttributes{
+						StaticHostAttributes: &pb.StaticHostAttributes{
+							Address: wrapperspb.String("2001:db8::1"),
+						},
+					},
+					AuthorizedActions: testAuthorizedActions[static.Subtype],
+				},
+			},
+		},
+		{
+			name: "Create with IPv6 address and port",
+			req: &pbs.CreateHostRequest{Item: &pb.Host{
+				HostCatalogId: hc.GetPublicId(),
+				Type:          "static",
+				Attrs: &pb.Host_StaticHostAttributes{
+					StaticHostAttributes: &pb.StaticHostAttributes{
+						Address: wrapperspb.String("[2001:db8::1]:22"),
+					},
+				},
+			}},
+			err:             handlers.ApiErrorWithCode(codes.InvalidArgument),
+			wantErrContains: "Address for static hosts does not support a port.",
+		},
 		{
 			name: "no-attributes",
 			req: &pbs.CreateHostRequest{Item: &pb.Host{
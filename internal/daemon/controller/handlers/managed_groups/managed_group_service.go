@@ -11,6 +11,7 @@ import (
 	requestauth "github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/intglobals"
@@ -88,6 +89,7 @@ func (s Service) ListManagedGroups(ctx context.Context, req *pbs.ListManagedGrou
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = db.WithReplicaReads(ctx)
 	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
 	if err != nil {
 		return nil, err
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// ValidatePageSize adds an entry to badFields if the page size of a list
+// request is larger than the maximum page size.
+func ValidatePageSize(pageSize uint32, badFields map[string]string) {
+	if pageSize > pagination.MaxPageSize {
+		badFields["page_size"] = fmt.Sprintf("This field must be at most %d.", pagination.MaxPageSize)
+	}
+}
+
+// ParseListToken parses the list token of a list request for resources of the
+// provided type. The request parameters are the parameters, other than the
+// page size and list token, which change the items listed, eg: the scope id
+// and the filter. An invalid argument error is returned if the token is
+// malformed, expired, or was returned for a different request.
+func ParseListToken(ctx context.Context, token string, resourceType resource.Type, requestParams ...string) (*pagination.ListToken, error) {
+	tok, err := pagination.ParseListToken(ctx, token, resourceType, pagination.RequestHash(requestParams...))
+	if err != nil {
+		msg := "This field could not be parsed."
+		var domainErr *errors.Err
+		if errors.As(err, &domainErr) && domainErr.Msg != "" {
+			msg = fmt.Sprintf("This field is not valid: %s.", domainErr.Msg)
+		}
+		return nil, InvalidArgumentErrorf("Error in provided request.", map[string]string{"list_token": msg})
+	}
+	return tok, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/aliases"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentialstores"
@@ -23,10 +24,12 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/workers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms/rotation"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -54,10 +57,13 @@ var (
 	CollectionActions = action.ActionSet{
 		action.Create,
 		action.List,
+		action.RotateKeys,
+		action.ReadKeyRewrapStatus,
 	}
 
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
+			resource.Alias:      aliases.CollectionActions,
 			resource.AuthMethod: authmethods.CollectionActions,
 			resource.AuthToken:  authtokens.CollectionActions,
 			resource.Group:      groups.CollectionActions,
@@ -98,18 +104,22 @@ func init() {
 type Service struct {
 	pbs.UnsafeScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn            common.IamRepoFactory
+	keyRotationRepoFn common.KeyRotationRepoFactory
 }
 
 var _ pbs.ScopeServiceServer = (*Service)(nil)
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, keyRotationRepoFn common.KeyRotationRepoFactory) (Service, error) {
 	const op = "scopes.(Service).NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if keyRotationRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing key rotation repository")
+	}
+	return Service{repoFn: repo, keyRotationRepoFn: keyRotationRepoFn}, nil
 }
 
 // ListScopes implements the interface pbs.ScopeServiceServer.
@@ -133,6 +143,7 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 			return nil, authResults.Error
 		}
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
 		ctx, s.repoFn, authResults, req.GetScopeId(), resource.Scope, req.GetRecursive())
@@ -355,6 +366,52 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// RotateKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateKeys(ctx context.Context, req *pbs.RotateKeysRequest) (*pbs.RotateKeysResponse, error) {
+	if err := validateRotateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.keyRotationRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	run, err := repo.RotateKeys(ctx, req.GetScopeId(), rotation.WithRewrap(req.GetRewrap()))
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to rotate keys: %v", err)
+	}
+	if run == nil {
+		return &pbs.RotateKeysResponse{}, nil
+	}
+	return &pbs.RotateKeysResponse{Item: keyRewrapStatusToProto(run)}, nil
+}
+
+// ReadKeyRewrapStatus implements the interface pbs.ScopeServiceServer.
+func (s Service) ReadKeyRewrapStatus(ctx context.Context, req *pbs.ReadKeyRewrapStatusRequest) (*pbs.ReadKeyRewrapStatusResponse, error) {
+	if err := validateReadKeyRewrapStatusRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.ReadKeyRewrapStatus)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.keyRotationRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	run, err := repo.LookupRewrapRun(ctx, req.GetScopeId())
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to read key rewrap status: %v", err)
+	}
+	if run == nil {
+		return nil, handlers.NotFoundErrorf("No key rewrap has been started for scope %q.", req.GetScopeId())
+	}
+	return &pbs.ReadKeyRewrapStatusResponse{Item: keyRewrapStatusToProto(run)}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -513,7 +570,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Scope), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create:
+	case action.List, action.Create, action.RotateKeys, action.ReadKeyRewrapStatus:
 		parentId = id
 		s, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -541,6 +598,21 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+func keyRewrapStatusToProto(in *rotation.RewrapRun) *pb.KeyRewrapStatus {
+	out := &pb.KeyRewrapStatus{
+		ScopeId:        in.ScopeId,
+		TotalCount:     in.TotalCount,
+		CompletedCount: in.CompletedCount,
+		Completed:      in.Completed(),
+		CreatedTime:    in.CreateTime.GetTimestamp(),
+		UpdatedTime:    in.UpdateTime.GetTimestamp(),
+	}
+	if in.Completed() {
+		out.CompletedTime = in.EndTime.GetTimestamp()
+	}
+	return out
+}
+
 func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Scope, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -745,3 +817,35 @@ func validateListRequest(req *pbs.ListScopesRequest) error {
 	}
 	return nil
 }
+
+func validateKeyScopeId(scopeId string) map[string]string {
+	badFields := map[string]string{}
+	switch {
+	case scopeId == scope.Global.String():
+	case strings.HasPrefix(scopeId, scope.Org.Prefix()):
+		if !handlers.ValidId(handlers.Id(scopeId), scope.Org.Prefix()) {
+			badFields["scope_id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(scopeId, scope.Project.Prefix()):
+		if !handlers.ValidId(handlers.Id(scopeId), scope.Project.Prefix()) {
+			badFields["scope_id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["scope_id"] = "Invalidly formatted scope id."
+	}
+	return badFields
+}
+
+func validateRotateKeysRequest(req *pbs.RotateKeysRequest) error {
+	if badFields := validateKeyScopeId(req.GetScopeId()); len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateReadKeyRewrapStatusRequest(req *pbs.ReadKeyRewrapStatusRequest) error {
+	if badFields := validateKeyScopeId(req.GetScopeId()); len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms/rotation"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -32,7 +33,7 @@ import (
 
 var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
 
-func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), func() (*rotation.Repository, error)) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -40,6 +41,10 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	rotationRepo := rotation.TestRepository(t, conn, wrap)
+	rotationRepoFn := func() (*rotation.Repository, error) {
+		return rotationRepo, nil
+	}
 
 	oRes, pRes := iam.TestScopes(t, iamRepo)
 
@@ -56,10 +61,16 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	require.NoError(t, err)
 	pRes, _, err = repo.UpdateScope(context.Background(), pRes, 1, []string{"Name", "Description"})
 	require.NoError(t, err)
-	return oRes, pRes, repoFn
+	return oRes, pRes, repoFn, rotationRepoFn
 }
 
 var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
+	"aliases": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"auth-methods": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -87,6 +98,8 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("rotate-keys"),
+			structpb.NewStringValue("read-key-rewrap-status"),
 		},
 	},
 	"users": {
@@ -134,6 +147,8 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("rotate-keys"),
+			structpb.NewStringValue("read-key-rewrap-status"),
 		},
 	},
 	"users": {
@@ -183,7 +198,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 }
 
 func TestGet(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
 	toMerge := &pbs.GetScopeRequest{
 		Id: proj.GetPublicId(),
 	}
@@ -271,7 +286,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetScopeRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, rotationRepoFn)
 			require.NoError(err, "Couldn't create new project service.")
 
 			got, gErr := s.GetScope(auth.DisabledAuthTestContext(repoFn, tc.scopeId), req)
@@ -291,6 +306,10 @@ func TestList(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	rotationRepo := rotation.TestRepository(t, conn, wrap)
+	rotationRepoFn := func() (*rotation.Repository, error) {
+		return rotationRepo, nil
+	}
 	repo, err := repoFn()
 	require.NoError(t, err)
 
@@ -352,7 +371,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, rotationRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			// Test with non-anonymous listing first
@@ -466,7 +485,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, rotationRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			// Test with non-anonymous listing first
@@ -493,9 +512,9 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, rotationRepoFn)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	cases := []struct {
@@ -567,9 +586,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, rotationRepoFn)
 	require.NoError(err, "Error when getting new scopes service")
 	ctx := auth.DisabledAuthTestContext(repoFn, org.GetPublicId())
 	req := &pbs.DeleteScopeRequest{
@@ -594,7 +613,7 @@ func TestDelete_twice(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
+	defaultOrg, defaultProj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
 	defaultProjCreated := defaultProj.GetCreateTime().GetTimestamp().AsTime()
 	toMerge := &pbs.CreateScopeRequest{}
 
@@ -777,7 +796,7 @@ func TestCreate(t *testing.T) {
 				req := proto.Clone(toMerge).(*pbs.CreateScopeRequest)
 				proto.Merge(req, tc.req)
 
-				s, err := scopes.NewService(repoFn)
+				s, err := scopes.NewService(repoFn, rotationRepoFn)
 				require.NoError(err, "Error when getting new project service.")
 
 				if name != "" {
@@ -851,8 +870,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
-	tested, err := scopes.NewService(repoFn)
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
+	tested, err := scopes.NewService(repoFn, rotationRepoFn)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	iamRepo, err := repoFn()
@@ -1284,3 +1303,106 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateKeys(t *testing.T) {
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
+	s, err := scopes.NewService(repoFn, rotationRepoFn)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name       string
+		req        *pbs.RotateKeysRequest
+		wantStatus bool
+		err        error
+	}{
+		{
+			name: "Rotate global keys",
+			req:  &pbs.RotateKeysRequest{ScopeId: scope.Global.String()},
+		},
+		{
+			name: "Rotate org keys",
+			req:  &pbs.RotateKeysRequest{ScopeId: org.GetPublicId()},
+		},
+		{
+			name:       "Rotate project keys with rewrap",
+			req:        &pbs.RotateKeysRequest{ScopeId: proj.GetPublicId(), Rewrap: true},
+			wantStatus: true,
+		},
+		{
+			name: "Nonexistent scope",
+			req:  &pbs.RotateKeysRequest{ScopeId: "p_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad scope id formatting",
+			req:  &pbs.RotateKeysRequest{ScopeId: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.RotateKeys(auth.DisabledAuthTestContext(repoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RotateKeys(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			if !tc.wantStatus {
+				assert.Nil(got.GetItem())
+				return
+			}
+			require.NotNil(got.GetItem())
+			assert.Equal(tc.req.GetScopeId(), got.GetItem().GetScopeId())
+			assert.False(got.GetItem().GetCompleted())
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.Nil(got.GetItem().GetCompletedTime())
+		})
+	}
+}
+
+func TestReadKeyRewrapStatus(t *testing.T) {
+	org, proj, repoFn, rotationRepoFn := createDefaultScopesAndRepo(t)
+	s, err := scopes.NewService(repoFn, rotationRepoFn)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	rotated, err := s.RotateKeys(auth.DisabledAuthTestContext(repoFn, proj.GetPublicId()), &pbs.RotateKeysRequest{ScopeId: proj.GetPublicId(), Rewrap: true})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.ReadKeyRewrapStatusRequest
+		res  *pbs.ReadKeyRewrapStatusResponse
+		err  error
+	}{
+		{
+			name: "Read started rewrap",
+			req:  &pbs.ReadKeyRewrapStatusRequest{ScopeId: proj.GetPublicId()},
+			res:  &pbs.ReadKeyRewrapStatusResponse{Item: rotated.GetItem()},
+		},
+		{
+			name: "No rewrap started",
+			req:  &pbs.ReadKeyRewrapStatusRequest{ScopeId: org.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad scope id formatting",
+			req:  &pbs.ReadKeyRewrapStatusRequest{ScopeId: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ReadKeyRewrapStatus(auth.DisabledAuthTestContext(repoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ReadKeyRewrapStatus(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "ReadKeyRewrapStatus(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
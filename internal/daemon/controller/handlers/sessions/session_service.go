@@ -4,13 +4,16 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/session"
@@ -74,6 +77,7 @@ func (s Service) GetSession(ctx context.Context, req *pbs.GetSessionRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = db.WithReplicaReads(ctx)
 	ses, err := s.getFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -124,6 +128,11 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
+	tok, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.Session,
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter(), strconv.FormatBool(req.GetIncludeTerminated()))
+	if err != nil {
+		return nil, err
+	}
 
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
 	if authResults.Error != nil {
@@ -138,9 +147,9 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 			return nil, authResults.Error
 		}
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	var scopeIds map[string]*scopes.ScopeInfo
-	var err error
 
 	if !req.GetRecursive() {
 		scopeIds = map[string]*scopes.ScopeInfo{authResults.Scope.Id: authResults.Scope}
@@ -158,28 +167,40 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	sesList, err := repo.ListSessions(ctx, session.WithTerminated(req.GetIncludeTerminated()))
-	if err != nil {
-		return nil, err
-	}
-	if len(sesList) == 0 {
-		return &pbs.ListSessionsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Session, 0, len(sesList))
+	paginated := req.GetPageSize() > 0 || req.GetListToken() != ""
+	finalItems := make([]*pb.Session, 0, req.GetPageSize())
+	var removedIds []string
 	res := perms.Resource{
 		Type: resource.Session,
 	}
-	for _, item := range sesList {
+	listFn := func(ctx context.Context, page *pagination.Page) ([]pagination.Item, error) {
+		// Sessions terminated since a listing without terminated sessions
+		// are returned as removed ids when refreshing it.
+		sesList, err := repo.ListSessions(ctx, session.WithTerminated(req.GetIncludeTerminated() || page.Refresh()), session.WithPage(page))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]pagination.Item, 0, len(sesList))
+		for _, item := range sesList {
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	appendFn := func(ctx context.Context, i pagination.Item) (bool, error) {
+		item := i.(*session.Session)
+		if tok.Refresh() && !req.GetIncludeTerminated() && item.TerminationReason != "" {
+			removedIds = append(removedIds, item.GetPublicId())
+			return false, nil
+		}
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -192,17 +213,49 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		finalItems = append(finalItems, pbItem)
+		return true, nil
+	}
+	next, complete, err := pagination.FillPage(ctx, tok, int(req.GetPageSize()), listFn, appendFn)
+	if err != nil {
+		return nil, err
+	}
+	if !paginated {
+		if len(finalItems) == 0 {
+			return &pbs.ListSessionsResponse{}, nil
+		}
+		return &pbs.ListSessionsResponse{Items: finalItems}, nil
 	}
 
-	return &pbs.ListSessionsResponse{Items: finalItems}, nil
+	if tok.Refresh() && tok.FirstPage() {
+		projectIds := make([]string, 0, len(scopeIds))
+		for id := range scopeIds {
+			projectIds = append(projectIds, id)
+		}
+		deletedIds, err := repo.ListDeletedIds(ctx, projectIds, tok.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+		removedIds = append(removedIds, deletedIds...)
+	}
+	listToken, err := next.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListSessionsResponse{
+		Items:        finalItems,
+		ResponseType: pagination.ResponseType(complete),
+		ListToken:    listToken,
+		RemovedIds:   removedIds,
+	}, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -476,6 +529,7 @@ func validateListRequest(req *pbs.ListSessionsRequest) error {
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
+	handlers.ValidatePageSize(req.GetPageSize(), badFields)
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
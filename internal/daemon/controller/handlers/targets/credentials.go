@@ -162,6 +162,18 @@ func staticToWorkerCredential(ctx context.Context, cred credential.Static) (sess
 			},
 		}
 
+	case *credstatic.SshCertificateCredential:
+		workerCred = &serverpb.Credential{
+			Credential: &serverpb.Credential_SshCertificate{
+				SshCertificate: &serverpb.SshCertificate{
+					Username:             c.GetUsername(),
+					Certificate:          string(c.GetCertificate()),
+					PrivateKey:           string(c.GetPrivateKey()),
+					PrivateKeyPassphrase: string(c.GetPrivateKeyPassphrase()),
+				},
+			},
+		}
+
 	default:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", c))
 	}
@@ -218,6 +230,29 @@ func staticToSessionCredential(ctx context.Context, cred credential.Static) (*pb
 			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for ssh private key credential"))
 		}
 
+	case *credstatic.SshCertificateCredential:
+		var err error
+		credType = string(credential.SshCertificateType)
+		credData, err = handlers.ProtoToStruct(
+			&pb.SshCertificateCredential{
+				Username:             c.GetUsername(),
+				Certificate:          string(c.GetCertificate()),
+				PrivateKey:           string(c.GetPrivateKey()),
+				PrivateKeyPassphrase: string(c.GetPrivateKeyPassphrase()),
+			},
+		)
+		secret = map[string]interface{}{
+			"username":    c.GetUsername(),
+			"certificate": string(c.GetCertificate()),
+			"private_key": string(c.GetPrivateKey()),
+		}
+		if len(c.GetPrivateKeyPassphrase()) > 0 {
+			secret["private_key_passphrase"] = string(c.GetPrivateKeyPassphrase())
+		}
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for ssh certificate credential"))
+		}
+
 	case *credstatic.JsonCredential:
 		var err error
 		credType = string(credential.JsonType)
@@ -0,0 +1,95 @@
+package rdp
+
+import (
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/rdp"
+	"github.com/hashicorp/boundary/internal/target/rdp/store"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+const (
+	defaultPortField = "attributes.default_port"
+
+	// defaultPort is the port used for a rdp.Target when no default port is
+	// provided on creation.
+	defaultPort = 3389
+)
+
+type attribute struct {
+	*pb.RdpTargetAttributes
+}
+
+func (a *attribute) Options() []target.Option {
+	var opts []target.Option
+	switch {
+	case a.GetDefaultPort() == nil:
+		opts = append(opts, target.WithDefaultPort(defaultPort))
+	case a.GetDefaultPort().GetValue() != 0:
+		opts = append(opts, target.WithDefaultPort(a.GetDefaultPort().GetValue()))
+	}
+	return opts
+}
+
+func (a *attribute) Vet() map[string]string {
+	badFields := map[string]string{}
+	if a.GetDefaultPort() != nil && a.GetDefaultPort().GetValue() == 0 {
+		badFields[defaultPortField] = "This field cannot be set to zero."
+	}
+	return badFields
+}
+
+func (a *attribute) VetForUpdate(p []string) map[string]string {
+	if !handlers.MaskContains(p, defaultPortField) {
+		return nil
+	}
+	badFields := map[string]string{}
+	if a.GetDefaultPort() == nil {
+		badFields[defaultPortField] = "This field is required."
+	} else if a.GetDefaultPort().GetValue() == 0 {
+		badFields[defaultPortField] = "This cannot be set to zero."
+	}
+	return badFields
+}
+
+func newAttribute(m interface{}) targets.Attributes {
+	a := &attribute{
+		&pb.RdpTargetAttributes{},
+	}
+	if rdpAttr, ok := m.(*pb.Target_RdpTargetAttributes); ok {
+		a.RdpTargetAttributes = rdpAttr.RdpTargetAttributes
+	}
+	return a
+}
+
+func setAttributes(t target.Target, out *pb.Target) error {
+	if t == nil {
+		return nil
+	}
+
+	attrs := &pb.Target_RdpTargetAttributes{
+		RdpTargetAttributes: &pb.RdpTargetAttributes{},
+	}
+	if t.GetDefaultPort() > 0 {
+		attrs.RdpTargetAttributes.DefaultPort = &wrappers.UInt32Value{Value: t.GetDefaultPort()}
+	}
+
+	out.Attrs = attrs
+	return nil
+}
+
+func init() {
+	var maskManager handlers.MaskManager
+	var err error
+
+	if maskManager, err = handlers.NewMaskManager(
+		handlers.MaskDestination{&store.Target{}},
+		handlers.MaskSource{&pb.Target{}, &pb.RdpTargetAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	targets.Register(rdp.Subtype, maskManager, newAttribute, setAttributes)
+}
@@ -9,13 +9,16 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/alias"
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
@@ -23,6 +26,8 @@ import (
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -32,6 +37,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
+	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-bexpr"
@@ -45,9 +51,8 @@ import (
 )
 
 const (
-	credentialDomain  = "credential"
-	hostDomain        = "host"
-	missingPortErrStr = "missing port in address"
+	credentialDomain = "credential"
+	hostDomain       = "host"
 )
 
 // extraWorkerFilterFunc takes in a set of workers and returns another set,
@@ -97,29 +102,40 @@ type Service struct {
 
 	repoFn           target.RepositoryFactory
 	iamRepoFn        common.IamRepoFactory
+	aliasRepoFn      common.AliasRepoFactory
 	serversRepoFn    common.ServersRepoFactory
 	sessionRepoFn    session.RepositoryFactory
 	pluginHostRepoFn common.PluginHostRepoFactory
 	staticHostRepoFn common.StaticRepoFactory
+	hostHealthRepoFn common.HostHealthRepoFactory
 	vaultCredRepoFn  common.VaultCredentialRepoFactory
 	staticCredRepoFn common.StaticCredentialRepoFactory
 	kmsCache         *kms.Kms
+
+	// sessionIdleTimeout is the idle timeout of the sessions of the targets
+	// without their own, if not zero.
+	sessionIdleTimeout time.Duration
 }
 
 var _ pbs.TargetServiceServer = (*Service)(nil)
 
-// NewService returns a target service which handles target related requests to boundary.
+// NewService returns a target service which handles target related requests to
+// boundary. sessionIdleTimeout is the idle timeout of the sessions of the
+// targets without their own; zero means no idle timeout.
 func NewService(
 	ctx context.Context,
 	kmsCache *kms.Kms,
 	repoFn target.RepositoryFactory,
 	iamRepoFn common.IamRepoFactory,
+	aliasRepoFn common.AliasRepoFactory,
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn session.RepositoryFactory,
 	pluginHostRepoFn common.PluginHostRepoFactory,
 	staticHostRepoFn common.StaticRepoFactory,
+	hostHealthRepoFn common.HostHealthRepoFactory,
 	vaultCredRepoFn common.VaultCredentialRepoFactory,
 	staticCredRepoFn common.StaticCredentialRepoFactory,
+	sessionIdleTimeout time.Duration,
 ) (Service, error) {
 	const op = "targets.NewService"
 	if repoFn == nil {
@@ -128,6 +144,9 @@ func NewService(
 	if iamRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
+	if aliasRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing alias repository")
+	}
 	if serversRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing server repository")
 	}
@@ -140,6 +159,9 @@ func NewService(
 	if staticHostRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static host repository")
 	}
+	if hostHealthRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing host health repository")
+	}
 	if vaultCredRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing vault credential repository")
 	}
@@ -147,15 +169,18 @@ func NewService(
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static credential repository")
 	}
 	return Service{
-		repoFn:           repoFn,
-		iamRepoFn:        iamRepoFn,
-		serversRepoFn:    serversRepoFn,
-		sessionRepoFn:    sessionRepoFn,
-		pluginHostRepoFn: pluginHostRepoFn,
-		staticHostRepoFn: staticHostRepoFn,
-		vaultCredRepoFn:  vaultCredRepoFn,
-		staticCredRepoFn: staticCredRepoFn,
-		kmsCache:         kmsCache,
+		repoFn:             repoFn,
+		iamRepoFn:          iamRepoFn,
+		aliasRepoFn:        aliasRepoFn,
+		serversRepoFn:      serversRepoFn,
+		sessionRepoFn:      sessionRepoFn,
+		pluginHostRepoFn:   pluginHostRepoFn,
+		staticHostRepoFn:   staticHostRepoFn,
+		hostHealthRepoFn:   hostHealthRepoFn,
+		vaultCredRepoFn:    vaultCredRepoFn,
+		staticCredRepoFn:   staticCredRepoFn,
+		kmsCache:           kmsCache,
+		sessionIdleTimeout: sessionIdleTimeout,
 	}, nil
 }
 
@@ -166,6 +191,11 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
+	tok, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.Target,
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
 	if authResults.Error != nil {
 		// If it's forbidden, and it's a recursive request, and they're
@@ -179,8 +209,8 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 			return nil, authResults.Error
 		}
 	}
+	ctx = db.WithReplicaReads(ctx)
 
-	var err error
 	var authzScopes map[string]*scopes.ScopeInfo
 	if req.GetRecursive() {
 		authzScopes, err = authResults.ScopesAuthorizedForList(ctx, req.GetScopeId(), resource.Target)
@@ -193,15 +223,8 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 
 	// Get all user permissions for the requested scope(s).
 	userPerms := authResults.ACL().ListPermissions(authzScopes, resource.Target, IdActions)
-	if len(userPerms) == 0 {
-		return &pbs.ListTargetsResponse{}, nil
-	}
-
-	tl, err := s.listFromRepo(ctx, userPerms)
-	if err != nil {
-		return nil, err
-	}
-	if len(tl) == 0 {
+	paginated := req.GetPageSize() > 0 || req.GetListToken() != ""
+	if len(userPerms) == 0 && !paginated {
 		return &pbs.ListTargetsResponse{}, nil
 	}
 
@@ -210,8 +233,20 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return nil, err
 	}
 
-	finalItems := make([]*pb.Target, 0, len(tl))
-	for _, item := range tl {
+	finalItems := make([]*pb.Target, 0, req.GetPageSize())
+	listFn := func(ctx context.Context, page *pagination.Page) ([]pagination.Item, error) {
+		tl, err := s.listFromRepo(ctx, userPerms, page)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]pagination.Item, 0, len(tl))
+		for _, item := range tl {
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	appendFn := func(ctx context.Context, i pagination.Item) (bool, error) {
+		item := i.(target.Target)
 		pr := perms.Resource{Id: item.GetPublicId(), ScopeId: item.GetProjectId(), Type: resource.Target}
 		outputFields := authResults.FetchOutputFields(pr, action.List).SelfOrDefaults(authResults.UserId)
 
@@ -226,21 +261,57 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		finalItems = append(finalItems, pbItem)
+		return true, nil
+	}
+	next, complete, err := pagination.FillPage(ctx, tok, int(req.GetPageSize()), listFn, appendFn)
+	if err != nil {
+		return nil, err
+	}
+	if !paginated {
+		if len(finalItems) == 0 {
+			return &pbs.ListTargetsResponse{}, nil
+		}
+		return &pbs.ListTargetsResponse{Items: finalItems}, nil
 	}
 
-	return &pbs.ListTargetsResponse{Items: finalItems}, nil
+	var removedIds []string
+	if tok.Refresh() && tok.FirstPage() {
+		projectIds := make([]string, 0, len(authzScopes))
+		for id := range authzScopes {
+			projectIds = append(projectIds, id)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		removedIds, err = repo.ListDeletedIds(ctx, projectIds, tok.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	listToken, err := next.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListTargetsResponse{
+		Items:        finalItems,
+		ResponseType: pagination.ResponseType(complete),
+		ListToken:    listToken,
+		RemovedIds:   removedIds,
+	}, nil
 }
 
 // GetTarget implements the interface pbs.TargetServiceServer.
@@ -609,6 +680,10 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 
 func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSessionRequest) (*pbs.AuthorizeSessionResponse, error) {
 	const op = "targets.(Service).AuthorizeSession"
+	req, viaAlias, err := s.resolveAlias(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateAuthorizeSessionRequest(req); err != nil {
 		return nil, err
 	}
@@ -618,6 +693,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		target.WithProjectName(req.GetScopeName()),
 	)
 	if authResults.Error != nil {
+		if viaAlias && stderrors.Is(authResults.Error, handlers.NotFoundError()) {
+			// The same error as for an unknown alias
+			return nil, handlers.ForbiddenError()
+		}
 		return nil, authResults.Error
 	}
 
@@ -740,6 +819,25 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	}
 
 	var chosenEndpoint *host.Endpoint
+	if requestedId == "" && len(endpoints) > 0 {
+		// Skip the hosts marked unhealthy by the health check of their host
+		// set. A host explicitly requested is used regardless of its health.
+		// When every host is unhealthy the health checks may be the ones
+		// failing, so a host is picked among all of them instead.
+		hostHealthRepo, err := s.hostHealthRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		healthy, err := hostHealthRepo.FilterHealthy(ctx, endpoints)
+		if err != nil {
+			return nil, err
+		}
+		if len(healthy) > 0 {
+			endpoints = healthy
+		} else {
+			event.WriteSysEvent(ctx, op, "no healthy hosts are available from the target host sources, picking among all hosts", "target_id", t.GetPublicId())
+		}
+	}
 	if requestedId != "" {
 		for _, ep := range endpoints {
 			if ep.HostId == requestedId {
@@ -764,13 +862,12 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		chosenEndpoint = endpoints[rand.Intn(len(endpoints))]
 	}
 
-	h, p, err := net.SplitHostPort(chosenEndpoint.Address)
+	h, p, err := util.SplitHostPort(chosenEndpoint.Address)
 	switch {
-	case err != nil && strings.Contains(err.Error(), missingPortErrStr):
+	case errors.Is(err, util.ErrMissingPort):
 		if t.GetDefaultPort() == 0 {
 			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("neither the selected host %q nor the target provides a port to use", chosenEndpoint.HostId))
 		}
-		h = chosenEndpoint.Address
 		p = strconv.FormatUint(uint64(t.GetDefaultPort()), 10)
 	case err != nil:
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error when parsing the chosen endpoints host address"))
@@ -813,6 +910,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
+	idleTimeoutSeconds := t.GetSessionIdleTimeoutSeconds()
+	if idleTimeoutSeconds == 0 {
+		idleTimeoutSeconds = uint32(s.sessionIdleTimeout / time.Second)
+	}
 	sessionComposition := session.ComposedOf{
 		UserId:             authResults.UserId,
 		HostId:             chosenEndpoint.HostId,
@@ -823,6 +924,7 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		Endpoint:           endpointUrl.String(),
 		ExpirationTime:     &timestamp.Timestamp{Timestamp: expTime},
 		ConnectionLimit:    t.GetSessionConnectionLimit(),
+		IdleTimeoutSeconds: idleTimeoutSeconds,
 		WorkerFilter:       t.GetWorkerFilter(),
 		DynamicCredentials: dynCreds,
 		StaticCredentials:  staticCreds,
@@ -960,6 +1062,47 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
+// resolveAlias returns the request with the id of the destination target of
+// the alias if the id of the request is the value of an alias, and whether it
+// was. If a host id is not requested, the host id of the alias is used.
+// Requests using a target id, or a target name and scope, are returned
+// unchanged. Aliases are only resolved for authenticated callers, which get a
+// forbidden error for an unknown alias or an alias without a destination, as
+// they do for a target they are not authorized to connect to, so aliases can't
+// be enumerated.
+func (s Service) resolveAlias(ctx context.Context, req *pbs.AuthorizeSessionRequest) (*pbs.AuthorizeSessionRequest, bool, error) {
+	const op = "targets.(Service).resolveAlias"
+	if req.GetName() != "" || handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		return req, false, nil
+	}
+	value := alias.NormalizeValue(req.GetId())
+	if err := alias.ValidateValue(ctx, value); err != nil {
+		// Not an alias, the request is rejected when validated.
+		return req, false, nil
+	}
+	authResults := auth.Verify(ctx, auth.WithType(resource.Target), auth.WithAction(action.AuthorizeSession), auth.WithScopeId(scope.Global.String()))
+	if authResults.UserId == auth.AnonymousUserId || (authResults.Error != nil && authResults.UserId == "") {
+		return nil, false, handlers.UnauthenticatedError()
+	}
+	repo, err := s.aliasRepoFn()
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	a, err := repo.LookupAliasByValue(ctx, value)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	if a == nil || a.GetDestinationId() == "" {
+		return nil, false, handlers.ForbiddenError()
+	}
+	req = proto.Clone(req).(*pbs.AuthorizeSessionRequest)
+	req.Id = a.GetDestinationId()
+	if req.GetHostId() == "" {
+		req.HostId = a.GetHostId()
+	}
+	return req, true, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -990,6 +1133,9 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
+	}
 	if item.GetWorkerFilter() != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
@@ -1033,6 +1179,9 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
+	}
 	if filter := item.GetWorkerFilter(); filter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
@@ -1094,12 +1243,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, perms []perms.Permission) ([]target.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, perms []perms.Permission, page *pagination.Page) ([]target.Target, error) {
 	repo, err := s.repoFn(target.WithPermissions(perms))
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx)
+	ul, err := repo.ListTargets(ctx, target.WithPage(page))
 	if err != nil {
 		return nil, err
 	}
@@ -1342,6 +1491,9 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 	if outputFields.Has(globals.SessionConnectionLimitField) {
 		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
 	}
+	if outputFields.Has(globals.SessionIdleTimeoutSecondsField) && in.GetSessionIdleTimeoutSeconds() != 0 {
+		out.SessionIdleTimeoutSeconds = wrapperspb.UInt32(in.GetSessionIdleTimeoutSeconds())
+	}
 	if outputFields.Has(globals.WorkerFilterField) && in.GetWorkerFilter() != "" {
 		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
@@ -1534,6 +1686,7 @@ func validateListRequest(req *pbs.ListTargetsRequest) error {
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
+	handlers.ValidatePageSize(req.GetPageSize(), badFields)
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -1625,6 +1778,7 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1636,6 +1790,7 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1646,7 +1801,8 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 			vault.CredentialLibraryPrefix,
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
-			credential.SshPrivateKeyCredentialPrefix) {
+			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1672,6 +1828,7 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1683,6 +1840,7 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1693,7 +1851,8 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 			vault.CredentialLibraryPrefix,
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
-			credential.SshPrivateKeyCredentialPrefix) {
+			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1723,6 +1882,7 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1734,6 +1894,7 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
@@ -1745,6 +1906,7 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			credential.UsernamePasswordCredentialPrefix,
 			credential.PreviousUsernamePasswordCredentialPrefix,
 			credential.SshPrivateKeyCredentialPrefix,
+			credential.SshCertificateCredentialPrefix,
 			credential.JsonCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
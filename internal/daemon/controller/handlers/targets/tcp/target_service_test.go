@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/alias"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/credential"
 	credstatic "github.com/hashicorp/boundary/internal/credential/static"
@@ -23,6 +24,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
+	"github.com/hashicorp/boundary/internal/host/health"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -88,6 +90,9 @@ func testService(t *testing.T, ctx context.Context, conn *db.DB, kms *kms.Kms, w
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	pluginHostRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
@@ -97,7 +102,10 @@ func testService(t *testing.T, ctx context.Context, conn *db.DB, kms *kms.Kms, w
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(context.Background(), rw, rw, kms)
 	}
-	return targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn)
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(context.Background(), rw, rw, kms)
+	}
+	return targets.NewService(ctx, kms, repoFn, iamRepoFn, aliasRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, hostHealthRepoFn, vaultCredRepoFn, staticCredRepoFn, 0)
 }
 
 func TestGet(t *testing.T) {
@@ -2251,12 +2259,18 @@ func TestAuthorizeSession(t *testing.T) {
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return staticRepo, nil
 	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	vaultCredRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -2320,6 +2334,14 @@ func TestAuthorizeSession(t *testing.T) {
 	hWithPort, _, err = staticRepo.UpdateHost(ctx, hcWithPort.GetProjectId(), hWithPort, hWithPort.GetVersion(), []string{"address"})
 	require.NoError(t, err)
 
+	hcIpv6 := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hIpv6 := static.TestHosts(t, conn, hcIpv6.GetPublicId(), 1)[0]
+	shsIpv6 := static.TestSets(t, conn, hcIpv6.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, shsIpv6.GetPublicId(), []*static.Host{hIpv6})
+	hIpv6.Address = "2001:db8::1"
+	hIpv6, _, err = staticRepo.UpdateHost(ctx, hcIpv6.GetProjectId(), hIpv6, hIpv6.GetVersion(), []string{"address"})
+	require.NoError(t, err)
+
 	phc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
 	phs := plugin.TestSet(t, conn, kms, sche, phc, plgm, plugin.WithPreferredEndpoints([]string{"cidr:10.0.0.0/24"}))
 
@@ -2375,6 +2397,13 @@ func TestAuthorizeSession(t *testing.T) {
 			wantedHostId:   hWithPort.GetPublicId(),
 			wantedEndpoint: hWithPort.GetAddress(),
 		},
+		{
+			name:           "static ipv6 host",
+			hostSourceId:   shsIpv6.GetPublicId(),
+			credSourceId:   clsResp.GetItem().GetId(),
+			wantedHostId:   hIpv6.GetPublicId(),
+			wantedEndpoint: fmt.Sprintf("[2001:db8::1]:%d", defaultPort),
+		},
 		{
 			name:           "plugin host",
 			hostSourceId:   phs.GetPublicId(),
@@ -2384,7 +2413,7 @@ func TestAuthorizeSession(t *testing.T) {
 		},
 	}
 
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, aliasRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, hostHealthRepoFn, vaultCredRepoFn, staticCredRepoFn, 0)
 	require.NoError(t, err)
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2482,6 +2511,151 @@ func TestAuthorizeSession(t *testing.T) {
 	}
 }
 
+func TestAuthorizeSession_Alias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	repoFn := func(o ...target.Option) (*target.Repository, error) {
+		return target.NewRepository(ctx, rw, rw, kms)
+	}
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func(opts ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opts...)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	vaultCredRepoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(ctx, rw, rw, kms)
+	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, aliasRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, hostHealthRepoFn, vaultCredRepoFn, staticCredRepoFn, 0)
+	require.NoError(t, err)
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx = auth.NewVerifierContext(requests.NewRequestContext(ctx),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		&authpb.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+		})
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hosts := static.TestHosts(t, conn, hc.GetPublicId(), 2)
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, hs.GetPublicId(), hosts)
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test", target.WithDefaultPort(22), target.WithHostSources([]string{hs.GetPublicId()}))
+	server.TestKmsWorker(t, conn, wrapper)
+
+	alias.TestAlias(t, conn, "prod.postgres", alias.WithDestinationId(tar.GetPublicId()))
+	alias.TestAlias(t, conn, "prod.postgres.second", alias.WithDestinationId(tar.GetPublicId()), alias.WithHostId(hosts[1].GetPublicId()))
+	alias.TestAlias(t, conn, "no.destination")
+
+	// A target in a project the user has no grants in
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	otherTar := tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "other", target.WithDefaultPort(22))
+	alias.TestAlias(t, conn, "other.project", alias.WithDestinationId(otherTar.GetPublicId()))
+
+	cases := []struct {
+		name       string
+		req        *pbs.AuthorizeSessionRequest
+		wantHostId string
+		wantErr    error
+	}{
+		{
+			name: "alias",
+			req:  &pbs.AuthorizeSessionRequest{Id: "Prod.Postgres"},
+		},
+		{
+			name:       "alias-with-host",
+			req:        &pbs.AuthorizeSessionRequest{Id: "prod.postgres.second"},
+			wantHostId: hosts[1].GetPublicId(),
+		},
+		{
+			name:       "alias-with-host-overridden",
+			req:        &pbs.AuthorizeSessionRequest{Id: "prod.postgres.second", HostId: hosts[0].GetPublicId()},
+			wantHostId: hosts[0].GetPublicId(),
+		},
+		{
+			name:    "unknown-alias",
+			req:     &pbs.AuthorizeSessionRequest{Id: "unknown.alias"},
+			wantErr: handlers.ForbiddenError(),
+		},
+		{
+			name:    "alias-without-destination",
+			req:     &pbs.AuthorizeSessionRequest{Id: "no.destination"},
+			wantErr: handlers.ForbiddenError(),
+		},
+		{
+			name:    "alias-unauthorized",
+			req:     &pbs.AuthorizeSessionRequest{Id: "other.project"},
+			wantErr: handlers.ForbiddenError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			res, err := s.AuthorizeSession(ctx, tc.req)
+			if tc.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.wantErr), "got error %v, wanted %v", err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tar.GetPublicId(), res.GetItem().GetTargetId())
+			if tc.wantHostId != "" {
+				assert.Equal(tc.wantHostId, res.GetItem().GetHostId())
+			}
+		})
+	}
+
+	// Anonymous callers can't tell which aliases exist
+	anonCtx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		&authpb.RequestInfo{})
+	for _, value := range []string{"prod.postgres", "unknown.alias", "no.destination"} {
+		_, err := s.AuthorizeSession(anonCtx, &pbs.AuthorizeSessionRequest{Id: value})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.UnauthenticatedError()), "got error %v for alias %q", err, value)
+	}
+}
+
 func TestAuthorizeSessionTypedCredentials(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -2509,12 +2683,18 @@ func TestAuthorizeSessionTypedCredentials(t *testing.T) {
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	vaultCredRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -2539,7 +2719,7 @@ func TestAuthorizeSessionTypedCredentials(t *testing.T) {
 	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
 	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
 
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, aliasRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, hostHealthRepoFn, vaultCredRepoFn, staticCredRepoFn, 0)
 	require.NoError(t, err)
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -3088,6 +3268,9 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	hostHealthRepoFn := func() (*health.Repository, error) {
+		return health.NewRepository(rw, rw)
+	}
 	pluginHostRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
@@ -3097,12 +3280,15 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 	org, proj := iam.TestScopes(t, iamRepo)
 
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, aliasRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, hostHealthRepoFn, vaultCredRepoFn, staticCredRepoFn, 0)
 	require.NoError(t, err)
 
 	// Authorized user gets full permissions
@@ -3174,6 +3360,29 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 		return apiTar.GetItem().GetVersion()
 	}
 
+	unhealthyHostExists := func(tar target.Target) (version uint32) {
+		hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+		h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+		hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+		_ = static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+		apiTar, err := s.AddTargetHostSources(ctx, &pbs.AddTargetHostSourcesRequest{
+			Id:            tar.GetPublicId(),
+			Version:       tar.GetVersion(),
+			HostSourceIds: []string{hs.GetPublicId()},
+		})
+		require.NoError(t, err)
+		healthRepo, err := hostHealthRepoFn()
+		require.NoError(t, err)
+		check, err := health.NewHealthCheck(ctx, hs.GetPublicId(), health.ProtocolTcp, 22, health.WithUnhealthyThreshold(1))
+		require.NoError(t, err)
+		_, err = healthRepo.SetHealthCheck(ctx, check)
+		require.NoError(t, err)
+		require.NoError(t, healthRepo.RecordResults(ctx, []*health.Result{
+			{HostSetId: hs.GetPublicId(), HostId: h.GetPublicId(), Error: "connection refused"},
+		}))
+		return apiTar.GetItem().GetVersion()
+	}
+
 	hostWithoutPort := func(tar target.Target) (version uint32) {
 		hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 		h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
@@ -3285,6 +3494,11 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 			setup: []func(tcpTarget target.Target) uint32{workerExists, hostSetNoHostExists, libraryExists},
 			err:   true,
 		},
+		{
+			// Every host is unhealthy so one is picked among all of them.
+			name:  "only unhealthy hosts",
+			setup: []func(tcpTarget target.Target) uint32{workerExists, unhealthyHostExists, libraryExists},
+		},
 		{
 			name:  "bad library configuration",
 			setup: []func(tcpTarget target.Target) uint32{workerExists, hostExists, misConfiguredlibraryExists},
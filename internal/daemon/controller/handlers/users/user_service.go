@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
@@ -10,11 +11,13 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -79,6 +82,11 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
+	tok, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.User,
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
 	if authResults.Error != nil {
 		// If it's forbidden, and it's a recursive request, and they're
@@ -92,22 +100,16 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 			return nil, authResults.Error
 		}
 	}
+	ctx = db.WithReplicaReads(ctx)
 
 	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
 		ctx, s.repoFn, authResults, req.GetScopeId(), resource.User, req.GetRecursive())
 	if err != nil {
 		return nil, err
 	}
+	paginated := req.GetPageSize() > 0 || req.GetListToken() != ""
 	// If no scopes match, return an empty response
-	if len(scopeIds) == 0 {
-		return &pbs.ListUsersResponse{}, nil
-	}
-
-	ul, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
+	if len(scopeIds) == 0 && !paginated {
 		return &pbs.ListUsersResponse{}, nil
 	}
 
@@ -115,16 +117,31 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.User, 0, len(ul))
+	finalItems := make([]*pb.User, 0, req.GetPageSize())
 	res := perms.Resource{
 		Type: resource.User,
 	}
-	for _, item := range ul {
+	listFn := func(ctx context.Context, page *pagination.Page) ([]pagination.Item, error) {
+		if len(scopeIds) == 0 {
+			return nil, nil
+		}
+		ul, err := s.listFromRepo(ctx, scopeIds, page)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]pagination.Item, 0, len(ul))
+		for _, item := range ul {
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	appendFn := func(ctx context.Context, i pagination.Item) (bool, error) {
+		item := i.(*iam.User)
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -137,16 +154,49 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		finalItems = append(finalItems, pbItem)
+		return true, nil
+	}
+	next, complete, err := pagination.FillPage(ctx, tok, int(req.GetPageSize()), listFn, appendFn)
+	if err != nil {
+		return nil, err
+	}
+	if !paginated {
+		if len(finalItems) == 0 {
+			return &pbs.ListUsersResponse{}, nil
+		}
+		return &pbs.ListUsersResponse{Items: finalItems}, nil
+	}
+
+	var removedIds []string
+	if tok.Refresh() && tok.FirstPage() {
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		removedIds, err = repo.ListDeletedIds(ctx, scopeIds, tok.UpdatedAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	listToken, err := next.Marshal(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListUsersResponse{Items: finalItems}, nil
+	return &pbs.ListUsersResponse{
+		Items:        finalItems,
+		ResponseType: pagination.ResponseType(complete),
+		ListToken:    listToken,
+		RemovedIds:   removedIds,
+	}, nil
 }
 
 // GetUsers implements the interface pbs.UserServiceServer.
@@ -486,12 +536,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.User, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, page *pagination.Page) ([]*iam.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListUsers(ctx, scopeIds)
+	ul, err := repo.ListUsers(ctx, scopeIds, iam.WithPage(page))
 	if err != nil {
 		return nil, err
 	}
@@ -696,6 +746,7 @@ func validateListRequest(req *pbs.ListUsersRequest) error {
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
+	handlers.ValidatePageSize(req.GetPageSize(), badFields)
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -316,6 +316,115 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+
+	var wantIds []string
+	for i := 0; i < 5; i++ {
+		u := iam.TestUser(t, iamRepo, o.GetPublicId())
+		wantIds = append(wantIds, u.GetPublicId())
+	}
+
+	s, err := users.NewService(repoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+
+	// Page through the users two at a time
+	var gotIds []string
+	req := &pbs.ListUsersRequest{ScopeId: o.GetPublicId(), PageSize: 2}
+	for {
+		got, err := s.ListUsers(ctx, req)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(got.GetItems()), 2)
+		assert.NotEmpty(t, got.GetListToken())
+		for _, item := range got.GetItems() {
+			gotIds = append(gotIds, item.GetId())
+		}
+		req.ListToken = got.GetListToken()
+		if got.GetResponseType() == "complete" {
+			break
+		}
+		assert.Equal(t, "delta", got.GetResponseType())
+	}
+	assert.ElementsMatch(t, wantIds, gotIds)
+
+	// Refreshing the listing returns the updated users and the ids of the
+	// deleted users
+	u, _, err := iamRepo.LookupUser(context.Background(), wantIds[0])
+	require.NoError(t, err)
+	u.Name = "updated"
+	_, _, _, err = iamRepo.UpdateUser(context.Background(), u, u.Version, []string{"Name"})
+	require.NoError(t, err)
+	_, err = iamRepo.DeleteUser(context.Background(), wantIds[1])
+	require.NoError(t, err)
+
+	got, err := s.ListUsers(ctx, req)
+	require.NoError(t, err)
+	var refreshedIds []string
+	for _, item := range got.GetItems() {
+		refreshedIds = append(refreshedIds, item.GetId())
+	}
+	assert.Contains(t, refreshedIds, wantIds[0])
+	assert.NotContains(t, refreshedIds, wantIds[1])
+	assert.Equal(t, []string{wantIds[1]}, got.GetRemovedIds())
+
+	// A list token can only be used with the request it was returned for
+	_, err = s.ListUsers(ctx, &pbs.ListUsersRequest{ScopeId: o.GetPublicId(), Filter: `"/item/id"=="doesntmatch"`, ListToken: req.GetListToken()})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.InvalidArgumentErrorf("bad request", nil)))
+
+	// The page size is limited
+	_, err = s.ListUsers(ctx, &pbs.ListUsersRequest{ScopeId: o.GetPublicId(), PageSize: 10001})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.InvalidArgumentErrorf("bad request", nil)))
+}
+
+func TestList_FilteredPagination(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+
+	var lastId string
+	for i := 0; i < 25; i++ {
+		lastId = iam.TestUser(t, iamRepo, o.GetPublicId()).GetPublicId()
+	}
+
+	s, err := users.NewService(repoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+
+	// The filter is evaluated on the listed users and the number of users
+	// scanned to fill a page is bounded, so the first pages are empty delta
+	// responses with a list token to continue the listing.
+	req := &pbs.ListUsersRequest{ScopeId: o.GetPublicId(), PageSize: 1, Filter: fmt.Sprintf(`"/item/id"==%q`, lastId)}
+	got, err := s.ListUsers(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, got.GetItems())
+	assert.Equal(t, "delta", got.GetResponseType())
+	assert.NotEmpty(t, got.GetListToken())
+
+	var gotIds []string
+	for got.GetResponseType() != "complete" {
+		req.ListToken = got.GetListToken()
+		got, err = s.ListUsers(ctx, req)
+		require.NoError(t, err)
+		for _, item := range got.GetItems() {
+			gotIds = append(gotIds, item.GetId())
+		}
+	}
+	assert.Equal(t, []string{lastId}, gotIds)
+}
+
 type sortableUsers struct {
 	users []*pb.User
 }
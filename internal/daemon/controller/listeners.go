@@ -77,10 +77,14 @@ func (c *Controller) startListeners() error {
 func (c *Controller) configureForApi(ln *base.ServerListener) ([]func(), error) {
 	apiServers := make([]func(), 0)
 
-	handler, err := c.apiHandler(HandlerProperties{
+	props := HandlerProperties{
 		ListenerConfig: ln.Config,
 		CancelCtx:      c.baseContext,
-	})
+	}
+	if ln.ClientAuth != nil {
+		props.ClientCertAuth = ln.ClientAuth.CertAuth
+	}
+	handler, err := c.apiHandler(props)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +212,7 @@ func (c *Controller) configureForCluster(ln *base.ServerListener) (func(), error
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				workerReqInterceptor,
+				maintenanceInterceptor(c),               // reject requests while sessions are frozen
 				auditRequestInterceptor(c.baseContext),  // before we get started, audit the request
 				auditResponseInterceptor(c.baseContext), // as we finish, audit the response
 			),
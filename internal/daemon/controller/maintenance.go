@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maintenanceExemptActions are the actions of the mutating api requests which
+// are still served in maintenance mode, unless sessions are frozen.
+var maintenanceExemptActions = []string{
+	":authorize-session",
+	":authenticate",
+}
+
+// maintenanceMode returns the maintenance mode last read from the database,
+// or nil if it has not been read yet.
+func (c *Controller) maintenanceMode() *server.Maintenance {
+	if c.maintenance == nil {
+		return nil
+	}
+	m, _ := c.maintenance.Load().(*server.Maintenance)
+	return m
+}
+
+// refreshMaintenance reads the maintenance mode from the database.
+func (c *Controller) refreshMaintenance(ctx context.Context) error {
+	const op = "controller.(Controller).refreshMaintenance"
+	repo, err := c.ServersRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error fetching repository for maintenance mode"))
+	}
+	m, err := repo.LookupMaintenance(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error reading maintenance mode"))
+	}
+	prev := c.maintenanceMode()
+	c.maintenance.Store(m)
+	switch {
+	case m.Enabled && (prev == nil || !prev.Enabled || prev.FreezeSessions != m.FreezeSessions):
+		event.WriteSysEvent(ctx, op, "maintenance mode enabled", "freeze_sessions", m.FreezeSessions, "message", m.Message)
+	case !m.Enabled && prev != nil && prev.Enabled:
+		event.WriteSysEvent(ctx, op, "maintenance mode disabled")
+	}
+	return nil
+}
+
+// maintenanceMessage returns the message of the errors returned to the
+// requests rejected in maintenance mode.
+func maintenanceMessage(m *server.Maintenance) string {
+	msg := "The controller is in maintenance mode."
+	if m.Message != "" {
+		msg = fmt.Sprintf("%s %s", msg, m.Message)
+	}
+	return msg
+}
+
+// maintenanceRejects reports whether the api request is rejected in the
+// maintenance mode.
+func maintenanceRejects(m *server.Maintenance, r *http.Request) bool {
+	if m == nil || !m.Enabled {
+		return false
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if m.FreezeSessions {
+		return true
+	}
+	for _, a := range maintenanceExemptActions {
+		if strings.HasSuffix(r.URL.Path, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapHandlerWithMaintenance rejects the mutating api requests with a 503
+// status and a Retry-After header while the controllers are in maintenance
+// mode.
+func wrapHandlerWithMaintenance(h http.Handler, c *Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const op = "controller.wrapHandlerWithMaintenance"
+		m := c.maintenanceMode()
+		if !maintenanceRejects(m, r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		body, err := json.Marshal(map[string]string{
+			"kind":    codes.Unavailable.String(),
+			"message": maintenanceMessage(m),
+		})
+		if err != nil {
+			event.WriteError(r.Context(), op, err, event.WithInfoMsg("error marshaling maintenance error"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(m.RetryAfter/time.Second), 10))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		if _, err := w.Write(body); err != nil {
+			event.WriteError(r.Context(), op, err, event.WithInfoMsg("error writing maintenance error"))
+		}
+	})
+}
+
+// maintenanceInterceptor rejects the requests of workers while sessions are
+// frozen in maintenance mode.
+func maintenanceInterceptor(c *Controller) grpc.UnaryServerInterceptor {
+	return func(interceptorCtx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if m := c.maintenanceMode(); m != nil && m.Enabled && m.FreezeSessions {
+			return nil, status.Error(codes.Unavailable, maintenanceMessage(m))
+		}
+		return handler(interceptorCtx, req)
+	}
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapHandlerWithMaintenance(t *testing.T) {
+	t.Parallel()
+	apiMaintenance := &server.Maintenance{Enabled: true, RetryAfter: 90 * time.Second, Message: "Upgrading."}
+	frozen := &server.Maintenance{Enabled: true, FreezeSessions: true, RetryAfter: time.Minute}
+
+	tests := []struct {
+		name        string
+		maintenance *server.Maintenance
+		method      string
+		path        string
+		wantBlocked bool
+	}{
+		{
+			name:   "not-loaded",
+			method: http.MethodPost,
+			path:   "/v1/scopes",
+		},
+		{
+			name:        "disabled",
+			maintenance: &server.Maintenance{RetryAfter: time.Minute},
+			method:      http.MethodDelete,
+			path:        "/v1/scopes/o_1234567890",
+		},
+		{
+			name:        "read",
+			maintenance: frozen,
+			method:      http.MethodGet,
+			path:        "/v1/scopes/o_1234567890",
+		},
+		{
+			name:        "create",
+			maintenance: apiMaintenance,
+			method:      http.MethodPost,
+			path:        "/v1/scopes",
+			wantBlocked: true,
+		},
+		{
+			name:        "update",
+			maintenance: apiMaintenance,
+			method:      http.MethodPatch,
+			path:        "/v1/scopes/o_1234567890",
+			wantBlocked: true,
+		},
+		{
+			name:        "delete",
+			maintenance: apiMaintenance,
+			method:      http.MethodDelete,
+			path:        "/v1/scopes/o_1234567890",
+			wantBlocked: true,
+		},
+		{
+			name:        "authorize-session",
+			maintenance: apiMaintenance,
+			method:      http.MethodPost,
+			path:        "/v1/targets/ttcp_1234567890:authorize-session",
+		},
+		{
+			name:        "authenticate",
+			maintenance: apiMaintenance,
+			method:      http.MethodPost,
+			path:        "/v1/auth-methods/ampw_1234567890:authenticate",
+		},
+		{
+			name:        "frozen-authorize-session",
+			maintenance: frozen,
+			method:      http.MethodPost,
+			path:        "/v1/targets/ttcp_1234567890:authorize-session",
+			wantBlocked: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			c := &Controller{maintenance: new(atomic.Value)}
+			if tt.maintenance != nil {
+				c.maintenance.Store(tt.maintenance)
+			}
+			var served bool
+			h := wrapHandlerWithMaintenance(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+			}), c)
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if !tt.wantBlocked {
+				assert.True(served)
+				return
+			}
+			assert.False(served)
+			assert.Equal(http.StatusServiceUnavailable, rec.Code)
+			assert.Equal("application/json", rec.Header().Get("Content-Type"))
+			assert.Equal(strconv.Itoa(int(tt.maintenance.RetryAfter/time.Second)), rec.Header().Get("Retry-After"))
+			var body map[string]string
+			require.NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal("Unavailable", body["kind"])
+			assert.Contains(body["message"], "maintenance mode")
+			assert.Contains(body["message"], tt.maintenance.Message)
+		})
+	}
+}
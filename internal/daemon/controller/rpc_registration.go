@@ -34,7 +34,7 @@ func registerControllerServerCoordinationService(ctx context.Context, c *Control
 	}
 
 	workerService := handlers.NewWorkerServiceServer(c.ServersRepoFn, c.SessionRepoFn, c.ConnectionRepoFn,
-		c.workerStatusUpdateTimes, c.kms)
+		c.StaticHostRepoFn, c.PluginHostRepoFn, c.HostHealthRepoFn, c.workerStatusUpdateTimes, c.kms)
 	pbs.RegisterServerCoordinationServiceServer(server, workerService)
 	return nil
 }
@@ -52,7 +52,7 @@ func registerControllerSessionService(ctx context.Context, c *Controller, server
 	}
 
 	workerService := handlers.NewWorkerServiceServer(c.ServersRepoFn, c.SessionRepoFn, c.ConnectionRepoFn,
-		c.workerStatusUpdateTimes, c.kms)
+		c.StaticHostRepoFn, c.PluginHostRepoFn, c.HostHealthRepoFn, c.workerStatusUpdateTimes, c.kms)
 	pbs.RegisterSessionServiceServer(server, workerService)
 	return nil
 }
@@ -554,7 +554,7 @@ func TestControllerConfig(t testing.TB, ctx context.Context, tc *TestController,
 	tc.b.DevUnprivilegedOidcAccountId = DefaultTestUnprivilegedOidcAccountId
 	tc.b.DevLoopbackHostPluginId = DefaultTestPluginId
 
-	tc.b.EnabledPlugins = append(tc.b.EnabledPlugins, base.EnabledPluginHostLoopback)
+	tc.b.EnabledPlugins = append(tc.b.EnabledPlugins, base.EnabledPluginHostLoopback, base.EnabledPluginCredentialLoopback)
 
 	// Start a logger
 	tc.b.Logger = opts.Logger
@@ -654,7 +654,7 @@ func TestControllerConfig(t testing.TB, ctx context.Context, tc *TestController,
 	for _, listener := range opts.Config.Listeners {
 		listener.RandomPort = true
 	}
-	if err := tc.b.SetupListeners(nil, opts.Config.SharedConfig, []string{"api", "cluster", "ops"}); err != nil {
+	if err := tc.b.SetupListeners(nil, opts.Config.SharedConfig, []string{"api", "cluster", "ops"}, base.WithListenerClientAuth(opts.Config.ListenerClientAuth)); err != nil {
 		t.Fatal(err)
 	}
 	if err := opts.Config.SetupControllerPublicClusterAddress(""); err != nil {
@@ -15,6 +15,8 @@ import (
 const (
 	statusInterval      = 10 * time.Second
 	terminationInterval = 1 * time.Minute
+	maintenanceInterval = 5 * time.Second
+	kmsReloadInterval   = 1 * time.Minute
 )
 
 // This is exported so it can be tweaked in tests
@@ -38,6 +40,45 @@ func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 	}
 }
 
+func (c *Controller) startMaintenanceTicking(cancelCtx context.Context) {
+	const op = "controller.(Controller).startMaintenanceTicking"
+	timer := time.NewTimer(maintenanceInterval)
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(cancelCtx, op, "maintenance ticking shutting down")
+			return
+
+		case <-timer.C:
+			if err := c.refreshMaintenance(cancelCtx); err != nil {
+				event.WriteError(cancelCtx, op, err)
+			}
+			timer.Reset(maintenanceInterval)
+		}
+	}
+}
+
+// startKmsReloadTicking periodically reloads the kms wrappers of the scopes
+// whose keys have been rotated, since the keys may have been rotated by
+// another controller.
+func (c *Controller) startKmsReloadTicking(cancelCtx context.Context) {
+	const op = "controller.(Controller).startKmsReloadTicking"
+	timer := time.NewTimer(kmsReloadInterval)
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(cancelCtx, op, "kms reload ticking shutting down")
+			return
+
+		case <-timer.C:
+			if err := c.kms.ReloadRotatedWrappers(cancelCtx); err != nil {
+				event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error reloading kms wrappers"))
+			}
+			timer.Reset(kmsReloadInterval)
+		}
+	}
+}
+
 func (c *Controller) upsertController(ctx context.Context) error {
 	const op = "controller.(Controller).upsertController"
 	controller := &store.Controller{
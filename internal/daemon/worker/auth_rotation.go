@@ -136,7 +136,7 @@ func rotateWorkerAuth(ctx context.Context, w *Worker, currentNodeCreds *types.No
 		ctx,
 		w.WorkerAuthStorage,
 		randReaderOpt,
-		nodeenrollment.WithWrapper(w.conf.WorkerAuthKms),
+		nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms),
 		nodeenrollment.WithSkipStorage(true),
 	)
 	if err != nil {
@@ -181,7 +181,7 @@ func rotateWorkerAuth(ctx context.Context, w *Worker, currentNodeCreds *types.No
 		ctx,
 		w.WorkerAuthStorage,
 		fetchResp,
-		nodeenrollment.WithWrapper(w.conf.WorkerAuthKms),
+		nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms),
 	)
 	if err != nil {
 		return berrors.Wrap(ctx, err, op)
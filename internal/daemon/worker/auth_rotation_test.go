@@ -88,6 +88,8 @@ func TestRotationTicking(t *testing.T) {
 	currNodeCreds, err := types.LoadNodeCredentials(w.Context(), w.Worker().WorkerAuthStorage, nodeenrollment.CurrentId, nodeenrollment.WithWrapper(w.Config().WorkerAuthStorageKms))
 	require.NoError(err)
 	currKey := currNodeCreds.CertificatePublicKeyPkix
+	storageKeyId, err := w.Config().WorkerAuthStorageKms.KeyId(w.Context())
+	require.NoError(err)
 
 	// Now we wait and check that we see new values in the DB and different
 	// creds on the worker after each rotation period
@@ -109,6 +111,12 @@ func TestRotationTicking(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(currKeyId, w.Worker().WorkerAuthCurrentKeyId.Load())
 
+		// The rotated credentials are still encrypted with the
+		// worker-auth-storage KMS
+		stored := &types.NodeCredentials{Id: string(nodeenrollment.CurrentId)}
+		require.NoError(w.Worker().WorkerAuthStorage.Load(w.Context(), stored))
+		assert.Equal(storageKeyId, stored.WrappingKeyId)
+
 		// Stop and start the client connections to ensure the new credentials
 		// are valid; if not, we won't establish a new connection and rotation
 		// will fail
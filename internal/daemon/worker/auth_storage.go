@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/internal/vaultstorage"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/nodeenrollment"
+	nodeefile "github.com/hashicorp/nodeenrollment/storage/file"
+	"github.com/hashicorp/nodeenrollment/types"
+)
+
+// AuthStorage is the storage of the node credentials of a worker
+type AuthStorage interface {
+	nodeenrollment.Storage
+
+	// BaseDir returns the directory of the storage, or an empty string if
+	// the storage is not file based.
+	BaseDir() string
+
+	// Cleanup removes the storage if it is temporary.
+	Cleanup()
+}
+
+// nodeCredsIds are the ids of the node credentials a worker can store.
+var nodeCredsIds = []nodeenrollment.KnownId{nodeenrollment.CurrentId, nodeenrollment.NextId}
+
+// setupAuthStorage sets the storage of the node credentials of the worker.
+// The credentials are stored in Vault when configured, otherwise in files.
+// Existing credentials are migrated: credentials found in files are moved to
+// Vault, and plaintext credentials are encrypted if a worker-auth-storage KMS
+// is configured.
+func (w *Worker) setupAuthStorage(ctx context.Context) error {
+	const op = "worker.(Worker).setupAuthStorage"
+	storagePath := w.conf.RawConfig.Worker.AuthStoragePath
+	vaultConf := w.conf.RawConfig.Worker.AuthStorageVault
+
+	if vaultConf == nil {
+		s, err := nodeefile.New(ctx, nodeefile.WithBaseDirectory(storagePath))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("error loading worker auth storage directory"))
+		}
+		w.WorkerAuthStorage = s
+		return w.encryptPlaintextNodeCreds(ctx)
+	}
+
+	s, err := vaultstorage.New(ctx, vaultConf)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error creating worker auth vault storage"))
+	}
+	w.WorkerAuthStorage = s
+	if storagePath != "" {
+		fileStorage, err := nodeefile.New(ctx, nodeefile.WithBaseDirectory(storagePath))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("error loading worker auth storage directory"))
+		}
+		if err := w.moveNodeCreds(ctx, fileStorage, s); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return w.encryptPlaintextNodeCreds(ctx)
+}
+
+// moveNodeCreds moves the node credentials found in the source storage to the
+// destination storage, unless the destination already has credentials with
+// the same id. The credentials are removed from the source storage.
+func (w *Worker) moveNodeCreds(ctx context.Context, src, dst nodeenrollment.Storage) error {
+	const op = "worker.(Worker).moveNodeCreds"
+	for _, id := range nodeCredsIds {
+		creds := &types.NodeCredentials{Id: string(id)}
+		switch err := src.Load(ctx, creds); {
+		case errors.Is(err, nodeenrollment.ErrNotFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("(%s) error loading %s worker auth creds: %w", op, id, err)
+		}
+		switch err := dst.Load(ctx, &types.NodeCredentials{Id: string(id)}); {
+		case errors.Is(err, nodeenrollment.ErrNotFound):
+			// The creds are stored as they are; they are encrypted later if
+			// they are not already.
+			if err := dst.Store(ctx, creds); err != nil {
+				return fmt.Errorf("(%s) error storing %s worker auth creds: %w", op, id, err)
+			}
+			event.WriteSysEvent(ctx, op, "moved worker auth creds to vault", "id", id)
+		case err != nil:
+			return fmt.Errorf("(%s) error loading %s worker auth creds: %w", op, id, err)
+		default:
+			event.WriteSysEvent(ctx, op, "worker auth creds already in vault, removing creds from storage directory", "id", id)
+		}
+		if err := src.Remove(ctx, creds); err != nil {
+			return fmt.Errorf("(%s) error removing %s worker auth creds from storage directory: %w", op, id, err)
+		}
+	}
+	return nil
+}
+
+// encryptPlaintextNodeCreds encrypts the stored node credentials with the
+// worker-auth-storage KMS, if configured, when they were stored in
+// plaintext.
+func (w *Worker) encryptPlaintextNodeCreds(ctx context.Context) error {
+	const op = "worker.(Worker).encryptPlaintextNodeCreds"
+	if w.conf.WorkerAuthStorageKms == nil {
+		return nil
+	}
+	for _, id := range nodeCredsIds {
+		creds, err := types.LoadNodeCredentials(ctx, w.WorkerAuthStorage, id, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms))
+		switch {
+		case errors.Is(err, nodeenrollment.ErrNotFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("(%s) error loading %s worker auth creds: %w", op, id, err)
+		case creds.WrappingKeyId != "":
+			continue
+		}
+		if err := creds.Store(ctx, w.WorkerAuthStorage, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms)); err != nil {
+			return fmt.Errorf("(%s) error encrypting %s worker auth creds: %w", op, id, err)
+		}
+		event.WriteSysEvent(ctx, op, "encrypted plaintext worker auth creds with the worker-auth-storage kms", "id", id)
+	}
+	return nil
+}
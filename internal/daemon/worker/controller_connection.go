@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/go-secure-stdlib/base62"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/multihop"
@@ -47,9 +48,9 @@ func (w *Worker) StartControllerConnections() error {
 		case strings.HasPrefix(addr, "/"):
 			initialAddrs = append(initialAddrs, addr)
 		default:
-			host, port, err := net.SplitHostPort(addr)
-			if err != nil && strings.Contains(err.Error(), "missing port in address") {
-				host, port, err = net.SplitHostPort(net.JoinHostPort(addr, "9201"))
+			host, port, err := util.SplitHostPort(addr)
+			if errors.Is(err, util.ErrMissingPort) {
+				port, err = "9201", nil
 			}
 			if err != nil {
 				return fmt.Errorf("error parsing upstream address: %w", err)
@@ -60,7 +61,7 @@ func (w *Worker) StartControllerConnections() error {
 
 	if len(initialAddrs) == 0 {
 		if w.conf.RawConfig.HcpbClusterId != "" {
-			clusterAddress := fmt.Sprintf("%s%s", w.conf.RawConfig.HcpbClusterId, hcpbUrlSuffix)
+			clusterAddress := hcpbClusterAddress(w.conf.RawConfig.HcpbClusterId)
 			initialAddrs = append(initialAddrs, clusterAddress)
 			event.WriteSysEvent(w.baseContext, op, fmt.Sprintf("Setting HCP Boundary cluster address %s as upstream address", clusterAddress))
 		} else {
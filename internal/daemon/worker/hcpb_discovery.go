@@ -0,0 +1,295 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/common"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// hcpbDiscoveryMinInterval and hcpbDiscoveryMaxInterval bound the time
+	// between two resolutions of the HCP Boundary cluster address, which is
+	// otherwise the ttl of its DNS records. The max interval is also used when
+	// the ttl is not known, e.g. when the address is in the hosts file.
+	hcpbDiscoveryMinInterval = 5 * time.Second
+	hcpbDiscoveryMaxInterval = 30 * time.Second
+
+	// hcpbDiscoveryMaxBackoff is the maximum time between two resolutions of
+	// the HCP Boundary cluster address when they fail.
+	hcpbDiscoveryMaxBackoff = 2 * time.Minute
+)
+
+// hcpbLookupFn resolves a host to its addresses and the ttl of its DNS
+// records. It is a variable so tests can override it.
+var hcpbLookupFn = lookupHostTtl
+
+// hcpbClusterAddress returns the upstream address of the HCP Boundary cluster.
+func hcpbClusterAddress(clusterId string) string {
+	return fmt.Sprintf("%s%s", clusterId, hcpbUrlSuffix)
+}
+
+// hcpbManaged reports whether the upstreams of the worker are discovered from
+// its HCP Boundary cluster rather than configured.
+func (w *Worker) hcpbManaged() bool {
+	return w.conf.RawConfig.HcpbClusterId != "" && len(w.conf.RawConfig.Worker.InitialUpstreams) == 0
+}
+
+// startHcpbDiscoveryTicking periodically resolves the address of the HCP
+// Boundary cluster of the worker, at the ttl of its DNS records, and backs off
+// when resolving fails. When the addresses of the cluster change while the
+// worker can not reach its upstreams, the worker reconnects right away rather
+// than waiting for its connection backoff, so workers recover when the
+// addresses of the managed cluster rotate.
+func (w *Worker) startHcpbDiscoveryTicking(cancelCtx context.Context) {
+	const op = "worker.(Worker).startHcpbDiscoveryTicking"
+	if !w.hcpbManaged() {
+		return
+	}
+	clusterAddress := hcpbClusterAddress(w.conf.RawConfig.HcpbClusterId)
+	host, _, err := net.SplitHostPort(clusterAddress)
+	if err != nil {
+		event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error parsing HCP Boundary cluster address", "cluster_address", clusterAddress))
+		return
+	}
+
+	var prevAddrs []string
+	var failures int
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(w.baseContext, op, "HCP Boundary cluster discovery ticking shutting down")
+			return
+
+		case <-timer.C:
+		}
+
+		lookupCtx, lookupCancel := context.WithTimeout(cancelCtx, common.StatusTimeout)
+		addrs, ttl, err := hcpbLookupFn(lookupCtx, host)
+		lookupCancel()
+		var next time.Duration
+		switch {
+		case err != nil:
+			failures++
+			next = hcpbDiscoveryBackoff(failures)
+			event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error resolving HCP Boundary cluster address",
+				"cluster_address", clusterAddress,
+				"failures", failures,
+				"retry_in", next.String(),
+			))
+
+		default:
+			if failures > 0 {
+				event.WriteSysEvent(cancelCtx, op, "resolved HCP Boundary cluster address after failures", "cluster_address", clusterAddress, "failures", failures)
+			}
+			failures = 0
+			next = hcpbDiscoveryInterval(ttl)
+			sort.Strings(addrs)
+			if prevAddrs != nil && !strutil.EquivalentSlices(prevAddrs, addrs) {
+				event.WriteSysEvent(cancelCtx, op, fmt.Sprintf("HCP Boundary cluster addresses have changed; old addresses were: %s, new addresses are: %s", prevAddrs, addrs),
+					"cluster_address", clusterAddress)
+				if w.hcpbUpstreamFallback.Load() && w.GrpcClientConn != nil {
+					event.WriteSysEvent(cancelCtx, op, "reconnecting to HCP Boundary cluster", "cluster_address", clusterAddress)
+					w.GrpcClientConn.ResetConnectBackoff()
+				}
+			}
+			prevAddrs = addrs
+		}
+		timer.Reset(next)
+	}
+}
+
+// fallBackToHcpbCluster adds the HCP Boundary cluster address to the upstreams
+// of the worker, which otherwise are the managed workers of the cluster the
+// worker learned from its last successful status. It is called when the
+// worker can not reach its upstreams, as the managed workers may have been
+// replaced since then. The upstreams are set back to the managed workers on
+// the next successful status.
+func (w *Worker) fallBackToHcpbCluster(cancelCtx context.Context, addressReceivers *[]addressReceiver) {
+	const op = "worker.(Worker).fallBackToHcpbCluster"
+	if !w.hcpbManaged() || !w.hcpbUpstreamFallback.CAS(false, true) {
+		return
+	}
+	clusterAddress := hcpbClusterAddress(w.conf.RawConfig.HcpbClusterId)
+	addrs := []string{clusterAddress}
+	if lastStatus := w.LastStatusSuccess(); lastStatus != nil {
+		for _, addr := range lastStatus.LastCalculatedUpstreams {
+			if addr != clusterAddress {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	for _, as := range *addressReceivers {
+		as.SetAddresses(addrs)
+	}
+	event.WriteSysEvent(cancelCtx, op, fmt.Sprintf("Unable to reach upstreams; falling back to HCP Boundary cluster, upstreams are: %s", addrs))
+}
+
+// hcpbDiscoveryInterval returns the time until the next resolution of the HCP
+// Boundary cluster address given the ttl of its DNS records, negative if
+// unknown.
+func hcpbDiscoveryInterval(ttl time.Duration) time.Duration {
+	switch {
+	case ttl < 0:
+		return hcpbDiscoveryMaxInterval
+	case ttl < hcpbDiscoveryMinInterval:
+		return hcpbDiscoveryMinInterval
+	case ttl > hcpbDiscoveryMaxInterval:
+		return hcpbDiscoveryMaxInterval
+	default:
+		return ttl
+	}
+}
+
+// hcpbDiscoveryBackoff returns the time until the next resolution of the HCP
+// Boundary cluster address after the given number of consecutive failures. It
+// doubles from one second up to hcpbDiscoveryMaxBackoff, with some jitter so
+// the workers of an autoscaling group do not retry in lockstep.
+func hcpbDiscoveryBackoff(failures int) time.Duration {
+	backoff := hcpbDiscoveryMaxBackoff
+	if failures < 32 {
+		backoff = time.Duration(math.Min(float64(time.Second)*math.Pow(2, float64(failures-1)), float64(hcpbDiscoveryMaxBackoff)))
+	}
+	jitter := time.Duration(mathrand.Int63n(int64(backoff/5) + 1))
+	return backoff - backoff/10 + jitter
+}
+
+// lookupHostTtl resolves host with the go resolver and returns its addresses
+// along with the lowest ttl of the DNS records in the responses of the
+// nameservers, which the resolver does not expose. The ttl is negative when
+// no nameserver was queried, e.g. when the host is in the hosts file.
+func lookupHostTtl(ctx context.Context, host string) ([]string, time.Duration, error) {
+	var rec ttlRecorder
+	var dialer net.Dialer
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+			return rec.wrap(conn), nil
+		},
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, 0, err
+	}
+	return addrs, rec.ttl(), nil
+}
+
+// ttlRecorder records the lowest ttl of the answers of the DNS responses read
+// from the connections it wraps.
+type ttlRecorder struct {
+	mu    sync.Mutex
+	min   uint32
+	found bool
+}
+
+// wrap returns a connection which records the ttls of the DNS responses read
+// from conn. The go resolver uses connections implementing net.PacketConn,
+// such as udp connections, as carrying a single message per read and other
+// connections as streams of length prefixed messages.
+func (r *ttlRecorder) wrap(conn net.Conn) net.Conn {
+	if pc, ok := conn.(net.PacketConn); ok {
+		return &ttlPacketConn{Conn: conn, pc: pc, rec: r}
+	}
+	return &ttlStreamConn{Conn: conn, rec: r}
+}
+
+// ttl returns the lowest ttl recorded, -1 if none was.
+func (r *ttlRecorder) ttl() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.found {
+		return -1
+	}
+	return time.Duration(r.min) * time.Second
+}
+
+// record records the ttls of the answers of the DNS message msg. Messages
+// which can not be parsed are ignored, the resolver reports the errors.
+func (r *ttlRecorder) record(msg []byte) {
+	var p dnsmessage.Parser
+	if _, err := p.Start(msg); err != nil {
+		return
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return
+	}
+	for {
+		h, err := p.AnswerHeader()
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		if !r.found || h.TTL < r.min {
+			r.min, r.found = h.TTL, true
+		}
+		r.mu.Unlock()
+		if err := p.SkipAnswer(); err != nil {
+			return
+		}
+	}
+}
+
+// ttlPacketConn records the ttls of the DNS messages read from a packet
+// connection.
+type ttlPacketConn struct {
+	net.Conn
+	pc  net.PacketConn
+	rec *ttlRecorder
+}
+
+func (c *ttlPacketConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.rec.record(b[:n])
+	}
+	return n, err
+}
+
+func (c *ttlPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, addr, err := c.pc.ReadFrom(b)
+	if n > 0 {
+		c.rec.record(b[:n])
+	}
+	return n, addr, err
+}
+
+func (c *ttlPacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	return c.pc.WriteTo(b, addr)
+}
+
+// ttlStreamConn records the ttls of the length prefixed DNS messages read from
+// a stream connection.
+type ttlStreamConn struct {
+	net.Conn
+	rec *ttlRecorder
+	buf []byte
+}
+
+func (c *ttlStreamConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.buf = append(c.buf, b[:n]...)
+	for len(c.buf) >= 2 {
+		l := int(c.buf[0])<<8 | int(c.buf[1])
+		if len(c.buf) < 2+l {
+			break
+		}
+		c.rec.record(c.buf[2 : 2+l])
+		c.buf = c.buf[2+l:]
+	}
+	return n, err
+}
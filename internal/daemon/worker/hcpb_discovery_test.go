@@ -0,0 +1,207 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ua "go.uber.org/atomic"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type testAddressReceiver struct {
+	addrs []string
+}
+
+func (r *testAddressReceiver) InitialAddresses(addrs []string) { r.addrs = addrs }
+func (r *testAddressReceiver) SetAddresses(addrs []string)     { r.addrs = addrs }
+func (r *testAddressReceiver) Type() receiverType              { return UnknownReceiverType }
+
+func TestHcpbDiscoveryBackoff(t *testing.T) {
+	for failures, want := range map[int]time.Duration{
+		1:   time.Second,
+		2:   2 * time.Second,
+		4:   8 * time.Second,
+		10:  hcpbDiscoveryMaxBackoff,
+		100: hcpbDiscoveryMaxBackoff,
+	} {
+		got := hcpbDiscoveryBackoff(failures)
+		assert.GreaterOrEqual(t, got, want-want/10, "failures %d", failures)
+		assert.LessOrEqual(t, got, want+want/10, "failures %d", failures)
+	}
+}
+
+func TestHcpbDiscoveryInterval(t *testing.T) {
+	assert.Equal(t, hcpbDiscoveryMaxInterval, hcpbDiscoveryInterval(-1))
+	assert.Equal(t, hcpbDiscoveryMinInterval, hcpbDiscoveryInterval(0))
+	assert.Equal(t, hcpbDiscoveryMinInterval, hcpbDiscoveryInterval(time.Second))
+	assert.Equal(t, 20*time.Second, hcpbDiscoveryInterval(20*time.Second))
+	assert.Equal(t, hcpbDiscoveryMaxInterval, hcpbDiscoveryInterval(24*time.Hour))
+}
+
+// testDnsResponse returns a DNS response with a CNAME record and two A records
+// whose lowest ttl is 45 seconds.
+func testDnsResponse(t *testing.T) []byte {
+	t.Helper()
+	name := dnsmessage.MustNewName("cluster.example.com.")
+	target := dnsmessage.MustNewName("lb.example.com.")
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 1, Response: true})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}))
+	require.NoError(t, b.StartAnswers())
+	require.NoError(t, b.CNAMEResource(dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: 300}, dnsmessage.CNAMEResource{CNAME: target}))
+	require.NoError(t, b.AResource(dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}}))
+	require.NoError(t, b.AResource(dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 45}, dnsmessage.AResource{A: [4]byte{10, 0, 0, 2}}))
+	msg, err := b.Finish()
+	require.NoError(t, err)
+	return msg
+}
+
+func TestTtlRecorder(t *testing.T) {
+	msg := testDnsResponse(t)
+
+	t.Run("packet", func(t *testing.T) {
+		server, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = server.Close() })
+		conn, err := net.Dial("udp", server.LocalAddr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		var rec ttlRecorder
+		assert.Equal(t, time.Duration(-1), rec.ttl())
+		wrapped := rec.wrap(conn)
+		_, ok := wrapped.(net.PacketConn)
+		require.True(t, ok, "the go resolver needs a net.PacketConn for udp")
+
+		_, err = server.WriteTo(msg, conn.LocalAddr())
+		require.NoError(t, err)
+		buf := make([]byte, 512)
+		n, err := wrapped.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, msg, buf[:n])
+		assert.Equal(t, 45*time.Second, rec.ttl())
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		client, server := net.Pipe()
+		t.Cleanup(func() { _ = client.Close(); _ = server.Close() })
+
+		var rec ttlRecorder
+		wrapped := rec.wrap(client)
+		_, ok := wrapped.(net.PacketConn)
+		require.False(t, ok)
+
+		framed := append([]byte{byte(len(msg) >> 8), byte(len(msg))}, msg...)
+		go func() { _, _ = server.Write(framed) }()
+		// Read the message in small chunks, as the ttl is only recorded once
+		// the whole message has been read.
+		var got []byte
+		buf := make([]byte, 7)
+		for len(got) < len(framed) {
+			n, err := wrapped.Read(buf)
+			require.NoError(t, err)
+			got = append(got, buf[:n]...)
+			if len(got) < len(framed) {
+				assert.Equal(t, time.Duration(-1), rec.ttl())
+			}
+		}
+		assert.Equal(t, framed, got)
+		assert.Equal(t, 45*time.Second, rec.ttl())
+	})
+}
+
+func TestStartHcpbDiscoveryTicking(t *testing.T) {
+	const clusterId = "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d"
+	lookups := make(chan string, 10)
+	prevLookupFn := hcpbLookupFn
+	t.Cleanup(func() { hcpbLookupFn = prevLookupFn })
+	var calls int
+	hcpbLookupFn = func(_ context.Context, host string) ([]string, time.Duration, error) {
+		lookups <- host
+		calls++
+		if calls == 1 {
+			return nil, 0, errors.New("no such host")
+		}
+		return []string{"10.0.0.1"}, time.Minute, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Worker{
+		baseContext: ctx,
+		conf: &Config{
+			RawConfig: &config.Config{
+				HcpbClusterId: clusterId,
+				Worker:        &config.Worker{},
+			},
+		},
+		hcpbUpstreamFallback: ua.NewBool(false),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.startHcpbDiscoveryTicking(ctx)
+	}()
+
+	// The failed lookup is retried after the backoff rather than the interval
+	for i := 0; i < 2; i++ {
+		select {
+		case host := <-lookups:
+			assert.Equal(t, clusterId+".proxy.boundary.hashicorp.cloud", host)
+		case <-time.After(hcpbDiscoveryBackoff(1) + 5*time.Second):
+			t.Fatal("timed out waiting for the HCP Boundary cluster address lookup")
+		}
+	}
+	cancel()
+	<-done
+}
+
+func TestFallBackToHcpbCluster(t *testing.T) {
+	const clusterId = "c1a3f8b4-6c1e-4d58-9d7c-3b8e1f1b2a6d"
+	clusterAddress := hcpbClusterAddress(clusterId)
+	newWorker := func(clusterId string, initialUpstreams ...string) *Worker {
+		w := &Worker{
+			conf: &Config{
+				RawConfig: &config.Config{
+					HcpbClusterId: clusterId,
+					Worker:        &config.Worker{InitialUpstreams: initialUpstreams},
+				},
+			},
+			lastStatusSuccess:    new(atomic.Value),
+			hcpbUpstreamFallback: ua.NewBool(false),
+		}
+		w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
+		return w
+	}
+
+	t.Run("not-hcpb-managed", func(t *testing.T) {
+		for _, w := range []*Worker{newWorker(""), newWorker(clusterId, "127.0.0.1:9201")} {
+			r := &testAddressReceiver{}
+			w.fallBackToHcpbCluster(context.Background(), &[]addressReceiver{r})
+			assert.Nil(t, r.addrs)
+			assert.False(t, w.hcpbUpstreamFallback.Load())
+		}
+	})
+	t.Run("hcpb-managed", func(t *testing.T) {
+		w := newWorker(clusterId)
+		w.lastStatusSuccess.Store(&LastStatusInformation{
+			StatusTime:              time.Now(),
+			LastCalculatedUpstreams: []string{"10.0.0.1:9202", "10.0.0.2:9202"},
+		})
+		r := &testAddressReceiver{}
+		w.fallBackToHcpbCluster(context.Background(), &[]addressReceiver{r})
+		assert.Equal(t, []string{clusterAddress, "10.0.0.1:9202", "10.0.0.2:9202"}, r.addrs)
+		assert.True(t, w.hcpbUpstreamFallback.Load())
+
+		// The upstreams are only set once until the worker reaches them again
+		r.addrs = nil
+		w.fallBackToHcpbCluster(context.Background(), &[]addressReceiver{r})
+		assert.Nil(t, r.addrs)
+	})
+}
@@ -0,0 +1,204 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host/health"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// hostHealthCheckTickInterval is how often the worker looks for host
+	// health checks which are due.
+	hostHealthCheckTickInterval = time.Second
+
+	// hostHealthCheckTimeout is the maximum duration of a single probe.
+	hostHealthCheckTimeout = 5 * time.Second
+
+	// maxPendingHostHealthCheckResults bounds the number of results kept
+	// while the controller cannot be reached. The oldest results are dropped
+	// first.
+	maxPendingHostHealthCheckResults = 1000
+)
+
+// hostHealthCheckState is a host health check assigned to the worker along
+// with the time it is due.
+type hostHealthCheckState struct {
+	check   *pbs.HostHealthCheck
+	nextRun time.Time
+	running bool
+}
+
+// hostHealthChecker runs the host health checks assigned to the worker by
+// the controller and buffers their results until the next status request.
+type hostHealthChecker struct {
+	mu      sync.Mutex
+	checks  map[string]*hostHealthCheckState
+	results []*pbs.HostHealthCheckResult
+
+	// probeFn runs a probe, it is only replaced in tests.
+	probeFn func(context.Context, *pbs.HostHealthCheck) error
+}
+
+func newHostHealthChecker() *hostHealthChecker {
+	return &hostHealthChecker{
+		checks:  make(map[string]*hostHealthCheckState),
+		probeFn: probeHost,
+	}
+}
+
+func hostHealthCheckKey(c *pbs.HostHealthCheck) string {
+	return c.GetHostSetId() + "/" + c.GetHostId()
+}
+
+// setChecks replaces the assigned health checks. Checks which were already
+// assigned and did not change keep their schedule, new or changed ones are
+// due immediately.
+func (hc *hostHealthChecker) setChecks(checks []*pbs.HostHealthCheck) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	now := time.Now()
+	updated := make(map[string]*hostHealthCheckState, len(checks))
+	for _, c := range checks {
+		key := hostHealthCheckKey(c)
+		if existing, ok := hc.checks[key]; ok && proto.Equal(existing.check, c) {
+			updated[key] = existing
+			continue
+		}
+		updated[key] = &hostHealthCheckState{check: c, nextRun: now}
+	}
+	hc.checks = updated
+}
+
+// takeResults returns the buffered results and clears them.
+func (hc *hostHealthChecker) takeResults() []*pbs.HostHealthCheckResult {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	ret := hc.results
+	hc.results = nil
+	return ret
+}
+
+// requeueResults puts back results which could not be sent to the
+// controller ahead of the ones buffered since.
+func (hc *hostHealthChecker) requeueResults(results []*pbs.HostHealthCheckResult) {
+	if len(results) == 0 {
+		return
+	}
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	pending := hc.results
+	hc.results = nil
+	hc.addResultsLocked(append(results, pending...)...)
+}
+
+func (hc *hostHealthChecker) addResultsLocked(results ...*pbs.HostHealthCheckResult) {
+	if len(results) > maxPendingHostHealthCheckResults {
+		results = results[len(results)-maxPendingHostHealthCheckResults:]
+	}
+	if overflow := len(hc.results) + len(results) - maxPendingHostHealthCheckResults; overflow > 0 {
+		hc.results = hc.results[overflow:]
+	}
+	hc.results = append(hc.results, results...)
+}
+
+// runDue starts the probes of the health checks which are due. Each probe
+// runs in its own goroutine tracked by wg.
+func (hc *hostHealthChecker) runDue(ctx context.Context, wg *sync.WaitGroup) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	now := time.Now()
+	for _, s := range hc.checks {
+		if s.running || now.Before(s.nextRun) {
+			continue
+		}
+		s.running = true
+		s.nextRun = now.Add(time.Duration(s.check.GetIntervalSeconds()) * time.Second)
+		wg.Add(1)
+		go func(s *hostHealthCheckState) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, hostHealthCheckTimeout)
+			err := hc.probeFn(probeCtx, s.check)
+			cancel()
+			res := &pbs.HostHealthCheckResult{
+				HostSetId: s.check.GetHostSetId(),
+				HostId:    s.check.GetHostId(),
+				Healthy:   err == nil,
+			}
+			if err != nil {
+				res.Error = err.Error()
+			}
+
+			hc.mu.Lock()
+			defer hc.mu.Unlock()
+			s.running = false
+			if ctx.Err() != nil {
+				// The probe was interrupted by the worker shutting down.
+				return
+			}
+			hc.addResultsLocked(res)
+		}(s)
+	}
+}
+
+// probeHost runs a tcp or http probe against the address of the check.
+func probeHost(ctx context.Context, c *pbs.HostHealthCheck) error {
+	switch c.GetProtocol() {
+	case health.ProtocolTcp:
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", c.GetAddress())
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case health.ProtocolHttp:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s%s", c.GetAddress(), c.GetPath()), nil)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{
+			// Redirects are considered healthy and not followed.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown protocol %q", c.GetProtocol())
+	}
+}
+
+// startHostHealthCheckTicking runs the due host health checks until the
+// context is canceled.
+func (w *Worker) startHostHealthCheckTicking(cancelCtx context.Context) {
+	const op = "worker.(Worker).startHostHealthCheckTicking"
+	var probesWg sync.WaitGroup
+	ticker := time.NewTicker(hostHealthCheckTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(w.baseContext, op, "host health check ticking shutting down")
+			probesWg.Wait()
+			return
+
+		case <-ticker.C:
+			w.hostHealthChecker.runDue(cancelCtx, &probesWg)
+		}
+	}
+}
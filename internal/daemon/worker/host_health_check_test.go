@@ -0,0 +1,206 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeHost(t *testing.T) {
+	ctx := context.Background()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/redirect":
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	srvAddr := strings.TrimPrefix(srv.URL, "http://")
+
+	tests := []struct {
+		name      string
+		check     *pbs.HostHealthCheck
+		wantErr   bool
+		wantErrIs string
+	}{
+		{
+			name:  "tcp-open",
+			check: &pbs.HostHealthCheck{Protocol: health.ProtocolTcp, Address: l.Addr().String()},
+		},
+		{
+			name:    "tcp-closed",
+			check:   &pbs.HostHealthCheck{Protocol: health.ProtocolTcp, Address: closedAddr},
+			wantErr: true,
+		},
+		{
+			name:  "http-ok",
+			check: &pbs.HostHealthCheck{Protocol: health.ProtocolHttp, Address: srvAddr, Path: "/ok"},
+		},
+		{
+			name:  "http-redirect",
+			check: &pbs.HostHealthCheck{Protocol: health.ProtocolHttp, Address: srvAddr, Path: "/redirect"},
+		},
+		{
+			name:      "http-unavailable",
+			check:     &pbs.HostHealthCheck{Protocol: health.ProtocolHttp, Address: srvAddr, Path: "/down"},
+			wantErr:   true,
+			wantErrIs: "unexpected status code 503",
+		},
+		{
+			name:    "http-closed",
+			check:   &pbs.HostHealthCheck{Protocol: health.ProtocolHttp, Address: closedAddr, Path: "/"},
+			wantErr: true,
+		},
+		{
+			name:      "unknown-protocol",
+			check:     &pbs.HostHealthCheck{Protocol: "udp", Address: l.Addr().String()},
+			wantErr:   true,
+			wantErrIs: `unknown protocol "udp"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := probeHost(ctx, tt.check)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrIs != "" {
+					assert.Contains(t, err.Error(), tt.wantErrIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHostHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	hc := newHostHealthChecker()
+	var mu sync.Mutex
+	probed := make(map[string]int)
+	hc.probeFn = func(_ context.Context, c *pbs.HostHealthCheck) error {
+		mu.Lock()
+		defer mu.Unlock()
+		probed[c.GetHostId()]++
+		if c.GetHostId() == "h_unhealthy" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	hc.setChecks([]*pbs.HostHealthCheck{
+		{HostSetId: "hs_1", HostId: "h_healthy", Protocol: health.ProtocolTcp, Address: "a:1", IntervalSeconds: 60},
+		{HostSetId: "hs_1", HostId: "h_unhealthy", Protocol: health.ProtocolTcp, Address: "b:1", IntervalSeconds: 60},
+	})
+
+	var wg sync.WaitGroup
+	hc.runDue(ctx, &wg)
+	wg.Wait()
+
+	results := hc.takeResults()
+	require.Len(results, 2)
+	got := make(map[string]*pbs.HostHealthCheckResult)
+	for _, r := range results {
+		got[r.GetHostId()] = r
+	}
+	assert.True(got["h_healthy"].GetHealthy())
+	assert.Empty(got["h_healthy"].GetError())
+	assert.False(got["h_unhealthy"].GetHealthy())
+	assert.Equal("connection refused", got["h_unhealthy"].GetError())
+	assert.Empty(hc.takeResults())
+
+	// Nothing is due until the interval elapses
+	hc.runDue(ctx, &wg)
+	wg.Wait()
+	assert.Empty(hc.takeResults())
+
+	// Unchanged checks keep their schedule, changed ones are due right away
+	hc.setChecks([]*pbs.HostHealthCheck{
+		{HostSetId: "hs_1", HostId: "h_healthy", Protocol: health.ProtocolTcp, Address: "a:1", IntervalSeconds: 60},
+		{HostSetId: "hs_1", HostId: "h_unhealthy", Protocol: health.ProtocolTcp, Address: "b:2", IntervalSeconds: 60},
+	})
+	hc.runDue(ctx, &wg)
+	wg.Wait()
+	results = hc.takeResults()
+	require.Len(results, 1)
+	assert.Equal("h_unhealthy", results[0].GetHostId())
+
+	mu.Lock()
+	assert.Equal(map[string]int{"h_healthy": 1, "h_unhealthy": 2}, probed)
+	mu.Unlock()
+
+	// Unassigned checks are no longer run
+	hc.setChecks(nil)
+	for _, s := range hc.checks {
+		s.nextRun = time.Time{}
+	}
+	hc.runDue(ctx, &wg)
+	wg.Wait()
+	assert.Empty(hc.takeResults())
+}
+
+func TestHostHealthChecker_RequeueResults(t *testing.T) {
+	assert := assert.New(t)
+	hc := newHostHealthChecker()
+
+	hc.mu.Lock()
+	hc.addResultsLocked(&pbs.HostHealthCheckResult{HostId: "h_new"})
+	hc.mu.Unlock()
+	hc.requeueResults([]*pbs.HostHealthCheckResult{{HostId: "h_old"}})
+
+	results := hc.takeResults()
+	if assert.Len(results, 2) {
+		assert.Equal("h_old", results[0].GetHostId())
+		assert.Equal("h_new", results[1].GetHostId())
+	}
+
+	// The oldest results are dropped once the buffer is full
+	old := make([]*pbs.HostHealthCheckResult, maxPendingHostHealthCheckResults)
+	for i := range old {
+		old[i] = &pbs.HostHealthCheckResult{HostId: "h_old"}
+	}
+	hc.mu.Lock()
+	hc.addResultsLocked(&pbs.HostHealthCheckResult{HostId: "h_new"})
+	hc.mu.Unlock()
+	hc.requeueResults(old)
+	results = hc.takeResults()
+	if assert.Len(results, maxPendingHostHealthCheckResults) {
+		assert.Equal("h_new", results[len(results)-1].GetHostId())
+	}
+}
@@ -0,0 +1,318 @@
+// Package vaultstorage provides a nodeenrollment.Storage which stores the
+// node credentials of a worker in a Vault KV version 2 secrets engine, so
+// they are never written to the disk of the worker.
+package vaultstorage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/go-rootcerts"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/hashicorp/nodeenrollment/types"
+	vault "github.com/hashicorp/vault/api"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	nodeCredsSubPath        = "nodecreds"
+	nodeInfoSubPath         = "nodeinfo"
+	rootsSubPath            = "roots"
+	activationTokensSubPath = "activationtokens"
+
+	// valueKey is the key of the secret data holding the base64 encoded
+	// marshaled message.
+	valueKey = "value"
+)
+
+var _ nodeenrollment.Storage = (*Storage)(nil)
+
+// Storage stores each message as a secret at <path>/<message type>/<id> of a
+// KV version 2 secrets engine.
+type Storage struct {
+	client *vault.Client
+	mount  string
+	path   string
+}
+
+// New returns a Storage using the provided configuration. The configuration
+// is expected to have been validated when parsed. If the token is renewable,
+// it is renewed until ctx is done.
+func New(ctx context.Context, conf *config.WorkerAuthStorageVault) (*Storage, error) {
+	const op = "vaultstorage.New"
+	if conf == nil {
+		return nil, fmt.Errorf("(%s) missing configuration", op)
+	}
+	vc := vault.DefaultConfig()
+	vc.Address = conf.Address
+	tlsConfig := vc.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	tlsConfig.InsecureSkipVerify = conf.TlsSkipVerify
+	if conf.TlsCaCert != "" {
+		if err := rootcerts.ConfigureTLS(tlsConfig, &rootcerts.Config{CACertificate: []byte(conf.TlsCaCert)}); err != nil {
+			return nil, fmt.Errorf("(%s) error configuring tls: %w", op, err)
+		}
+	}
+	client, err := vault.NewClient(vc)
+	if err != nil {
+		return nil, fmt.Errorf("(%s) error creating vault client: %w", op, err)
+	}
+	client.SetToken(conf.Token)
+	if conf.Namespace != "" {
+		client.SetNamespace(conf.Namespace)
+	}
+	s := &Storage{
+		client: client,
+		mount:  conf.Mount,
+		path:   conf.Path,
+	}
+	if err := s.renewToken(ctx); err != nil {
+		return nil, fmt.Errorf("(%s) %w", op, err)
+	}
+	return s, nil
+}
+
+// renewToken looks up the token of the client and, if it is renewable, starts
+// renewing it until ctx is done. Tokens without a ttl, like root tokens, do
+// not need to be renewed.
+func (s *Storage) renewToken(ctx context.Context) error {
+	const op = "vaultstorage.(Storage).renewToken"
+	secret, err := s.request(ctx, http.MethodGet, "auth/token/lookup-self", nil)
+	if err != nil {
+		return fmt.Errorf("error looking up vault token: %w", err)
+	}
+	if secret == nil {
+		return fmt.Errorf("error looking up vault token: empty response")
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return fmt.Errorf("error looking up vault token: %w", err)
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return fmt.Errorf("error looking up vault token: %w", err)
+	}
+	if !renewable || ttl == 0 {
+		return nil
+	}
+	watcher, err := s.client.NewLifetimeWatcher(&vault.LifetimeWatcherInput{
+		Secret: &vault.Secret{
+			Auth: &vault.SecretAuth{
+				ClientToken:   s.client.Token(),
+				Renewable:     renewable,
+				LeaseDuration: int(ttl / time.Second),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating vault token lifetime watcher: %w", err)
+	}
+	go watcher.Start()
+	go func() {
+		defer watcher.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-watcher.DoneCh():
+				// The token can no longer be renewed, either because of an
+				// error or because it reached its max ttl.
+				if err == nil {
+					err = fmt.Errorf("vault token can no longer be renewed")
+				}
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error renewing worker auth storage vault token"))
+				return
+			case <-watcher.RenewCh():
+			}
+		}
+	}()
+	return nil
+}
+
+// request sends a request to the path of the Vault API, bound to ctx. Like
+// the Logical methods of the Vault client, it returns a nil secret and no
+// error if nothing is found at the path.
+func (s *Storage) request(ctx context.Context, method, p string, data map[string]interface{}) (*vault.Secret, error) {
+	r := s.client.NewRequest(method, "/v1/"+p)
+	if data != nil {
+		if err := r.SetJSONBody(data); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := s.client.RawRequestWithContext(ctx, r)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		secret, parseErr := vault.ParseSecret(resp.Body)
+		switch {
+		case parseErr == io.EOF:
+			return nil, nil
+		case parseErr != nil:
+			return nil, err
+		case secret != nil && (len(secret.Warnings) > 0 || len(secret.Data) > 0):
+			return secret, nil
+		default:
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	secret, err := vault.ParseSecret(resp.Body)
+	switch {
+	case err == io.EOF:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return secret, nil
+}
+
+// BaseDir returns an empty string as the storage does not use a directory.
+func (s *Storage) BaseDir() string {
+	return ""
+}
+
+// Cleanup is a no-op; the stored values are kept.
+func (s *Storage) Cleanup() {}
+
+// subPathFromMsg determines what sub path to use based on the message type
+func subPathFromMsg(msg proto.Message) (string, error) {
+	switch t := msg.(type) {
+	case *types.NodeCredentials:
+		return nodeCredsSubPath, nil
+	case *types.NodeInformation:
+		return nodeInfoSubPath, nil
+	case *types.RootCertificates:
+		return rootsSubPath, nil
+	case *types.ServerLedActivationToken:
+		return activationTokensSubPath, nil
+	default:
+		return "", fmt.Errorf("unknown message type %T", t)
+	}
+}
+
+func (s *Storage) dataPath(subPath, id string) string {
+	return path.Join(s.mount, "data", s.path, subPath, id)
+}
+
+func (s *Storage) metadataPath(subPath string, id ...string) string {
+	return path.Join(append([]string{s.mount, "metadata", s.path, subPath}, id...)...)
+}
+
+// Store implements the Storage interface. If the message already exists, it
+// is overwritten.
+func (s *Storage) Store(ctx context.Context, msg nodeenrollment.MessageWithId) error {
+	const op = "vaultstorage.(Storage).Store"
+	if err := types.ValidateMessage(msg); err != nil {
+		return fmt.Errorf("(%s) given message cannot be stored: %w", op, err)
+	}
+	subPath, err := subPathFromMsg(msg)
+	if err != nil {
+		return fmt.Errorf("(%s) given message cannot be stored: %w", op, err)
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("(%s) error marshaling message: %w", op, err)
+	}
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			valueKey: base64.StdEncoding.EncodeToString(b),
+		},
+	}
+	if _, err := s.request(ctx, http.MethodPut, s.dataPath(subPath, msg.GetId()), data); err != nil {
+		return fmt.Errorf("(%s) error writing value to vault: %w", op, err)
+	}
+	return nil
+}
+
+// Load implements the Storage interface. It returns nodeenrollment.ErrNotFound
+// if the message is not stored.
+func (s *Storage) Load(ctx context.Context, msg nodeenrollment.MessageWithId) error {
+	const op = "vaultstorage.(Storage).Load"
+	if err := types.ValidateMessage(msg); err != nil {
+		return fmt.Errorf("(%s) given message cannot be loaded: %w", op, err)
+	}
+	subPath, err := subPathFromMsg(msg)
+	if err != nil {
+		return fmt.Errorf("(%s) given message cannot be loaded: %w", op, err)
+	}
+	secret, err := s.request(ctx, http.MethodGet, s.dataPath(subPath, msg.GetId()), nil)
+	if err != nil {
+		return fmt.Errorf("(%s) error reading value from vault: %w", op, err)
+	}
+	// A deleted version of a KV version 2 secret is read as a secret without
+	// data
+	if secret == nil || secret.Data == nil || secret.Data["data"] == nil {
+		return nodeenrollment.ErrNotFound
+	}
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("(%s) unexpected secret data type %T", op, secret.Data["data"])
+	}
+	value, ok := data[valueKey].(string)
+	if !ok {
+		return fmt.Errorf("(%s) secret is missing the %q key", op, valueKey)
+	}
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("(%s) error decoding value: %w", op, err)
+	}
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return fmt.Errorf("(%s) error unmarshaling value: %w", op, err)
+	}
+	return nil
+}
+
+// Remove implements the Storage interface. All the versions of the secret are
+// removed.
+func (s *Storage) Remove(ctx context.Context, msg nodeenrollment.MessageWithId) error {
+	const op = "vaultstorage.(Storage).Remove"
+	if err := types.ValidateMessage(msg); err != nil {
+		return fmt.Errorf("(%s) given message cannot be removed: %w", op, err)
+	}
+	subPath, err := subPathFromMsg(msg)
+	if err != nil {
+		return fmt.Errorf("(%s) given message cannot be removed: %w", op, err)
+	}
+	if _, err := s.request(ctx, http.MethodDelete, s.metadataPath(subPath, msg.GetId()), nil); err != nil {
+		return fmt.Errorf("(%s) error removing value from vault: %w", op, err)
+	}
+	return nil
+}
+
+// List implements the Storage interface
+func (s *Storage) List(ctx context.Context, msg proto.Message) ([]string, error) {
+	const op = "vaultstorage.(Storage).List"
+	subPath, err := subPathFromMsg(msg)
+	if err != nil {
+		return nil, fmt.Errorf("(%s) given messages cannot be listed: %w", op, err)
+	}
+	secret, err := s.request(ctx, "LIST", s.metadataPath(subPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("(%s) error listing values from vault: %w", op, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+	keys, ok := secret.Data["keys"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("(%s) unexpected keys type %T", op, secret.Data["keys"])
+	}
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		id, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("(%s) unexpected key type %T", op, k)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,131 @@
+package vaultstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/hashicorp/nodeenrollment/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testStorage(t *testing.T, v *vault.TestVaultServer, token string) *Storage {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s, err := New(ctx, &config.WorkerAuthStorageVault{
+		Address:   v.Addr,
+		Token:     token,
+		Mount:     "secret",
+		Path:      "workers/" + t.Name(),
+		TlsCaCert: string(v.CaCert),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	return s
+}
+
+func TestStorage_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	v := vault.NewTestVaultServer(t, vault.WithTestVaultTLS(vault.TestServerTLS))
+	s := testStorage(t, v, v.RootToken)
+
+	ids, err := s.List(ctx, (*types.NodeCredentials)(nil))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	current := &types.NodeCredentials{
+		Id:                         string(nodeenrollment.CurrentId),
+		CertificatePrivateKeyPkcs8: []byte("current private key"),
+		CertificatePrivateKeyType:  types.KEYTYPE_ED25519,
+	}
+	next := &types.NodeCredentials{
+		Id:                         string(nodeenrollment.NextId),
+		CertificatePrivateKeyPkcs8: []byte("next private key"),
+		CertificatePrivateKeyType:  types.KEYTYPE_ED25519,
+	}
+	require.NoError(t, s.Store(ctx, current))
+	require.NoError(t, s.Store(ctx, next))
+
+	got := &types.NodeCredentials{Id: current.Id}
+	require.NoError(t, s.Load(ctx, got))
+	assert.True(t, proto.Equal(current, got))
+
+	// Storing a message again overwrites it
+	current.CertificatePrivateKeyPkcs8 = []byte("new private key")
+	require.NoError(t, s.Store(ctx, current))
+	got = &types.NodeCredentials{Id: current.Id}
+	require.NoError(t, s.Load(ctx, got))
+	assert.True(t, proto.Equal(current, got))
+
+	// Messages of another type are stored at another path
+	ids, err = s.List(ctx, (*types.NodeInformation)(nil))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	ids, err = s.List(ctx, (*types.NodeCredentials)(nil))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{current.Id, next.Id}, ids)
+
+	require.NoError(t, s.Remove(ctx, current))
+	err = s.Load(ctx, &types.NodeCredentials{Id: current.Id})
+	assert.ErrorIs(t, err, nodeenrollment.ErrNotFound)
+	ids, err = s.List(ctx, (*types.NodeCredentials)(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{next.Id}, ids)
+
+	// Removing a missing message is not an error
+	require.NoError(t, s.Remove(ctx, current))
+}
+
+func TestStorage_MissingKeys(t *testing.T) {
+	ctx := context.Background()
+	v := vault.NewTestVaultServer(t)
+	s := testStorage(t, v, v.RootToken)
+
+	err := s.Load(ctx, &types.NodeCredentials{Id: string(nodeenrollment.CurrentId)})
+	assert.ErrorIs(t, err, nodeenrollment.ErrNotFound)
+	err = s.Load(ctx, &types.RootCertificates{Id: string(nodeenrollment.CurrentId)})
+	assert.ErrorIs(t, err, nodeenrollment.ErrNotFound)
+
+	// The id of a message is required
+	err = s.Load(ctx, &types.NodeCredentials{})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, nodeenrollment.ErrNotFound)
+
+	// A canceled context is not reported as a missing key
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = s.Load(canceledCtx, &types.NodeCredentials{Id: string(nodeenrollment.CurrentId)})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, nodeenrollment.ErrNotFound)
+}
+
+func TestStorage_RenewToken(t *testing.T) {
+	ctx := context.Background()
+	v := vault.NewTestVaultServer(t)
+	v.AddKVPolicy(t)
+	period := 5 * time.Second
+	_, token := v.CreateToken(t, vault.WithPolicies([]string{"default", "secret"}), vault.WithTokenPeriod(period))
+	s := testStorage(t, v, token)
+
+	creds := &types.NodeCredentials{
+		Id:                         string(nodeenrollment.CurrentId),
+		CertificatePrivateKeyPkcs8: []byte("current private key"),
+		CertificatePrivateKeyType:  types.KEYTYPE_ED25519,
+	}
+	require.NoError(t, s.Store(ctx, creds))
+
+	// The token would have expired if it was not renewed
+	time.Sleep(2 * period)
+	got := &types.NodeCredentials{Id: creds.Id}
+	require.NoError(t, s.Load(ctx, got))
+	assert.True(t, proto.Equal(creds, got))
+	ttl, err := v.LookupToken(t, token).TokenTTL()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+}
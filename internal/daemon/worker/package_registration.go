@@ -1,5 +1,6 @@
 package worker
 
 import (
+	_ "github.com/hashicorp/boundary/internal/daemon/worker/proxy/rdp"
 	_ "github.com/hashicorp/boundary/internal/daemon/worker/proxy/tcp"
 )
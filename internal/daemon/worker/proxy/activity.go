@@ -0,0 +1,40 @@
+package proxy
+
+import (
+	"net"
+)
+
+// activityConn is a net.Conn which records on its session that bytes were
+// proxied on its connection, so the worker can cancel idle sessions.
+type activityConn struct {
+	net.Conn
+	conf Config
+}
+
+// TrackActivity wraps conn so reading or writing bytes on it records activity
+// on the connection of the proxy config. Proxies wrap the client connection
+// with it so sessions are not considered idle while bytes are proxied.
+func (c Config) TrackActivity(conn net.Conn) net.Conn {
+	return &activityConn{
+		Conn: conn,
+		conf: c,
+	}
+}
+
+// Read implements net.Conn
+func (c *activityConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.conf.Session.RecordConnectionActivity(c.conf.ConnectionId)
+	}
+	return n, err
+}
+
+// Write implements net.Conn
+func (c *activityConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.conf.Session.RecordConnectionActivity(c.conf.ConnectionId)
+	}
+	return n, err
+}
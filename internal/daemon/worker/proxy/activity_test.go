@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type activitySession struct {
+	testSession
+	connIds []string
+}
+
+func (s *activitySession) RecordConnectionActivity(connId string) {
+	s.connIds = append(s.connIds, connId)
+}
+
+func TestConfigTrackActivity(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	s := &activitySession{}
+	conf := Config{
+		Session:      s,
+		ConnectionId: "conn1",
+	}
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	tracked := conf.TrackActivity(server)
+
+	go func() {
+		_, _ = client.Write([]byte("ping"))
+	}()
+	buf := make([]byte, 4)
+	n, err := tracked.Read(buf)
+	require.NoError(err)
+	assert.Equal(4, n)
+	assert.Equal([]string{"conn1"}, s.connIds)
+
+	go func() {
+		_, _ = client.Read(buf)
+	}()
+	n, err = tracked.Write([]byte("pong"))
+	require.NoError(err)
+	assert.Equal(4, n)
+	assert.Equal([]string{"conn1", "conn1"}, s.connIds)
+
+	// Failed writes do not record activity.
+	require.NoError(client.Close())
+	_, err = tracked.Write([]byte("pong"))
+	require.Error(err)
+	assert.Len(s.connIds, 2)
+}
@@ -0,0 +1,217 @@
+package rdp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The CredSSP implementation below performs the client side of the protocol
+// using NTLM as the authentication package. See [MS-CSSP] for the details.
+
+const (
+	// credSSPVersion is the highest CredSSP version supported.
+	credSSPVersion = 6
+
+	// credSSPPasswordCreds is the credType of TSPasswordCreds.
+	credSSPPasswordCreds = 1
+)
+
+var (
+	clientServerHashMagic = []byte("CredSSP Client-To-Server Binding Hash\x00")
+	serverClientHashMagic = []byte("CredSSP Server-To-Client Binding Hash\x00")
+)
+
+type negoToken struct {
+	Token []byte `asn1:"explicit,tag:0"`
+}
+
+type tsRequest struct {
+	Version     int         `asn1:"explicit,tag:0"`
+	NegoTokens  []negoToken `asn1:"explicit,optional,tag:1"`
+	AuthInfo    []byte      `asn1:"explicit,optional,tag:2"`
+	PubKeyAuth  []byte      `asn1:"explicit,optional,tag:3"`
+	ErrorCode   int64       `asn1:"explicit,optional,tag:4"`
+	ClientNonce []byte      `asn1:"explicit,optional,tag:5"`
+}
+
+type tsCredentials struct {
+	CredType    int    `asn1:"explicit,tag:0"`
+	Credentials []byte `asn1:"explicit,tag:1"`
+}
+
+type tsPasswordCreds struct {
+	DomainName []byte `asn1:"explicit,tag:0"`
+	UserName   []byte `asn1:"explicit,tag:1"`
+	Password   []byte `asn1:"explicit,tag:2"`
+}
+
+// credSSPAuthenticate authenticates to the server as the client of a CredSSP
+// exchange over rw, which must be the TLS connection to the server, and
+// delegates the credentials to the server. serverPublicKey is the
+// SubjectPublicKey of the server's TLS certificate.
+func credSSPAuthenticate(rw io.ReadWriter, serverPublicKey []byte, username, password string) error {
+	ntlm := newNtlmClient(username, password)
+
+	if err := writeTsRequest(rw, &tsRequest{
+		Version:    credSSPVersion,
+		NegoTokens: []negoToken{{Token: ntlm.negotiateMessage()}},
+	}); err != nil {
+		return err
+	}
+
+	resp, err := readTsRequest(rw)
+	if err != nil {
+		return err
+	}
+	if len(resp.NegoTokens) == 0 {
+		return errors.New("credssp: missing ntlm challenge")
+	}
+	version := credSSPVersion
+	if resp.Version < version {
+		version = resp.Version
+	}
+	authenticate, err := ntlm.authenticateMessage(resp.NegoTokens[0].Token)
+	if err != nil {
+		return fmt.Errorf("credssp: %w", err)
+	}
+	sec, err := ntlm.security()
+	if err != nil {
+		return fmt.Errorf("credssp: %w", err)
+	}
+
+	req := &tsRequest{
+		Version:    version,
+		NegoTokens: []negoToken{{Token: authenticate}},
+	}
+	if version >= 5 {
+		req.ClientNonce = make([]byte, 32)
+		if _, err := rand.Read(req.ClientNonce); err != nil {
+			return err
+		}
+	}
+	req.PubKeyAuth = sec.seal(clientPubKeyAuth(version, req.ClientNonce, serverPublicKey))
+	if err := writeTsRequest(rw, req); err != nil {
+		return err
+	}
+
+	resp, err = readTsRequest(rw)
+	if err != nil {
+		return err
+	}
+	got, err := sec.unseal(resp.PubKeyAuth)
+	if err != nil {
+		return fmt.Errorf("credssp: %w", err)
+	}
+	if !bytes.Equal(got, serverPubKeyAuth(version, req.ClientNonce, serverPublicKey)) {
+		return errors.New("credssp: server public key verification failed")
+	}
+
+	creds, err := marshalPasswordCredentials(ntlm.domain, ntlm.user, ntlm.password)
+	if err != nil {
+		return err
+	}
+	return writeTsRequest(rw, &tsRequest{
+		Version:  version,
+		AuthInfo: sec.seal(creds),
+	})
+}
+
+// clientPubKeyAuth returns the value binding the client's authentication to
+// the server's public key.
+func clientPubKeyAuth(version int, nonce, publicKey []byte) []byte {
+	if version < 5 {
+		return publicKey
+	}
+	h := sha256.New()
+	h.Write(clientServerHashMagic)
+	h.Write(nonce)
+	h.Write(publicKey)
+	return h.Sum(nil)
+}
+
+// serverPubKeyAuth returns the value the server is expected to send back to
+// prove it has access to the private key of its certificate.
+func serverPubKeyAuth(version int, nonce, publicKey []byte) []byte {
+	if version < 5 {
+		out := append([]byte(nil), publicKey...)
+		if len(out) > 0 {
+			out[0]++
+		}
+		return out
+	}
+	h := sha256.New()
+	h.Write(serverClientHashMagic)
+	h.Write(nonce)
+	h.Write(publicKey)
+	return h.Sum(nil)
+}
+
+func marshalPasswordCredentials(domain, user, password string) ([]byte, error) {
+	creds, err := asn1.Marshal(tsPasswordCreds{
+		DomainName: utf16le(domain),
+		UserName:   utf16le(user),
+		Password:   utf16le(password),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(tsCredentials{
+		CredType:    credSSPPasswordCreds,
+		Credentials: creds,
+	})
+}
+
+func writeTsRequest(w io.Writer, req *tsRequest) error {
+	b, err := asn1.Marshal(*req)
+	if err != nil {
+		return fmt.Errorf("credssp: %w", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// readTsRequest reads a single DER encoded TSRequest from r.
+func readTsRequest(r io.Reader) (*tsRequest, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, err
+	}
+	if hdr[0] != 0x30 {
+		return nil, fmt.Errorf("credssp: unexpected tag %#x", hdr[0])
+	}
+	length := int(hdr[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 2 {
+			return nil, errors.New("credssp: invalid length")
+		}
+		lb := make([]byte, n)
+		if _, err := io.ReadFull(r, lb); err != nil {
+			return nil, err
+		}
+		hdr = append(hdr, lb...)
+		length = 0
+		for _, b := range lb {
+			length = length<<8 | int(b)
+		}
+	}
+	b := make([]byte, len(hdr)+length)
+	copy(b, hdr)
+	if _, err := io.ReadFull(r, b[len(hdr):]); err != nil {
+		return nil, err
+	}
+
+	var req tsRequest
+	if _, err := asn1.Unmarshal(b, &req); err != nil {
+		return nil, fmt.Errorf("credssp: %w", err)
+	}
+	if req.ErrorCode != 0 {
+		return nil, fmt.Errorf("credssp: server returned error code %#x", uint32(req.ErrorCode))
+	}
+	return &req, nil
+}
@@ -0,0 +1,88 @@
+package rdp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTsRequest_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *tsRequest
+	}{
+		{
+			name: "nego-token",
+			req:  &tsRequest{Version: credSSPVersion, NegoTokens: []negoToken{{Token: []byte("token")}}},
+		},
+		{
+			name: "pub-key-auth",
+			req: &tsRequest{
+				Version:     credSSPVersion,
+				NegoTokens:  []negoToken{{Token: []byte("token")}},
+				PubKeyAuth:  []byte("pubkeyauth"),
+				ClientNonce: bytes.Repeat([]byte{0x01}, 32),
+			},
+		},
+		{
+			name: "long-auth-info",
+			req:  &tsRequest{Version: 2, AuthInfo: bytes.Repeat([]byte{0x02}, 1024)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var buf bytes.Buffer
+			require.NoError(writeTsRequest(&buf, tt.req))
+			buf.WriteString("trailing")
+			got, err := readTsRequest(&buf)
+			require.NoError(err)
+			assert.Equal(tt.req, got)
+			assert.Equal("trailing", buf.String())
+		})
+	}
+
+	t.Run("error-code", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeTsRequest(&buf, &tsRequest{Version: credSSPVersion, ErrorCode: -1073741715}))
+		_, err := readTsRequest(&buf)
+		assert.Error(t, err)
+	})
+}
+
+func TestPubKeyAuth(t *testing.T) {
+	assert := assert.New(t)
+	publicKey := []byte{0x30, 0x01, 0x02}
+	nonce := bytes.Repeat([]byte{0x03}, 32)
+
+	assert.Equal(publicKey, clientPubKeyAuth(2, nil, publicKey))
+	assert.Equal([]byte{0x31, 0x01, 0x02}, serverPubKeyAuth(2, nil, publicKey))
+	assert.Equal(publicKey, []byte{0x30, 0x01, 0x02})
+
+	want := sha256.Sum256(append(append([]byte("CredSSP Client-To-Server Binding Hash\x00"), nonce...), publicKey...))
+	assert.Equal(want[:], clientPubKeyAuth(credSSPVersion, nonce, publicKey))
+	want = sha256.Sum256(append(append([]byte("CredSSP Server-To-Client Binding Hash\x00"), nonce...), publicKey...))
+	assert.Equal(want[:], serverPubKeyAuth(credSSPVersion, nonce, publicKey))
+}
+
+func TestMarshalPasswordCredentials(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	b, err := marshalPasswordCredentials("Domain", "User", "Password")
+	require.NoError(err)
+
+	var creds tsCredentials
+	_, err = asn1.Unmarshal(b, &creds)
+	require.NoError(err)
+	assert.Equal(credSSPPasswordCreds, creds.CredType)
+	var password tsPasswordCreds
+	_, err = asn1.Unmarshal(creds.Credentials, &password)
+	require.NoError(err)
+	assert.Equal(utf16le("Domain"), password.DomainName)
+	assert.Equal(utf16le("User"), password.UserName)
+	assert.Equal(utf16le("Password"), password.Password)
+}
@@ -0,0 +1,84 @@
+package rdp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Once the credentials have been injected, the protocol the client negotiated
+// with the worker (TLS) differs from the one negotiated with the server
+// (CredSSP). Both sides echo the negotiated protocols in the core data of the
+// MCS Connect Initial and Connect Response PDUs and disconnect when they do
+// not match, so those values are rewritten. See [MS-RDPBCGR] 2.2.1.3.2 and
+// 2.2.1.4.2.
+
+const (
+	clientCoreDataType = 0xc001
+	serverCoreDataType = 0x0c01
+
+	// clientSelectedProtocolOffset is the offset of serverSelectedProtocol in
+	// the TS_UD_CS_CORE block.
+	clientSelectedProtocolOffset = 212
+
+	// serverRequestedProtocolsOffset is the offset of clientRequestedProtocols
+	// in the TS_UD_SC_CORE block.
+	serverRequestedProtocolsOffset = 8
+)
+
+var (
+	// clientH221Key precedes the client data blocks of the GCC Conference
+	// Create Request.
+	clientH221Key = []byte("Duca")
+
+	// serverH221Key precedes the server data blocks of the GCC Conference
+	// Create Response.
+	serverH221Key = []byte("McDn")
+)
+
+// patchClientCoreData sets the serverSelectedProtocol of the client core data
+// in an MCS Connect Initial PDU.
+func patchClientCoreData(pdu []byte, protocol uint32) error {
+	return patchCoreData(pdu, clientH221Key, clientCoreDataType, clientSelectedProtocolOffset, protocol)
+}
+
+// patchServerCoreData sets the clientRequestedProtocols of the server core
+// data in an MCS Connect Response PDU.
+func patchServerCoreData(pdu []byte, protocols uint32) error {
+	return patchCoreData(pdu, serverH221Key, serverCoreDataType, serverRequestedProtocolsOffset, protocols)
+}
+
+// patchCoreData finds the data block of the given type following key and sets
+// the uint32 at offset in it to value. Older clients and servers may send core
+// data blocks too short to contain the field, those are left untouched.
+func patchCoreData(pdu, key []byte, blockType uint16, offset int, value uint32) error {
+	i := bytes.Index(pdu, key)
+	if i < 0 {
+		return fmt.Errorf("mcs pdu does not contain gcc user data %q", key)
+	}
+	off := i + len(key)
+	if off >= len(pdu) {
+		return fmt.Errorf("truncated gcc user data %q", key)
+	}
+	// Skip the PER encoded length of the user data.
+	if pdu[off]&0x80 != 0 {
+		off += 2
+	} else {
+		off++
+	}
+	for off+4 <= len(pdu) {
+		t := binary.LittleEndian.Uint16(pdu[off:])
+		l := int(binary.LittleEndian.Uint16(pdu[off+2:]))
+		if l < 4 || off+l > len(pdu) {
+			return fmt.Errorf("invalid gcc user data block length %d", l)
+		}
+		if t == blockType {
+			if l >= offset+4 {
+				binary.LittleEndian.PutUint32(pdu[off+offset:], value)
+			}
+			return nil
+		}
+		off += l
+	}
+	return fmt.Errorf("gcc user data does not contain core data block %#x", blockType)
+}
@@ -0,0 +1,63 @@
+package rdp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDataBlock(blockType uint16, length int) []byte {
+	b := make([]byte, length)
+	binary.LittleEndian.PutUint16(b, blockType)
+	binary.LittleEndian.PutUint16(b[2:], uint16(length))
+	return b
+}
+
+func testGccPdu(key []byte, blocks ...[]byte) []byte {
+	pdu := []byte{tpktVersion, 0x00, 0x00, 0x00, 0x02, 0xf0, 0x80, 0x7f, 0x65}
+	pdu = append(pdu, key...)
+	var data []byte
+	for _, b := range blocks {
+		data = append(data, b...)
+	}
+	pdu = append(pdu, 0x80|byte(len(data)>>8), byte(len(data)))
+	return append(pdu, data...)
+}
+
+func TestPatchClientCoreData(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	pdu := testGccPdu(clientH221Key, testDataBlock(clientCoreDataType, 234), testDataBlock(0xc002, 12))
+	require.NoError(patchClientCoreData(pdu, protocolHybrid))
+	off := len(pdu) - 12 - 234 + clientSelectedProtocolOffset
+	assert.Equal(uint32(protocolHybrid), binary.LittleEndian.Uint32(pdu[off:]))
+
+	// The core data block does not have to be the first one.
+	pdu = testGccPdu(clientH221Key, testDataBlock(0xc002, 12), testDataBlock(clientCoreDataType, 216))
+	require.NoError(patchClientCoreData(pdu, protocolHybrid))
+	assert.Equal(uint32(protocolHybrid), binary.LittleEndian.Uint32(pdu[len(pdu)-4:]))
+
+	// Short core data blocks are left untouched.
+	pdu = testGccPdu(clientH221Key, testDataBlock(clientCoreDataType, 132))
+	want := append([]byte(nil), pdu...)
+	require.NoError(patchClientCoreData(pdu, protocolHybrid))
+	assert.Equal(want, pdu)
+
+	assert.Error(patchClientCoreData(testGccPdu(serverH221Key, testDataBlock(clientCoreDataType, 216)), protocolHybrid))
+	assert.Error(patchClientCoreData(testGccPdu(clientH221Key, testDataBlock(0xc002, 12)), protocolHybrid))
+	pdu = testGccPdu(clientH221Key, testDataBlock(clientCoreDataType, 216))
+	assert.Error(patchClientCoreData(pdu[:len(pdu)-1], protocolHybrid))
+}
+
+func TestPatchServerCoreData(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	pdu := testGccPdu(serverH221Key, testDataBlock(serverCoreDataType, 16), testDataBlock(0x0c02, 12))
+	require.NoError(patchServerCoreData(pdu, protocolSSL|protocolHybrid|0x08))
+	off := len(pdu) - 12 - 16 + serverRequestedProtocolsOffset
+	assert.Equal(uint32(protocolSSL|protocolHybrid|0x08), binary.LittleEndian.Uint32(pdu[off:]))
+
+	assert.Error(patchServerCoreData(testGccPdu(clientH221Key, testDataBlock(serverCoreDataType, 16)), protocolSSL))
+}
@@ -0,0 +1,373 @@
+package rdp
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// The NTLM implementation below only covers what is needed to act as the
+// client side of an NTLMv2 authentication with extended session security and
+// key exchange, as used by CredSSP. See [MS-NLMP] for the details.
+
+const (
+	ntlmNegotiateUnicode                 = 0x00000001
+	ntlmRequestTarget                    = 0x00000004
+	ntlmNegotiateSign                    = 0x00000010
+	ntlmNegotiateSeal                    = 0x00000020
+	ntlmNegotiateNtlm                    = 0x00000200
+	ntlmNegotiateAlwaysSign              = 0x00008000
+	ntlmNegotiateExtendedSessionSecurity = 0x00080000
+	ntlmNegotiateVersion                 = 0x02000000
+	ntlmNegotiate128                     = 0x20000000
+	ntlmNegotiateKeyExch                 = 0x40000000
+	ntlmNegotiate56                      = 0x80000000
+
+	ntlmDefaultFlags = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateSign |
+		ntlmNegotiateSeal | ntlmNegotiateNtlm | ntlmNegotiateAlwaysSign |
+		ntlmNegotiateExtendedSessionSecurity | ntlmNegotiateVersion |
+		ntlmNegotiate128 | ntlmNegotiateKeyExch | ntlmNegotiate56
+
+	ntlmNegotiateType    = 1
+	ntlmChallengeType    = 2
+	ntlmAuthenticateType = 3
+
+	msvAvEOL       = 0
+	msvAvFlags     = 6
+	msvAvTimestamp = 7
+
+	// msvAvFlagMicProvided indicates the AUTHENTICATE message contains a MIC.
+	msvAvFlagMicProvided = 0x00000002
+
+	// ntlmAuthenticateHeaderLen is the size of the fixed part of the
+	// AUTHENTICATE message, including the version and the MIC.
+	ntlmAuthenticateHeaderLen = 88
+	ntlmMicOffset             = 72
+)
+
+var (
+	ntlmSignature = []byte("NTLMSSP\x00")
+
+	// ntlmVersion identifies the NTLM revision in use, the product version
+	// is informational only.
+	ntlmVersion = []byte{0x06, 0x01, 0xb1, 0x1d, 0x00, 0x00, 0x00, 0x0f}
+
+	clientSigningMagic = []byte("session key to client-to-server signing key magic constant\x00")
+	serverSigningMagic = []byte("session key to server-to-client signing key magic constant\x00")
+	clientSealingMagic = []byte("session key to client-to-server sealing key magic constant\x00")
+	serverSealingMagic = []byte("session key to server-to-client sealing key magic constant\x00")
+)
+
+// ntlmClient performs the client side of an NTLMv2 authentication.
+type ntlmClient struct {
+	domain   string
+	user     string
+	password string
+
+	negotiate   []byte
+	challenge   []byte
+	exportedKey []byte
+}
+
+// newNtlmClient creates an ntlmClient for the provided username and
+// password. A username in the form DOMAIN\user is split into its domain and
+// user parts.
+func newNtlmClient(username, password string) *ntlmClient {
+	c := &ntlmClient{user: username, password: password}
+	if i := strings.Index(username, `\`); i >= 0 {
+		c.domain, c.user = username[:i], username[i+1:]
+	}
+	return c
+}
+
+// negotiateMessage returns the NEGOTIATE message which starts the
+// authentication.
+func (c *ntlmClient) negotiateMessage() []byte {
+	m := make([]byte, 40)
+	copy(m, ntlmSignature)
+	binary.LittleEndian.PutUint32(m[8:], ntlmNegotiateType)
+	binary.LittleEndian.PutUint32(m[12:], ntlmDefaultFlags)
+	// The domain and workstation fields are left empty.
+	copy(m[32:], ntlmVersion)
+	c.negotiate = m
+	return m
+}
+
+// ntlmChallenge is the parsed content of a CHALLENGE message.
+type ntlmChallenge struct {
+	flags           uint32
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+func parseNtlmChallenge(m []byte) (*ntlmChallenge, error) {
+	if len(m) < 48 || !bytes.Equal(m[:8], ntlmSignature) {
+		return nil, errors.New("invalid ntlm challenge message")
+	}
+	if t := binary.LittleEndian.Uint32(m[8:]); t != ntlmChallengeType {
+		return nil, fmt.Errorf("unexpected ntlm message type %d", t)
+	}
+	l := int(binary.LittleEndian.Uint16(m[40:]))
+	off := int(binary.LittleEndian.Uint32(m[44:]))
+	if off+l > len(m) {
+		return nil, errors.New("invalid ntlm challenge target info")
+	}
+	return &ntlmChallenge{
+		flags:           binary.LittleEndian.Uint32(m[20:]),
+		serverChallenge: append([]byte(nil), m[24:32]...),
+		targetInfo:      append([]byte(nil), m[off:off+l]...),
+	}, nil
+}
+
+// authenticateMessage processes the CHALLENGE message received from the
+// server and returns the AUTHENTICATE message to send in response.
+func (c *ntlmClient) authenticateMessage(challenge []byte) ([]byte, error) {
+	chal, err := parseNtlmChallenge(challenge)
+	if err != nil {
+		return nil, err
+	}
+	if chal.flags&ntlmNegotiateExtendedSessionSecurity == 0 || chal.flags&ntlmNegotiateKeyExch == 0 {
+		return nil, errors.New("ntlm server does not support extended session security with key exchange")
+	}
+	c.challenge = challenge
+
+	avPairs, timestamp, err := authenticateAvPairs(chal.targetInfo)
+	if err != nil {
+		return nil, err
+	}
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	responseKey := ntowfv2(c.user, c.password, c.domain)
+	// The LM response is not sent when the server provides a timestamp.
+	lmResponse := make([]byte, 24)
+	if timestamp == nil {
+		timestamp = make([]byte, 8)
+		binary.LittleEndian.PutUint64(timestamp, filetime(time.Now()))
+		mac := hmac.New(md5.New, responseKey)
+		mac.Write(chal.serverChallenge)
+		mac.Write(clientChallenge)
+		lmResponse = append(mac.Sum(nil), clientChallenge...)
+	}
+	ntResponse, sessionBaseKey := ntlmv2Response(responseKey, chal.serverChallenge, clientChallenge, timestamp, avPairs)
+
+	c.exportedKey = make([]byte, 16)
+	if _, err := rand.Read(c.exportedKey); err != nil {
+		return nil, err
+	}
+	encryptedKey := make([]byte, 16)
+	cipher, err := rc4.NewCipher(sessionBaseKey)
+	if err != nil {
+		return nil, err
+	}
+	cipher.XORKeyStream(encryptedKey, c.exportedKey)
+
+	domain, user := utf16le(c.domain), utf16le(c.user)
+	m := make([]byte, ntlmAuthenticateHeaderLen)
+	copy(m, ntlmSignature)
+	binary.LittleEndian.PutUint32(m[8:], ntlmAuthenticateType)
+	for i, payload := range [][]byte{lmResponse, ntResponse, domain, user, nil, encryptedKey} {
+		f := 12 + 8*i
+		binary.LittleEndian.PutUint16(m[f:], uint16(len(payload)))
+		binary.LittleEndian.PutUint16(m[f+2:], uint16(len(payload)))
+		binary.LittleEndian.PutUint32(m[f+4:], uint32(len(m)))
+		m = append(m, payload...)
+	}
+	binary.LittleEndian.PutUint32(m[60:], chal.flags)
+	copy(m[64:], ntlmVersion)
+
+	mic := hmac.New(md5.New, c.exportedKey)
+	mic.Write(c.negotiate)
+	mic.Write(c.challenge)
+	mic.Write(m)
+	copy(m[ntlmMicOffset:], mic.Sum(nil))
+	return m, nil
+}
+
+// security returns the ntlmSecurity to use to seal and unseal messages once
+// the authentication completed.
+func (c *ntlmClient) security() (*ntlmSecurity, error) {
+	if c.exportedKey == nil {
+		return nil, errors.New("ntlm authentication has not completed")
+	}
+	return newNtlmSecurity(c.exportedKey, true)
+}
+
+// authenticateAvPairs returns the AV pairs to include in the NTLMv2 response
+// and the timestamp provided by the server, if any. When the server provided a
+// timestamp the MIC provided flag is set.
+func authenticateAvPairs(targetInfo []byte) ([]byte, []byte, error) {
+	var out bytes.Buffer
+	var timestamp []byte
+	var flags uint32
+	for b := targetInfo; ; {
+		if len(b) < 4 {
+			return nil, nil, errors.New("invalid ntlm target info")
+		}
+		id, l := binary.LittleEndian.Uint16(b), int(binary.LittleEndian.Uint16(b[2:]))
+		if len(b) < 4+l {
+			return nil, nil, errors.New("invalid ntlm target info")
+		}
+		if id == msvAvEOL {
+			break
+		}
+		switch id {
+		case msvAvFlags:
+			if l == 4 {
+				flags = binary.LittleEndian.Uint32(b[4:])
+			}
+		case msvAvTimestamp:
+			timestamp = append([]byte(nil), b[4:4+l]...)
+			fallthrough
+		default:
+			out.Write(b[:4+l])
+		}
+		b = b[4+l:]
+	}
+
+	if timestamp != nil {
+		flags |= msvAvFlagMicProvided
+	}
+	if flags != 0 {
+		pair := []byte{msvAvFlags, 0x00, 0x04, 0x00, 0, 0, 0, 0}
+		binary.LittleEndian.PutUint32(pair[4:], flags)
+		out.Write(pair)
+	}
+	out.Write([]byte{msvAvEOL, 0x00, 0x00, 0x00})
+	return out.Bytes(), timestamp, nil
+}
+
+// ntowfv2 returns the NTLMv2 response key for the provided credentials.
+func ntowfv2(user, password, domain string) []byte {
+	h := md4.New()
+	h.Write(utf16le(password))
+	mac := hmac.New(md5.New, h.Sum(nil))
+	mac.Write(utf16le(strings.ToUpper(user) + domain))
+	return mac.Sum(nil)
+}
+
+// ntlmv2Response computes the NTLMv2 challenge response and the session base
+// key.
+func ntlmv2Response(responseKey, serverChallenge, clientChallenge, timestamp, avPairs []byte) ([]byte, []byte) {
+	var temp bytes.Buffer
+	temp.Write([]byte{0x01, 0x01, 0, 0, 0, 0, 0, 0})
+	temp.Write(timestamp)
+	temp.Write(clientChallenge)
+	temp.Write([]byte{0, 0, 0, 0})
+	temp.Write(avPairs)
+	temp.Write([]byte{0, 0, 0, 0})
+
+	mac := hmac.New(md5.New, responseKey)
+	mac.Write(serverChallenge)
+	mac.Write(temp.Bytes())
+	ntProofStr := mac.Sum(nil)
+
+	mac = hmac.New(md5.New, responseKey)
+	mac.Write(ntProofStr)
+	return append(ntProofStr, temp.Bytes()...), mac.Sum(nil)
+}
+
+// ntlmSecurity seals and unseals messages using the keys derived from an
+// NTLM authentication with extended session security.
+type ntlmSecurity struct {
+	sendSigningKey []byte
+	recvSigningKey []byte
+	sendSealing    *rc4.Cipher
+	recvSealing    *rc4.Cipher
+	sendSeq        uint32
+	recvSeq        uint32
+}
+
+// newNtlmSecurity derives the signing and sealing keys from the exported
+// session key. client reports whether the keys are used by the client or the
+// server side of the authentication.
+func newNtlmSecurity(exportedKey []byte, client bool) (*ntlmSecurity, error) {
+	sendSign, recvSign := clientSigningMagic, serverSigningMagic
+	sendSeal, recvSeal := clientSealingMagic, serverSealingMagic
+	if !client {
+		sendSign, recvSign = recvSign, sendSign
+		sendSeal, recvSeal = recvSeal, sendSeal
+	}
+	s := &ntlmSecurity{
+		sendSigningKey: deriveKey(exportedKey, sendSign),
+		recvSigningKey: deriveKey(exportedKey, recvSign),
+	}
+	var err error
+	if s.sendSealing, err = rc4.NewCipher(deriveKey(exportedKey, sendSeal)); err != nil {
+		return nil, err
+	}
+	if s.recvSealing, err = rc4.NewCipher(deriveKey(exportedKey, recvSeal)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// seal encrypts the message and returns it prefixed with its signature.
+func (s *ntlmSecurity) seal(message []byte) []byte {
+	out := make([]byte, 16+len(message))
+	s.sendSealing.XORKeyStream(out[16:], message)
+	copy(out, signature(s.sendSigningKey, s.sendSealing, s.sendSeq, message))
+	s.sendSeq++
+	return out
+}
+
+// unseal decrypts a message produced by seal and verifies its signature.
+func (s *ntlmSecurity) unseal(sealed []byte) ([]byte, error) {
+	if len(sealed) < 16 {
+		return nil, errors.New("sealed message too short")
+	}
+	message := make([]byte, len(sealed)-16)
+	s.recvSealing.XORKeyStream(message, sealed[16:])
+	want := signature(s.recvSigningKey, s.recvSealing, s.recvSeq, message)
+	s.recvSeq++
+	if !hmac.Equal(want, sealed[:16]) {
+		return nil, errors.New("invalid message signature")
+	}
+	return message, nil
+}
+
+func signature(signingKey []byte, sealing *rc4.Cipher, seq uint32, message []byte) []byte {
+	sig := make([]byte, 16)
+	binary.LittleEndian.PutUint32(sig, 1)
+	binary.LittleEndian.PutUint32(sig[12:], seq)
+	mac := hmac.New(md5.New, signingKey)
+	mac.Write(sig[12:])
+	mac.Write(message)
+	sealing.XORKeyStream(sig[4:12], mac.Sum(nil)[:8])
+	return sig
+}
+
+func deriveKey(key, magic []byte) []byte {
+	h := md5.New()
+	h.Write(key)
+	h.Write(magic)
+	return h.Sum(nil)
+}
+
+func utf16le(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i, r := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+	return b
+}
+
+// filetime returns t as the number of 100 nanosecond intervals since
+// January 1, 1601 UTC.
+func filetime(t time.Time) uint64 {
+	const epochDelta = 116444736000000000
+	return uint64(t.UnixNano()/100) + epochDelta
+}
@@ -0,0 +1,137 @@
+package rdp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The test vectors come from [MS-NLMP] 4.2.4.
+var (
+	testServerChallenge = []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+	testClientChallenge = bytes.Repeat([]byte{0xaa}, 8)
+	testTargetInfo      = append(append([]byte{0x02, 0x00, 0x0c, 0x00}, utf16le("Domain")...),
+		append(append([]byte{0x01, 0x00, 0x0c, 0x00}, utf16le("Server")...), 0x00, 0x00, 0x00, 0x00)...)
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestNtlmv2Response(t *testing.T) {
+	assert := assert.New(t)
+	responseKey := ntowfv2("User", "Password", "Domain")
+	assert.Equal(mustHex(t, "0c868a403bfd7a93a3001ef22ef02e3f"), responseKey)
+
+	ntResponse, sessionBaseKey := ntlmv2Response(responseKey, testServerChallenge, testClientChallenge, make([]byte, 8), testTargetInfo)
+	assert.Equal(mustHex(t, "68cd0ab851e51c96aabc927bebef6a1c"), ntResponse[:16])
+	assert.Equal(mustHex(t, "8de40ccadbc14a82f15cb0ad0de95ca3"), sessionBaseKey)
+}
+
+func TestNtlmSecurity_Seal(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	exportedKey := bytes.Repeat([]byte{0x55}, 16)
+
+	client, err := newNtlmSecurity(exportedKey, true)
+	require.NoError(err)
+	assert.Equal(mustHex(t, "4788dc861b4782f35d43fd98fe1a2d39"), client.sendSigningKey)
+
+	sealed := client.seal(utf16le("Plaintext"))
+	assert.Equal(mustHex(t, "010000007fb38ec5c55d497600000000"), sealed[:16])
+	assert.Equal(mustHex(t, "54e50165bf1936dc996020c1811b0f06fb5f"), sealed[16:])
+
+	server, err := newNtlmSecurity(exportedKey, false)
+	require.NoError(err)
+	got, err := server.unseal(sealed)
+	require.NoError(err)
+	assert.Equal(utf16le("Plaintext"), got)
+
+	// Sequence numbers and cipher states are kept across messages.
+	for _, msg := range []string{"first", "second"} {
+		got, err := server.unseal(client.seal([]byte(msg)))
+		require.NoError(err)
+		assert.Equal([]byte(msg), got)
+		got, err = client.unseal(server.seal([]byte(msg)))
+		require.NoError(err)
+		assert.Equal([]byte(msg), got)
+	}
+
+	tampered := client.seal([]byte("message"))
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = server.unseal(tampered)
+	assert.Error(err)
+}
+
+func TestAuthenticateAvPairs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	pairs, timestamp, err := authenticateAvPairs(testTargetInfo)
+	require.NoError(err)
+	assert.Nil(timestamp)
+	assert.Equal(testTargetInfo, pairs)
+
+	ts := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	info := append(append([]byte(nil), testTargetInfo[:len(testTargetInfo)-4]...), msvAvTimestamp, 0x00, 0x08, 0x00)
+	info = append(append(info, ts...), 0x00, 0x00, 0x00, 0x00)
+	pairs, timestamp, err = authenticateAvPairs(info)
+	require.NoError(err)
+	assert.Equal(ts, timestamp)
+	flags := []byte{msvAvFlags, 0x00, 0x04, 0x00, 0x02, 0x00, 0x00, 0x00}
+	assert.Equal(append(append(info[:len(info)-4:len(info)-4], flags...), 0x00, 0x00, 0x00, 0x00), pairs)
+
+	_, _, err = authenticateAvPairs(testTargetInfo[:len(testTargetInfo)-4])
+	assert.Error(err)
+}
+
+func TestNtlmClient_AuthenticateMessage(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := newNtlmClient(`Domain\User`, "Password")
+	assert.Equal("Domain", c.domain)
+	assert.Equal("User", c.user)
+
+	negotiate := c.negotiateMessage()
+	assert.Len(negotiate, 40)
+	assert.Equal(uint32(ntlmDefaultFlags), binary.LittleEndian.Uint32(negotiate[12:]))
+
+	challenge := make([]byte, 56)
+	copy(challenge, ntlmSignature)
+	binary.LittleEndian.PutUint32(challenge[8:], ntlmChallengeType)
+	binary.LittleEndian.PutUint32(challenge[20:], ntlmDefaultFlags)
+	copy(challenge[24:], testServerChallenge)
+	binary.LittleEndian.PutUint16(challenge[40:], uint16(len(testTargetInfo)))
+	binary.LittleEndian.PutUint16(challenge[42:], uint16(len(testTargetInfo)))
+	binary.LittleEndian.PutUint32(challenge[44:], uint32(len(challenge)))
+	challenge = append(challenge, testTargetInfo...)
+
+	_, err := c.security()
+	assert.Error(err)
+	authenticate, err := c.authenticateMessage(challenge)
+	require.NoError(err)
+	assert.Equal(ntlmSignature, authenticate[:8])
+	assert.Equal(uint32(ntlmAuthenticateType), binary.LittleEndian.Uint32(authenticate[8:]))
+
+	field := func(i int) []byte {
+		f := 12 + 8*i
+		l := int(binary.LittleEndian.Uint16(authenticate[f:]))
+		off := int(binary.LittleEndian.Uint32(authenticate[f+4:]))
+		return authenticate[off : off+l]
+	}
+	assert.Equal(utf16le("Domain"), field(2))
+	assert.Equal(utf16le("User"), field(3))
+	assert.Empty(field(4))
+	assert.Len(field(5), 16)
+	_, err = c.security()
+	assert.NoError(err)
+
+	// A challenge without key exchange is rejected.
+	binary.LittleEndian.PutUint32(challenge[20:], ntlmDefaultFlags&^ntlmNegotiateKeyExch)
+	_, err = newNtlmClient("user", "pass").authenticateMessage(challenge)
+	assert.Error(err)
+}
@@ -0,0 +1,314 @@
+// Package rdp provides the worker proxy handler for rdp targets. When the
+// session has an injected username/password credential, the worker performs
+// the CredSSP (NLA) authentication with the RDP server on behalf of the client
+// so the client never has access to the secret.
+package rdp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"nhooyr.io/websocket"
+)
+
+var (
+	// dialTimeout bounds the time taken to connect to the remote endpoint.
+	dialTimeout = 30 * time.Second
+	// handshakeTimeout bounds the time taken by the client and the server to
+	// complete the credential injection handshake.
+	handshakeTimeout = 30 * time.Second
+)
+
+func init() {
+	err := proxy.RegisterHandler("rdp", handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates an rdp proxy between the incoming websocket conn and the
+// connection it creates with the remote endpoint. handleProxy sets the
+// connectionId as connected in the repository.
+//
+// If an injected application credential of the username_password type is
+// provided, it is used to authenticate to the remote endpoint with CredSSP.
+// Injected application credentials of any other type fail the connection, so
+// it is never proxied without the credentials the session expects. Without
+// injected application credentials, the traffic is proxied as is.
+//
+// handleProxy blocks until an error (EOF on happy path) is received on either
+// connection.
+func handleProxy(ctx context.Context, conf proxy.Config, opt ...proxy.Option) error {
+	conn := conf.ClientConn
+	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
+	if err != nil {
+		return fmt.Errorf("error parsing endpoint information: %w", err)
+	}
+	if sessionUrl.Scheme != "rdp" {
+		return fmt.Errorf("invalid scheme for rdp proxy: %v", sessionUrl.Scheme)
+	}
+	opts := proxy.GetOpts(opt...)
+	username, password, inject, err := usernamePassword(opts.WithInjectedApplicationCredentials)
+	if err != nil {
+		return fmt.Errorf("error reading injected application credentials: %w", err)
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	remoteConn, err := dialer.DialContext(ctx, "tcp", sessionUrl.Host)
+	if err != nil {
+		return fmt.Errorf("error dialing endpoint: %w", err)
+	}
+	tcpRemoteConn := remoteConn.(*net.TCPConn)
+
+	endpointAddr := tcpRemoteConn.RemoteAddr().(*net.TCPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       conf.ConnectionId,
+		ClientTcpAddress:   conf.ClientAddress.IP.String(),
+		ClientTcpPort:      uint32(conf.ClientAddress.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "rdp",
+		UserClientIp:       conf.UserClientIp.String(),
+	}
+
+	if err := conf.Session.RequestConnectConnection(ctx, connectionInfo); err != nil {
+		_ = tcpRemoteConn.Close()
+		return fmt.Errorf("error marking connection as connected: %w", err)
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy, and record the activity
+	// of the connection for the session idle timeout
+	var clientConn, serverConn net.Conn = conf.TrackActivity(websocket.NetConn(ctx, conn, websocket.MessageBinary)), tcpRemoteConn
+	if inject {
+		clientConn, serverConn, err = injectCredentials(clientConn, serverConn, username, password)
+		if err != nil {
+			return fmt.Errorf("error injecting credentials: %w", err)
+		}
+	}
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		_, _ = io.Copy(clientConn, serverConn)
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		_, _ = io.Copy(serverConn, clientConn)
+		_ = serverConn.Close()
+		_ = clientConn.Close()
+	}()
+	connWg.Wait()
+	return nil
+}
+
+// usernamePassword returns the first username_password credential of creds,
+// and whether there is one to inject. Only username_password credentials can
+// be injected, so an error is returned if creds has a credential of any other
+// type.
+func usernamePassword(creds []*pbs.Credential) (string, string, bool, error) {
+	var up *pbs.UsernamePassword
+	for _, c := range creds {
+		switch {
+		case c.GetUsernamePassword() == nil:
+			return "", "", false, fmt.Errorf("unsupported injected application credential type %T", c.GetCredential())
+		case up == nil:
+			up = c.GetUsernamePassword()
+		}
+	}
+	if up == nil {
+		return "", "", false, nil
+	}
+	return up.GetUsername(), up.GetPassword(), true, nil
+}
+
+// injectCredentials negotiates TLS with the client and CredSSP with the
+// server, then authenticates to the server with the provided credentials. It
+// returns the TLS connections to use to proxy the rest of the RDP session. The
+// provided connections are closed if an error is returned. The handshake must
+// complete within handshakeTimeout.
+func injectCredentials(client, server net.Conn, username, password string) (_ net.Conn, _ net.Conn, retErr error) {
+	defer func() {
+		if retErr != nil {
+			_ = client.Close()
+			_ = server.Close()
+		}
+	}()
+
+	deadline := time.Now().Add(handshakeTimeout)
+	if err := client.SetDeadline(deadline); err != nil {
+		return nil, nil, fmt.Errorf("error setting client handshake deadline: %w", err)
+	}
+	if err := server.SetDeadline(deadline); err != nil {
+		return nil, nil, fmt.Errorf("error setting server handshake deadline: %w", err)
+	}
+
+	cr, err := readTpkt(client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading client connection request: %w", err)
+	}
+	clientProtocols, err := requestedProtocols(cr)
+	if err != nil || clientProtocols&protocolSSL == 0 {
+		_, _ = client.Write(negotiationFailurePdu(sslRequiredByServer))
+		return nil, nil, errors.New("client does not support tls security")
+	}
+
+	// Only request CredSSP, the early user authorization result of
+	// PROTOCOL_HYBRID_EX is not needed.
+	serverCr, err := withRequestedProtocols(cr, protocolSSL|protocolHybrid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := server.Write(serverCr); err != nil {
+		return nil, nil, err
+	}
+	cc, err := readTpkt(server)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading server connection confirm: %w", err)
+	}
+	selected, err := selectedProtocol(cc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if selected != protocolHybrid {
+		return nil, nil, fmt.Errorf("server selected protocol %#x, credential injection requires network level authentication", selected)
+	}
+
+	// RDP servers commonly use self-signed certificates, so the certificate
+	// is not verified. CredSSP binds the authentication to the public key of
+	// the TLS connection and the credentials are only delegated once the
+	// server has proven it owns it.
+	tlsServer := tls.Client(server, &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err := tlsServer.Handshake(); err != nil {
+		return nil, nil, fmt.Errorf("error establishing tls with server: %w", err)
+	}
+	publicKey, err := subjectPublicKey(tlsServer.ConnectionState().PeerCertificates[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := credSSPAuthenticate(tlsServer, publicKey, username, password); err != nil {
+		return nil, nil, err
+	}
+
+	clientCc, err := withSelectedProtocol(cc, protocolSSL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := client.Write(clientCc); err != nil {
+		return nil, nil, err
+	}
+	cert, err := serverCertificate()
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsClient := tls.Server(client, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err := tlsClient.Handshake(); err != nil {
+		return nil, nil, fmt.Errorf("error establishing tls with client: %w", err)
+	}
+
+	connectInitial, err := readTpkt(tlsClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading client mcs connect initial: %w", err)
+	}
+	if err := patchClientCoreData(connectInitial, protocolHybrid); err != nil {
+		return nil, nil, err
+	}
+	if _, err := tlsServer.Write(connectInitial); err != nil {
+		return nil, nil, err
+	}
+	connectResponse, err := readTpkt(tlsServer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading server mcs connect response: %w", err)
+	}
+	if err := patchServerCoreData(connectResponse, clientProtocols); err != nil {
+		return nil, nil, err
+	}
+	if _, err := tlsClient.Write(connectResponse); err != nil {
+		return nil, nil, err
+	}
+
+	// The rest of the session is proxied without deadlines, idle sessions
+	// are handled by the session idle timeout.
+	if err := client.SetDeadline(time.Time{}); err != nil {
+		return nil, nil, fmt.Errorf("error clearing client handshake deadline: %w", err)
+	}
+	if err := server.SetDeadline(time.Time{}); err != nil {
+		return nil, nil, fmt.Errorf("error clearing server handshake deadline: %w", err)
+	}
+	return tlsClient, tlsServer, nil
+}
+
+// subjectPublicKey returns the content of the subjectPublicKey field of the
+// certificate, which CredSSP uses to bind the authentication to the TLS
+// connection.
+func subjectPublicKey(cert *x509.Certificate) ([]byte, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, fmt.Errorf("error parsing server public key: %w", err)
+	}
+	return spki.PublicKey.Bytes, nil
+}
+
+var (
+	certOnce sync.Once
+	cert     tls.Certificate
+	certErr  error
+)
+
+// serverCertificate returns the self-signed certificate presented to RDP
+// clients. It is generated the first time it is needed.
+func serverCertificate() (tls.Certificate, error) {
+	certOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			certErr = err
+			return
+		}
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			certErr = err
+			return
+		}
+		template := &x509.Certificate{
+			SerialNumber: serial,
+			Subject:      pkix.Name{CommonName: "boundary-worker"},
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(10 * 365 * 24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			certErr = err
+			return
+		}
+		cert = tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	})
+	return cert, certErr
+}
@@ -0,0 +1,112 @@
+package rdp
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsernamePassword(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	_, _, ok, err := usernamePassword(nil)
+	require.NoError(err)
+	assert.False(ok)
+
+	username, password, ok, err := usernamePassword([]*pbs.Credential{
+		{Credential: &pbs.Credential_UsernamePassword{UsernamePassword: &pbs.UsernamePassword{Username: `DOMAIN\user`, Password: "pass"}}},
+	})
+	require.NoError(err)
+	assert.True(ok)
+	assert.Equal(`DOMAIN\user`, username)
+	assert.Equal("pass", password)
+
+	// Credentials which cannot be injected fail the connection, even along
+	// with a username_password credential
+	_, _, ok, err = usernamePassword([]*pbs.Credential{
+		{Credential: &pbs.Credential_SshPrivateKey{SshPrivateKey: &pbs.SshPrivateKey{Username: "ssh"}}},
+	})
+	assert.Error(err)
+	assert.False(ok)
+	_, _, ok, err = usernamePassword([]*pbs.Credential{
+		{Credential: &pbs.Credential_UsernamePassword{UsernamePassword: &pbs.UsernamePassword{Username: `DOMAIN\user`, Password: "pass"}}},
+		{Credential: &pbs.Credential_SshPrivateKey{SshPrivateKey: &pbs.SshPrivateKey{Username: "ssh"}}},
+	})
+	assert.Error(err)
+	assert.False(ok)
+	_, _, ok, err = usernamePassword([]*pbs.Credential{{}})
+	assert.Error(err)
+	assert.False(ok)
+}
+
+func TestInjectCredentials_ClientWithoutTls(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	client, proxyClient := net.Pipe()
+	proxyServer, server := net.Pipe()
+	defer server.Close()
+
+	errCh := make(chan error)
+	go func() {
+		_, _, err := injectCredentials(proxyClient, proxyServer, "user", "pass")
+		errCh <- err
+	}()
+
+	_, err := client.Write(testConnectionRequest(0))
+	require.NoError(err)
+	resp, err := readTpkt(client)
+	require.NoError(err)
+	assert.Equal(negotiationFailurePdu(sslRequiredByServer), resp)
+	assert.Error(<-errCh)
+
+	// The connections are closed on error.
+	_, err = client.Read(make([]byte, 1))
+	assert.Error(err)
+	_, err = server.Read(make([]byte, 1))
+	assert.Error(err)
+}
+
+func TestInjectCredentials_HandshakeTimeout(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	oldTimeout := handshakeTimeout
+	handshakeTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { handshakeTimeout = oldTimeout })
+
+	client, proxyClient := net.Pipe()
+	proxyServer, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	errCh := make(chan error)
+	go func() {
+		_, _, err := injectCredentials(proxyClient, proxyServer, "user", "pass")
+		errCh <- err
+	}()
+
+	// The client sends its connection request but the server never answers.
+	_, err := client.Write(testConnectionRequest(protocolSSL))
+	require.NoError(err)
+	go func() { _, _ = server.Read(make([]byte, 1024)) }()
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(err, os.ErrDeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("credential injection did not time out")
+	}
+}
+
+func TestServerCertificate(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cert, err := serverCertificate()
+	require.NoError(err)
+	require.Len(cert.Certificate, 1)
+
+	again, err := serverCertificate()
+	require.NoError(err)
+	assert.Equal(cert.Certificate, again.Certificate)
+}
@@ -0,0 +1,129 @@
+package rdp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// See [MS-RDPBCGR] 2.2.1.1 and 2.2.1.2 for the Connection Request and
+// Connection Confirm PDUs.
+
+const (
+	tpktVersion   = 3
+	tpktHeaderLen = 4
+
+	x224ConnectionRequest = 0xe0
+	x224ConnectionConfirm = 0xd0
+
+	negotiationLen     = 8
+	negotiationRequest = 0x01
+	negotiationResp    = 0x02
+	negotiationFailure = 0x03
+
+	protocolSSL    = 0x00000001
+	protocolHybrid = 0x00000002
+
+	// sslRequiredByServer is the failure code sent to clients which do not
+	// support TLS.
+	sslRequiredByServer = 0x00000001
+)
+
+// readTpkt reads a single TPKT framed PDU from r, including its header.
+func readTpkt(r io.Reader) ([]byte, error) {
+	hdr := make([]byte, tpktHeaderLen)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, err
+	}
+	if hdr[0] != tpktVersion {
+		return nil, fmt.Errorf("unexpected tpkt version %d", hdr[0])
+	}
+	l := int(binary.BigEndian.Uint16(hdr[2:]))
+	if l < tpktHeaderLen+3 {
+		return nil, fmt.Errorf("invalid tpkt length %d", l)
+	}
+	pdu := make([]byte, l)
+	copy(pdu, hdr)
+	if _, err := io.ReadFull(r, pdu[tpktHeaderLen:]); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+// negotiationOffset returns the offset of the RDP negotiation structure of
+// the given type in an X.224 Connection Request or Connection Confirm PDU.
+func negotiationOffset(pdu []byte, code, negType byte) (int, error) {
+	if len(pdu) < tpktHeaderLen+2 || pdu[tpktHeaderLen+1] != code {
+		return 0, fmt.Errorf("unexpected x224 pdu, expected code %#x", code)
+	}
+	// The length indicator does not include itself.
+	end := tpktHeaderLen + 1 + int(pdu[tpktHeaderLen])
+	if end > len(pdu) || end-tpktHeaderLen < 7+negotiationLen {
+		return 0, errors.New("x224 pdu does not contain rdp negotiation data")
+	}
+	off := end - negotiationLen
+	if pdu[off] != negType || binary.LittleEndian.Uint16(pdu[off+2:]) != negotiationLen {
+		return 0, errors.New("x224 pdu does not contain rdp negotiation data")
+	}
+	return off, nil
+}
+
+// requestedProtocols returns the protocols requested by the client in an X.224
+// Connection Request PDU.
+func requestedProtocols(pdu []byte) (uint32, error) {
+	off, err := negotiationOffset(pdu, x224ConnectionRequest, negotiationRequest)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(pdu[off+4:]), nil
+}
+
+// withRequestedProtocols returns a copy of the X.224 Connection Request PDU
+// requesting the provided protocols.
+func withRequestedProtocols(pdu []byte, protocols uint32) ([]byte, error) {
+	off, err := negotiationOffset(pdu, x224ConnectionRequest, negotiationRequest)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte(nil), pdu...)
+	binary.LittleEndian.PutUint32(out[off+4:], protocols)
+	return out, nil
+}
+
+// selectedProtocol returns the protocol selected by the server in an X.224
+// Connection Confirm PDU.
+func selectedProtocol(pdu []byte) (uint32, error) {
+	if off, err := negotiationOffset(pdu, x224ConnectionConfirm, negotiationFailure); err == nil {
+		return 0, fmt.Errorf("server refused rdp negotiation with failure code %#x", binary.LittleEndian.Uint32(pdu[off+4:]))
+	}
+	off, err := negotiationOffset(pdu, x224ConnectionConfirm, negotiationResp)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(pdu[off+4:]), nil
+}
+
+// withSelectedProtocol returns a copy of the X.224 Connection Confirm PDU
+// selecting the provided protocol.
+func withSelectedProtocol(pdu []byte, protocol uint32) ([]byte, error) {
+	off, err := negotiationOffset(pdu, x224ConnectionConfirm, negotiationResp)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte(nil), pdu...)
+	binary.LittleEndian.PutUint32(out[off+4:], protocol)
+	return out, nil
+}
+
+// negotiationFailurePdu returns an X.224 Connection Confirm PDU carrying an
+// RDP negotiation failure with the provided failure code.
+func negotiationFailurePdu(code uint32) []byte {
+	pdu := []byte{
+		tpktVersion, 0x00, 0x00, 0x13,
+		0x0e, x224ConnectionConfirm, 0x00, 0x00, 0x00, 0x00, 0x00,
+		negotiationFailure, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	binary.LittleEndian.PutUint32(pdu[15:], code)
+	return pdu
+}
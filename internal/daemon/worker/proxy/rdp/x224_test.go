@@ -0,0 +1,92 @@
+package rdp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConnectionRequest(protocols uint32) []byte {
+	cookie := []byte("Cookie: mstshash=user\r\n")
+	pdu := []byte{tpktVersion, 0x00, 0x00, 0x00, 0x00, x224ConnectionRequest, 0x00, 0x00, 0x00, 0x00, 0x00}
+	pdu = append(pdu, cookie...)
+	pdu = append(pdu, negotiationRequest, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00)
+	binary.LittleEndian.PutUint32(pdu[len(pdu)-4:], protocols)
+	binary.BigEndian.PutUint16(pdu[2:], uint16(len(pdu)))
+	pdu[4] = byte(len(pdu) - 5)
+	return pdu
+}
+
+func testConnectionConfirm(selected uint32) []byte {
+	pdu := []byte{
+		tpktVersion, 0x00, 0x00, 0x13,
+		0x0e, x224ConnectionConfirm, 0x00, 0x00, 0x12, 0x34, 0x00,
+		negotiationResp, 0x1f, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	binary.LittleEndian.PutUint32(pdu[15:], selected)
+	return pdu
+}
+
+func TestReadTpkt(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cr := testConnectionRequest(protocolSSL)
+	r := bytes.NewReader(append(append([]byte(nil), cr...), 0x03, 0x00))
+	got, err := readTpkt(r)
+	require.NoError(err)
+	assert.Equal(cr, got)
+
+	_, err = readTpkt(r)
+	assert.Error(err)
+	_, err = readTpkt(bytes.NewReader([]byte{0x30, 0x00, 0x00, 0x08, 0, 0, 0, 0}))
+	assert.Error(err)
+}
+
+func TestConnectionRequest(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cr := testConnectionRequest(protocolSSL | protocolHybrid | 0x08)
+
+	got, err := requestedProtocols(cr)
+	require.NoError(err)
+	assert.Equal(uint32(protocolSSL|protocolHybrid|0x08), got)
+
+	patched, err := withRequestedProtocols(cr, protocolSSL|protocolHybrid)
+	require.NoError(err)
+	got, err = requestedProtocols(patched)
+	require.NoError(err)
+	assert.Equal(uint32(protocolSSL|protocolHybrid), got)
+	assert.Equal(cr[:len(cr)-4], patched[:len(patched)-4])
+
+	// A client only supporting standard RDP security does not send
+	// negotiation data.
+	legacy := []byte{tpktVersion, 0x00, 0x00, 0x0b, 0x06, x224ConnectionRequest, 0x00, 0x00, 0x00, 0x00, 0x00}
+	_, err = requestedProtocols(legacy)
+	assert.Error(err)
+	_, err = requestedProtocols(testConnectionConfirm(protocolSSL))
+	assert.Error(err)
+}
+
+func TestConnectionConfirm(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cc := testConnectionConfirm(protocolHybrid)
+
+	got, err := selectedProtocol(cc)
+	require.NoError(err)
+	assert.Equal(uint32(protocolHybrid), got)
+
+	patched, err := withSelectedProtocol(cc, protocolSSL)
+	require.NoError(err)
+	got, err = selectedProtocol(patched)
+	require.NoError(err)
+	assert.Equal(uint32(protocolSSL), got)
+	// The flags sent by the server are preserved.
+	assert.Equal(byte(0x1f), patched[12])
+
+	_, err = selectedProtocol(negotiationFailurePdu(sslRequiredByServer))
+	assert.Error(err)
+	off, err := negotiationOffset(negotiationFailurePdu(sslRequiredByServer), x224ConnectionConfirm, negotiationFailure)
+	require.NoError(err)
+	assert.Equal(uint32(sslRequiredByServer), binary.LittleEndian.Uint32(negotiationFailurePdu(sslRequiredByServer)[off+4:]))
+}
@@ -59,8 +59,9 @@ func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) erro
 		return fmt.Errorf("error marking connection as connected: %w", err)
 	}
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
+	// Get a wrapped net.Conn so we can use io.Copy, and record the activity
+	// of the connection for the session idle timeout
+	netConn := conf.TrackActivity(websocket.NetConn(ctx, conn, websocket.MessageBinary))
 
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
@@ -13,6 +13,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/session"
+	ua "go.uber.org/atomic"
 )
 
 // ValidateSessionTimeout is the duration of the timeout when the worker queries the
@@ -33,6 +34,9 @@ type ConnInfo struct {
 	// The time the controller has successfully reported that this connection is
 	// closed.
 	CloseTime time.Time
+
+	// The unix time, in nanoseconds, bytes were last proxied on the connection.
+	lastActivity *ua.Int64
 }
 
 // Session is the local representation of a session.  After initial loading
@@ -59,6 +63,13 @@ type Session interface {
 	GetHostKeys() ([]crypto.Signer, error)
 	GetCredentials() []*pbs.Credential
 	GetExpiration() time.Time
+	// GetIdleTimeout returns the amount of time the session can go without
+	// any traffic before it is canceled. Zero means no idle timeout.
+	GetIdleTimeout() time.Duration
+	// GetLastActivityTime returns the last time bytes were proxied on any of
+	// the connections of the session, or the time the session was loaded on
+	// this worker if none were.
+	GetLastActivityTime() time.Time
 	GetCertificate() *x509.Certificate
 	GetPrivateKey() []byte
 	GetId() string
@@ -81,6 +92,16 @@ type Session interface {
 	// request
 	RequestCancel(ctx context.Context) error
 
+	// RequestIdleCancel sends a request to the controller to cancel the session
+	// because it has been idle for longer than its idle timeout. If there is
+	// no error the local session's status is updated with the result of the
+	// cancel request
+	RequestIdleCancel(ctx context.Context) error
+
+	// RecordConnectionActivity records that bytes were just proxied on the
+	// connection. It is called by the proxies.
+	RecordConnectionActivity(connId string)
+
 	// RequestActivate sends session activation request to the controller.  The Session's
 	// status is then updated with the result of the call.  After a successful
 	// call to RequestActivate, subsequent calls will fail.
@@ -110,6 +131,8 @@ type sess struct {
 	status      pbs.SESSIONSTATUS
 	cert        *x509.Certificate
 	sessionId   string
+	// loadTime is the time the session was loaded on this worker.
+	loadTime time.Time
 }
 
 func newSess(client pbs.SessionServiceClient, resp *pbs.LookupSessionResponse) (*sess, error) {
@@ -132,6 +155,7 @@ func newSess(client pbs.SessionServiceClient, resp *pbs.LookupSessionResponse) (
 		status:      resp.GetStatus(),
 		cert:        parsedCert,
 		sessionId:   resp.GetAuthorization().GetSessionId(),
+		loadTime:    time.Now(),
 	}
 	return s, nil
 }
@@ -242,6 +266,35 @@ func (s *sess) GetExpiration() time.Time {
 	return s.resp.GetExpiration().AsTime()
 }
 
+func (s *sess) GetIdleTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return time.Duration(s.resp.GetIdleTimeoutSeconds()) * time.Second
+}
+
+func (s *sess) GetLastActivityTime() time.Time {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	last := s.loadTime
+	for _, ci := range s.connInfoMap {
+		if ci.lastActivity == nil {
+			continue
+		}
+		if t := time.Unix(0, ci.lastActivity.Load()); t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+func (s *sess) RecordConnectionActivity(connId string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if ci, ok := s.connInfoMap[connId]; ok && ci.lastActivity != nil {
+		ci.lastActivity.Store(time.Now().UnixNano())
+	}
+}
+
 func (s *sess) GetCertificate() *x509.Certificate {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -259,7 +312,16 @@ func (s *sess) GetId() string {
 }
 
 func (s *sess) RequestCancel(ctx context.Context) error {
-	st, err := cancel(ctx, s.client, s.GetId())
+	st, err := cancel(ctx, s.client, s.GetId(), "")
+	if err != nil {
+		return err
+	}
+	s.ApplyLocalStatus(st)
+	return nil
+}
+
+func (s *sess) RequestIdleCancel(ctx context.Context) error {
+	st, err := cancel(ctx, s.client, s.GetId(), session.IdleTimeout.String())
 	if err != nil {
 		return err
 	}
@@ -288,6 +350,7 @@ func (s *sess) RequestAuthorizeConnection(ctx context.Context, workerId string,
 		return ConnInfo{}, connsLeft, err
 	}
 	ci.connCtxCancelFunc = connCancel
+	ci.lastActivity = ua.NewInt64(time.Now().UnixNano())
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	s.connInfoMap[ci.Id] = ci
@@ -366,10 +429,11 @@ func activate(ctx context.Context, sessClient pbs.SessionServiceClient, sessionI
 }
 
 // cancel is a helper worker function that sends session cancellation request to the
-// controller.
-func cancel(ctx context.Context, sessClient pbs.SessionServiceClient, sessionId string) (pbs.SESSIONSTATUS, error) {
+// controller. reason is empty unless the session is canceled for being idle.
+func cancel(ctx context.Context, sessClient pbs.SessionServiceClient, sessionId, reason string) (pbs.SESSIONSTATUS, error) {
 	resp, err := sessClient.CancelSession(ctx, &pbs.CancelSessionRequest{
 		SessionId: sessionId,
+		Reason:    reason,
 	})
 	if err != nil {
 		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, fmt.Errorf("error canceling session: %w", err)
@@ -140,6 +140,69 @@ func TestSession_RequestCancel(t *testing.T) {
 	assert.Equal(t, pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING, sess.GetStatus())
 }
 
+func TestSession_RequestIdleCancel(t *testing.T) {
+	mockClient := pbs.NewMockSessionServiceClient()
+	var gotReason string
+	mockClient.CancelSessionFn = func(_ context.Context, req *pbs.CancelSessionRequest) (*pbs.CancelSessionResponse, error) {
+		gotReason = req.GetReason()
+		return &pbs.CancelSessionResponse{Status: pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING}, nil
+	}
+	sess := &sess{
+		client: mockClient,
+		resp: &pbs.LookupSessionResponse{
+			Version:            1,
+			Status:             pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+			ConnectionLimit:    -1,
+			IdleTimeoutSeconds: 60,
+		},
+		status: pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+	}
+	assert.Equal(t, time.Minute, sess.GetIdleTimeout())
+	assert.NoError(t, sess.RequestIdleCancel(context.Background()))
+	assert.Equal(t, session.IdleTimeout.String(), gotReason)
+	assert.Equal(t, pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING, sess.GetStatus())
+
+	assert.NoError(t, sess.RequestCancel(context.Background()))
+	assert.Empty(t, gotReason)
+}
+
+func TestSession_RecordConnectionActivity(t *testing.T) {
+	mockClient := pbs.NewMockSessionServiceClient()
+	mockClient.AuthorizeConnectionFn = func(ctx context.Context, request *pbs.AuthorizeConnectionRequest) (*pbs.AuthorizeConnectionResponse, error) {
+		return &pbs.AuthorizeConnectionResponse{
+			ConnectionId:    "conn1",
+			Status:          pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED,
+			ConnectionsLeft: -1,
+		}, nil
+	}
+	loadTime := time.Now().Add(-time.Hour)
+	sess := &sess{
+		client:      mockClient,
+		connInfoMap: make(map[string]*ConnInfo),
+		resp: &pbs.LookupSessionResponse{
+			Status:          pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+			ConnectionLimit: -1,
+		},
+		status:   pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+		loadTime: loadTime,
+	}
+	assert.Equal(t, loadTime, sess.GetLastActivityTime())
+
+	// Unknown connections are ignored.
+	sess.RecordConnectionActivity("unknown")
+	assert.Equal(t, loadTime, sess.GetLastActivityTime())
+
+	_, cancel := context.WithCancel(context.Background())
+	_, _, err := sess.RequestAuthorizeConnection(context.Background(), "workerid", cancel)
+	require.NoError(t, err)
+	authorized := sess.GetLastActivityTime()
+	assert.True(t, authorized.After(loadTime))
+
+	time.Sleep(time.Millisecond)
+	sess.RecordConnectionActivity("conn1")
+	assert.True(t, sess.GetLastActivityTime().After(authorized))
+}
+
 func TestSession_RequestAuthorizeConnection(t *testing.T) {
 	mockClient := pbs.NewMockSessionServiceClient()
 	mockClient.AuthorizeConnectionFn = func(ctx context.Context, request *pbs.AuthorizeConnectionRequest) (*pbs.AuthorizeConnectionResponse, error) {
@@ -162,6 +162,7 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 			event.WithInfoMsg("error making status request to controller"))
 	}
 	versionInfo := version.Get()
+	hostHealthCheckResults := w.hostHealthChecker.takeResults()
 	result, err := client.Status(statusCtx, &pbs.StatusRequest{
 		Jobs: activeJobs,
 		WorkerStatus: &pb.ServerWorkerStatus{
@@ -173,10 +174,16 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 			ReleaseVersion:   versionInfo.FullVersionNumber(false),
 			OperationalState: w.operationalState.Load().(server.OperationalState).String(),
 		},
-		UpdateTags: w.updateTags.Load(),
+		UpdateTags:             w.updateTags.Load(),
+		HostHealthCheckResults: hostHealthCheckResults,
 	})
 	if err != nil {
 		event.WriteError(statusCtx, op, err, event.WithInfoMsg("error making status request to controller"))
+		// Keep the host health check results around for the next status
+		w.hostHealthChecker.requeueResults(hostHealthCheckResults)
+		// The managed workers an HCP managed worker got from its last status
+		// may be gone, so make sure it can reach its HCP Boundary cluster.
+		w.fallBackToHcpbCluster(cancelCtx, addressReceivers)
 		// Check for last successful status. Ignore nil last status, this probably
 		// means that we've never connected to a controller, and as such probably
 		// don't have any sessions to worry about anyway.
@@ -211,6 +218,7 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 	}
 
 	w.updateTags.Store(false)
+	w.hostHealthChecker.setChecks(result.GetHostHealthChecks())
 	var addrs []string
 	// This may be empty if we are in a multiple hop scenario
 	if len(result.CalculatedUpstreams) > 0 {
@@ -218,7 +226,7 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 		for _, v := range result.CalculatedUpstreams {
 			addrs = append(addrs, v.Address)
 		}
-	} else if w.conf.RawConfig.HcpbClusterId != "" && len(w.conf.RawConfig.Worker.InitialUpstreams) == 0 {
+	} else if w.hcpbManaged() {
 		// This is a worker that is one hop away from managed workers, so attempt to get that list
 		hcpbWorkersCtx, hcpbWorkersCancel := context.WithTimeout(cancelCtx, common.StatusTimeout)
 		defer hcpbWorkersCancel()
@@ -233,10 +241,18 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 		}
 	}
 
+	// The upstreams need to be set back if they were falling back to the HCP
+	// Boundary cluster
+	fellBack := w.hcpbUpstreamFallback.CAS(true, false)
 	if len(addrs) > 0 {
 		lastStatus := w.lastStatusSuccess.Load().(*LastStatusInformation)
 		// Compare upstreams; update resolver if there is a difference, and emit an event with old and new addresses
-		if lastStatus != nil && !strutil.EquivalentSlices(lastStatus.LastCalculatedUpstreams, addrs) {
+		if fellBack {
+			event.WriteSysEvent(cancelCtx, op, fmt.Sprintf("Upstreams reached again; upstreams set to: %s", addrs))
+			for _, as := range *addressReceivers {
+				as.SetAddresses(addrs)
+			}
+		} else if lastStatus != nil && !strutil.EquivalentSlices(lastStatus.LastCalculatedUpstreams, addrs) {
 			upstreamsMessage := fmt.Sprintf("Upstreams has changed; old upstreams were: %s, new upstreams are: %s", lastStatus.LastCalculatedUpstreams, addrs)
 			event.WriteSysEvent(cancelCtx, op, upstreamsMessage)
 			for _, as := range *addressReceivers {
@@ -291,6 +307,10 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 		}
 	}
 
+	// Cancel the sessions which have been idle for longer than their idle
+	// timeout, so their connections are closed by the cleanup below.
+	w.cancelIdleSessions(cancelCtx, sessionManager)
+
 	// Standard cleanup: Run through current jobs. Cancel connections
 	// for any canceling session or any session that is expired.
 	w.cleanupConnections(cancelCtx, false, sessionManager)
@@ -320,6 +340,37 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 	}
 }
 
+// cancelIdleSessions requests the controller to cancel the pending and active
+// sessions which have gone without any traffic on their connections for longer
+// than their idle timeout. The sessions are then terminated with the idle
+// timeout termination reason once their connections are closed.
+func (w *Worker) cancelIdleSessions(cancelCtx context.Context, sessionManager session.Manager) {
+	const op = "worker.(Worker).cancelIdleSessions"
+	sessionManager.ForEachLocalSession(func(s session.Session) bool {
+		switch s.GetStatus() {
+		case pbs.SESSIONSTATUS_SESSIONSTATUS_PENDING, pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE:
+		default:
+			return true
+		}
+		idleTimeout := s.GetIdleTimeout()
+		if idleTimeout <= 0 {
+			return true
+		}
+		idleTime := time.Since(s.GetLastActivityTime())
+		if idleTime < idleTimeout {
+			return true
+		}
+		cancelSessionCtx, cancelSessionCancel := context.WithTimeout(cancelCtx, common.StatusTimeout)
+		defer cancelSessionCancel()
+		if err := s.RequestIdleCancel(cancelSessionCtx); err != nil {
+			event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error canceling idle session", "session_id", s.GetId()))
+			return true
+		}
+		event.WriteSysEvent(cancelCtx, op, "canceled idle session", "session_id", s.GetId(), "idle_timeout", idleTimeout.String(), "idle_time", idleTime.String())
+		return true
+	})
+}
+
 // cleanupConnections walks all sessions and shuts down all proxy connections.
 // After the local connections are terminated, they are requested to be marked
 // close on the controller.
@@ -29,7 +29,6 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/nodeenrollment"
 	nodeenet "github.com/hashicorp/nodeenrollment/net"
-	nodeefile "github.com/hashicorp/nodeenrollment/storage/file"
 	"github.com/hashicorp/nodeenrollment/types"
 	"github.com/mr-tron/base58"
 	ua "go.uber.org/atomic"
@@ -89,6 +88,9 @@ type Worker struct {
 	controllerStatusConn *atomic.Value
 	everAuthenticated    *ua.Uint32
 	lastStatusSuccess    *atomic.Value
+	// hcpbUpstreamFallback is set while the upstreams of an HCP managed
+	// worker include its HCP Boundary cluster because it can not reach them.
+	hcpbUpstreamFallback *ua.Bool
 	workerStartTime      time.Time
 	operationalState     *atomic.Value
 
@@ -108,7 +110,7 @@ type Worker struct {
 	updateTags *ua.Bool
 
 	// The storage for node enrollment
-	WorkerAuthStorage             *nodeefile.Storage
+	WorkerAuthStorage             AuthStorage
 	WorkerAuthCurrentKeyId        *ua.String
 	WorkerAuthRegistrationRequest string
 	workerAuthSplitListener       *nodeenet.SplitListener
@@ -123,6 +125,10 @@ type Worker struct {
 	TestOverrideAuthRotationPeriod time.Duration
 
 	statusLock sync.Mutex
+
+	// hostHealthChecker runs the host health checks assigned by the
+	// controller.
+	hostHealthChecker *hostHealthChecker
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -138,12 +144,14 @@ func New(conf *Config) (*Worker, error) {
 		controllerStatusConn:   new(atomic.Value),
 		everAuthenticated:      ua.NewUint32(authenticationStatusNeverAuthenticated),
 		lastStatusSuccess:      new(atomic.Value),
+		hcpbUpstreamFallback:   ua.NewBool(false),
 		controllerMultihopConn: new(atomic.Value),
 		tags:                   new(atomic.Value),
 		updateTags:             ua.NewBool(false),
 		nonceFn:                base62.Random,
 		WorkerAuthCurrentKeyId: new(ua.String),
 		operationalState:       new(atomic.Value),
+		hostHealthChecker:      newHostHealthChecker(),
 	}
 
 	if downstreamRouterFactory != nil {
@@ -257,11 +265,8 @@ func (w *Worker) Start() error {
 		// Note that if a controller-generated activation token has been
 		// supplied, we do not output a fetch request; we attempt to use that
 		// directly later.
-		var err error
-		w.WorkerAuthStorage, err = nodeefile.New(w.baseContext,
-			nodeefile.WithBaseDirectory(w.conf.RawConfig.Worker.AuthStoragePath))
-		if err != nil {
-			return errors.Wrap(w.baseContext, err, op, errors.WithMsg("error loading worker auth storage directory"))
+		if err := w.setupAuthStorage(w.baseContext); err != nil {
+			return errors.Wrap(w.baseContext, err, op)
 		}
 
 		var createNodeAuthCreds bool
@@ -389,7 +394,7 @@ func (w *Worker) Start() error {
 	// Rather than deal with some of the potential error conditions for Add on
 	// the waitgroup vs. Done (in case a function exits immediately), we will
 	// always start rotation and simply exit early if we're using KMS
-	w.tickerWg.Add(3)
+	w.tickerWg.Add(5)
 	go func() {
 		defer w.tickerWg.Done()
 		w.startStatusTicking(w.baseContext, w.sessionManager, &w.addressReceivers)
@@ -398,6 +403,14 @@ func (w *Worker) Start() error {
 		defer w.tickerWg.Done()
 		w.startAuthRotationTicking(w.baseContext)
 	}()
+	go func() {
+		defer w.tickerWg.Done()
+		w.startHostHealthCheckTicking(w.baseContext)
+	}()
+	go func() {
+		defer w.tickerWg.Done()
+		w.startHcpbDiscoveryTicking(w.baseContext)
+	}()
 	go func() {
 		defer w.tickerWg.Done()
 		if w.downstreamRoutes != nil {
@@ -272,3 +272,45 @@ func TestSetupWorkerAuthStorage(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupWorkerAuthStorage_EncryptPlaintextCreds(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := db.TestWrapper(t)
+	keyId, err := wrapper.KeyId(ctx)
+	require.NoError(t, err)
+
+	tmpDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(tmpDir)) })
+
+	// Store plaintext node credentials, as a worker without a
+	// worker-auth-storage KMS would have
+	storage, err := nodeefile.New(ctx, nodeefile.WithBaseDirectory(tmpDir))
+	require.NoError(t, err)
+	plainCreds, err := types.NewNodeCredentials(ctx, storage)
+	require.NoError(t, err)
+	plainKeyId, err := nodeenrollment.KeyIdFromPkix(plainCreds.CertificatePublicKeyPkix)
+	require.NoError(t, err)
+
+	tw := NewTestWorker(t, &TestWorkerOpts{
+		WorkerAuthStorageKms:  wrapper,
+		WorkerAuthStoragePath: tmpDir,
+		DisableAutoStart:      true,
+	})
+	t.Cleanup(tw.Shutdown)
+	require.NoError(t, tw.Worker().Start())
+
+	// The existing credentials are kept...
+	assert.Equal(t, plainKeyId, tw.Worker().WorkerAuthCurrentKeyId.Load())
+
+	// ...but are now stored encrypted
+	stored := &types.NodeCredentials{Id: string(nodeenrollment.CurrentId)}
+	require.NoError(t, storage.Load(ctx, stored))
+	assert.Equal(t, keyId, stored.WrappingKeyId)
+	assert.NotEqual(t, plainCreds.CertificatePrivateKeyPkcs8, stored.CertificatePrivateKeyPkcs8)
+
+	loaded, err := types.LoadNodeCredentials(ctx, storage, nodeenrollment.CurrentId, nodeenrollment.WithWrapper(wrapper))
+	require.NoError(t, err)
+	assert.Equal(t, plainCreds.CertificatePrivateKeyPkcs8, loaded.CertificatePrivateKeyPkcs8)
+}
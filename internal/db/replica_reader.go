@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// replicaReadsKey is the context key marking the reads which can be served by
+// the read replica.
+type replicaReadsKey struct{}
+
+// WithReplicaReads returns a context whose reads through a ReplicaReader are
+// served by the read replica. It is only meant for the reads which don't need
+// to see the latest writes, like the ones of the list endpoints, and must not
+// be used for reads whose results are written back. Reads which authorize
+// requests, like the validation of auth tokens, must check anything the
+// replica may be behind on against the primary.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// replicaReads reports whether the context was returned by WithReplicaReads.
+func replicaReads(ctx context.Context) bool {
+	ok, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return ok
+}
+
+// ReplicaReader is a Reader which reads from a read replica of the database
+// when the context was returned by WithReplicaReads, and from the primary
+// otherwise. Lookups of a single resource which is not found on the replica,
+// for instance because it was just created and has not been replicated yet,
+// are retried on the primary. Searches and queries are only run on the
+// replica.
+type ReplicaReader struct {
+	replica Reader
+	primary Reader
+}
+
+var _ Reader = (*ReplicaReader)(nil)
+
+// NewReplicaReader creates a new ReplicaReader reading from the replica, and
+// falling back to the primary for lookups of resources not found on the
+// replica.
+func NewReplicaReader(ctx context.Context, replica, primary Reader) (*ReplicaReader, error) {
+	const op = "db.NewReplicaReader"
+	switch {
+	case isNil(replica):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing replica reader")
+	case isNil(primary):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing primary reader")
+	}
+	return &ReplicaReader{
+		replica: replica,
+		primary: primary,
+	}, nil
+}
+
+// LookupById will lookup a resource by its primary key id on the replica,
+// and on the primary if it is not found on the replica. See Db.LookupById
+func (r *ReplicaReader) LookupById(ctx context.Context, resource interface{}, opt ...Option) error {
+	if !replicaReads(ctx) {
+		return r.primary.LookupById(ctx, resource, opt...)
+	}
+	err := r.replica.LookupById(ctx, resource, opt...)
+	if errors.IsNotFoundError(err) {
+		return r.primary.LookupById(ctx, resource, opt...)
+	}
+	return err
+}
+
+// LookupByPublicId will lookup a resource by its public_id on the replica,
+// and on the primary if it is not found on the replica. See
+// Db.LookupByPublicId
+func (r *ReplicaReader) LookupByPublicId(ctx context.Context, resource ResourcePublicIder, opt ...Option) error {
+	if !replicaReads(ctx) {
+		return r.primary.LookupByPublicId(ctx, resource, opt...)
+	}
+	err := r.replica.LookupByPublicId(ctx, resource, opt...)
+	if errors.IsNotFoundError(err) {
+		return r.primary.LookupByPublicId(ctx, resource, opt...)
+	}
+	return err
+}
+
+// LookupWhere will lookup the first resource using a where clause with
+// parameters on the replica, and on the primary if it is not found on the
+// replica. See Db.LookupWhere
+func (r *ReplicaReader) LookupWhere(ctx context.Context, resource interface{}, where string, args []interface{}, opt ...Option) error {
+	if !replicaReads(ctx) {
+		return r.primary.LookupWhere(ctx, resource, where, args, opt...)
+	}
+	err := r.replica.LookupWhere(ctx, resource, where, args, opt...)
+	if errors.IsNotFoundError(err) {
+		return r.primary.LookupWhere(ctx, resource, where, args, opt...)
+	}
+	return err
+}
+
+// SearchWhere will search for all the resources it can find on the replica
+// using a where clause with parameters. See Db.SearchWhere
+func (r *ReplicaReader) SearchWhere(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+	if !replicaReads(ctx) {
+		return r.primary.SearchWhere(ctx, resources, where, args, opt...)
+	}
+	return r.replica.SearchWhere(ctx, resources, where, args, opt...)
+}
+
+// Query will run the raw query on the replica. See Db.Query
+func (r *ReplicaReader) Query(ctx context.Context, sql string, values []interface{}, opt ...Option) (*sql.Rows, error) {
+	if !replicaReads(ctx) {
+		return r.primary.Query(ctx, sql, values, opt...)
+	}
+	return r.replica.Query(ctx, sql, values, opt...)
+}
+
+// ScanRows will scan sql rows into the interface provided. See Db.ScanRows
+func (r *ReplicaReader) ScanRows(ctx context.Context, rows *sql.Rows, result interface{}) error {
+	if !replicaReads(ctx) {
+		return r.primary.ScanRows(ctx, rows, result)
+	}
+	return r.replica.ScanRows(ctx, rows, result)
+}
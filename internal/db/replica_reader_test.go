@@ -0,0 +1,86 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/db_test"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplicaReader(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := db.NewReplicaReader(ctx, nil, rw)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = db.NewReplicaReader(ctx, rw, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	r, err := db.NewReplicaReader(ctx, rw, rw)
+	require.NoError(t, err)
+	assert.NotNil(t, r)
+}
+
+func TestReplicaReader(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	primaryConn, _ := db.TestSetup(t, "postgres")
+	db.TestCreateTables(t, primaryConn)
+	primary := db.New(primaryConn)
+	replicaConn, _ := db.TestSetup(t, "postgres")
+	db.TestCreateTables(t, replicaConn)
+	replica := db.New(replicaConn)
+
+	r, err := db.NewReplicaReader(ctx, replica, primary)
+	require.NoError(err)
+
+	// A user which has been replicated
+	replicated, err := db_test.NewTestUser()
+	require.NoError(err)
+	replicated.Name = "replicated"
+	require.NoError(primary.Create(ctx, replicated))
+	require.NoError(replica.Create(ctx, replicated.Clone().(*db_test.TestUser)))
+
+	// A user which has not been replicated yet
+	pending, err := db_test.NewTestUser()
+	require.NoError(err)
+	pending.Name = "pending"
+	require.NoError(primary.Create(ctx, pending))
+
+	replicaCtx := db.WithReplicaReads(ctx)
+
+	// Lookups fall back to the primary
+	found := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: pending.PublicId}}
+	require.NoError(r.LookupByPublicId(replicaCtx, found))
+	assert.Equal("pending", found.Name)
+	found = &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: pending.PublicId}}
+	require.NoError(r.LookupById(replicaCtx, found))
+	assert.Equal("pending", found.Name)
+	found = &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{}}
+	require.NoError(r.LookupWhere(replicaCtx, found, "name = ?", []interface{}{"pending"}))
+	assert.Equal(pending.PublicId, found.PublicId)
+
+	found = &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: replicated.PublicId}}
+	require.NoError(r.LookupByPublicId(replicaCtx, found))
+	assert.Equal("replicated", found.Name)
+
+	missing := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: "u_1234567890"}}
+	err = r.LookupByPublicId(replicaCtx, missing)
+	assert.True(errors.IsNotFoundError(err))
+
+	// Searches only use the replica
+	var users []*db_test.TestUser
+	require.NoError(r.SearchWhere(replicaCtx, &users, "1 = 1", nil))
+	require.Len(users, 1)
+	assert.Equal(replicated.PublicId, users[0].PublicId)
+
+	// Reads without WithReplicaReads only use the primary
+	users = nil
+	require.NoError(r.SearchWhere(ctx, &users, "1 = 1", nil))
+	assert.Len(users, 2)
+}
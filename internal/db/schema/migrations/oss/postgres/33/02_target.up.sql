@@ -63,6 +63,7 @@ begin;
     'target_credential_source is a view where each row contains a credential source and the id of the parent credential store. '
     'No encrypted data is returned. This view can be used to retrieve data which will be returned external to boundary.';
 
+  -- Replaced in 70/01_credential_source_credential_type.up.sql
   create view credential_source_all_types
   as
     select
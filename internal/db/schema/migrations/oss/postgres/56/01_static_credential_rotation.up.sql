@@ -0,0 +1,164 @@
+begin;
+
+  create table credential_static_rotation (
+    credential_id wt_public_id primary key
+      constraint credential_static_fkey
+        references credential_static (public_id)
+        on delete cascade
+        on update cascade,
+    store_id wt_public_id not null,
+    project_id wt_public_id not null,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+
+    rotator text not null
+      constraint rotator_must_not_be_empty
+        check(length(trim(rotator)) > 0),
+    attributes bytea not null,
+    rotation_interval_seconds integer not null
+      constraint rotation_interval_seconds_must_be_greater_than_zero
+        check(rotation_interval_seconds > 0),
+    grace_period_seconds integer not null default 0
+      constraint grace_period_seconds_must_not_be_negative
+        check(grace_period_seconds >= 0),
+    secret_version bigint not null default 1
+      constraint secret_version_must_be_greater_than_zero
+        check(secret_version > 0),
+    last_rotation_time timestamp with time zone,
+    next_rotation_time timestamp with time zone not null
+  );
+  comment on table credential_static_rotation is
+    'credential_static_rotation is a table where each row contains the rotation configuration of a static credential.';
+
+  create index credential_static_rotation_next_rotation_time_ix
+    on credential_static_rotation (next_rotation_time);
+
+  -- insert_credential_static_rotation sets the store_id and project_id of a
+  -- rotation configuration from the static credential it belongs to.
+  create function insert_credential_static_rotation() returns trigger
+  as $$
+  begin
+    select store_id, project_id
+      into new.store_id, new.project_id
+      from credential_static
+     where public_id = new.credential_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- set_credential_static_rotation_next_rotation_time calculates when a
+  -- static credential is due for rotation. It is calculated from the last
+  -- rotation, or from now if the credential has never been rotated.
+  create function set_credential_static_rotation_next_rotation_time() returns trigger
+  as $$
+  begin
+    if tg_op = 'INSERT'
+      or new.rotation_interval_seconds is distinct from old.rotation_interval_seconds
+      or new.last_rotation_time is distinct from old.last_rotation_time then
+      new.next_rotation_time = coalesce(new.last_rotation_time, current_timestamp)
+        + make_interval(secs => new.rotation_interval_seconds);
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_credential_static_rotation before insert on credential_static_rotation
+    for each row execute procedure insert_credential_static_rotation();
+
+  create trigger set_next_rotation_time before insert or update on credential_static_rotation
+    for each row execute procedure set_credential_static_rotation_next_rotation_time();
+
+  create trigger update_version_column after update on credential_static_rotation
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_rotation
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_rotation
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_rotation
+    for each row execute procedure immutable_columns('credential_id', 'store_id', 'project_id', 'create_time');
+
+  create table credential_static_retired_secret (
+    credential_id wt_public_id not null
+      constraint credential_static_rotation_fkey
+        references credential_static_rotation (credential_id)
+        on delete cascade
+        on update cascade,
+    secret_version bigint not null
+      constraint secret_version_must_be_greater_than_zero
+        check(secret_version > 0),
+    create_time wt_timestamp,
+    retire_time timestamp with time zone not null,
+    secret_encrypted bytea not null
+      constraint secret_encrypted_must_not_be_empty
+        check(length(secret_encrypted) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    primary key(credential_id, secret_version)
+  );
+  comment on table credential_static_retired_secret is
+    'credential_static_retired_secret is a table where each row contains a previous secret of a rotated static credential. '
+    'The secret is kept until no session that was created before the rotation is still using it.';
+
+  -- insert_credential_static_retired_secret sets the retire_time of a retired
+  -- secret from the grace period of the rotation configuration.
+  create function insert_credential_static_retired_secret() returns trigger
+  as $$
+  begin
+    select current_timestamp + make_interval(secs => grace_period_seconds)
+      into new.retire_time
+      from credential_static_rotation
+     where credential_id = new.credential_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_credential_static_retired_secret before insert on credential_static_retired_secret
+    for each row execute procedure insert_credential_static_retired_secret();
+
+  create trigger default_create_time_column before insert on credential_static_retired_secret
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_retired_secret
+    for each row execute procedure immutable_columns('credential_id', 'secret_version', 'create_time', 'retire_time', 'secret_encrypted', 'key_id');
+
+  -- credential_static_retired_secret_retirable is a view of the retired
+  -- secrets that have passed their retire_time and are not used by any
+  -- session that was created before the secret was retired and has not yet
+  -- terminated.
+  create view credential_static_retired_secret_retirable as
+    select rs.credential_id,
+           rs.secret_version,
+           rs.create_time,
+           rs.retire_time,
+           rs.secret_encrypted,
+           rs.key_id,
+           r.project_id
+      from credential_static_retired_secret rs
+      join credential_static_rotation r
+        on r.credential_id = rs.credential_id
+     where rs.retire_time <= current_timestamp
+       and not exists (
+             select 1
+               from session_credential_static scs
+               join session s
+                 on s.public_id = scs.session_id
+               join session_state ss
+                 on ss.session_id = s.public_id
+              where scs.credential_static_id = rs.credential_id
+                and s.create_time < rs.create_time
+                and ss.end_time is null
+                and ss.state != 'terminated'
+           );
+
+  insert into oplog_ticket (name, version)
+    values
+      ('credential_static_rotation', 1);
+
+commit;
@@ -0,0 +1,70 @@
+begin;
+
+  -- host_set_health_check configures the probes workers run against the hosts
+  -- of a host set. A host set has at most one health check.
+  create table host_set_health_check (
+    host_set_id wt_public_id primary key
+      constraint host_set_fkey
+        references host_set (public_id)
+        on delete cascade
+        on update cascade,
+    protocol text not null
+      constraint protocol_must_be_tcp_or_http
+        check(protocol in ('tcp', 'http')),
+    port integer not null
+      constraint port_must_be_a_valid_port_number
+        check(port > 0 and port < 65536),
+    -- path is only used by http probes.
+    path text
+      constraint path_only_allowed_with_http_protocol
+        check(path is null or protocol = 'http'),
+    interval_seconds integer not null default 30
+      constraint interval_seconds_must_be_greater_than_0
+        check(interval_seconds > 0),
+    unhealthy_threshold integer not null default 3
+      constraint unhealthy_threshold_must_be_greater_than_0
+        check(unhealthy_threshold > 0),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+  comment on table host_set_health_check is
+    'host_set_health_check is a table where each row configures the health check of a host set.';
+
+  create trigger immutable_columns before update on host_set_health_check
+    for each row execute procedure immutable_columns('host_set_id', 'create_time');
+
+  create trigger update_time_column before update on host_set_health_check
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_set_health_check
+    for each row execute procedure default_create_time();
+
+  -- host_health records the result of the health check probes of the hosts of
+  -- a host set. Rows are deleted with the health check of the host set.
+  create table host_health (
+    host_set_id wt_public_id not null
+      constraint host_set_health_check_fkey
+        references host_set_health_check (host_set_id)
+        on delete cascade
+        on update cascade,
+    host_id wt_public_id not null
+      constraint host_fkey
+        references host (public_id)
+        on delete cascade
+        on update cascade,
+    healthy boolean not null,
+    consecutive_failures integer not null default 0
+      constraint consecutive_failures_must_not_be_negative
+        check(consecutive_failures >= 0),
+    last_error text,
+    last_check_time wt_timestamp,
+    primary key(host_set_id, host_id)
+  );
+  comment on table host_health is
+    'host_health is a table where each row is the health of a host, as probed by the health check of a host set.';
+
+  create trigger immutable_columns before update on host_health
+    for each row execute procedure immutable_columns('host_set_id', 'host_id');
+
+commit;
@@ -0,0 +1,97 @@
+begin;
+
+  create table notification_event_type_enm (
+    name text primary key
+      constraint only_predefined_notification_event_types_allowed
+        check (
+          name in (
+            'session.authorized',
+            'session.activated',
+            'session.terminated',
+            'worker.connected',
+            'worker.disconnected'
+          )
+        )
+  );
+  comment on table notification_event_type_enm is
+    'notification_event_type_enm is an enumeration table for the types of notification events.';
+
+  insert into notification_event_type_enm (name)
+  values
+    ('session.authorized'),
+    ('session.activated'),
+    ('session.terminated'),
+    ('worker.connected'),
+    ('worker.disconnected');
+
+  -- notification_event is an outbox of the events which have not been
+  -- delivered to the configured webhooks yet. Rows are deleted by the
+  -- notification delivery job once they have been delivered or dead-lettered.
+  create table notification_event (
+    id bigint generated always as identity primary key,
+    event_type text not null
+      constraint notification_event_type_enm_fkey
+        references notification_event_type_enm (name)
+        on delete restrict
+        on update cascade,
+    -- scope_id is the scope of the resource the event is about. It is not a
+    -- foreign key so events are still delivered after the scope is deleted.
+    scope_id wt_scope_id not null,
+    resource_id wt_public_id not null,
+    create_time wt_timestamp
+  );
+  comment on table notification_event is
+    'notification_event is a table where each row is an event waiting to be delivered to webhooks.';
+
+  create trigger default_create_time_column before insert on notification_event
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on notification_event
+    for each row execute procedure immutable_columns('id', 'event_type', 'scope_id', 'resource_id', 'create_time');
+
+  create function insert_session_state_notification_event() returns trigger
+  as $$
+  begin
+    insert into notification_event
+      (event_type, scope_id, resource_id)
+    select
+      case new.state
+        when 'pending'    then 'session.authorized'
+        when 'active'     then 'session.activated'
+        when 'terminated' then 'session.terminated'
+      end,
+      s.project_id,
+      s.public_id
+    from session s
+    where s.public_id = new.session_id;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_session_state_notification_event is
+    'insert_session_state_notification_event is an after insert trigger function for the session_state table '
+    'which adds a notification event for the pending, active and terminated session states.';
+
+  create trigger insert_session_state_notification_event after insert on session_state
+    for each row
+    when (new.state in ('pending', 'active', 'terminated'))
+    execute procedure insert_session_state_notification_event();
+
+  -- notification_worker_presence records whether a worker was considered
+  -- connected the last time the notification delivery job ran. It is used to
+  -- detect the workers which connected or disconnected since.
+  create table notification_worker_presence (
+    worker_id wt_public_id primary key
+      constraint server_worker_fkey
+        references server_worker (public_id)
+        on delete cascade
+        on update cascade,
+    connected boolean not null,
+    update_time wt_timestamp
+  );
+  comment on table notification_worker_presence is
+    'notification_worker_presence is a table where each row records whether a worker was last seen connected.';
+
+  create trigger update_time_column before update on notification_worker_presence
+    for each row execute procedure update_time_column();
+
+commit;
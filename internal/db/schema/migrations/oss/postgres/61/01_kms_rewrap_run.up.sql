@@ -0,0 +1,43 @@
+begin;
+
+  -- the encrypted token of a vault credential store is rewrapped using the
+  -- current version of the database key when the keys of its project are
+  -- rotated.
+  drop trigger immutable_columns on credential_vault_token;
+  create trigger immutable_columns before update on credential_vault_token
+    for each row execute procedure immutable_columns('token_hmac', 'store_id', 'create_time');
+
+  -- kms_rewrap_run tracks the progress of rewrapping the values encrypted
+  -- using the previous versions of the data keys of a scope after its keys
+  -- have been rotated. There is at most one run per scope: rotating the keys
+  -- of a scope again replaces its run.
+  create table kms_rewrap_run (
+    scope_id wt_scope_id primary key
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    total_count bigint not null default 0
+      constraint total_count_must_not_be_negative
+        check(total_count >= 0),
+    completed_count bigint not null default 0
+      constraint completed_count_must_not_be_negative
+        check(completed_count >= 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    -- end_time is null until all the values have been rewrapped.
+    end_time timestamp with time zone
+  );
+  comment on table kms_rewrap_run is
+    'kms_rewrap_run is a table where each row is the progress of rewrapping the values encrypted using the previous versions of the data keys of a scope.';
+
+  create trigger default_create_time_column before insert on kms_rewrap_run
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on kms_rewrap_run
+    for each row execute procedure update_time_column();
+
+  create trigger immutable_columns before update on kms_rewrap_run
+    for each row execute procedure immutable_columns('scope_id', 'create_time');
+
+commit;
@@ -0,0 +1,73 @@
+begin;
+
+  -- deleted_resource records the public ids of deleted resources which can be
+  -- listed with pagination. Refreshing a listing with a list token returns the
+  -- ids of the resources deleted since the listing the token was returned by.
+  -- Rows are removed once they are older than the maximum age of a list token.
+  create table deleted_resource (
+    public_id wt_public_id primary key,
+    resource_type text not null
+      constraint resource_type_must_not_be_empty
+        check(length(trim(resource_type)) > 0),
+    -- parent_id is the id of the scope, or host catalog for hosts, the
+    -- resource belonged to. It is used to limit the removed ids returned to
+    -- the parents the caller is allowed to list.
+    parent_id text,
+    delete_time wt_timestamp
+  );
+  comment on table deleted_resource is
+    'deleted_resource is a table where each row is the public id of a deleted resource which can be listed with pagination.';
+
+  create index deleted_resource_resource_type_delete_time_ix
+    on deleted_resource (resource_type, delete_time);
+
+  -- insert_deleted_resource is an after delete trigger function which records
+  -- the public id of the deleted row. The first argument is the resource
+  -- type and the second argument is the name of the parent id column.
+  create function insert_deleted_resource() returns trigger
+  as $$
+  begin
+    insert into deleted_resource
+      (public_id, resource_type, parent_id)
+    values
+      (old.public_id, tg_argv[0], to_jsonb(old) ->> tg_argv[1])
+    on conflict (public_id) do nothing;
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_deleted_resource is
+    'insert_deleted_resource is an after delete trigger function which records the public id of the deleted row in the deleted_resource table.';
+
+  create trigger insert_deleted_resource after delete on session
+    for each row execute procedure insert_deleted_resource('session', 'project_id');
+
+  create trigger insert_deleted_resource after delete on target
+    for each row execute procedure insert_deleted_resource('target', 'project_id');
+
+  create trigger insert_deleted_resource after delete on host
+    for each row execute procedure insert_deleted_resource('host', 'catalog_id');
+
+  create trigger insert_deleted_resource after delete on iam_user
+    for each row execute procedure insert_deleted_resource('user', 'scope_id');
+
+  -- auth tokens do not have a scope id, it is looked up from their account.
+  -- The account no longer exists when its tokens are deleted because it was
+  -- deleted, the parent id is null in that case.
+  create function insert_deleted_auth_token() returns trigger
+  as $$
+  begin
+    insert into deleted_resource
+      (public_id, resource_type, parent_id)
+    values
+      (old.public_id, 'auth-token', (select scope_id from auth_account where public_id = old.auth_account_id))
+    on conflict (public_id) do nothing;
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_deleted_auth_token is
+    'insert_deleted_auth_token is an after delete trigger function which records the public id of the deleted auth token in the deleted_resource table.';
+
+  create trigger insert_deleted_resource after delete on auth_token
+    for each row execute procedure insert_deleted_auth_token();
+
+commit;
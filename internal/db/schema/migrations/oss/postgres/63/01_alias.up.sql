@@ -0,0 +1,67 @@
+begin;
+
+  -- alias is a globally unique, user friendly name which can be used in place
+  -- of the id of its destination target. Aliases only exist in the global
+  -- scope. The value of an alias is a DNS-style name made of lowercase labels
+  -- separated by dots, like prod.postgres.primary.
+  create table alias (
+    public_id wt_public_id primary key,
+    scope_id wt_scope_id not null
+      constraint iam_scope_global_fkey
+        references iam_scope_global (scope_id)
+        on delete cascade
+        on update cascade,
+    name text,
+    description text,
+    value text not null
+      constraint value_must_be_lowercase
+        check(lower(trim(value)) = value)
+      constraint value_must_be_a_dns_name
+        check(value ~ '^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$')
+      constraint value_must_not_be_too_long
+        check(length(value) <= 253)
+      constraint alias_value_uq
+        unique,
+    -- destination_id is null when the alias does not point to a target, for
+    -- instance after its target has been deleted.
+    destination_id wt_public_id
+      constraint target_fkey
+        references target (public_id)
+        on delete set null
+        on update cascade,
+    -- host_id is the host a session is authorized for when the alias is used,
+    -- if a host is not provided in the request.
+    host_id wt_public_id
+      constraint host_fkey
+        references host (public_id)
+        on delete set null
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint alias_scope_id_name_uq
+      unique(scope_id, name),
+    constraint host_requires_destination
+      check(host_id is null or destination_id is not null)
+  );
+  comment on table alias is
+    'alias is a table where each row is a globally unique name which can be used in place of the id of a target.';
+
+  create trigger immutable_columns before update on alias
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on alias
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on alias
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on alias
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('alias', 1);
+
+commit;
@@ -0,0 +1,30 @@
+begin;
+
+  -- controller_maintenance holds the maintenance mode of the controllers of
+  -- the cluster. It has a single row which the controllers poll, so enabling
+  -- or disabling maintenance mode applies to all the controllers.
+  create table controller_maintenance (
+    id int primary key default 1
+      constraint only_one_row
+        check(id = 1),
+    enabled boolean not null default false,
+    -- freeze_sessions is true when session authorization and the requests of
+    -- workers are rejected as well as the mutating api requests.
+    freeze_sessions boolean not null default false,
+    retry_after_seconds int not null default 60
+      constraint retry_after_seconds_must_be_greater_than_0
+        check(retry_after_seconds > 0),
+    message text
+      constraint message_must_not_be_empty
+        check(length(trim(message)) > 0),
+    update_time wt_timestamp
+  );
+  comment on table controller_maintenance is
+    'controller_maintenance is a table with a single row holding the maintenance mode of the controllers.';
+
+  create trigger update_time_column before update on controller_maintenance
+    for each row execute procedure update_time_column();
+
+  insert into controller_maintenance (id) values (1);
+
+commit;
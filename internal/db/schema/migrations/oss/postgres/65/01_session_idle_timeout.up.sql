@@ -0,0 +1,88 @@
+begin;
+
+  -- session_idle_timeout_seconds is the amount of time the sessions of a
+  -- target can go without any traffic before the worker cancels them. Zero
+  -- means the session idle timeout of the controller applies.
+  alter table target_tcp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+        check(session_idle_timeout_seconds >= 0);
+
+  alter table target_rdp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+        check(session_idle_timeout_seconds >= 0);
+
+  -- Replaces target_all_subtypes defined in 58/01_target_rdp.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_idle_timeout_seconds
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'rdp' as type,
+         session_idle_timeout_seconds
+    from target_rdp;
+
+  -- idle_timeout_seconds is the idle timeout the worker enforces for the
+  -- session, resolved from the target and the controller when the session is
+  -- authorized. Zero means the session has no idle timeout.
+  -- idle_timeout_time is set when the worker cancels the session because it
+  -- has been idle, so the session is terminated with the 'idle timeout'
+  -- reason rather than 'canceled'.
+  alter table session
+    add column idle_timeout_seconds int not null default 0
+      constraint idle_timeout_seconds_must_not_be_negative
+        check(idle_timeout_seconds >= 0),
+    add column idle_timeout_time timestamp with time zone;
+
+  -- Replaces the immutable columns trigger from 1/01_server_tags_migrations.up.sql
+  -- to add idle_timeout_seconds
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'idle_timeout_seconds');
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'idle timeout'
+        )
+      );
+
+  insert into session_termination_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+  -- Replaces the trigger defined in 56/01_static_credential_rotation.up.sql
+  -- so the retired secrets can be rewrapped after the keys of their project
+  -- are rotated.
+  drop trigger immutable_columns on credential_static_retired_secret;
+  create trigger immutable_columns before update on credential_static_retired_secret
+    for each row execute procedure immutable_columns('credential_id', 'secret_version', 'create_time', 'retire_time');
+
+commit;
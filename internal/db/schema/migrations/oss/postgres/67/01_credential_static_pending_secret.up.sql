@@ -0,0 +1,32 @@
+begin;
+
+  create table credential_static_pending_secret (
+    credential_id wt_public_id primary key
+      constraint credential_static_rotation_fkey
+        references credential_static_rotation (credential_id)
+        on delete cascade
+        on update cascade,
+    secret_version bigint not null
+      constraint secret_version_must_be_greater_than_zero
+        check(secret_version > 0),
+    create_time wt_timestamp,
+    secret_encrypted bytea not null
+      constraint secret_encrypted_must_not_be_empty
+        check(length(secret_encrypted) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade
+  );
+  comment on table credential_static_pending_secret is
+    'credential_static_pending_secret is a table where each row contains a secret that a rotator has applied to a static credential '
+    'but that has not replaced the secret of the credential yet. The row is deleted in the transaction that stores the secret.';
+
+  create trigger default_create_time_column before insert on credential_static_pending_secret
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_pending_secret
+    for each row execute procedure immutable_columns('credential_id', 'secret_version', 'create_time');
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+  -- clear_alias_host_id clears the host of an alias when its destination is
+  -- cleared, which happens when the destination target is deleted, since the
+  -- host_requires_destination constraint does not allow a host without a
+  -- destination.
+  create function clear_alias_host_id() returns trigger
+  as $$
+  begin
+    if new.destination_id is null then
+      new.host_id = null;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger clear_alias_host_id before update on alias
+    for each row execute procedure clear_alias_host_id();
+
+commit;
@@ -0,0 +1,21 @@
+begin;
+
+  -- Replaces the function defined in 0/11_auth_token.up.sql so the token can
+  -- be rewrapped after the keys of its scope are rotated: the encrypted token
+  -- can only change along with the key used to encrypt it.
+  create or replace function immutable_auth_token_columns() returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token and new.key_id is not distinct from old.key_id then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function immutable_auth_token_columns() is
+    'function used in before update triggers to make specific columns immutable';
+
+commit;
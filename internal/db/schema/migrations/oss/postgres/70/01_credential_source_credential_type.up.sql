@@ -0,0 +1,45 @@
+begin;
+
+  -- Replaces the view defined in 33/02_target.up.sql to add the type of the
+  -- credentials of each source, so targets can vet the types of the
+  -- credentials attached to them.
+  create or replace view credential_source_all_types
+  as
+    select
+      public_id,
+      'library' as type,
+      credential_type
+    from
+      credential_library
+    union
+    select
+      public_id,
+      'static' as type,
+      'username_password' as credential_type
+    from
+      credential_static_username_password_credential
+    union
+    select
+      public_id,
+      'static' as type,
+      'ssh_private_key' as credential_type
+    from
+      credential_static_ssh_private_key_credential
+    union
+    select
+      public_id,
+      'static' as type,
+      'ssh_certificate' as credential_type
+    from
+      credential_static_ssh_certificate_credential
+    union
+    select
+      public_id,
+      'static' as type,
+      'json' as credential_type
+    from
+      credential_static_json_credential;
+  comment on view credential_source_all_types is
+    'credential_source_all_types is a view where each row contains the credential source id, its type and the type of its credentials.';
+
+commit;
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+var (
+	// destructiveStatement matches statements which may result in a loss of
+	// data.
+	destructiveStatement = regexp.MustCompile(`(?i)\b(drop\s+table|drop\s+column|truncate)\b`)
+	// sqlComment matches single line sql comments.
+	sqlComment = regexp.MustCompile(`--[^\n]*`)
+)
+
+// PlannedMigration is a migration which has not yet been applied to the
+// database.
+type PlannedMigration struct {
+	Edition    string
+	Version    int
+	Statements []byte
+
+	// Destructive is true if the migration drops tables or columns or
+	// truncates tables, which may result in a loss of data.
+	Destructive bool
+}
+
+// PlanMigrations returns the migrations ApplyMigrations would apply to the
+// database, in the order in which they would be applied. The database is not
+// modified and no lock is acquired so a plan can be created while controllers
+// are running.
+func (b *Manager) PlanMigrations(ctx context.Context) ([]PlannedMigration, error) {
+	const op = "schema.(Manager).PlanMigrations"
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var plan []PlannedMigration
+	p := provider.New(state.databaseState(), b.editions)
+	for p.Next() {
+		plan = append(plan, PlannedMigration{
+			Edition:     p.Edition(),
+			Version:     p.Version(),
+			Statements:  p.Statements(),
+			Destructive: isDestructive(p.Statements()),
+		})
+	}
+	return plan, nil
+}
+
+// isDestructive reports whether the statements contain a statement which may
+// result in a loss of data.
+func isDestructive(statements []byte) bool {
+	return destructiveStatement.Match(sqlComment.ReplaceAll(statements, nil))
+}
@@ -0,0 +1,90 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/edition"
+	"github.com/hashicorp/boundary/internal/db/schema/migration"
+	"github.com/hashicorp/boundary/testing/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanMigrations(t *testing.T) {
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+	d, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	migrations := migration.Migrations{
+		1: migration.Migration{
+			Statements: []byte(`create table plan_test (id int primary key, name text);`),
+			Edition:    "oss",
+			Version:    1,
+		},
+		2: migration.Migration{
+			Statements: []byte(`alter table plan_test drop column name;`),
+			Edition:    "oss",
+			Version:    2,
+		},
+		3: migration.Migration{
+			Statements: []byte("-- the plan_test table is no longer truncated\ninsert into plan_test (id) values (1);"),
+			Edition:    "oss",
+			Version:    3,
+		},
+	}
+	editions := func(latest int) edition.Editions {
+		m := make(migration.Migrations)
+		for v := 1; v <= latest; v++ {
+			m[v] = migrations[v]
+		}
+		return edition.Editions{
+			{
+				Name:          "oss",
+				Dialect:       schema.Postgres,
+				LatestVersion: latest,
+				Migrations:    m,
+				Priority:      0,
+			},
+		}
+	}
+
+	m, err := schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(editions(1)))
+	require.NoError(t, err)
+	plan, err := m.PlanMigrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.PlannedMigration{
+		{Edition: "oss", Version: 1, Statements: migrations[1].Statements},
+	}, plan)
+	_, err = m.ApplyMigrations(ctx)
+	require.NoError(t, err)
+
+	m, err = schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(editions(3)))
+	require.NoError(t, err)
+	plan, err = m.PlanMigrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.PlannedMigration{
+		{Edition: "oss", Version: 2, Statements: migrations[2].Statements, Destructive: true},
+		{Edition: "oss", Version: 3, Statements: migrations[3].Statements},
+	}, plan)
+
+	// Planning does not modify the database.
+	state, err := m.CurrentState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.Editions[0].DatabaseSchemaVersion)
+
+	_, err = m.ApplyMigrations(ctx)
+	require.NoError(t, err)
+	plan, err = m.PlanMigrations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+}
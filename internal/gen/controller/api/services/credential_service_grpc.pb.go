@@ -44,6 +44,17 @@ type CredentialServiceClient interface {
 	// DeleteCredential removes an Credential from Boundary. If the Credential id
 	// is malformed or not provided an error is returned.
 	DeleteCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*DeleteCredentialResponse, error)
+	// SetCredentialRotation creates or updates the rotation configuration of a
+	// static Credential. A rotation configuration is created when the version
+	// is not set, otherwise all the fields of the existing rotation
+	// configuration are replaced.
+	SetCredentialRotation(ctx context.Context, in *SetCredentialRotationRequest, opts ...grpc.CallOption) (*SetCredentialRotationResponse, error)
+	// ReadCredentialRotation returns the rotation configuration of a static
+	// Credential.
+	ReadCredentialRotation(ctx context.Context, in *ReadCredentialRotationRequest, opts ...grpc.CallOption) (*ReadCredentialRotationResponse, error)
+	// RemoveCredentialRotation deletes the rotation configuration of a static
+	// Credential, which stops it from being rotated.
+	RemoveCredentialRotation(ctx context.Context, in *RemoveCredentialRotationRequest, opts ...grpc.CallOption) (*RemoveCredentialRotationResponse, error)
 }
 
 type credentialServiceClient struct {
@@ -99,6 +110,33 @@ func (c *credentialServiceClient) DeleteCredential(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *credentialServiceClient) SetCredentialRotation(ctx context.Context, in *SetCredentialRotationRequest, opts ...grpc.CallOption) (*SetCredentialRotationResponse, error) {
+	out := new(SetCredentialRotationResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialService/SetCredentialRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialServiceClient) ReadCredentialRotation(ctx context.Context, in *ReadCredentialRotationRequest, opts ...grpc.CallOption) (*ReadCredentialRotationResponse, error) {
+	out := new(ReadCredentialRotationResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialService/ReadCredentialRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialServiceClient) RemoveCredentialRotation(ctx context.Context, in *RemoveCredentialRotationRequest, opts ...grpc.CallOption) (*RemoveCredentialRotationResponse, error) {
+	out := new(RemoveCredentialRotationResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialService/RemoveCredentialRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CredentialServiceServer is the server API for CredentialService service.
 // All implementations must embed UnimplementedCredentialServiceServer
 // for forward compatibility
@@ -129,6 +167,17 @@ type CredentialServiceServer interface {
 	// DeleteCredential removes an Credential from Boundary. If the Credential id
 	// is malformed or not provided an error is returned.
 	DeleteCredential(context.Context, *DeleteCredentialRequest) (*DeleteCredentialResponse, error)
+	// SetCredentialRotation creates or updates the rotation configuration of a
+	// static Credential. A rotation configuration is created when the version
+	// is not set, otherwise all the fields of the existing rotation
+	// configuration are replaced.
+	SetCredentialRotation(context.Context, *SetCredentialRotationRequest) (*SetCredentialRotationResponse, error)
+	// ReadCredentialRotation returns the rotation configuration of a static
+	// Credential.
+	ReadCredentialRotation(context.Context, *ReadCredentialRotationRequest) (*ReadCredentialRotationResponse, error)
+	// RemoveCredentialRotation deletes the rotation configuration of a static
+	// Credential, which stops it from being rotated.
+	RemoveCredentialRotation(context.Context, *RemoveCredentialRotationRequest) (*RemoveCredentialRotationResponse, error)
 	mustEmbedUnimplementedCredentialServiceServer()
 }
 
@@ -151,6 +200,15 @@ func (UnimplementedCredentialServiceServer) UpdateCredential(context.Context, *U
 func (UnimplementedCredentialServiceServer) DeleteCredential(context.Context, *DeleteCredentialRequest) (*DeleteCredentialResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredential not implemented")
 }
+func (UnimplementedCredentialServiceServer) SetCredentialRotation(context.Context, *SetCredentialRotationRequest) (*SetCredentialRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCredentialRotation not implemented")
+}
+func (UnimplementedCredentialServiceServer) ReadCredentialRotation(context.Context, *ReadCredentialRotationRequest) (*ReadCredentialRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCredentialRotation not implemented")
+}
+func (UnimplementedCredentialServiceServer) RemoveCredentialRotation(context.Context, *RemoveCredentialRotationRequest) (*RemoveCredentialRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCredentialRotation not implemented")
+}
 func (UnimplementedCredentialServiceServer) mustEmbedUnimplementedCredentialServiceServer() {}
 
 // UnsafeCredentialServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +312,60 @@ func _CredentialService_DeleteCredential_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CredentialService_SetCredentialRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCredentialRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialServiceServer).SetCredentialRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialService/SetCredentialRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialServiceServer).SetCredentialRotation(ctx, req.(*SetCredentialRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialService_ReadCredentialRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCredentialRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialServiceServer).ReadCredentialRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialService/ReadCredentialRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialServiceServer).ReadCredentialRotation(ctx, req.(*ReadCredentialRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialService_RemoveCredentialRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCredentialRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialServiceServer).RemoveCredentialRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialService/RemoveCredentialRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialServiceServer).RemoveCredentialRotation(ctx, req.(*RemoveCredentialRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CredentialService_ServiceDesc is the grpc.ServiceDesc for CredentialService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +393,18 @@ var CredentialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCredential",
 			Handler:    _CredentialService_DeleteCredential_Handler,
 		},
+		{
+			MethodName: "SetCredentialRotation",
+			Handler:    _CredentialService_SetCredentialRotation_Handler,
+		},
+		{
+			MethodName: "ReadCredentialRotation",
+			Handler:    _CredentialService_ReadCredentialRotation_Handler,
+		},
+		{
+			MethodName: "RemoveCredentialRotation",
+			Handler:    _CredentialService_RemoveCredentialRotation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/credential_service.proto",
@@ -0,0 +1,141 @@
+package health
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+)
+
+// A Probe is the probe of a host a worker is assigned to run.
+type Probe struct {
+	HostSetId string
+	HostId    string
+	// Address is the host:port to probe.
+	Address         string
+	Protocol        string
+	Path            string
+	IntervalSeconds uint32
+}
+
+// AssignProbes returns the probes of the endpoints the worker workerId is
+// assigned to run. Each endpoint of a host set with a health check is
+// assigned to exactly one of the workers matching the worker filter of the
+// check. Assignments are stable as long as the set of matching workers does
+// not change, and only the endpoints of a removed worker are reassigned.
+func AssignProbes(ctx context.Context, workerId string, checks []*HealthCheck, endpoints []*host.Endpoint, workers []*server.Worker) ([]*Probe, error) {
+	const op = "health.AssignProbes"
+	if workerId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+	probes, err := AssignAllProbes(ctx, checks, endpoints, workers)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return probes[workerId], nil
+}
+
+// AssignAllProbes returns the probes of the endpoints keyed by the id of the
+// worker they are assigned to, as AssignProbes does for a single worker.
+func AssignAllProbes(ctx context.Context, checks []*HealthCheck, endpoints []*host.Endpoint, workers []*server.Worker) (map[string][]*Probe, error) {
+	const op = "health.AssignAllProbes"
+	if len(checks) == 0 || len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	checksBySet := make(map[string]*HealthCheck, len(checks))
+	candidates := make(map[string][]*server.Worker, len(checks))
+	for _, hc := range checks {
+		checksBySet[hc.HostSetId] = hc
+		matching, err := filterWorkers(hc.WorkerFilter, workers)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to filter workers for host set %s", hc.HostSetId)))
+		}
+		candidates[hc.HostSetId] = matching
+	}
+
+	probes := make(map[string][]*Probe)
+	for _, ep := range endpoints {
+		hc, ok := checksBySet[ep.SetId]
+		if !ok {
+			continue
+		}
+		workerId := assignedWorker(ep, candidates[ep.SetId])
+		if workerId == "" {
+			continue
+		}
+		probes[workerId] = append(probes[workerId], &Probe{
+			HostSetId:       ep.SetId,
+			HostId:          ep.HostId,
+			Address:         net.JoinHostPort(addressHost(ep.Address), strconv.FormatUint(uint64(hc.Port), 10)),
+			Protocol:        hc.Protocol,
+			Path:            hc.Path,
+			IntervalSeconds: hc.IntervalSeconds,
+		})
+	}
+	return probes, nil
+}
+
+// filterWorkers returns the workers matching the filter, all of them if the
+// filter is empty.
+func filterWorkers(filter string, workers []*server.Worker) ([]*server.Worker, error) {
+	if filter == "" {
+		return workers, nil
+	}
+	eval, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*server.Worker
+	for _, w := range workers {
+		filterInput := map[string]interface{}{
+			"name": w.GetName(),
+			"tags": w.CanonicalTags(),
+		}
+		ok, err := eval.Evaluate(filterInput)
+		if err != nil && !stderrors.Is(err, pointerstructure.ErrNotFound) {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, w)
+		}
+	}
+	return ret, nil
+}
+
+// assignedWorker returns the id of the worker with the highest rendezvous
+// hash for the endpoint, or an empty string if there are no workers.
+func assignedWorker(ep *host.Endpoint, workers []*server.Worker) string {
+	var chosen string
+	var highest uint64
+	for _, w := range workers {
+		h := fnv.New64a()
+		h.Write([]byte(w.GetPublicId()))
+		h.Write([]byte{0})
+		h.Write([]byte(ep.SetId))
+		h.Write([]byte{0})
+		h.Write([]byte(ep.HostId))
+		if sum := h.Sum64(); chosen == "" || sum > highest {
+			chosen, highest = w.GetPublicId(), sum
+		}
+	}
+	return chosen
+}
+
+// addressHost returns the host part of an endpoint address, which may or may
+// not include a port.
+func addressHost(address string) string {
+	if h, _, err := util.SplitHostPort(address); err == nil || errors.Is(err, util.ErrMissingPort) {
+		return h
+	}
+	return address
+}
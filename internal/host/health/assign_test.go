@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorker(id, name string) *server.Worker {
+	w := server.NewWorker(scope.Global.String(), server.WithName(name))
+	w.PublicId = id
+	return w
+}
+
+func TestAssignProbes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	workers := []*server.Worker{
+		testWorker("w_1111111111", "w1"),
+		testWorker("w_2222222222", "w2"),
+		testWorker("w_3333333333", "w3"),
+	}
+	checks := []*HealthCheck{
+		{HostSetId: "hsst_1", Protocol: ProtocolTcp, Port: 22, IntervalSeconds: 30},
+		{HostSetId: "hsst_2", Protocol: ProtocolHttp, Port: 8080, Path: "/health", IntervalSeconds: 10, WorkerFilter: `"/name" == "w2"`},
+	}
+	var endpoints []*host.Endpoint
+	for i := 0; i < 20; i++ {
+		endpoints = append(endpoints,
+			&host.Endpoint{SetId: "hsst_1", HostId: fmt.Sprintf("hst_%d", i), Address: fmt.Sprintf("10.0.0.%d", i)},
+			&host.Endpoint{SetId: "hsst_2", HostId: fmt.Sprintf("hst_%d", i), Address: fmt.Sprintf("10.0.0.%d:9000", i)},
+			&host.Endpoint{SetId: "hsst_3", HostId: fmt.Sprintf("hst_%d", i), Address: fmt.Sprintf("10.0.0.%d", i)},
+		)
+	}
+
+	t.Run("missing-worker-id", func(t *testing.T) {
+		_, err := AssignProbes(ctx, "", checks, endpoints, workers)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid-worker-filter", func(t *testing.T) {
+		_, err := AssignProbes(ctx, "w_1111111111", []*HealthCheck{{HostSetId: "hsst_1", WorkerFilter: `"dev" in`}}, endpoints, workers)
+		require.Error(t, err)
+	})
+
+	t.Run("each-endpoint-assigned-once", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		assigned := make(map[string]string)
+		for _, w := range workers {
+			probes, err := AssignProbes(ctx, w.GetPublicId(), checks, endpoints, workers)
+			require.NoError(err)
+			for _, p := range probes {
+				key := p.HostSetId + "/" + p.HostId
+				assert.Emptyf(assigned[key], "%s assigned to both %s and %s", key, assigned[key], w.GetPublicId())
+				assigned[key] = w.GetPublicId()
+
+				switch p.HostSetId {
+				case "hsst_1":
+					assert.Equal(ProtocolTcp, p.Protocol)
+					assert.Regexp(`^10\.0\.0\.\d+:22$`, p.Address)
+				case "hsst_2":
+					assert.Equal("w_2222222222", w.GetPublicId(), "worker filter not applied")
+					assert.Equal(ProtocolHttp, p.Protocol)
+					assert.Equal("/health", p.Path)
+					assert.EqualValues(10, p.IntervalSeconds)
+					assert.Regexp(`^10\.0\.0\.\d+:8080$`, p.Address)
+				default:
+					assert.Failf("unexpected probe", "host set %s has no health check", p.HostSetId)
+				}
+			}
+		}
+		assert.Len(assigned, 40)
+	})
+
+	t.Run("all-probes-match-per-worker", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := AssignAllProbes(ctx, checks, endpoints, workers)
+		require.NoError(err)
+		var total int
+		for _, w := range workers {
+			probes, err := AssignProbes(ctx, w.GetPublicId(), checks, endpoints, workers)
+			require.NoError(err)
+			assert.Equal(probes, all[w.GetPublicId()])
+			total += len(probes)
+		}
+		assert.Equal(40, total)
+	})
+
+	t.Run("stable-when-worker-removed", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		before, err := AssignProbes(ctx, "w_1111111111", checks, endpoints, workers)
+		require.NoError(err)
+		after, err := AssignProbes(ctx, "w_1111111111", checks, endpoints, workers[:2])
+		require.NoError(err)
+		assert.Subset(after, before)
+	})
+
+	t.Run("ipv6-address", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		eps := []*host.Endpoint{
+			{SetId: "hsst_1", HostId: "hst_a", Address: "2001:db8::1"},
+			{SetId: "hsst_1", HostId: "hst_b", Address: "[2001:db8::2]:443"},
+		}
+		probes, err := AssignProbes(ctx, "w_1111111111", checks[:1], eps, workers[:1])
+		require.NoError(err)
+		require.Len(probes, 2)
+		assert.Equal("[2001:db8::1]:22", probes[0].Address)
+		assert.Equal("[2001:db8::2]:22", probes[1].Address)
+	})
+}
@@ -0,0 +1,24 @@
+// Package health provides the health checks of host sets and the health of
+// their hosts.
+//
+// A host set can have a single health check, which configures the probe
+// workers run against each of its hosts. The controller assigns each host to
+// one of the active workers matching the worker filter of the check and sends
+// the checks in the worker status responses. Workers report the results of
+// the probes in their next status requests and a host is marked unhealthy
+// once the number of consecutive failed probes reaches the unhealthy
+// threshold of the check. A single successful probe marks it healthy again.
+//
+// Unhealthy hosts are skipped when authorizing sessions, unless every host of
+// the target is unhealthy in which case the host is picked among all of them,
+// since the checks themselves may be failing. A host for which no
+// probe result was recorded in the last three intervals of the check is
+// considered healthy, so hosts are not excluded when no worker can probe them.
+//
+// # Repository
+//
+// A repository provides methods for setting, retrieving and deleting the
+// health check of a host set, recording the results of probes and filtering
+// out the unhealthy hosts of a list of endpoints. A new repository should be
+// created for each transaction.
+package health
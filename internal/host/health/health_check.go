@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-bexpr"
+)
+
+const (
+	// ProtocolTcp probes succeed when a TCP connection can be established.
+	ProtocolTcp = "tcp"
+	// ProtocolHttp probes succeed when a GET request returns a 2xx or 3xx
+	// status.
+	ProtocolHttp = "http"
+
+	// DefaultPath is the path requested by http probes when none is set.
+	DefaultPath = "/"
+	// DefaultIntervalSeconds is the interval between two probes of a host
+	// when none is set.
+	DefaultIntervalSeconds = 30
+	// DefaultUnhealthyThreshold is the number of consecutive failed probes
+	// after which a host is unhealthy when none is set.
+	DefaultUnhealthyThreshold = 3
+)
+
+// A HealthCheck configures the probes run by workers against the hosts of a
+// host set.
+type HealthCheck struct {
+	HostSetId          string `gorm:"primary_key"`
+	Protocol           string
+	Port               uint32
+	Path               string `gorm:"default:null"`
+	IntervalSeconds    uint32
+	UnhealthyThreshold uint32
+	WorkerFilter       string `gorm:"default:null"`
+	CreateTime         *timestamp.Timestamp
+	UpdateTime         *timestamp.Timestamp
+}
+
+// NewHealthCheck creates a new in memory HealthCheck for the host set. All
+// options are ignored except WithPath, WithIntervalSeconds,
+// WithUnhealthyThreshold and WithWorkerFilter. WithPath is only valid for
+// the http protocol.
+func NewHealthCheck(ctx context.Context, hostSetId, protocol string, port uint32, opt ...Option) (*HealthCheck, error) {
+	const op = "health.NewHealthCheck"
+	opts := getOpts(opt...)
+	hc := &HealthCheck{
+		HostSetId:          hostSetId,
+		Protocol:           protocol,
+		Port:               port,
+		Path:               opts.withPath,
+		IntervalSeconds:    opts.withIntervalSeconds,
+		UnhealthyThreshold: opts.withUnhealthyThreshold,
+		WorkerFilter:       opts.withWorkerFilter,
+	}
+	if hc.Protocol == ProtocolHttp && hc.Path == "" {
+		hc.Path = DefaultPath
+	}
+	if hc.IntervalSeconds == 0 {
+		hc.IntervalSeconds = DefaultIntervalSeconds
+	}
+	if hc.UnhealthyThreshold == 0 {
+		hc.UnhealthyThreshold = DefaultUnhealthyThreshold
+	}
+	if err := hc.validate(ctx, op); err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+func (hc *HealthCheck) validate(ctx context.Context, caller errors.Op) error {
+	switch {
+	case hc.HostSetId == "":
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing host set id")
+	case hc.Protocol != ProtocolTcp && hc.Protocol != ProtocolHttp:
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("unknown protocol %q", hc.Protocol))
+	case hc.Port == 0 || hc.Port > math.MaxUint16:
+		return errors.New(ctx, errors.InvalidParameter, caller, "port must be between 1 and 65535")
+	case hc.Path != "" && hc.Protocol != ProtocolHttp:
+		return errors.New(ctx, errors.InvalidParameter, caller, "path is only valid for the http protocol")
+	case hc.IntervalSeconds > math.MaxInt32:
+		return errors.New(ctx, errors.InvalidParameter, caller, "interval seconds is too large")
+	case hc.UnhealthyThreshold > math.MaxInt32:
+		return errors.New(ctx, errors.InvalidParameter, caller, "unhealthy threshold is too large")
+	}
+	if hc.WorkerFilter != "" {
+		if _, err := bexpr.CreateEvaluator(hc.WorkerFilter); err != nil {
+			return errors.Wrap(ctx, err, caller, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid worker filter"))
+		}
+	}
+	return nil
+}
+
+// TableName returns the table name.
+func (hc *HealthCheck) TableName() string {
+	return "host_set_health_check"
+}
+
+// A HostHealth is the health of a host as probed by the health check of a
+// host set.
+type HostHealth struct {
+	HostSetId           string `gorm:"primary_key"`
+	HostId              string `gorm:"primary_key"`
+	Healthy             bool
+	ConsecutiveFailures uint32
+	LastError           string `gorm:"default:null"`
+	LastCheckTime       *timestamp.Timestamp
+}
+
+// TableName returns the table name.
+func (h *HostHealth) TableName() string {
+	return "host_health"
+}
+
+// A Result is the result of a probe of a host reported by a worker.
+type Result struct {
+	HostSetId string
+	HostId    string
+	Healthy   bool
+	Error     string
+}
@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		hostSetId string
+		protocol  string
+		port      uint32
+		opts      []Option
+		want      *HealthCheck
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "missing-host-set-id",
+			protocol:  ProtocolTcp,
+			port:      22,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "unknown-protocol",
+			hostSetId: "hsst_1234567890",
+			protocol:  "udp",
+			port:      22,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-port",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolTcp,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "port-too-large",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolTcp,
+			port:      65536,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "path-with-tcp",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolTcp,
+			port:      22,
+			opts:      []Option{WithPath("/health")},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-worker-filter",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolTcp,
+			port:      22,
+			opts:      []Option{WithWorkerFilter(`"dev" in`)},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "valid-tcp-defaults",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolTcp,
+			port:      22,
+			want: &HealthCheck{
+				HostSetId:          "hsst_1234567890",
+				Protocol:           ProtocolTcp,
+				Port:               22,
+				IntervalSeconds:    DefaultIntervalSeconds,
+				UnhealthyThreshold: DefaultUnhealthyThreshold,
+			},
+		},
+		{
+			name:      "valid-http-default-path",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolHttp,
+			port:      8080,
+			want: &HealthCheck{
+				HostSetId:          "hsst_1234567890",
+				Protocol:           ProtocolHttp,
+				Port:               8080,
+				Path:               DefaultPath,
+				IntervalSeconds:    DefaultIntervalSeconds,
+				UnhealthyThreshold: DefaultUnhealthyThreshold,
+			},
+		},
+		{
+			name:      "valid-http-with-options",
+			hostSetId: "hsst_1234567890",
+			protocol:  ProtocolHttp,
+			port:      8080,
+			opts: []Option{
+				WithPath("/health"),
+				WithIntervalSeconds(10),
+				WithUnhealthyThreshold(5),
+				WithWorkerFilter(`"dev" in "/tags/type"`),
+			},
+			want: &HealthCheck{
+				HostSetId:          "hsst_1234567890",
+				Protocol:           ProtocolHttp,
+				Port:               8080,
+				Path:               "/health",
+				IntervalSeconds:    10,
+				UnhealthyThreshold: 5,
+				WorkerFilter:       `"dev" in "/tags/type"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewHealthCheck(ctx, tt.hostSetId, tt.protocol, tt.port, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package health
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withPath               string
+	withIntervalSeconds    uint32
+	withUnhealthyThreshold uint32
+	withWorkerFilter       string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithPath provides an optional path requested by http probes.
+func WithPath(path string) Option {
+	return func(o *options) {
+		o.withPath = path
+	}
+}
+
+// WithIntervalSeconds provides an optional interval between two probes of a
+// host.
+func WithIntervalSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.withIntervalSeconds = secs
+	}
+}
+
+// WithUnhealthyThreshold provides an optional number of consecutive failed
+// probes after which a host is unhealthy.
+func WithUnhealthyThreshold(threshold uint32) Option {
+	return func(o *options) {
+		o.withUnhealthyThreshold = threshold
+	}
+}
+
+// WithWorkerFilter provides an optional filter restricting the workers
+// running the probes.
+func WithWorkerFilter(filter string) Option {
+	return func(o *options) {
+		o.withWorkerFilter = filter
+	}
+}
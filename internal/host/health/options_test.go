@@ -0,0 +1,35 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithPath", func(t *testing.T) {
+		opts := getOpts(WithPath("/health"))
+		testOpts := getDefaultOptions()
+		testOpts.withPath = "/health"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithIntervalSeconds", func(t *testing.T) {
+		opts := getOpts(WithIntervalSeconds(10))
+		testOpts := getDefaultOptions()
+		testOpts.withIntervalSeconds = 10
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithUnhealthyThreshold", func(t *testing.T) {
+		opts := getOpts(WithUnhealthyThreshold(5))
+		testOpts := getDefaultOptions()
+		testOpts.withUnhealthyThreshold = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithWorkerFilter", func(t *testing.T) {
+		opts := getOpts(WithWorkerFilter(`"dev" in "/tags/type"`))
+		testOpts := getDefaultOptions()
+		testOpts.withWorkerFilter = `"dev" in "/tags/type"`
+		assert.Equal(t, opts, testOpts)
+	})
+}
@@ -0,0 +1,70 @@
+package health
+
+const (
+	upsertHealthCheckQuery = `
+insert into host_set_health_check
+  (host_set_id, protocol, port, path, interval_seconds, unhealthy_threshold, worker_filter)
+values
+  (@host_set_id, @protocol, @port, nullif(@path, ''), @interval_seconds, @unhealthy_threshold, nullif(@worker_filter, ''))
+on conflict (host_set_id) do update
+  set protocol            = excluded.protocol,
+      port                = excluded.port,
+      path                = excluded.path,
+      interval_seconds    = excluded.interval_seconds,
+      unhealthy_threshold = excluded.unhealthy_threshold,
+      worker_filter       = excluded.worker_filter;
+`
+
+	deleteHostHealthQuery = `
+delete from host_health
+ where host_set_id = @host_set_id;
+`
+
+	deleteHealthCheckQuery = `
+delete from host_set_health_check
+ where host_set_id = @host_set_id;
+`
+
+	// upsertResultQuery records the result of a probe. A failed probe only
+	// marks the host unhealthy once the number of consecutive failures
+	// reaches the threshold of the health check. Results for hosts which no
+	// longer exist or for host sets without a health check are ignored.
+	upsertResultQuery = `
+insert into host_health
+  (host_set_id, host_id, healthy, consecutive_failures, last_error, last_check_time)
+select hc.host_set_id,
+       h.public_id,
+       @healthy or hc.unhealthy_threshold > 1,
+       case when @healthy then 0 else 1 end,
+       nullif(@last_error, ''),
+       now()
+  from host_set_health_check hc,
+       host h
+ where hc.host_set_id = @host_set_id
+   and h.public_id    = @host_id
+on conflict (host_set_id, host_id) do update
+  set healthy              = @healthy or host_health.consecutive_failures + 1 < (
+                               select hc.unhealthy_threshold
+                                 from host_set_health_check hc
+                                where hc.host_set_id = host_health.host_set_id
+                             ),
+      consecutive_failures = case when @healthy then 0 else host_health.consecutive_failures + 1 end,
+      last_error           = excluded.last_error,
+      last_check_time      = excluded.last_check_time;
+`
+
+	// unhealthyHostsQuery returns the hosts of the host sets which are
+	// currently unhealthy. Results older than three intervals of the health
+	// check are ignored so that hosts are not excluded when no worker is
+	// probing them anymore.
+	unhealthyHostsQuery = `
+select hh.host_set_id,
+       hh.host_id
+  from host_health hh
+  join host_set_health_check hc
+    on hc.host_set_id = hh.host_set_id
+ where hh.host_set_id in (@host_set_ids)
+   and not hh.healthy
+   and hh.last_check_time > now() - make_interval(secs => hc.interval_seconds * 3);
+`
+)
@@ -0,0 +1,231 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host"
+)
+
+// A Repository stores and retrieves the health checks of host sets and the
+// health of their hosts. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it.
+func NewRepository(r db.Reader, w db.Writer) (*Repository, error) {
+	const op = "health.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "db.Reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "db.Writer")
+	}
+	return &Repository{
+		reader: r,
+		writer: w,
+	}, nil
+}
+
+// SetHealthCheck creates or replaces the health check of the host set
+// hc.HostSetId and returns it. The recorded health of the hosts of the set
+// is reset.
+func (r *Repository) SetHealthCheck(ctx context.Context, hc *HealthCheck) (*HealthCheck, error) {
+	const op = "health.(Repository).SetHealthCheck"
+	if hc == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing health check")
+	}
+	if err := hc.validate(ctx, op); err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{
+		sql.Named("host_set_id", hc.HostSetId),
+		sql.Named("protocol", hc.Protocol),
+		sql.Named("port", hc.Port),
+		sql.Named("path", hc.Path),
+		sql.Named("interval_seconds", hc.IntervalSeconds),
+		sql.Named("unhealthy_threshold", hc.UnhealthyThreshold),
+		sql.Named("worker_filter", hc.WorkerFilter),
+	}
+	returned := &HealthCheck{}
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, upsertHealthCheckQuery, args); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to upsert health check"))
+			}
+			if _, err := w.Exec(ctx, deleteHostHealthQuery, []interface{}{sql.Named("host_set_id", hc.HostSetId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to reset host health"))
+			}
+			if err := reader.LookupWhere(ctx, returned, "host_set_id = ?", []interface{}{hc.HostSetId}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to read health check"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for host set %s", hc.HostSetId)))
+	}
+	return returned, nil
+}
+
+// LookupHealthCheck returns the health check of the host set. Returns nil,
+// nil if the host set has no health check.
+func (r *Repository) LookupHealthCheck(ctx context.Context, hostSetId string) (*HealthCheck, error) {
+	const op = "health.(Repository).LookupHealthCheck"
+	if hostSetId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing host set id")
+	}
+	hc := &HealthCheck{}
+	if err := r.reader.LookupWhere(ctx, hc, "host_set_id = ?", []interface{}{hostSetId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", hostSetId)))
+	}
+	return hc, nil
+}
+
+// ListHealthChecks returns the health checks of the host sets. If no host
+// set ids are provided, all health checks are returned.
+func (r *Repository) ListHealthChecks(ctx context.Context, hostSetIds ...string) ([]*HealthCheck, error) {
+	const op = "health.(Repository).ListHealthChecks"
+	var where string
+	var args []interface{}
+	if len(hostSetIds) > 0 {
+		where = "host_set_id in (?)"
+		args = append(args, hostSetIds)
+	}
+	var hcs []*HealthCheck
+	if err := r.reader.SearchWhere(ctx, &hcs, where, args, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return hcs, nil
+}
+
+// DeleteHealthCheck deletes the health check of the host set along with the
+// recorded health of its hosts. Returns the number of health checks
+// deleted, which is 0 if the host set had none.
+func (r *Repository) DeleteHealthCheck(ctx context.Context, hostSetId string) (int, error) {
+	const op = "health.(Repository).DeleteHealthCheck"
+	if hostSetId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing host set id")
+	}
+	var rowsDeleted int
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsDeleted, err = w.Exec(ctx, deleteHealthCheckQuery, []interface{}{sql.Named("host_set_id", hostSetId)})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", hostSetId)))
+	}
+	return rowsDeleted, nil
+}
+
+// RecordResults records the results of probes reported by a worker.
+// Results for hosts or host sets which no longer exist, or for host sets
+// which no longer have a health check, are ignored.
+func (r *Repository) RecordResults(ctx context.Context, results []*Result) error {
+	const op = "health.(Repository).RecordResults"
+	if len(results) == 0 {
+		return nil
+	}
+	for _, res := range results {
+		switch {
+		case res == nil:
+			return errors.New(ctx, errors.InvalidParameter, op, "nil result")
+		case res.HostSetId == "":
+			return errors.New(ctx, errors.InvalidParameter, op, "missing host set id")
+		case res.HostId == "":
+			return errors.New(ctx, errors.InvalidParameter, op, "missing host id")
+		}
+	}
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			for _, res := range results {
+				lastError := res.Error
+				if res.Healthy {
+					lastError = ""
+				}
+				args := []interface{}{
+					sql.Named("host_set_id", res.HostSetId),
+					sql.Named("host_id", res.HostId),
+					sql.Named("healthy", res.Healthy),
+					sql.Named("last_error", lastError),
+				}
+				if _, err := w.Exec(ctx, upsertResultQuery, args); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to record result for host %s of host set %s", res.HostId, res.HostSetId)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// FilterHealthy returns the endpoints whose host is not currently unhealthy
+// according to the health check of its host set. The order of the endpoints
+// is preserved.
+func (r *Repository) FilterHealthy(ctx context.Context, endpoints []*host.Endpoint) ([]*host.Endpoint, error) {
+	const op = "health.(Repository).FilterHealthy"
+	if len(endpoints) == 0 {
+		return endpoints, nil
+	}
+	setIdsSeen := make(map[string]bool)
+	var setIds []string
+	for _, ep := range endpoints {
+		if !setIdsSeen[ep.SetId] {
+			setIdsSeen[ep.SetId] = true
+			setIds = append(setIds, ep.SetId)
+		}
+	}
+
+	rows, err := r.reader.Query(ctx, unhealthyHostsQuery, []interface{}{sql.Named("host_set_ids", setIds)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to query unhealthy hosts"))
+	}
+	defer rows.Close()
+	type setHost struct{ setId, hostId string }
+	unhealthy := make(map[setHost]bool)
+	for rows.Next() {
+		var sh setHost
+		if err := rows.Scan(&sh.setId, &sh.hostId); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan unhealthy host"))
+		}
+		unhealthy[sh] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read unhealthy hosts"))
+	}
+	if len(unhealthy) == 0 {
+		return endpoints, nil
+	}
+
+	healthy := make([]*host.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if unhealthy[setHost{setId: ep.SetId, hostId: ep.HostId}] {
+			continue
+		}
+		healthy = append(healthy, ep)
+	}
+	return healthy, nil
+}
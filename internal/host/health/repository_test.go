@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_New(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	got, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	_, err = NewRepository(nil, rw)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	_, err = NewRepository(rw, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+}
+
+func TestRepository_HealthCheck(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := static.TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	sets := static.TestSets(t, conn, catalog.PublicId, 2)
+
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("lookup-missing", func(t *testing.T) {
+		got, err := repo.LookupHealthCheck(ctx, sets[0].PublicId)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("set-invalid", func(t *testing.T) {
+		_, err := repo.SetHealthCheck(ctx, nil)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		_, err = repo.SetHealthCheck(ctx, &HealthCheck{HostSetId: sets[0].PublicId, Protocol: ProtocolTcp})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+
+	t.Run("set-lookup-replace-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		hc, err := NewHealthCheck(ctx, sets[0].PublicId, ProtocolTcp, 22)
+		require.NoError(err)
+		got, err := repo.SetHealthCheck(ctx, hc)
+		require.NoError(err)
+		assert.Equal(ProtocolTcp, got.Protocol)
+		assert.EqualValues(22, got.Port)
+		assert.Empty(got.Path)
+		assert.EqualValues(DefaultIntervalSeconds, got.IntervalSeconds)
+		assert.NotNil(got.CreateTime)
+
+		hc, err = NewHealthCheck(ctx, sets[0].PublicId, ProtocolHttp, 8080, WithPath("/health"), WithWorkerFilter(`"/name" == "w1"`))
+		require.NoError(err)
+		got, err = repo.SetHealthCheck(ctx, hc)
+		require.NoError(err)
+		assert.Equal(ProtocolHttp, got.Protocol)
+		assert.Equal("/health", got.Path)
+		assert.Equal(`"/name" == "w1"`, got.WorkerFilter)
+
+		found, err := repo.LookupHealthCheck(ctx, sets[0].PublicId)
+		require.NoError(err)
+		assert.Equal(got, found)
+
+		all, err := repo.ListHealthChecks(ctx)
+		require.NoError(err)
+		assert.Len(all, 1)
+		listed, err := repo.ListHealthChecks(ctx, sets[1].PublicId)
+		require.NoError(err)
+		assert.Empty(listed)
+
+		n, err := repo.DeleteHealthCheck(ctx, sets[0].PublicId)
+		require.NoError(err)
+		assert.Equal(1, n)
+		n, err = repo.DeleteHealthCheck(ctx, sets[0].PublicId)
+		require.NoError(err)
+		assert.Equal(0, n)
+		found, err = repo.LookupHealthCheck(ctx, sets[0].PublicId)
+		require.NoError(err)
+		assert.Nil(found)
+	})
+}
+
+func TestRepository_RecordResults(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := static.TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	sets := static.TestSets(t, conn, catalog.PublicId, 2)
+	hosts := static.TestHosts(t, conn, catalog.PublicId, 2)
+	static.TestSetMembers(t, conn, sets[0].PublicId, hosts)
+	static.TestSetMembers(t, conn, sets[1].PublicId, hosts)
+
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+	hc, err := NewHealthCheck(ctx, sets[0].PublicId, ProtocolTcp, 22, WithUnhealthyThreshold(2))
+	require.NoError(t, err)
+	_, err = repo.SetHealthCheck(ctx, hc)
+	require.NoError(t, err)
+
+	var endpoints []*host.Endpoint
+	for _, s := range sets {
+		for _, h := range hosts {
+			endpoints = append(endpoints, &host.Endpoint{SetId: s.PublicId, HostId: h.PublicId, Address: h.Address})
+		}
+	}
+	healthyCount := func(t *testing.T) int {
+		t.Helper()
+		got, err := repo.FilterHealthy(ctx, endpoints)
+		require.NoError(t, err)
+		return len(got)
+	}
+	fail := &Result{HostSetId: sets[0].PublicId, HostId: hosts[0].PublicId, Error: "connection refused"}
+	succeed := &Result{HostSetId: sets[0].PublicId, HostId: hosts[0].PublicId, Healthy: true}
+
+	t.Run("invalid", func(t *testing.T) {
+		err := repo.RecordResults(ctx, []*Result{{HostId: hosts[0].PublicId}})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		err = repo.RecordResults(ctx, []*Result{{HostSetId: sets[0].PublicId}})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+
+	t.Run("ignored-results", func(t *testing.T) {
+		// host set without a health check and unknown host
+		require.NoError(t, repo.RecordResults(ctx, []*Result{
+			{HostSetId: sets[1].PublicId, HostId: hosts[0].PublicId},
+			{HostSetId: sets[0].PublicId, HostId: "hst_unknown"},
+		}))
+		assert.Equal(t, 4, healthyCount(t))
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		require.NoError(t, repo.RecordResults(ctx, []*Result{fail}))
+		assert.Equal(t, 4, healthyCount(t), "unhealthy before reaching the threshold")
+
+		require.NoError(t, repo.RecordResults(ctx, []*Result{fail}))
+		got, err := repo.FilterHealthy(ctx, endpoints)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		for _, ep := range got {
+			assert.False(t, ep.SetId == sets[0].PublicId && ep.HostId == hosts[0].PublicId)
+		}
+
+		var hh HostHealth
+		require.NoError(t, rw.LookupWhere(ctx, &hh, "host_set_id = ? and host_id = ?", []interface{}{sets[0].PublicId, hosts[0].PublicId}))
+		assert.False(t, hh.Healthy)
+		assert.EqualValues(t, 2, hh.ConsecutiveFailures)
+		assert.Equal(t, "connection refused", hh.LastError)
+
+		require.NoError(t, repo.RecordResults(ctx, []*Result{succeed}))
+		assert.Equal(t, 4, healthyCount(t))
+	})
+
+	t.Run("stale-results-ignored", func(t *testing.T) {
+		require.NoError(t, repo.RecordResults(ctx, []*Result{fail, fail}))
+		assert.Equal(t, 3, healthyCount(t))
+
+		_, err := rw.Exec(ctx, "update host_health set last_check_time = now() - interval '1 hour'", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 4, healthyCount(t))
+	})
+
+	t.Run("reset-on-set", func(t *testing.T) {
+		require.NoError(t, repo.RecordResults(ctx, []*Result{fail, fail}))
+		assert.Equal(t, 3, healthyCount(t))
+		_, err := repo.SetHealthCheck(ctx, hc)
+		require.NoError(t, err)
+		assert.Equal(t, 4, healthyCount(t))
+	})
+}
@@ -1,6 +1,9 @@
 package plugin
 
-import "google.golang.org/protobuf/types/known/structpb"
+import (
+	"github.com/hashicorp/boundary/internal/pagination"
+	"google.golang.org/protobuf/types/known/structpb"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -29,6 +32,7 @@ type options struct {
 	withLimit               int
 	withSetIds              []string
 	withSecretsHmac         []byte
+	withPage                *pagination.Page
 }
 
 func getDefaultOptions() options {
@@ -130,3 +134,11 @@ func WithSecretsHmac(secretsHmac []byte) Option {
 		o.withSecretsHmac = secretsHmac
 	}
 }
+
+// WithPage provides an option to list a page of hosts. It overrides the
+// WithLimit option.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
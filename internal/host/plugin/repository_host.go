@@ -3,10 +3,13 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // LookupHost will look up a host in the repository. If the host is not
@@ -33,7 +36,7 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 }
 
 // ListHostsByCatalogId returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// Supports the WithLimit and WithPage options.
 func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string, opt ...Option) ([]*Host, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).ListHostsByCatalogId"
 	if catalogId == "" {
@@ -45,8 +48,20 @@ func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withPage != nil {
+		if pageWhere, pageArgs := opts.withPage.Where(); pageWhere != "" {
+			where += " and " + pageWhere
+			args = append(args, pageArgs...)
+		}
+		if opts.withPage.Size != 0 {
+			dbOpts[0] = db.WithLimit(opts.withPage.Size)
+		}
+		dbOpts = append(dbOpts, db.WithOrder(opts.withPage.OrderBy()))
+	}
 	var hostAggs []*hostAgg
-	err := r.reader.SearchWhere(ctx, &hostAggs, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hostAggs, where, args, dbOpts...)
 
 	switch {
 	case err != nil:
@@ -69,6 +84,17 @@ func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string,
 	return hosts, plg, nil
 }
 
+// ListDeletedIds lists the public ids of the hosts of the catalogs deleted
+// after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, catalogIds []string, since time.Time) ([]string, error) {
+	const op = "plugin.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.Host, catalogIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // ListHostsBySetId returns a slice of Hosts for the given set IDs.
 // WithLimit is the only option supported.
 func (r *Repository) ListHostsBySetIds(ctx context.Context, setIds []string, opt ...Option) ([]*Host, error) {
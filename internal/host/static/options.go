@@ -1,5 +1,7 @@
 package static
 
+import "github.com/hashicorp/boundary/internal/pagination"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -19,6 +21,7 @@ type options struct {
 	withLimit       int
 	withAddress     string
 	withPublicId    string
+	withPage        *pagination.Page
 }
 
 func getDefaultOptions() options {
@@ -65,3 +68,11 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithPage provides an option to list a page of hosts. It overrides the
+// WithLimit option.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
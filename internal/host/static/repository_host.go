@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -223,7 +226,7 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 }
 
 // ListHosts returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// Supports the WithLimit and WithPage options.
 func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
 	const op = "static.(Repository).ListHosts"
 	if catalogId == "" {
@@ -235,8 +238,20 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withPage != nil {
+		if pageWhere, pageArgs := opts.withPage.Where(); pageWhere != "" {
+			where += " and " + pageWhere
+			args = append(args, pageArgs...)
+		}
+		if opts.withPage.Size != 0 {
+			dbOpts[0] = db.WithLimit(opts.withPage.Size)
+		}
+		dbOpts = append(dbOpts, db.WithOrder(opts.withPage.OrderBy()))
+	}
 	var aggs []*hostAgg
-	err := r.reader.SearchWhere(ctx, &aggs, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &aggs, where, args, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -248,6 +263,17 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 	return hosts, nil
 }
 
+// ListDeletedIds lists the public ids of the hosts of the catalogs deleted
+// after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, catalogIds []string, since time.Time) ([]string, error) {
+	const op = "static.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.Host, catalogIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // DeleteHost deletes the host for the provided id from the repository
 // returning a count of the number of records deleted. All options are
 // ignored.
@@ -1,6 +1,10 @@
 package iam
 
-import "io"
+import (
+	"io"
+
+	"github.com/hashicorp/boundary/internal/pagination"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -29,6 +33,7 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withPage                    *pagination.Page
 }
 
 func getDefaultOptions() options {
@@ -141,3 +146,11 @@ func WithPrimaryAuthMethodId(id string) Option {
 		o.withPrimaryAuthMethodId = id
 	}
 }
+
+// WithPage provides an option to list a page of users. It overrides the
+// WithLimit option.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
 )
@@ -205,7 +208,8 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, _ ...O
 	return rowsDeleted, nil
 }
 
-// ListUsers lists users in the given scopes and supports the WithLimit option.
+// ListUsers lists users in the given scopes and supports the WithLimit and
+// WithPage options.
 func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt ...Option) ([]*User, error) {
 	const op = "iam.(Repository).ListUsers"
 	if len(withScopeIds) == 0 {
@@ -218,6 +222,17 @@ func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt .
 	return users, nil
 }
 
+// ListDeletedIds lists the public ids of the users of the scopes deleted
+// after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, scopeIds []string, since time.Time) ([]string, error) {
+	const op = "iam.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.User, scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // LookupUserWithLogin will attempt to lookup the user with a matching
 // account id and return the user if found. If a user is not found and the
 // account's scope is not the PrimaryAuthMethod, then an error is returned.
@@ -885,6 +900,15 @@ func (r *Repository) getUsers(ctx context.Context, userId string, scopeIds []str
 	default:
 		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
 	}
+	if opts.withPage != nil {
+		if pageWhere, pageArgs := opts.withPage.Where(); pageWhere != "" {
+			where, args = append(where, pageWhere), append(args, pageArgs...)
+		}
+		if opts.withPage.Size != 0 {
+			dbArgs[0] = db.WithLimit(opts.withPage.Size)
+		}
+		dbArgs = append(dbArgs, db.WithOrder(opts.withPage.OrderBy()))
+	}
 	var usersAcctInfo []*userAccountInfo
 	err := r.reader.SearchWhere(ctx, &usersAcctInfo, strings.Join(where, " and "), args, dbArgs...)
 	if err != nil {
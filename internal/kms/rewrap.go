@@ -0,0 +1,65 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// RewrapFn rewraps the values of a table which were encrypted using the data
+// key version dataKeyVersionId of the scope, by decrypting them and
+// encrypting them again using the current version of the data key. It
+// returns the number of rewrapped rows.
+//
+// Values are rewrapped outside of the transactions of the repositories
+// writing them, so a RewrapFn must only update the rows which are still
+// encrypted using dataKeyVersionId, for instance by using
+// db.WithWhere("key_id = ?", dataKeyVersionId).
+type RewrapFn func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kms *Kms) (int, error)
+
+var (
+	tableRewrapFns     = map[string]RewrapFn{}
+	tableRewrapFnsLock sync.RWMutex
+)
+
+// RegisterTableRewrapFn registers the function used to rewrap the values of
+// the table when the keys of a scope are rotated. The table must have a key_id
+// column containing the id of the data key version used to encrypt its rows.
+// It is meant to be called from the init function of the package owning the
+// table and panics if a function is already registered for the table.
+func RegisterTableRewrapFn(tableName string, fn RewrapFn) {
+	tableRewrapFnsLock.Lock()
+	defer tableRewrapFnsLock.Unlock()
+	if _, ok := tableRewrapFns[tableName]; ok {
+		panic(fmt.Sprintf("rewrap function already registered for table %s", tableName))
+	}
+	tableRewrapFns[tableName] = fn
+}
+
+// TableRewrapFns returns a copy of the registered rewrap functions, keyed by
+// table name.
+func TableRewrapFns() map[string]RewrapFn {
+	tableRewrapFnsLock.RLock()
+	defer tableRewrapFnsLock.RUnlock()
+	ret := make(map[string]RewrapFn, len(tableRewrapFns))
+	for k, v := range tableRewrapFns {
+		ret[k] = v
+	}
+	return ret
+}
+
+// RewrapTables returns the sorted names of the tables with a registered
+// rewrap function.
+func RewrapTables() []string {
+	tableRewrapFnsLock.RLock()
+	defer tableRewrapFnsLock.RUnlock()
+	ret := make([]string, 0, len(tableRewrapFns))
+	for k := range tableRewrapFns {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,209 @@
+package kms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
+)
+
+const dataKeyVersionsQuery = `
+select dkv.private_id as id,
+       dk.purpose,
+       dkv.version,
+       dkv.version = max(dkv.version) over (partition by dkv.data_key_id) as current
+  from kms_data_key_version dkv
+  join kms_data_key dk on dk.private_id = dkv.data_key_id
+  join kms_root_key rk on rk.private_id = dk.root_key_id
+ where rk.scope_id = @scope_id
+ order by dk.purpose, dkv.version;
+`
+
+// rotatedDataKeyVersionsQuery returns the current versions of the data keys
+// which have more than one version, along with the scope of the data key.
+const rotatedDataKeyVersionsQuery = `
+select rk.scope_id,
+       dk.purpose,
+       dkv.private_id as id
+  from kms_data_key_version dkv
+  join kms_data_key dk on dk.private_id = dkv.data_key_id
+  join kms_root_key rk on rk.private_id = dk.root_key_id
+ where (dkv.data_key_id, dkv.version) in (
+         select data_key_id, max(version)
+           from kms_data_key_version
+          group by data_key_id
+         having count(*) > 1
+       );
+`
+
+// DataKeyVersion is a version of one of the data keys of a scope.
+type DataKeyVersion struct {
+	// Id is the key id of the version, which is stored as the key_id of the
+	// values encrypted using it.
+	Id      string
+	Purpose KeyPurpose
+	Version uint32
+	// Current is true if the version is the latest version of its data key,
+	// which is the version used to encrypt new values.
+	Current bool
+}
+
+// RotateKeys rotates the root key and the data keys of the scope. A new
+// version of each key is created and the previous versions of the data keys
+// are rewrapped using the new version of the root key. The previous versions
+// are kept so values encrypted using them can still be decrypted. Supports
+// the WithRandomReader(...) and WithReaderWriter(...) options. When
+// WithReaderWriter(...) is used the caller is responsible for managing the
+// transaction and for calling ReloadWrappers once it has been committed.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) error {
+	const op = "kms.(Kms).RotateKeys"
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	kmsOpts := []wrappingKms.Option{
+		wrappingKms.WithRandomReader(opts.withRandomReader),
+		wrappingKms.WithRewrap(true),
+	}
+	switch {
+	case !isNil(opts.withReader) && isNil(opts.withWriter):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	case isNil(opts.withReader) && !isNil(opts.withWriter):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case !isNil(opts.withReader) && !isNil(opts.withWriter):
+		r, ok := opts.withReader.(*db.Db)
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, "unable to convert reader to db.Db")
+		}
+		w, ok := opts.withWriter.(*db.Db)
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, "unable to convert writer to db.Db")
+		}
+		kmsOpts = append(kmsOpts, wrappingKms.WithReaderWriter(db.NewChangeSafeDbwReader(r), db.NewChangeSafeDbwWriter(w)))
+	}
+	if err := k.underlying.RotateKeys(ctx, scopeId, kmsOpts...); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rotate keys in scope %s", scopeId)))
+	}
+	if isNil(opts.withWriter) {
+		if err := k.ReloadWrappers(ctx, scopeId); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+// ListDataKeyVersions returns all the versions of the data keys of the
+// scope, ordered by purpose and version.
+func (k *Kms) ListDataKeyVersions(ctx context.Context, scopeId string) ([]DataKeyVersion, error) {
+	const op = "kms.(Kms).ListDataKeyVersions"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	rows, err := k.reader.Query(ctx, dataKeyVersionsQuery, []interface{}{sql.Named("scope_id", scopeId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var versions []DataKeyVersion
+	for rows.Next() {
+		var v struct {
+			Id      string
+			Purpose string
+			Version uint32
+			Current bool
+		}
+		if err := k.reader.ScanRows(ctx, rows, &v); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan data key version"))
+		}
+		versions = append(versions, DataKeyVersion{
+			Id:      v.Id,
+			Purpose: purposeFromString(v.Purpose),
+			Version: v.Version,
+			Current: v.Current,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return versions, nil
+}
+
+// ReloadWrappers makes sure the wrappers of the scope cached by k encrypt
+// using the current versions of the data keys of the scope. It must be
+// called after the keys of the scope have been rotated by another
+// controller, otherwise the cached wrappers keep encrypting using the
+// previous versions until they are refreshed. See ReloadRotatedWrappers.
+func (k *Kms) ReloadWrappers(ctx context.Context, scopeId string) error {
+	const op = "kms.(Kms).ReloadWrappers"
+	versions, err := k.ListDataKeyVersions(ctx, scopeId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, v := range versions {
+		if !v.Current || v.Purpose == KeyPurposeUnknown {
+			continue
+		}
+		// Requesting the wrapper of a key id missing from the cached
+		// wrapper refreshes it from the database.
+		if _, err := k.GetWrapper(ctx, scopeId, v.Purpose, WithKeyId(v.Id)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to reload %s wrapper", v.Purpose)))
+		}
+	}
+	return nil
+}
+
+// ReloadRotatedWrappers calls ReloadWrappers for every scope whose keys have
+// been rotated. Keys are rotated by a single controller, so every controller
+// calls it periodically to stop encrypting using the previous versions
+// cached before the rotation.
+func (k *Kms) ReloadRotatedWrappers(ctx context.Context) error {
+	const op = "kms.(Kms).ReloadRotatedWrappers"
+	rows, err := k.reader.Query(ctx, rotatedDataKeyVersionsQuery, nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	type current struct {
+		ScopeId string
+		Purpose string
+		Id      string
+	}
+	var versions []current
+	for rows.Next() {
+		var v current
+		if err := k.reader.ScanRows(ctx, rows, &v); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan data key version"))
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	for _, v := range versions {
+		purpose := purposeFromString(v.Purpose)
+		if purpose == KeyPurposeUnknown {
+			continue
+		}
+		// Requesting the wrapper of a key id missing from the cached
+		// wrapper refreshes it from the database.
+		if _, err := k.GetWrapper(ctx, v.ScopeId, purpose, WithKeyId(v.Id)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to reload %s wrapper of scope %s", purpose, v.ScopeId)))
+		}
+	}
+	return nil
+}
+
+func purposeFromString(s string) KeyPurpose {
+	for _, p := range ValidDekPurposes() {
+		if p.String() == s {
+			return p
+		}
+	}
+	return KeyPurposeUnknown
+}
@@ -0,0 +1,110 @@
+package kms_test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	extWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, extWrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, extWrapper))
+
+	t.Run("missing scope id", func(t *testing.T) {
+		err := kmsCache.RotateKeys(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing writer", func(t *testing.T) {
+		err := kmsCache.RotateKeys(ctx, proj.GetPublicId(), kms.WithReaderWriter(db.New(conn), nil))
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	assert, require := assert.New(t), require.New(t)
+	before, err := kmsCache.ListDataKeyVersions(ctx, proj.GetPublicId())
+	require.NoError(err)
+	require.Len(before, len(kms.ValidDekPurposes()))
+	for _, v := range before {
+		assert.True(v.Current)
+		assert.Equal(uint32(1), v.Version)
+		assert.NotEqual(kms.KeyPurposeUnknown, v.Purpose)
+	}
+
+	wrapper, err := kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	blob, err := wrapper.Encrypt(ctx, []byte("secret"))
+	require.NoError(err)
+
+	require.NoError(kmsCache.RotateKeys(ctx, proj.GetPublicId(), kms.WithRandomReader(rand.Reader)))
+
+	after, err := kmsCache.ListDataKeyVersions(ctx, proj.GetPublicId())
+	require.NoError(err)
+	require.Len(after, 2*len(kms.ValidDekPurposes()))
+	current := map[kms.KeyPurpose]string{}
+	for _, v := range after {
+		if v.Current {
+			assert.Equal(uint32(2), v.Version)
+			current[v.Purpose] = v.Id
+		}
+	}
+	require.Len(current, len(kms.ValidDekPurposes()))
+
+	// The cached wrapper encrypts using the new version and can still
+	// decrypt values encrypted using the previous one.
+	wrapper, err = kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	keyId, err := wrapper.KeyId(ctx)
+	require.NoError(err)
+	assert.Equal(current[kms.KeyPurposeDatabase], keyId)
+	pt, err := wrapper.Decrypt(ctx, blob)
+	require.NoError(err)
+	assert.Equal([]byte("secret"), pt)
+
+	// A kms created before the rotation keeps using the previous version
+	// until its wrappers are reloaded.
+	other := kms.TestKms(t, conn, extWrapper)
+	_, err = other.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	require.NoError(kmsCache.RotateKeys(ctx, proj.GetPublicId(), kms.WithRandomReader(rand.Reader)))
+	require.NoError(other.ReloadWrappers(ctx, proj.GetPublicId()))
+	latest, err := kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	otherWrapper, err := other.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	assert.Equal(keyIdOf(t, latest), keyIdOf(t, otherWrapper))
+
+	// The wrappers of every rotated scope can be reloaded at once.
+	require.NoError(kmsCache.RotateKeys(ctx, proj.GetPublicId(), kms.WithRandomReader(rand.Reader)))
+	require.NoError(other.ReloadRotatedWrappers(ctx))
+	latest, err = kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	otherWrapper, err = other.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	assert.Equal(keyIdOf(t, latest), keyIdOf(t, otherWrapper))
+}
+
+func TestRegisterTableRewrapFn(t *testing.T) {
+	fn := func(context.Context, string, string, db.Reader, db.Writer, *kms.Kms) (int, error) { return 0, nil }
+	kms.RegisterTableRewrapFn("kms_test_rewrap_table", fn)
+	assert.Contains(t, kms.RewrapTables(), "kms_test_rewrap_table")
+	assert.Contains(t, kms.TableRewrapFns(), "kms_test_rewrap_table")
+	assert.Panics(t, func() { kms.RegisterTableRewrapFn("kms_test_rewrap_table", fn) })
+}
+
+func keyIdOf(t *testing.T, w wrapping.Wrapper) string {
+	t.Helper()
+	id, err := w.KeyId(context.Background())
+	require.NoError(t, err)
+	return id
+}
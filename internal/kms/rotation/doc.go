@@ -0,0 +1,23 @@
+// Package rotation provides the rotation of the keys of a scope and the
+// rewrapping of the values encrypted using the previous versions of its data
+// keys.
+//
+// Rotating the keys of a scope creates a new version of its root key and of
+// each of its data keys, and rewraps the previous versions of the data keys
+// using the new version of the root key. New values are encrypted using the
+// new versions while values encrypted using the previous versions can still
+// be decrypted.
+//
+// When requested, the values encrypted using the previous versions of the
+// data keys are rewrapped in the background by the kms_rewrap job. The
+// packages owning encrypted values register the function rewrapping the
+// values of each of their tables using kms.RegisterTableRewrapFn. The
+// progress of the rewrapping of a scope is recorded in a RewrapRun, which is
+// replaced when the keys of the scope are rotated again.
+//
+// # Repository
+//
+// A repository provides methods for rotating the keys of a scope and
+// retrieving the progress of its rewrap run. A new repository should be
+// created for each transaction.
+package rotation
@@ -0,0 +1,205 @@
+package rotation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+const (
+	rewrapJobName = "kms_rewrap"
+
+	// rewrapFrequency is the interval at which the rewrap job checks for
+	// runs which have not completed, for instance because the controller
+	// running the job was stopped. Rotating keys runs the job immediately.
+	rewrapFrequency = 10 * time.Minute
+)
+
+// rewrapJob is a periodic job which rewraps the values encrypted using the
+// previous versions of the data keys of the scopes with a rewrap run which
+// has not completed.
+type rewrapJob struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+
+	mu        sync.Mutex
+	total     int
+	completed int
+}
+
+func newRewrapJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms) (*rewrapJob, error) {
+	const op = "rotation.newRewrapJob"
+	switch {
+	case isNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case isNil(w):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+	return &rewrapJob{
+		reader: r,
+		writer: w,
+		kms:    kms,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *rewrapJob) Name() string { return rewrapJobName }
+
+// Description returns the description for the job.
+func (j *rewrapJob) Description() string {
+	return "Rewrap values encrypted using previous versions of rotated data keys"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *rewrapJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return rewrapFrequency, nil
+}
+
+// Status returns the status of the running job.
+func (j *rewrapJob) Status() scheduler.JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return scheduler.JobStatus{
+		Completed: j.completed,
+		Total:     j.total,
+	}
+}
+
+// Run executes the job.
+func (j *rewrapJob) Run(ctx context.Context) error {
+	const op = "rotation.(rewrapJob).Run"
+	j.mu.Lock()
+	j.total, j.completed = 0, 0
+	j.mu.Unlock()
+
+	var runs []*RewrapRun
+	if err := j.reader.SearchWhere(ctx, &runs, "end_time is null", nil, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, run := range runs {
+		if err := j.rewrap(ctx, run); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+// rewrap rewraps the values of every table with a registered rewrap
+// function which are encrypted using the previous versions of the data keys
+// of the scope of the run, and ends the run.
+func (j *rewrapJob) rewrap(ctx context.Context, run *RewrapRun) error {
+	const op = "rotation.(rewrapJob).rewrap"
+	// The keys may have been rotated by another controller.
+	if err := j.kms.ReloadWrappers(ctx, run.ScopeId); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	versions, err := j.kms.ListDataKeyVersions(ctx, run.ScopeId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	var previous []string
+	for _, v := range versions {
+		if !v.Current {
+			previous = append(previous, v.Id)
+		}
+	}
+	tables := kms.RewrapTables()
+	fns := kms.TableRewrapFns()
+
+	runArgs := func(args ...interface{}) []interface{} {
+		return append(args, sql.Named("scope_id", run.ScopeId), sql.Named("create_time", run.CreateTime))
+	}
+
+	if run.TotalCount == 0 && run.CompletedCount == 0 {
+		var total int64
+		for _, table := range tables {
+			for _, keyId := range previous {
+				n, err := j.count(ctx, table, keyId)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				total += n
+			}
+		}
+		if _, err := j.writer.Exec(ctx, setRewrapRunTotalQuery, runArgs(sql.Named("total_count", total))); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set rewrap run total"))
+		}
+		run.TotalCount = total
+	}
+	j.mu.Lock()
+	j.total += int(run.TotalCount)
+	j.completed += int(run.CompletedCount)
+	j.mu.Unlock()
+
+	for _, table := range tables {
+		for _, keyId := range previous {
+			n, err := fns[table](ctx, keyId, run.ScopeId, j.reader, j.writer, j.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rewrap %s for scope %s", table, run.ScopeId)))
+			}
+			if n == 0 {
+				continue
+			}
+			if _, err := j.writer.Exec(ctx, incrementRewrapRunCompletedQuery, runArgs(sql.Named("count", n))); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update rewrap run progress"))
+			}
+			j.mu.Lock()
+			j.completed += n
+			j.mu.Unlock()
+		}
+	}
+
+	// Controllers which have not yet reloaded the wrappers of the scope may
+	// have encrypted new values using the previous versions while they were
+	// rewrapped. The run is only ended once no value references them, it is
+	// continued by the next run of the job otherwise.
+	var remaining int64
+	for _, table := range tables {
+		for _, keyId := range previous {
+			n, err := j.count(ctx, table, keyId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			remaining += n
+		}
+	}
+	if remaining > 0 {
+		event.WriteSysEvent(ctx, op, "values still encrypted using previous key versions, rewrap run not ended", "scope_id", run.ScopeId, "remaining", remaining)
+		return nil
+	}
+
+	if _, err := j.writer.Exec(ctx, endRewrapRunQuery, runArgs()); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to end rewrap run"))
+	}
+	return nil
+}
+
+func (j *rewrapJob) count(ctx context.Context, table, keyId string) (int64, error) {
+	const op = "rotation.(rewrapJob).count"
+	rows, err := j.reader.Query(ctx, fmt.Sprintf(countKeyIdQuery, table), []interface{}{sql.Named("key_id", keyId)})
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to count %s", table)))
+	}
+	defer rows.Close()
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(&n); err != nil {
+			return 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to count %s", table)))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	return n, nil
+}
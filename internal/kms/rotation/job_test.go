@@ -0,0 +1,160 @@
+package rotation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assert the interface
+var _ = scheduler.Job(new(rewrapJob))
+
+const testRewrapTable = "kms_rewrap_test_value"
+
+// testRewrapValue is a row of a table created by the test. Rewrapping a
+// value only updates its key id.
+type testRewrapValue struct {
+	Id    string `gorm:"primary_key"`
+	KeyId string
+}
+
+func (*testRewrapValue) TableName() string { return testRewrapTable }
+
+// skipTestRewrap makes the rewrap function of the test table leave its values
+// encrypted using the previous versions.
+var skipTestRewrap bool
+
+func init() {
+	kms.RegisterTableRewrapFn(testRewrapTable, func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, k *kms.Kms) (int, error) {
+		if skipTestRewrap {
+			return 0, nil
+		}
+		var values []*testRewrapValue
+		if err := reader.SearchWhere(ctx, &values, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+			return 0, err
+		}
+		wrapper, err := k.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return 0, err
+		}
+		keyId, err := wrapper.KeyId(ctx)
+		if err != nil {
+			return 0, err
+		}
+		var rewrapped int
+		for _, v := range values {
+			v.KeyId = keyId
+			n, err := writer.Update(ctx, v, []string{"KeyId"}, nil, db.WithWhere("key_id = ?", dataKeyVersionId))
+			if err != nil {
+				return rewrapped, err
+			}
+			rewrapped += n
+		}
+		return rewrapped, nil
+	})
+}
+
+func TestRewrapJob(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	_, err := rw.Exec(ctx, fmt.Sprintf(`create table %s (id text primary key, key_id text not null)`, testRewrapTable), nil)
+	require.NoError(err)
+
+	dbWrapper, err := kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	previousKeyId, err := dbWrapper.KeyId(ctx)
+	require.NoError(err)
+	for i := 0; i < 3; i++ {
+		require.NoError(rw.Create(ctx, &testRewrapValue{Id: fmt.Sprintf("value_%d", i), KeyId: previousKeyId}))
+	}
+
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(err)
+	_, err = repo.RotateKeys(ctx, proj.GetPublicId(), WithRewrap(true))
+	require.NoError(err)
+
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+	assert.Equal(scheduler.JobStatus{Completed: 3, Total: 3}, job.Status())
+
+	run, err := repo.LookupRewrapRun(ctx, proj.GetPublicId())
+	require.NoError(err)
+	require.NotNil(run)
+	assert.True(run.Completed())
+	assert.Equal(int64(3), run.TotalCount)
+	assert.Equal(int64(3), run.CompletedCount)
+
+	dbWrapper, err = kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	currentKeyId, err := dbWrapper.KeyId(ctx)
+	require.NoError(err)
+	assert.NotEqual(previousKeyId, currentKeyId)
+	var values []*testRewrapValue
+	require.NoError(rw.SearchWhere(ctx, &values, "key_id = ?", []interface{}{currentKeyId}))
+	assert.Len(values, 3)
+
+	// Completed runs are not run again.
+	require.NoError(job.Run(ctx))
+	assert.Equal(scheduler.JobStatus{}, job.Status())
+}
+
+func TestRewrapJob_RemainingValues(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	_, err := rw.Exec(ctx, fmt.Sprintf(`create table %s (id text primary key, key_id text not null)`, testRewrapTable), nil)
+	require.NoError(err)
+
+	dbWrapper, err := kmsCache.GetWrapper(ctx, proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	previousKeyId, err := dbWrapper.KeyId(ctx)
+	require.NoError(err)
+	require.NoError(rw.Create(ctx, &testRewrapValue{Id: "value", KeyId: previousKeyId}))
+
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(err)
+	_, err = repo.RotateKeys(ctx, proj.GetPublicId(), WithRewrap(true))
+	require.NoError(err)
+
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	// The run is not ended while a value is still encrypted using a
+	// previous version.
+	skipTestRewrap = true
+	t.Cleanup(func() { skipTestRewrap = false })
+	require.NoError(job.Run(ctx))
+	run, err := repo.LookupRewrapRun(ctx, proj.GetPublicId())
+	require.NoError(err)
+	require.NotNil(run)
+	assert.False(run.Completed())
+
+	skipTestRewrap = false
+	require.NoError(job.Run(ctx))
+	run, err = repo.LookupRewrapRun(ctx, proj.GetPublicId())
+	require.NoError(err)
+	require.NotNil(run)
+	assert.True(run.Completed())
+	assert.Equal(int64(1), run.CompletedCount)
+}
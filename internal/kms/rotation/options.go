@@ -0,0 +1,42 @@
+package rotation
+
+import "io"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withRewrap       bool
+	withRandomReader io.Reader
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithRewrap provides an option to rewrap the values encrypted using the
+// previous versions of the data keys of the scope once its keys have been
+// rotated.
+func WithRewrap(rewrap bool) Option {
+	return func(o *options) {
+		o.withRewrap = rewrap
+	}
+}
+
+// WithRandomReader provides an optional random reader used to generate the
+// new versions of the keys. By default the reader from crypto/rand is used.
+func WithRandomReader(r io.Reader) Option {
+	return func(o *options) {
+		o.withRandomReader = r
+	}
+}
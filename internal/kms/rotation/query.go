@@ -0,0 +1,49 @@
+package rotation
+
+const (
+	deleteRewrapRunQuery = `
+delete from kms_rewrap_run
+ where scope_id = @scope_id;
+`
+
+	insertRewrapRunQuery = `
+insert into kms_rewrap_run
+  (scope_id)
+values
+  (@scope_id);
+`
+
+	// The updates of a run are conditioned on its create time so a job
+	// rewrapping the values of a scope does not update the run replacing it
+	// when the keys of the scope are rotated again.
+
+	setRewrapRunTotalQuery = `
+update kms_rewrap_run
+   set total_count = @total_count
+ where scope_id = @scope_id
+   and create_time = @create_time;
+`
+
+	incrementRewrapRunCompletedQuery = `
+update kms_rewrap_run
+   set completed_count = completed_count + @count
+ where scope_id = @scope_id
+   and create_time = @create_time;
+`
+
+	endRewrapRunQuery = `
+update kms_rewrap_run
+   set end_time    = now(),
+       total_count = greatest(total_count, completed_count)
+ where scope_id = @scope_id
+   and create_time = @create_time;
+`
+
+	// countKeyIdQuery is formatted with the name of a table with a
+	// registered rewrap function.
+	countKeyIdQuery = `
+select count(*)
+  from %s
+ where key_id = @key_id;
+`
+)
@@ -0,0 +1,110 @@
+package rotation
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// A Repository rotates the keys of scopes and retrieves the progress of
+// their rewrap runs. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader    db.Reader
+	writer    db.Writer
+	kms       *kms.Kms
+	scheduler *scheduler.Scheduler
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it.
+func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, scheduler *scheduler.Scheduler) (*Repository, error) {
+	const op = "rotation.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "db.Reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "db.Writer")
+	case kms == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "kms")
+	case scheduler == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "scheduler")
+	}
+	return &Repository{
+		reader:    r,
+		writer:    w,
+		kms:       kms,
+		scheduler: scheduler,
+	}, nil
+}
+
+// RotateKeys rotates the root key and the data keys of the scope. When
+// WithRewrap(true) is provided, the values encrypted using the previous
+// versions of the data keys are rewrapped in the background and the new
+// rewrap run of the scope is returned, replacing any previous run.
+// Otherwise nil is returned. Supports the WithRewrap and WithRandomReader
+// options.
+func (r *Repository) RotateKeys(ctx context.Context, scopeId string, opt ...Option) (*RewrapRun, error) {
+	const op = "rotation.(Repository).RotateKeys"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	randomReader := opts.withRandomReader
+	if randomReader == nil {
+		randomReader = rand.Reader
+	}
+
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if err := r.kms.RotateKeys(ctx, scopeId, kms.WithRandomReader(randomReader), kms.WithReaderWriter(reader, w)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if !opts.withRewrap {
+				return nil
+			}
+			args := []interface{}{sql.Named("scope_id", scopeId)}
+			if _, err := w.Exec(ctx, deleteRewrapRunQuery, args); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete previous rewrap run"))
+			}
+			if _, err := w.Exec(ctx, insertRewrapRunQuery, args); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create rewrap run"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for scope %s", scopeId)))
+	}
+	if err := r.kms.ReloadWrappers(ctx, scopeId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !opts.withRewrap {
+		return nil, nil
+	}
+	_ = r.scheduler.UpdateJobNextRunInAtLeast(ctx, rewrapJobName, 0, scheduler.WithRunNow(true))
+	return r.LookupRewrapRun(ctx, scopeId)
+}
+
+// LookupRewrapRun returns the rewrap run of the scope. Returns nil, nil if
+// the scope has no rewrap run.
+func (r *Repository) LookupRewrapRun(ctx context.Context, scopeId string) (*RewrapRun, error) {
+	const op = "rotation.(Repository).LookupRewrapRun"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	run := &RewrapRun{}
+	if err := r.reader.LookupWhere(ctx, run, "scope_id = ?", []interface{}{scopeId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", scopeId)))
+	}
+	return run, nil
+}
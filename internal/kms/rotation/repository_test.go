@@ -0,0 +1,87 @@
+package rotation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	_, err := NewRepository(nil, rw, kmsCache, sche)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = NewRepository(rw, nil, kmsCache, sche)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = NewRepository(rw, rw, nil, sche)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = NewRepository(rw, rw, kmsCache, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(t, err)
+	assert.NotNil(t, repo)
+}
+
+func TestRepository_RotateKeys(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(t, err)
+
+	t.Run("missing scope id", func(t *testing.T) {
+		_, err := repo.RotateKeys(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.LookupRewrapRun(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("without rewrap", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		run, err := repo.RotateKeys(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Nil(run)
+		run, err = repo.LookupRewrapRun(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Nil(run)
+
+		versions, err := kmsCache.ListDataKeyVersions(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Len(versions, 2*len(kms.ValidDekPurposes()))
+	})
+
+	t.Run("with rewrap", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		run, err := repo.RotateKeys(ctx, proj.GetPublicId(), WithRewrap(true))
+		require.NoError(err)
+		require.NotNil(run)
+		assert.Equal(proj.GetPublicId(), run.ScopeId)
+		assert.False(run.Completed())
+		assert.NotNil(run.CreateTime)
+
+		// Rotating again replaces the run.
+		again, err := repo.RotateKeys(ctx, proj.GetPublicId(), WithRewrap(true))
+		require.NoError(err)
+		require.NotNil(again)
+		assert.True(again.CreateTime.AsTime().After(run.CreateTime.AsTime()))
+
+		found, err := repo.LookupRewrapRun(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Equal(again, found)
+	})
+}
@@ -0,0 +1,28 @@
+package rotation
+
+import "github.com/hashicorp/boundary/internal/db/timestamp"
+
+// A RewrapRun is the progress of rewrapping the values encrypted using the
+// previous versions of the data keys of a scope.
+type RewrapRun struct {
+	ScopeId string `gorm:"primary_key"`
+	// TotalCount is the number of values which were encrypted using the
+	// previous versions when the run started.
+	TotalCount int64
+	// CompletedCount is the number of values rewrapped so far. It can end
+	// up greater than TotalCount when values are encrypted using the
+	// previous versions while the run is in progress.
+	CompletedCount int64
+	CreateTime     *timestamp.Timestamp
+	UpdateTime     *timestamp.Timestamp
+	// EndTime is nil until all the values have been rewrapped.
+	EndTime *timestamp.Timestamp
+}
+
+// TableName returns the table name.
+func (*RewrapRun) TableName() string { return "kms_rewrap_run" }
+
+// Completed reports whether all the values have been rewrapped.
+func (r *RewrapRun) Completed() bool {
+	return r.EndTime != nil
+}
@@ -0,0 +1,22 @@
+package rotation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepository returns a Repository using a test kms and scheduler.
+func TestRepository(t testing.TB, conn *db.DB, rootWrapper wrapping.Wrapper) *Repository {
+	t.Helper()
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	sche := scheduler.TestScheduler(t, conn, rootWrapper)
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(t, err)
+	return repo
+}
@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+)
+
+// maxBackoff is the maximum time waited between two delivery attempts.
+const maxBackoff = 30 * time.Second
+
+// errNotRetryable marks a delivery failure which is not retried.
+var errNotRetryable = stderrors.New("not retryable")
+
+// deliverer posts payloads to webhooks.
+type deliverer struct {
+	client *http.Client
+
+	// backoff returns the time to wait before the given attempt; attempts
+	// start at 1. It is only replaced in tests.
+	backoff func(attempt int) time.Duration
+
+	// now is only replaced in tests.
+	now func() time.Time
+}
+
+func newDeliverer() *deliverer {
+	return &deliverer{
+		client:  &http.Client{},
+		backoff: exponentialBackoff,
+		now:     time.Now,
+	}
+}
+
+func exponentialBackoff(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	d := time.Second << (attempt - 2)
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// deliver posts the payload to the webhook, retrying up to the maximum number
+// of attempts of the webhook. When every attempt failed the payload is
+// dead-lettered and deliver returns false. An error is only returned when the
+// context is done before the payload was delivered or dead-lettered.
+func (d *deliverer) deliver(ctx context.Context, w *Webhook, p *Payload) (bool, error) {
+	const op = "notification.(deliverer).deliver"
+	body, err := json.Marshal(p)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to marshal payload"))
+	}
+
+	var attempt int
+	var lastErr error
+	for attempt = 1; attempt <= w.MaxAttempts; attempt++ {
+		if wait := d.backoff(attempt); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return false, errors.Wrap(ctx, ctx.Err(), op)
+			case <-timer.C:
+			}
+		}
+		lastErr = d.post(ctx, w, p, body)
+		if lastErr == nil {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, errors.Wrap(ctx, ctx.Err(), op)
+		}
+		if stderrors.Is(lastErr, errNotRetryable) {
+			break
+		}
+	}
+	if attempt > w.MaxAttempts {
+		attempt = w.MaxAttempts
+	}
+
+	event.WriteError(ctx, op, lastErr,
+		event.WithInfoMsg("webhook delivery failed, dead-lettering notification event",
+			"webhook", w.Name,
+			"event_id", p.Id,
+			"event_type", string(p.Type),
+			"attempts", attempt,
+			"payload", string(body),
+		))
+	return false, nil
+}
+
+// post makes a single delivery attempt.
+func (d *deliverer) post(ctx context.Context, w *Webhook, p *Payload, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %s", errNotRetryable, err)
+	}
+	ts := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(p.Type))
+	req.Header.Set(DeliveryHeader, p.Id)
+	req.Header.Set(TimestampHeader, fmt.Sprintf("%d", ts))
+	req.Header.Set(SignatureHeader, Sign(w.Secret, ts, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("%w: unexpected status code %d", errNotRetryable, resp.StatusCode)
+	}
+}
@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{"id":"ne_1"}`)
+	sig := Sign("secret", 1700000000, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, sig)
+	assert.Equal(t, sig, Sign("secret", 1700000000, body))
+	assert.NotEqual(t, sig, Sign("other", 1700000000, body))
+	assert.NotEqual(t, sig, Sign("secret", 1700000001, body))
+	assert.NotEqual(t, sig, Sign("secret", 1700000000, []byte(`{"id":"ne_2"}`)))
+}
+
+func TestDeliverer_deliver(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	payload := &Payload{
+		Id:      "ne_1",
+		Type:    SessionAuthorized,
+		Time:    now,
+		ScopeId: "p_1234567890",
+		Session: &SessionData{Id: "s_1234567890"},
+	}
+
+	tests := []struct {
+		name          string
+		statuses      []int
+		maxAttempts   int
+		wantDelivered bool
+		wantAttempts  int
+	}{
+		{
+			name:          "delivered",
+			statuses:      []int{http.StatusNoContent},
+			maxAttempts:   3,
+			wantDelivered: true,
+			wantAttempts:  1,
+		},
+		{
+			name:          "retried-server-error",
+			statuses:      []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK},
+			maxAttempts:   3,
+			wantDelivered: true,
+			wantAttempts:  3,
+		},
+		{
+			name:          "dead-lettered-after-max-attempts",
+			statuses:      []int{http.StatusInternalServerError},
+			maxAttempts:   2,
+			wantDelivered: false,
+			wantAttempts:  2,
+		},
+		{
+			name:          "client-error-not-retried",
+			statuses:      []int{http.StatusBadRequest},
+			maxAttempts:   3,
+			wantDelivered: false,
+			wantAttempts:  1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+
+			var mu sync.Mutex
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(err)
+				assert.Equal("application/json", r.Header.Get("Content-Type"))
+				assert.Equal(string(SessionAuthorized), r.Header.Get(EventHeader))
+				assert.Equal("ne_1", r.Header.Get(DeliveryHeader))
+				assert.Equal(strconv.FormatInt(now.Unix(), 10), r.Header.Get(TimestampHeader))
+				assert.Equal(Sign("secret", now.Unix(), body), r.Header.Get(SignatureHeader))
+				var got Payload
+				assert.NoError(json.Unmarshal(body, &got))
+				assert.Equal("s_1234567890", got.Session.Id)
+
+				status := tt.statuses[len(tt.statuses)-1]
+				if attempts < len(tt.statuses) {
+					status = tt.statuses[attempts]
+				}
+				attempts++
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+
+			wh, err := NewWebhook(ctx, "test", "global", srv.URL, "secret", WithMaxAttempts(tt.maxAttempts))
+			require.NoError(err)
+			d := newDeliverer()
+			d.backoff = func(int) time.Duration { return 0 }
+			d.now = func() time.Time { return now }
+
+			delivered, err := d.deliver(ctx, wh, payload)
+			require.NoError(err)
+			assert.Equal(tt.wantDelivered, delivered)
+			mu.Lock()
+			assert.Equal(tt.wantAttempts, attempts)
+			mu.Unlock()
+		})
+	}
+
+	t.Run("canceled-context", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		wh, err := NewWebhook(ctx, "test", "global", srv.URL, "secret", WithMaxAttempts(5))
+		require.NoError(t, err)
+		cancelCtx, cancel := context.WithCancel(ctx)
+		d := newDeliverer()
+		d.backoff = func(attempt int) time.Duration {
+			if attempt > 1 {
+				cancel()
+				return time.Hour
+			}
+			return 0
+		}
+		delivered, err := d.deliver(cancelCtx, wh, payload)
+		require.Error(t, err)
+		assert.False(t, delivered)
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, time.Duration(0), exponentialBackoff(1))
+	assert.Equal(t, time.Second, exponentialBackoff(2))
+	assert.Equal(t, 2*time.Second, exponentialBackoff(3))
+	assert.Equal(t, 4*time.Second, exponentialBackoff(4))
+	assert.Equal(t, maxBackoff, exponentialBackoff(10))
+	assert.Equal(t, maxBackoff, exponentialBackoff(100))
+}
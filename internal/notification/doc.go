@@ -0,0 +1,30 @@
+// Package notification delivers session and worker lifecycle events to the
+// webhooks configured on the controller.
+//
+// Events are written to an outbox table in the database: session events by a
+// trigger on session state transitions, worker events by the delivery job
+// when it notices a worker started or stopped reporting status. A single
+// delivery job running on one of the controllers reads the outbox, posts the
+// events to the webhooks whose scope and event types match and removes them
+// from the outbox. When the controller running the job has no webhooks
+// configured, the events are left for the controllers which do and are only
+// removed once they are older than a day.
+//
+// # Webhooks
+//
+// A webhook receives the events of the resources in its scope. A webhook in
+// the global scope receives every event, a webhook in an org receives the
+// events of the org and of its projects and a webhook in a project only the
+// events of the project. Worker events are in the global scope.
+//
+// Each event is posted as a JSON Payload. The request carries the event type,
+// a delivery id and a timestamp in headers along with an HMAC-SHA256
+// signature of the timestamp and the body computed with the secret of the
+// webhook; see Sign. Deliveries are retried with an exponential backoff on
+// network errors and on 429 and 5xx responses. An event which could not be
+// delivered after the maximum number of attempts is dead-lettered: an error
+// event containing the payload is emitted and the event is dropped.
+//
+// Delivery is at least once, receivers should use the delivery id to ignore
+// duplicates.
+package notification
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"strconv"
+	"time"
+)
+
+// EventType is the type of a notification event.
+type EventType string
+
+const (
+	SessionAuthorized  EventType = "session.authorized"
+	SessionActivated   EventType = "session.activated"
+	SessionTerminated  EventType = "session.terminated"
+	WorkerConnected    EventType = "worker.connected"
+	WorkerDisconnected EventType = "worker.disconnected"
+)
+
+// EventTypes are all the notification event types.
+var EventTypes = []EventType{
+	SessionAuthorized,
+	SessionActivated,
+	SessionTerminated,
+	WorkerConnected,
+	WorkerDisconnected,
+}
+
+func validEventType(t EventType) bool {
+	for _, et := range EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body posted to webhooks.
+type Payload struct {
+	// Id identifies the event. Retried deliveries of an event have the same
+	// id.
+	Id      string    `json:"id"`
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	ScopeId string    `json:"scope_id"`
+
+	// Session is set for session events.
+	Session *SessionData `json:"session,omitempty"`
+	// Worker is set for worker events.
+	Worker *WorkerData `json:"worker,omitempty"`
+}
+
+// SessionData describes the session of a session event. Fields referencing
+// resources which were deleted since the event occurred are empty.
+type SessionData struct {
+	Id                string `json:"id"`
+	UserId            string `json:"user_id,omitempty"`
+	TargetId          string `json:"target_id,omitempty"`
+	HostId            string `json:"host_id,omitempty"`
+	HostSetId         string `json:"host_set_id,omitempty"`
+	Endpoint          string `json:"endpoint,omitempty"`
+	TerminationReason string `json:"termination_reason,omitempty"`
+}
+
+// WorkerData describes the worker of a worker event.
+type WorkerData struct {
+	Id      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// outboxEvent is a row of the notification_event outbox joined with the data of
+// the resource it is about.
+type outboxEvent struct {
+	Id                int64
+	EventType         string
+	ScopeId           string
+	ParentScopeId     string
+	ResourceId        string
+	CreateTime        time.Time
+	UserId            string
+	TargetId          string
+	HostId            string
+	HostSetId         string
+	Endpoint          string
+	TerminationReason string
+	WorkerName        string
+	WorkerAddress     string
+}
+
+func (e *outboxEvent) payload() *Payload {
+	p := &Payload{
+		Id:      formatEventId(e.Id),
+		Type:    EventType(e.EventType),
+		Time:    e.CreateTime,
+		ScopeId: e.ScopeId,
+	}
+	switch p.Type {
+	case WorkerConnected, WorkerDisconnected:
+		p.Worker = &WorkerData{
+			Id:      e.ResourceId,
+			Name:    e.WorkerName,
+			Address: e.WorkerAddress,
+		}
+	default:
+		p.Session = &SessionData{
+			Id:        e.ResourceId,
+			UserId:    e.UserId,
+			TargetId:  e.TargetId,
+			HostId:    e.HostId,
+			HostSetId: e.HostSetId,
+			Endpoint:  e.Endpoint,
+		}
+		if p.Type == SessionTerminated {
+			p.Session.TerminationReason = e.TerminationReason
+		}
+	}
+	return p
+}
+
+func formatEventId(id int64) string {
+	return "ne_" + strconv.FormatInt(id, 10)
+}
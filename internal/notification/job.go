@@ -0,0 +1,199 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// defaultBatchSize is the maximum number of events delivered in a single run
+// of the delivery job.
+const defaultBatchSize = 500
+
+// defaultEventRetention is how long an event stays in the outbox when the
+// controller running the delivery job has no webhooks configured, so a
+// controller which does can still deliver it.
+const defaultEventRetention = 24 * time.Hour
+
+// deliveryJob is a periodic job which delivers the events of the outbox to
+// the webhooks. It also records which workers connected or disconnected
+// since its previous run.
+type deliveryJob struct {
+	reader   db.Reader
+	writer   db.Writer
+	webhooks []*Webhook
+
+	// gracePeriod is the time after which a worker which has not reported
+	// status is considered disconnected.
+	gracePeriod time.Duration
+	batchSize   int
+	deliverer   *deliverer
+
+	// eventRetention is the age after which events are removed from the
+	// outbox by a job without webhooks.
+	eventRetention time.Duration
+
+	// numDeliveries and numDone count the deliveries of the current run,
+	// one per event and matching webhook.
+	mu            sync.Mutex
+	numDeliveries int
+	numDone       int
+}
+
+func newDeliveryJob(ctx context.Context, r db.Reader, w db.Writer, webhooks []*Webhook, gracePeriod time.Duration) (*deliveryJob, error) {
+	const op = "notification.newDeliveryJob"
+	switch {
+	case isNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case isNil(w):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case gracePeriod <= 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "grace period must be greater than zero")
+	}
+	return &deliveryJob{
+		reader:         r,
+		writer:         w,
+		webhooks:       webhooks,
+		gracePeriod:    gracePeriod,
+		batchSize:      defaultBatchSize,
+		deliverer:      newDeliverer(),
+		eventRetention: defaultEventRetention,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *deliveryJob) Name() string { return "notification_delivery" }
+
+// Description returns the description for the job.
+func (j *deliveryJob) Description() string {
+	return "Deliver session and worker lifecycle events to webhooks"
+}
+
+// NextRunIn returns the next run time after a job is completed. Events
+// should be delivered shortly after they occur, so the job runs again after
+// one second.
+func (j *deliveryJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return time.Second, nil
+}
+
+// Status returns the status of the running job.
+func (j *deliveryJob) Status() scheduler.JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return scheduler.JobStatus{
+		Completed: j.numDone,
+		Total:     j.numDeliveries,
+	}
+}
+
+// Run executes the job.
+func (j *deliveryJob) Run(ctx context.Context) error {
+	const op = "notification.(deliveryJob).Run"
+	j.mu.Lock()
+	j.numDeliveries, j.numDone = 0, 0
+	j.mu.Unlock()
+
+	if len(j.webhooks) == 0 {
+		// The webhooks are configured per controller and another controller
+		// may have some, so the outbox is only kept from growing without
+		// bounds by removing the events older than the retention.
+		if _, err := j.writer.Exec(ctx, deleteExpiredEventsQuery, []interface{}{
+			sql.Named("retention_seconds", j.eventRetention.Seconds()),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired events"))
+		}
+		return nil
+	}
+
+	if _, err := j.writer.Exec(ctx, syncWorkerPresenceQuery, []interface{}{
+		sql.Named("grace_period_seconds", j.gracePeriod.Seconds()),
+	}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to sync worker presence"))
+	}
+
+	events, err := j.listEvents(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	var numDeliveries int
+	for _, w := range j.webhooks {
+		for _, e := range events {
+			if w.matches(EventType(e.EventType), e.ScopeId, e.ParentScopeId) {
+				numDeliveries++
+			}
+		}
+	}
+	j.mu.Lock()
+	j.numDeliveries = numDeliveries
+	j.mu.Unlock()
+
+	// Each webhook receives its events in order, independently of the
+	// other webhooks so a slow webhook does not delay the others.
+	var wg sync.WaitGroup
+	errs := make([]error, len(j.webhooks))
+	for i, w := range j.webhooks {
+		wg.Add(1)
+		go func(i int, w *Webhook) {
+			defer wg.Done()
+			for _, e := range events {
+				if !w.matches(EventType(e.EventType), e.ScopeId, e.ParentScopeId) {
+					continue
+				}
+				if _, err := j.deliverer.deliver(ctx, w, e.payload()); err != nil {
+					errs[i] = err
+					return
+				}
+				j.mu.Lock()
+				j.numDone++
+				j.mu.Unlock()
+			}
+		}(i, w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			// The events are left in the outbox and delivered again by the
+			// next run.
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.Id)
+	}
+	if _, err := j.writer.Exec(ctx, deleteEventsQuery, []interface{}{sql.Named("ids", ids)}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete delivered events"))
+	}
+	return nil
+}
+
+func (j *deliveryJob) listEvents(ctx context.Context) ([]*outboxEvent, error) {
+	const op = "notification.(deliveryJob).listEvents"
+	rows, err := j.reader.Query(ctx, listEventsQuery, []interface{}{sql.Named("limit", j.batchSize)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var events []*outboxEvent
+	for rows.Next() {
+		var e outboxEvent
+		if err := j.reader.ScanRows(ctx, rows, &e); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return events, nil
+}
@@ -0,0 +1,216 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assert the interface
+var _ = scheduler.Job(new(deliveryJob))
+
+// testReceiver is a webhook endpoint recording the payloads it receives.
+type testReceiver struct {
+	t      *testing.T
+	secret string
+
+	mu       sync.Mutex
+	payloads []*Payload
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+	ts, err := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+	require.NoError(r.t, err)
+	if Sign(r.secret, ts, body) != req.Header.Get(SignatureHeader) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var p Payload
+	require.NoError(r.t, json.Unmarshal(body, &p))
+	r.mu.Lock()
+	r.payloads = append(r.payloads, &p)
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *testReceiver) types() []EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []EventType
+	for _, p := range r.payloads {
+		ret = append(ret, p.Type)
+	}
+	return ret
+}
+
+func (r *testReceiver) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.payloads = nil
+}
+
+func testOutboxCount(t *testing.T, rw *db.Db) int {
+	t.Helper()
+	rows, err := rw.Query(context.Background(), "select count(*) from notification_event", nil)
+	require.NoError(t, err)
+	defer rows.Close()
+	var count int
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&count))
+	return count
+}
+
+func TestDeliveryJob(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sessionRepo, err := session.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	globalReceiver := &testReceiver{t: t, secret: "global-secret"}
+	globalSrv := httptest.NewServer(globalReceiver)
+	defer globalSrv.Close()
+	orgReceiver := &testReceiver{t: t, secret: "org-secret"}
+	orgSrv := httptest.NewServer(orgReceiver)
+	defer orgSrv.Close()
+	otherReceiver := &testReceiver{t: t, secret: "other-secret"}
+	otherSrv := httptest.NewServer(otherReceiver)
+	defer otherSrv.Close()
+
+	worker := server.TestKmsWorker(t, conn, wrapper)
+	sess := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+	proj, err := iamRepo.LookupScope(ctx, sess.ProjectId)
+	require.NoError(err)
+
+	globalHook, err := NewWebhook(ctx, "global", "global", globalSrv.URL, globalReceiver.secret)
+	require.NoError(err)
+	orgHook, err := NewWebhook(ctx, "org", proj.GetParentId(), orgSrv.URL, orgReceiver.secret,
+		WithEventTypes([]EventType{SessionActivated, SessionTerminated}))
+	require.NoError(err)
+	otherHook, err := NewWebhook(ctx, "other", "p_0000000000", otherSrv.URL, otherReceiver.secret)
+	require.NoError(err)
+
+	job, err := newDeliveryJob(ctx, rw, rw, []*Webhook{globalHook, orgHook, otherHook}, 15*time.Second)
+	require.NoError(err)
+	job.deliverer.backoff = func(int) time.Duration { return 0 }
+
+	require.NoError(job.Run(ctx))
+	assert.ElementsMatch([]EventType{WorkerConnected, SessionAuthorized}, globalReceiver.types())
+	assert.Empty(orgReceiver.types())
+	assert.Empty(otherReceiver.types())
+	assert.Equal(0, testOutboxCount(t, rw))
+	status := job.Status()
+	assert.Equal(2, status.Total)
+	assert.Equal(2, status.Completed)
+
+	globalReceiver.mu.Lock()
+	for _, p := range globalReceiver.payloads {
+		switch p.Type {
+		case WorkerConnected:
+			assert.Equal("global", p.ScopeId)
+			require.NotNil(p.Worker)
+			assert.Equal(worker.GetPublicId(), p.Worker.Id)
+			assert.Equal(worker.GetName(), p.Worker.Name)
+			assert.Equal(worker.GetAddress(), p.Worker.Address)
+		case SessionAuthorized:
+			assert.Equal(sess.ProjectId, p.ScopeId)
+			require.NotNil(p.Session)
+			assert.Equal(sess.PublicId, p.Session.Id)
+			assert.Equal(sess.UserId, p.Session.UserId)
+			assert.Equal(sess.TargetId, p.Session.TargetId)
+			assert.Equal(sess.HostId, p.Session.HostId)
+			assert.Equal(sess.Endpoint, p.Session.Endpoint)
+			assert.Empty(p.Session.TerminationReason)
+		}
+	}
+	globalReceiver.mu.Unlock()
+	globalReceiver.reset()
+
+	// Nothing changed, nothing is delivered
+	require.NoError(job.Run(ctx))
+	assert.Empty(globalReceiver.types())
+
+	// Activate and then terminate the session
+	sess, _, err = sessionRepo.ActivateSession(ctx, sess.PublicId, sess.Version, session.TestTofu(t))
+	require.NoError(err)
+	_, err = sessionRepo.CancelSession(ctx, sess.PublicId, sess.Version)
+	require.NoError(err)
+	_, err = sessionRepo.TerminateCompletedSessions(ctx)
+	require.NoError(err)
+
+	require.NoError(job.Run(ctx))
+	assert.Equal([]EventType{SessionActivated, SessionTerminated}, globalReceiver.types())
+	assert.Equal([]EventType{SessionActivated, SessionTerminated}, orgReceiver.types())
+	assert.Empty(otherReceiver.types())
+	orgReceiver.mu.Lock()
+	assert.Equal("canceled", orgReceiver.payloads[1].Session.TerminationReason)
+	orgReceiver.mu.Unlock()
+	globalReceiver.reset()
+
+	// The worker has not reported status within the grace period
+	time.Sleep(10 * time.Millisecond)
+	job.gracePeriod = time.Millisecond
+	require.NoError(job.Run(ctx))
+	assert.Equal([]EventType{WorkerDisconnected}, globalReceiver.types())
+}
+
+func TestDeliveryJob_NoWebhooks(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	session.TestDefaultSession(t, conn, wrapper, iamRepo)
+	require.Equal(1, testOutboxCount(t, rw))
+
+	// Another controller may have webhooks, so the event stays in the outbox
+	// until it expires.
+	job, err := newDeliveryJob(ctx, rw, rw, nil, 15*time.Second)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+	require.Equal(1, testOutboxCount(t, rw))
+
+	time.Sleep(10 * time.Millisecond)
+	job.eventRetention = time.Millisecond
+	require.NoError(job.Run(ctx))
+	require.Equal(0, testOutboxCount(t, rw))
+}
+
+func TestNewDeliveryJob(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := newDeliveryJob(ctx, nil, rw, nil, time.Second)
+	assert.Error(t, err)
+	_, err = newDeliveryJob(ctx, rw, nil, nil, time.Second)
+	assert.Error(t, err)
+	_, err = newDeliveryJob(ctx, rw, rw, nil, 0)
+	assert.Error(t, err)
+	job, err := newDeliveryJob(ctx, rw, rw, nil, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "notification_delivery", job.Name())
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// RegisterJobs registers the notification delivery job with the provided
+// scheduler. The job is registered even when no webhooks are configured so
+// the outbox does not grow.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, webhooks []*Webhook, gracePeriod time.Duration) error {
+	const op = "notification.RegisterJobs"
+	if isNil(scheduler) {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scheduler")
+	}
+
+	deliveryJob, err := newDeliveryJob(ctx, r, w, webhooks, gracePeriod)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, deliveryJob); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func isNil(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	switch reflect.TypeOf(i).Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Array, reflect.Chan, reflect.Slice:
+		return reflect.ValueOf(i).IsNil()
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package notification
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withEventTypes  []EventType
+	withMaxAttempts int
+	withTimeout     time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithEventTypes provides an optional list of event types a webhook
+// receives. A webhook receives all event types by default.
+func WithEventTypes(t []EventType) Option {
+	return func(o *options) {
+		o.withEventTypes = t
+	}
+}
+
+// WithMaxAttempts provides an optional maximum number of delivery attempts
+// of an event before it is dead-lettered.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.withMaxAttempts = n
+	}
+}
+
+// WithTimeout provides an optional timeout for a single delivery attempt.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.withTimeout = d
+	}
+}
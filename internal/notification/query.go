@@ -0,0 +1,78 @@
+package notification
+
+const (
+	// syncWorkerPresenceQuery records which workers reported status within the
+	// grace period and adds a notification event for each worker which
+	// connected or disconnected since the previous sync. Workers seen for the
+	// first time only generate an event if they are connected.
+	syncWorkerPresenceQuery = `
+with
+current_presence (worker_id, connected) as (
+  select public_id,
+         coalesce(last_status_time > now() - make_interval(secs => @grace_period_seconds), false)
+    from server_worker
+),
+changed (worker_id, connected) as (
+  select c.worker_id, c.connected
+    from current_presence c
+    left join notification_worker_presence p
+      on p.worker_id = c.worker_id
+   where (p.worker_id is null and c.connected)
+      or (p.worker_id is not null and p.connected != c.connected)
+),
+upsert as (
+  insert into notification_worker_presence
+    (worker_id, connected)
+  select worker_id, connected
+    from current_presence
+  on conflict (worker_id) do update
+    set connected = excluded.connected
+    where notification_worker_presence.connected != excluded.connected
+)
+insert into notification_event
+  (event_type, scope_id, resource_id)
+select case when connected then 'worker.connected' else 'worker.disconnected' end,
+       'global',
+       worker_id
+  from changed;
+`
+
+	// listEventsQuery returns the oldest events of the outbox along with the
+	// parent of their scope and the data of the session or worker they are
+	// about.
+	listEventsQuery = `
+select e.id,
+       e.event_type,
+       e.scope_id,
+       coalesce(sc.parent_id, '')          as parent_scope_id,
+       e.resource_id,
+       e.create_time,
+       coalesce(s.user_id, '')             as user_id,
+       coalesce(s.target_id, '')           as target_id,
+       coalesce(s.host_id, '')             as host_id,
+       coalesce(s.host_set_id, '')         as host_set_id,
+       coalesce(s.endpoint, '')            as endpoint,
+       coalesce(s.termination_reason, '')  as termination_reason,
+       coalesce(w.name, '')                as worker_name,
+       coalesce(w.address, '')             as worker_address
+  from notification_event e
+  left join iam_scope sc
+    on sc.public_id = e.scope_id
+  left join session s
+    on s.public_id = e.resource_id
+  left join server_worker w
+    on w.public_id = e.resource_id
+ order by e.id
+ limit @limit;
+`
+
+	deleteEventsQuery = `
+delete from notification_event
+ where id in (@ids);
+`
+
+	deleteExpiredEventsQuery = `
+delete from notification_event
+ where create_time < now() - make_interval(secs => @retention_seconds);
+`
+)
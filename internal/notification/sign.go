@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// EventHeader is the request header containing the event type.
+	EventHeader = "X-Boundary-Event"
+
+	// DeliveryHeader is the request header containing the id of the event.
+	// Retried deliveries of an event have the same id.
+	DeliveryHeader = "X-Boundary-Delivery"
+
+	// TimestampHeader is the request header containing the time the request
+	// was signed, in seconds since the Unix epoch.
+	TimestampHeader = "X-Boundary-Timestamp"
+
+	// SignatureHeader is the request header containing the signature of the
+	// request computed by Sign.
+	SignatureHeader = "X-Boundary-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of a webhook request: the hex encoded
+// HMAC-SHA256, keyed with the secret of the webhook, of the timestamp header
+// value, a dot and the body, prefixed with "sha256=". Receivers should compute
+// the signature of the requests they receive, compare it to the signature
+// header in constant time and reject requests with an old timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
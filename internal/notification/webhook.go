@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+const (
+	// DefaultMaxAttempts is the default maximum number of delivery attempts
+	// of an event.
+	DefaultMaxAttempts = 3
+
+	// DefaultTimeout is the default timeout of a delivery attempt.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Webhook is an endpoint receiving the events of the resources in a scope.
+type Webhook struct {
+	Name        string
+	ScopeId     string
+	Url         string
+	Secret      string
+	EventTypes  []EventType
+	MaxAttempts int
+	Timeout     time.Duration
+}
+
+// NewWebhook creates a new webhook posting to url the events in the scope
+// scopeId, signed with secret. All event types are delivered unless
+// WithEventTypes is used. WithMaxAttempts and WithTimeout are the other
+// supported options.
+func NewWebhook(ctx context.Context, name, scopeId, webhookUrl, secret string, opt ...Option) (*Webhook, error) {
+	const op = "notification.NewWebhook"
+	opts := getOpts(opt...)
+	w := &Webhook{
+		Name:        name,
+		ScopeId:     scopeId,
+		Url:         webhookUrl,
+		Secret:      secret,
+		EventTypes:  opts.withEventTypes,
+		MaxAttempts: opts.withMaxAttempts,
+		Timeout:     opts.withTimeout,
+	}
+	if len(w.EventTypes) == 0 {
+		w.EventTypes = EventTypes
+	}
+	if w.MaxAttempts == 0 {
+		w.MaxAttempts = DefaultMaxAttempts
+	}
+	if w.Timeout == 0 {
+		w.Timeout = DefaultTimeout
+	}
+
+	switch {
+	case w.Name == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
+	case w.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case w.ScopeId != scope.Global.String() &&
+		!strings.HasPrefix(w.ScopeId, scope.Org.Prefix()+"_") &&
+		!strings.HasPrefix(w.ScopeId, scope.Project.Prefix()+"_"):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid scope id %q", w.ScopeId))
+	case w.Secret == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing secret")
+	case w.MaxAttempts < 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "max attempts must be greater than zero")
+	case w.Timeout < 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "timeout must be greater than zero")
+	}
+	u, err := url.Parse(w.Url)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid url"))
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("url %q must be an absolute http or https url", w.Url))
+	}
+	for _, t := range w.EventTypes {
+		if !validEventType(t) {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown event type %q", t))
+		}
+	}
+	return w, nil
+}
+
+// matches reports whether the webhook receives events of type t for a
+// resource in the scope scopeId whose parent is parentScopeId.
+func (w *Webhook) matches(t EventType, scopeId, parentScopeId string) bool {
+	switch w.ScopeId {
+	case scope.Global.String(), scopeId, parentScopeId:
+	default:
+		return false
+	}
+	for _, et := range w.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	type args struct {
+		name    string
+		scopeId string
+		url     string
+		secret  string
+		opt     []Option
+	}
+	valid := args{
+		name:    "chatops",
+		scopeId: "o_1234567890",
+		url:     "https://hooks.example.com/boundary",
+		secret:  "s3cr3t",
+	}
+	tests := []struct {
+		name    string
+		args    func(a args) args
+		want    *Webhook
+		wantErr errors.Code
+	}{
+		{
+			name: "defaults",
+			args: func(a args) args { return a },
+			want: &Webhook{
+				Name:        "chatops",
+				ScopeId:     "o_1234567890",
+				Url:         "https://hooks.example.com/boundary",
+				Secret:      "s3cr3t",
+				EventTypes:  EventTypes,
+				MaxAttempts: DefaultMaxAttempts,
+				Timeout:     DefaultTimeout,
+			},
+		},
+		{
+			name: "with-options",
+			args: func(a args) args {
+				a.scopeId = "global"
+				a.opt = []Option{
+					WithEventTypes([]EventType{SessionTerminated}),
+					WithMaxAttempts(5),
+					WithTimeout(time.Second),
+				}
+				return a
+			},
+			want: &Webhook{
+				Name:        "chatops",
+				ScopeId:     "global",
+				Url:         "https://hooks.example.com/boundary",
+				Secret:      "s3cr3t",
+				EventTypes:  []EventType{SessionTerminated},
+				MaxAttempts: 5,
+				Timeout:     time.Second,
+			},
+		},
+		{
+			name:    "missing-name",
+			args:    func(a args) args { a.name = ""; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "missing-scope",
+			args:    func(a args) args { a.scopeId = ""; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-scope",
+			args:    func(a args) args { a.scopeId = "u_1234567890"; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "missing-secret",
+			args:    func(a args) args { a.secret = ""; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "relative-url",
+			args:    func(a args) args { a.url = "/boundary"; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-url-scheme",
+			args:    func(a args) args { a.url = "ftp://hooks.example.com"; return a },
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "unknown-event-type",
+			args: func(a args) args {
+				a.opt = []Option{WithEventTypes([]EventType{"session.canceling"})}
+				return a
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "negative-max-attempts",
+			args: func(a args) args {
+				a.opt = []Option{WithMaxAttempts(-1)}
+				return a
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "negative-timeout",
+			args: func(a args) args {
+				a.opt = []Option{WithTimeout(-time.Second)}
+				return a
+			},
+			wantErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			a := tt.args(valid)
+			got, err := NewWebhook(ctx, a.name, a.scopeId, a.url, a.secret, a.opt...)
+			if tt.wantErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err code: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestWebhook_matches(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	global, err := NewWebhook(ctx, "global", "global", "https://example.com", "secret")
+	require.NoError(t, err)
+	org, err := NewWebhook(ctx, "org", "o_1", "https://example.com", "secret")
+	require.NoError(t, err)
+	project, err := NewWebhook(ctx, "project", "p_1", "https://example.com", "secret",
+		WithEventTypes([]EventType{SessionTerminated}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		webhook       *Webhook
+		eventType     EventType
+		scopeId       string
+		parentScopeId string
+		want          bool
+	}{
+		{"global-worker-event", global, WorkerConnected, "global", "", true},
+		{"global-session-event", global, SessionAuthorized, "p_1", "o_1", true},
+		{"org-own-project", org, SessionAuthorized, "p_1", "o_1", true},
+		{"org-other-project", org, SessionAuthorized, "p_2", "o_2", false},
+		{"org-worker-event", org, WorkerConnected, "global", "", false},
+		{"project-matching-type", project, SessionTerminated, "p_1", "o_1", true},
+		{"project-other-type", project, SessionAuthorized, "p_1", "o_1", false},
+		{"project-other-project", project, SessionTerminated, "p_2", "o_1", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.webhook.matches(tt.eventType, tt.scopeId, tt.parentScopeId))
+		})
+	}
+}
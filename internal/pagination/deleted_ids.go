@@ -0,0 +1,44 @@
+package pagination
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// deletedResource is a row of the deleted_resource table.
+type deletedResource struct {
+	PublicId string `gorm:"primary_key"`
+}
+
+func (*deletedResource) TableName() string { return "deleted_resource" }
+
+// ListDeletedIds returns the public ids of the resources of the provided type
+// which belonged to one of the parents and were deleted after the provided
+// time. The parents are the scopes of the resources, or the host catalog for
+// hosts.
+func ListDeletedIds(ctx context.Context, r db.Reader, resourceType resource.Type, parentIds []string, since time.Time) ([]string, error) {
+	const op = "pagination.ListDeletedIds"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case resourceType == resource.Unknown:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource type")
+	}
+	if len(parentIds) == 0 {
+		return nil, nil
+	}
+	var deleted []*deletedResource
+	if err := r.SearchWhere(ctx, &deleted, "resource_type = ? and parent_id in (?) and delete_time > ?",
+		[]interface{}{resourceType.String(), parentIds, since}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ids := make([]string, 0, len(deleted))
+	for _, d := range deleted {
+		ids = append(ids, d.PublicId)
+	}
+	return ids, nil
+}
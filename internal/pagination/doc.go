@@ -0,0 +1,28 @@
+// Package pagination provides the cursor-based pagination of list requests.
+//
+// Items are listed in a stable order, sorted by create time and then public
+// id. A list request with a page size returns at most that many items along
+// with a list token. The list token is an opaque string encoding a
+// ListToken, which holds the position of the last item returned, and is used
+// to request the next page. Items the caller is not authorized to see or
+// which do not match the filter of the request are skipped while filling a
+// page. The number of items scanned to fill a page is bounded, so a page can
+// be smaller than the page size, or empty, when many items are skipped; the
+// listing is only complete once a page is returned with the complete response
+// type.
+//
+// Once all the pages of a listing have been returned, the list token returned
+// with the last page refreshes the listing: the items updated since the
+// listing started are returned, sorted by update time and then public id,
+// along with the public ids of the items deleted since then. The public ids
+// of deleted items are recorded in the deleted_resource table by database
+// triggers and are removed by the deleted_resource_cleanup job once they are
+// older than MaxListTokenAge, after which list tokens expire.
+//
+// # Repositories
+//
+// The repositories of the resources which can be listed with pagination
+// accept a Page describing the page of items to return, and provide a
+// ListDeletedIds method, which is implemented using the ListDeletedIds
+// function of this package.
+package pagination
@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// maxFillBatches is the number of times FillPage lists items to fill a page.
+// It bounds the number of rows a single request scans when most items are
+// skipped, for instance because of a filter matching few items.
+const maxFillBatches = 10
+
+// ListFunc lists the items of the page from a repository.
+type ListFunc func(ctx context.Context, page *Page) ([]Item, error)
+
+// AppendFunc appends the item to the response if it is accepted, ie: if the
+// caller is authorized to see it and it matches the filter of the request.
+// It reports whether the item was appended.
+type AppendFunc func(ctx context.Context, item Item) (bool, error)
+
+// FillPage lists the items following the last item of the token and appends
+// them until pageSize items have been appended or there are no more items.
+// A zero pageSize appends all the items listed by a single call to listFn
+// with the default limit of the repository. listFn is called at most
+// maxFillBatches times, so the page can hold fewer than pageSize items even
+// though the listing is not complete. It returns the token for the next page
+// and reports whether the listing is complete, in which case the token
+// refreshes the listing.
+func FillPage(ctx context.Context, tok *ListToken, pageSize int, listFn ListFunc, appendFn AppendFunc) (*ListToken, bool, error) {
+	const op = "pagination.FillPage"
+	switch {
+	case tok == nil:
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "missing list token")
+	case pageSize < 0:
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "negative page size")
+	case listFn == nil:
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "missing list function")
+	case appendFn == nil:
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "missing append function")
+	}
+
+	page := tok.page()
+	if pageSize > 0 {
+		// List one more item than needed to know whether more items follow.
+		page.Size = pageSize + 1
+	}
+	var appended int
+	for batch := 1; ; batch++ {
+		items, err := listFn(ctx, page)
+		if err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		more := pageSize > 0 && len(items) > pageSize
+		if more {
+			items = items[:pageSize]
+		}
+		for i, item := range items {
+			ok, err := appendFn(ctx, item)
+			if err != nil {
+				return nil, false, errors.Wrap(ctx, err, op)
+			}
+			if !ok {
+				continue
+			}
+			appended++
+			if appended == pageSize && (more || i < len(items)-1) {
+				return tok.next(item), false, nil
+			}
+		}
+		if !more {
+			return tok.complete(), true, nil
+		}
+		last := items[len(items)-1]
+		if batch == maxFillBatches {
+			return tok.next(last), false, nil
+		}
+		page.AfterItemId, page.AfterItemTime = last.GetPublicId(), tok.itemTime(last)
+	}
+}
@@ -0,0 +1,196 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	id         string
+	createTime time.Time
+	updateTime time.Time
+}
+
+func (i *testItem) GetPublicId() string                 { return i.id }
+func (i *testItem) GetCreateTime() *timestamp.Timestamp { return timestamp.New(i.createTime) }
+func (i *testItem) GetUpdateTime() *timestamp.Timestamp { return timestamp.New(i.updateTime) }
+
+// testListFn returns a ListFunc listing the items, which must be sorted by
+// create time, the way a repository would list them for the page.
+func testListFn(items []*testItem, calls *int) ListFunc {
+	return func(_ context.Context, page *Page) ([]Item, error) {
+		*calls++
+		var listed []Item
+		for _, i := range items {
+			if page.Refresh() {
+				if !i.updateTime.After(page.UpdatedAfter) {
+					continue
+				}
+				if page.AfterItemId != "" && (i.updateTime.Before(page.AfterItemTime) ||
+					i.updateTime.Equal(page.AfterItemTime) && i.id <= page.AfterItemId) {
+					continue
+				}
+			} else if page.AfterItemId != "" && (i.createTime.Before(page.AfterItemTime) ||
+				i.createTime.Equal(page.AfterItemTime) && i.id <= page.AfterItemId) {
+				continue
+			}
+			if page.Size > 0 && len(listed) == page.Size {
+				break
+			}
+			listed = append(listed, i)
+		}
+		return listed, nil
+	}
+}
+
+func TestFillPage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	var items []*testItem
+	for i := 0; i < 10; i++ {
+		ts := base.Add(time.Duration(i) * time.Second)
+		items = append(items, &testItem{id: fmt.Sprintf("i_%02d", i), createTime: ts, updateTime: ts})
+	}
+	// Only items with an even index are accepted, like the items a caller is
+	// authorized to see.
+	var appended []string
+	appendFn := func(_ context.Context, item Item) (bool, error) {
+		if n := item.GetPublicId(); (n[len(n)-1]-'0')%2 == 0 {
+			appended = append(appended, n)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		appended = nil
+		var calls int
+		tok := &ListToken{ResourceType: resource.Target, RequestHash: RequestHash(), ListStartTime: time.Now()}
+		var pages []string
+		for {
+			appended = nil
+			next, complete, err := FillPage(ctx, tok, 2, testListFn(items, &calls), appendFn)
+			require.NoError(t, err)
+			pages = append(pages, strings.Join(appended, ","))
+			if complete {
+				assert.True(t, next.Refresh())
+				assert.True(t, next.FirstPage())
+				break
+			}
+			assert.False(t, next.FirstPage())
+			tok = next
+		}
+		assert.Equal(t, []string{"i_00,i_02", "i_04,i_06", "i_08"}, pages)
+	})
+
+	t.Run("no-page-size", func(t *testing.T) {
+		appended = nil
+		var calls int
+		tok := &ListToken{ResourceType: resource.Target, RequestHash: RequestHash(), ListStartTime: time.Now()}
+		next, complete, err := FillPage(ctx, tok, 0, testListFn(items, &calls), appendFn)
+		require.NoError(t, err)
+		assert.True(t, complete)
+		assert.True(t, next.Refresh())
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, []string{"i_00", "i_02", "i_04", "i_06", "i_08"}, appended)
+	})
+
+	t.Run("full-last-page", func(t *testing.T) {
+		// The page is filled before the last item is listed, so the listing
+		// is only complete after an empty page.
+		appended = nil
+		var calls int
+		tok := &ListToken{ResourceType: resource.Target, RequestHash: RequestHash(), ListStartTime: time.Now()}
+		next, complete, err := FillPage(ctx, tok, 5, testListFn(items, &calls), appendFn)
+		require.NoError(t, err)
+		assert.False(t, complete)
+		assert.Equal(t, []string{"i_00", "i_02", "i_04", "i_06", "i_08"}, appended)
+
+		appended = nil
+		_, complete, err = FillPage(ctx, next, 5, testListFn(items, &calls), appendFn)
+		require.NoError(t, err)
+		assert.True(t, complete)
+		assert.Empty(t, appended)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		appended = nil
+		var calls int
+		updated := []*testItem{}
+		for _, i := range items {
+			c := *i
+			updated = append(updated, &c)
+		}
+		updatedAfter := time.Now().Add(-time.Minute)
+		updated[4].updateTime = time.Now()
+		updated[5].updateTime = time.Now()
+		updated[8].updateTime = time.Now().Add(time.Second)
+		tok := &ListToken{ResourceType: resource.Target, RequestHash: RequestHash(), ListStartTime: time.Now(), UpdatedAfter: updatedAfter}
+		_, complete, err := FillPage(ctx, tok, 10, testListFn(updated, &calls), appendFn)
+		require.NoError(t, err)
+		assert.True(t, complete)
+		assert.ElementsMatch(t, []string{"i_04", "i_08"}, appended)
+	})
+
+	t.Run("bounded-batches", func(t *testing.T) {
+		// Only the last item is accepted, so filling the first page stops
+		// after maxFillBatches batches with an empty page.
+		var many []*testItem
+		for i := 0; i < 3*maxFillBatches; i++ {
+			ts := base.Add(time.Duration(i) * time.Second)
+			many = append(many, &testItem{id: fmt.Sprintf("m_%02d", i), createTime: ts, updateTime: ts})
+		}
+		lastId := many[len(many)-1].id
+		var got []string
+		appendLast := func(_ context.Context, item Item) (bool, error) {
+			if item.GetPublicId() != lastId {
+				return false, nil
+			}
+			got = append(got, item.GetPublicId())
+			return true, nil
+		}
+		var calls int
+		tok := &ListToken{ResourceType: resource.Target, RequestHash: RequestHash(), ListStartTime: time.Now()}
+		next, complete, err := FillPage(ctx, tok, 1, testListFn(many, &calls), appendLast)
+		require.NoError(t, err)
+		assert.False(t, complete)
+		assert.Equal(t, maxFillBatches, calls)
+		assert.Empty(t, got)
+		assert.False(t, next.FirstPage())
+
+		// Each page scans maxFillBatches more items until the listing is
+		// complete.
+		pages := 1
+		for !complete {
+			next, complete, err = FillPage(ctx, next, 1, testListFn(many, &calls), appendLast)
+			require.NoError(t, err)
+			pages++
+		}
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, []string{lastId}, got)
+	})
+
+	t.Run("invalid-parameters", func(t *testing.T) {
+		var calls int
+		tok := &ListToken{ResourceType: resource.Target}
+		_, _, err := FillPage(ctx, nil, 1, testListFn(items, &calls), appendFn)
+		assert.Error(t, err)
+		_, _, err = FillPage(ctx, tok, -1, testListFn(items, &calls), appendFn)
+		assert.Error(t, err)
+		_, _, err = FillPage(ctx, tok, 1, nil, appendFn)
+		assert.Error(t, err)
+		_, _, err = FillPage(ctx, tok, 1, testListFn(items, &calls), nil)
+		assert.Error(t, err)
+		assert.Zero(t, calls)
+	})
+}
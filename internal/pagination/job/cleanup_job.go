@@ -0,0 +1,74 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+const (
+	cleanupJobName = "deleted_resource_cleanup"
+
+	cleanupFrequency = time.Hour
+
+	deleteExpiredQuery = `
+delete from deleted_resource
+ where delete_time < ?;
+`
+)
+
+// cleanupJob is a periodic job which removes the public ids of deleted
+// resources once they are older than pagination.MaxListTokenAge.
+type cleanupJob struct {
+	writer db.Writer
+
+	// the number of ids removed in the most recent run
+	removedInRun int
+}
+
+func newCleanupJob(ctx context.Context, w db.Writer) (*cleanupJob, error) {
+	const op = "job.newCleanupJob"
+	if isNil(w) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	}
+	return &cleanupJob{
+		writer: w,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *cleanupJob) Name() string { return cleanupJobName }
+
+// Description returns the description for the job.
+func (j *cleanupJob) Description() string {
+	return "Remove the ids of deleted resources which are older than the maximum age of a list token"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *cleanupJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return cleanupFrequency, nil
+}
+
+// Status returns the status of the running job.
+func (j *cleanupJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.removedInRun,
+		Total:     j.removedInRun,
+	}
+}
+
+// Run executes the job.
+func (j *cleanupJob) Run(ctx context.Context) error {
+	const op = "job.(cleanupJob).Run"
+	j.removedInRun = 0
+	n, err := j.writer.Exec(ctx, deleteExpiredQuery, []interface{}{time.Now().Add(-pagination.MaxListTokenAge)})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.removedInRun = n
+	return nil
+}
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// RegisterJobs registers the deleted resource cleanup job with the provided
+// scheduler.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, w db.Writer) error {
+	const op = "job.RegisterJobs"
+	if isNil(scheduler) {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scheduler")
+	}
+	cleanupJob, err := newCleanupJob(ctx, w)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := scheduler.RegisterJob(ctx, cleanupJob); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func isNil(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	switch reflect.TypeOf(i).Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Array, reflect.Chan, reflect.Slice:
+		return reflect.ValueOf(i).IsNil()
+	}
+	return false
+}
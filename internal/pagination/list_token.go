@@ -0,0 +1,168 @@
+package pagination
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	// ResponseTypeComplete is the response type of the last page of a
+	// listing.
+	ResponseTypeComplete = "complete"
+
+	// ResponseTypeDelta is the response type of a page which is followed by
+	// more pages.
+	ResponseTypeDelta = "delta"
+
+	// MaxPageSize is the maximum page size of a list request.
+	MaxPageSize = 10000
+
+	// MaxListTokenAge is the maximum age of the listing a list token
+	// continues or refreshes. The public ids of deleted items are only
+	// retained for this long.
+	MaxListTokenAge = 30 * 24 * time.Hour
+
+	// refreshBuffer is subtracted from the time a listing started when
+	// refreshing it, so the items updated by transactions which were in
+	// progress when the listing started are not missed. Items updated within
+	// the buffer may be returned again.
+	refreshBuffer = 30 * time.Second
+)
+
+// A ListToken is the state of a paginated listing. It is returned to the
+// client as an opaque string with each page. The token is not signed: it can
+// only be used to change the position of the caller's own listing, the items
+// returned are still limited by the caller's permissions.
+type ListToken struct {
+	// ResourceType is the type of the listed resources.
+	ResourceType resource.Type `json:"r"`
+
+	// RequestHash is a hash of the parameters of the request which started
+	// the listing. A token cannot be used with different parameters, for
+	// instance a different filter.
+	RequestHash string `json:"h"`
+
+	// ListStartTime is the time the current listing started.
+	ListStartTime time.Time `json:"s"`
+
+	// UpdatedAfter is zero for an initial listing. When refreshing a
+	// listing, it is the time the previous listing started.
+	UpdatedAfter time.Time `json:"u"`
+
+	// LastItemId and LastItemTime are the public id and sort time of the last
+	// item returned. They are empty when the token starts a new listing.
+	LastItemId   string    `json:"i,omitempty"`
+	LastItemTime time.Time `json:"t"`
+}
+
+// RequestHash returns a hash of the provided request parameters.
+func RequestHash(params ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(params, "\x00")))
+	return base58.Encode(sum[:16])
+}
+
+// ParseListToken parses the list token of a list request for resources of
+// the provided type. An empty token starts a new listing. An error is
+// returned if the token is malformed, was returned for a different resource
+// type or request, or has expired.
+func ParseListToken(ctx context.Context, token string, resourceType resource.Type, requestHash string) (*ListToken, error) {
+	const op = "pagination.ParseListToken"
+	if token == "" {
+		return &ListToken{
+			ResourceType:  resourceType,
+			RequestHash:   requestHash,
+			ListStartTime: time.Now(),
+		}, nil
+	}
+	decoded, err := base58.FastBase58Decoding(token)
+	if err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "malformed list token")
+	}
+	var t ListToken
+	if err := json.Unmarshal(decoded, &t); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "malformed list token")
+	}
+	switch {
+	case t.ResourceType != resourceType:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token was returned for a different resource type")
+	case t.RequestHash != requestHash:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token was returned for a request with different parameters")
+	case !t.UpdatedAfter.IsZero() && time.Since(t.UpdatedAfter) > MaxListTokenAge,
+		t.LastItemId != "" && time.Since(t.ListStartTime) > MaxListTokenAge:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token has expired")
+	}
+	if t.LastItemId == "" {
+		// The token starts a refresh of a complete listing.
+		t.ListStartTime = time.Now()
+	}
+	return &t, nil
+}
+
+// Marshal encodes the token as an opaque string.
+func (t *ListToken) Marshal(ctx context.Context) (string, error) {
+	const op = "pagination.(ListToken).Marshal"
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return base58.FastBase58Encoding(b), nil
+}
+
+// Refresh reports whether the token refreshes a complete listing.
+func (t *ListToken) Refresh() bool {
+	return !t.UpdatedAfter.IsZero()
+}
+
+// FirstPage reports whether the token starts a new listing.
+func (t *ListToken) FirstPage() bool {
+	return t.LastItemId == ""
+}
+
+// page returns the page following the last item of the token.
+func (t *ListToken) page() *Page {
+	return &Page{
+		UpdatedAfter:  t.UpdatedAfter,
+		AfterItemId:   t.LastItemId,
+		AfterItemTime: t.LastItemTime,
+	}
+}
+
+// itemTime returns the sort time of the item.
+func (t *ListToken) itemTime(item Item) time.Time {
+	if t.Refresh() {
+		return item.GetUpdateTime().AsTime()
+	}
+	return item.GetCreateTime().AsTime()
+}
+
+// next returns the token for the page following the item.
+func (t *ListToken) next(item Item) *ListToken {
+	n := *t
+	n.LastItemId = item.GetPublicId()
+	n.LastItemTime = t.itemTime(item)
+	return &n
+}
+
+// complete returns the token refreshing the listing once it is complete.
+func (t *ListToken) complete() *ListToken {
+	return &ListToken{
+		ResourceType: t.ResourceType,
+		RequestHash:  t.RequestHash,
+		UpdatedAfter: t.ListStartTime.Add(-refreshBuffer),
+	}
+}
+
+// ResponseType returns the response type of a page.
+func ResponseType(complete bool) string {
+	if complete {
+		return ResponseTypeComplete
+	}
+	return ResponseTypeDelta
+}
@@ -0,0 +1,141 @@
+package pagination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	hash := RequestHash("p_1234567890", "filter")
+
+	marshal := func(t *testing.T, tok *ListToken) string {
+		t.Helper()
+		s, err := tok.Marshal(ctx)
+		require.NoError(t, err)
+		return s
+	}
+
+	tests := []struct {
+		name         string
+		token        string
+		resourceType resource.Type
+		hash         string
+		wantErrMsg   string
+	}{
+		{
+			name:         "malformed",
+			token:        "not a token",
+			resourceType: resource.Target,
+			hash:         hash,
+			wantErrMsg:   "malformed list token",
+		},
+		{
+			name:         "wrong-resource-type",
+			token:        marshal(t, &ListToken{ResourceType: resource.Session, RequestHash: hash, ListStartTime: time.Now(), LastItemId: "s_1234567890"}),
+			resourceType: resource.Target,
+			hash:         hash,
+			wantErrMsg:   "list token was returned for a different resource type",
+		},
+		{
+			name:         "wrong-request-hash",
+			token:        marshal(t, &ListToken{ResourceType: resource.Target, RequestHash: RequestHash("p_1234567890"), ListStartTime: time.Now(), LastItemId: "ttcp_1234567890"}),
+			resourceType: resource.Target,
+			hash:         hash,
+			wantErrMsg:   "list token was returned for a request with different parameters",
+		},
+		{
+			name:         "expired-listing",
+			token:        marshal(t, &ListToken{ResourceType: resource.Target, RequestHash: hash, ListStartTime: time.Now().Add(-MaxListTokenAge - time.Hour), LastItemId: "ttcp_1234567890"}),
+			resourceType: resource.Target,
+			hash:         hash,
+			wantErrMsg:   "list token has expired",
+		},
+		{
+			name:         "expired-refresh",
+			token:        marshal(t, &ListToken{ResourceType: resource.Target, RequestHash: hash, UpdatedAfter: time.Now().Add(-MaxListTokenAge - time.Hour)}),
+			resourceType: resource.Target,
+			hash:         hash,
+			wantErrMsg:   "list token has expired",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseListToken(ctx, tt.token, tt.resourceType, tt.hash)
+			require.Error(t, err)
+			assert.Nil(t, got)
+			assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+			assert.Contains(t, err.Error(), tt.wantErrMsg)
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := ParseListToken(ctx, "", resource.Target, hash)
+		require.NoError(t, err)
+		assert.Equal(t, resource.Target, got.ResourceType)
+		assert.Equal(t, hash, got.RequestHash)
+		assert.False(t, got.ListStartTime.IsZero())
+		assert.True(t, got.FirstPage())
+		assert.False(t, got.Refresh())
+	})
+
+	t.Run("next-page", func(t *testing.T) {
+		want := &ListToken{
+			ResourceType:  resource.Target,
+			RequestHash:   hash,
+			ListStartTime: time.Now().Add(-time.Hour).Round(0).UTC(),
+			LastItemId:    "ttcp_1234567890",
+			LastItemTime:  time.Now().Add(-2 * time.Hour).Round(0).UTC(),
+		}
+		got, err := ParseListToken(ctx, marshal(t, want), resource.Target, hash)
+		require.NoError(t, err)
+		assert.True(t, want.ListStartTime.Equal(got.ListStartTime))
+		assert.True(t, want.LastItemTime.Equal(got.LastItemTime))
+		assert.Equal(t, want.LastItemId, got.LastItemId)
+		assert.False(t, got.FirstPage())
+		assert.False(t, got.Refresh())
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		updatedAfter := time.Now().Add(-time.Hour).Round(0).UTC()
+		tok := &ListToken{
+			ResourceType: resource.Target,
+			RequestHash:  hash,
+			UpdatedAfter: updatedAfter,
+		}
+		before := time.Now()
+		got, err := ParseListToken(ctx, marshal(t, tok), resource.Target, hash)
+		require.NoError(t, err)
+		assert.True(t, updatedAfter.Equal(got.UpdatedAfter))
+		assert.False(t, got.ListStartTime.Before(before))
+		assert.True(t, got.FirstPage())
+		assert.True(t, got.Refresh())
+	})
+}
+
+func TestListToken_complete(t *testing.T) {
+	t.Parallel()
+	start := time.Now().Add(-time.Minute)
+	tok := &ListToken{
+		ResourceType:  resource.User,
+		RequestHash:   RequestHash("global"),
+		ListStartTime: start,
+		LastItemId:    "u_1234567890",
+		LastItemTime:  start,
+	}
+	got := tok.complete()
+	assert.Equal(t, tok.ResourceType, got.ResourceType)
+	assert.Equal(t, tok.RequestHash, got.RequestHash)
+	assert.True(t, got.Refresh())
+	assert.True(t, got.FirstPage())
+	assert.Equal(t, start.Add(-refreshBuffer), got.UpdatedAfter)
+}
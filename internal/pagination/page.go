@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// Item is an item which can be listed with pagination.
+type Item interface {
+	GetPublicId() string
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}
+
+// Page describes a page of items to list from a repository.
+type Page struct {
+	// Size is the maximum number of items in the page. It follows the
+	// semantics of the WithLimit option of the repositories: a negative size
+	// signals unlimited results and zero signals the default limit of the
+	// repository.
+	Size int
+
+	// UpdatedAfter limits the items of a refresh to the items updated after
+	// it. Items are then sorted by update time instead of create time. It is
+	// zero for the pages of an initial listing.
+	UpdatedAfter time.Time
+
+	// AfterItemId and AfterItemTime are the public id and sort time of the
+	// last item of the previous page. They are empty for the first page.
+	AfterItemId   string
+	AfterItemTime time.Time
+}
+
+// Refresh reports whether the page is a page of a refresh.
+func (p *Page) Refresh() bool {
+	return !p.UpdatedAfter.IsZero()
+}
+
+func (p *Page) sortColumn() string {
+	if p.Refresh() {
+		return "update_time"
+	}
+	return "create_time"
+}
+
+// Where returns the where clause limiting the items listed to the items of
+// the page, using positional arguments. The clause is empty for the first
+// page of an initial listing.
+func (p *Page) Where() (string, []interface{}) {
+	var where []string
+	var args []interface{}
+	if p.Refresh() {
+		where = append(where, "update_time > ?")
+		args = append(args, p.UpdatedAfter)
+	}
+	if p.AfterItemId != "" {
+		where = append(where, "("+p.sortColumn()+", public_id) > (?, ?)")
+		args = append(args, p.AfterItemTime, p.AfterItemId)
+	}
+	return strings.Join(where, " and "), args
+}
+
+// NamedWhere returns the same where clause as Where, using named arguments.
+func (p *Page) NamedWhere() (string, []interface{}) {
+	var where []string
+	var args []interface{}
+	if p.Refresh() {
+		where = append(where, "update_time > @page_updated_after")
+		args = append(args, sql.Named("page_updated_after", p.UpdatedAfter))
+	}
+	if p.AfterItemId != "" {
+		where = append(where, "("+p.sortColumn()+", public_id) > (@page_after_item_time, @page_after_item_id)")
+		args = append(args, sql.Named("page_after_item_time", p.AfterItemTime), sql.Named("page_after_item_id", p.AfterItemId))
+	}
+	return strings.Join(where, " and "), args
+}
+
+// OrderBy returns the order by clause, without the order by keywords, sorting
+// the items of the page.
+func (p *Page) OrderBy() string {
+	return p.sortColumn() + " asc, public_id asc"
+}
@@ -298,7 +298,8 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo, reque
 
 func topLevelType(typ resource.Type) bool {
 	switch typ {
-	case resource.AuthMethod,
+	case resource.Alias,
+		resource.AuthMethod,
 		resource.AuthToken,
 		resource.CredentialStore,
 		resource.Group,
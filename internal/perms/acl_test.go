@@ -791,11 +791,11 @@ func Test_AnonRestrictions(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			for i := resource.Type(1); i <= resource.Credential; i++ {
+			for i := resource.Type(1); i <= resource.Alias; i++ {
 				if i == resource.Controller || i == resource.Worker {
 					continue
 				}
-				for j := action.Type(1); j <= action.ReadCertificateAuthority; j++ {
+				for j := action.Type(1); j <= action.ReadKeyRewrapStatus; j++ {
 					res := Resource{
 						ScopeId: scope.Global.String(),
 						Id:      "foobar",
@@ -23,4 +23,18 @@ const (
 		delete from worker_auth_ca_certificate
  		where state = @state;
 	`
+
+	lookupMaintenanceQuery = `
+		select enabled, freeze_sessions, retry_after_seconds, coalesce(message, ''), update_time
+		  from controller_maintenance
+		 where id = 1;
+	`
+	setMaintenanceQuery = `
+		update controller_maintenance
+		   set enabled = @enabled,
+		       freeze_sessions = @freeze_sessions,
+		       retry_after_seconds = @retry_after_seconds,
+		       message = nullif(@message, '')
+		 where id = 1;
+	`
 )
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// DefaultMaintenanceRetryAfter is the time clients are asked to wait before
+// retrying a request rejected in maintenance mode, when not specified.
+const DefaultMaintenanceRetryAfter = 60 * time.Second
+
+// Maintenance is the maintenance mode of the controllers of the cluster. When
+// enabled, the controllers reject the mutating api requests, except session
+// authorization and authentication. When FreezeSessions is also set, those
+// and the requests of workers are rejected as well.
+type Maintenance struct {
+	Enabled        bool
+	FreezeSessions bool
+	// RetryAfter is the time clients are asked to wait before retrying a
+	// rejected request. It is rounded to seconds.
+	RetryAfter time.Duration
+	// Message is an optional message returned with the rejected requests.
+	Message    string
+	UpdateTime time.Time
+}
+
+// LookupMaintenance returns the maintenance mode of the controllers.
+func (r *Repository) LookupMaintenance(ctx context.Context) (*Maintenance, error) {
+	const op = "server.(Repository).LookupMaintenance"
+	rows, err := r.reader.Query(ctx, lookupMaintenanceQuery, nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "controller maintenance row not found")
+	}
+	var m Maintenance
+	var retryAfterSeconds int64
+	if err := rows.Scan(&m.Enabled, &m.FreezeSessions, &retryAfterSeconds, &m.Message, &m.UpdateTime); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	m.RetryAfter = time.Duration(retryAfterSeconds) * time.Second
+	return &m, nil
+}
+
+// SetMaintenance sets the maintenance mode of the controllers. The
+// controllers apply it the next time they poll the database. A zero
+// RetryAfter is set to DefaultMaintenanceRetryAfter.
+func (r *Repository) SetMaintenance(ctx context.Context, m *Maintenance) (*Maintenance, error) {
+	const op = "server.(Repository).SetMaintenance"
+	switch {
+	case m == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing maintenance")
+	case m.RetryAfter < 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "retry after must not be negative")
+	case m.FreezeSessions && !m.Enabled:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "sessions can only be frozen when maintenance is enabled")
+	}
+	retryAfter := m.RetryAfter.Round(time.Second)
+	switch {
+	case m.RetryAfter == 0:
+		retryAfter = DefaultMaintenanceRetryAfter
+	case retryAfter < time.Second:
+		retryAfter = time.Second
+	}
+	rowsUpdated, err := r.writer.Exec(ctx, setMaintenanceQuery, []interface{}{
+		sql.Named("enabled", m.Enabled),
+		sql.Named("freeze_sessions", m.FreezeSessions),
+		sql.Named("retry_after_seconds", int64(retryAfter/time.Second)),
+		sql.Named("message", strings.TrimSpace(m.Message)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated != 1 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "controller maintenance row not found")
+	}
+	return r.LookupMaintenance(ctx)
+}
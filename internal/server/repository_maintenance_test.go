@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	got, err := repo.LookupMaintenance(ctx)
+	require.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.False(t, got.FreezeSessions)
+	assert.Equal(t, DefaultMaintenanceRetryAfter, got.RetryAfter)
+	assert.Empty(t, got.Message)
+
+	tests := []struct {
+		name      string
+		in        *Maintenance
+		want      *Maintenance
+		wantErrIs errors.Code
+	}{
+		{
+			name:      "nil",
+			wantErrIs: errors.InvalidParameter,
+		},
+		{
+			name:      "negative-retry-after",
+			in:        &Maintenance{Enabled: true, RetryAfter: -time.Second},
+			wantErrIs: errors.InvalidParameter,
+		},
+		{
+			name:      "freeze-sessions-when-disabled",
+			in:        &Maintenance{FreezeSessions: true},
+			wantErrIs: errors.InvalidParameter,
+		},
+		{
+			name: "enabled",
+			in:   &Maintenance{Enabled: true, Message: " schema migration "},
+			want: &Maintenance{Enabled: true, RetryAfter: DefaultMaintenanceRetryAfter, Message: "schema migration"},
+		},
+		{
+			name: "freeze-sessions",
+			in:   &Maintenance{Enabled: true, FreezeSessions: true, RetryAfter: 90*time.Second + 300*time.Millisecond},
+			want: &Maintenance{Enabled: true, FreezeSessions: true, RetryAfter: 90 * time.Second},
+		},
+		{
+			name: "disabled",
+			in:   &Maintenance{},
+			want: &Maintenance{RetryAfter: DefaultMaintenanceRetryAfter},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.SetMaintenance(ctx, tt.in)
+			if tt.wantErrIs != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantErrIs), err))
+				return
+			}
+			require.NoError(err)
+			assert.False(got.UpdateTime.IsZero())
+			got.UpdateTime = time.Time{}
+			assert.Equal(tt.want, got)
+
+			looked, err := repo.LookupMaintenance(ctx)
+			require.NoError(err)
+			looked.UpdateTime = time.Time{}
+			assert.Equal(tt.want, looked)
+		})
+	}
+}
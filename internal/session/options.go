@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 )
 
@@ -34,6 +35,7 @@ type options struct {
 	withWorkerStateDelay  time.Duration
 	withTerminated        bool
 	withPermissions       *perms.UserPermissions
+	withPage              *pagination.Page
 }
 
 func getDefaultOptions() options {
@@ -130,3 +132,11 @@ func WithPermissions(p *perms.UserPermissions) Option {
 		o.withPermissions = p
 	}
 }
+
+// WithPage is used to list a page of sessions. It overrides the WithLimit and
+// WithOrderByCreateTime options.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
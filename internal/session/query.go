@@ -166,6 +166,8 @@ where
       case 
         -- timed out sessions
         when now() > us.expiration_time then 'timed out'
+        -- sessions canceled by the worker for being idle
+        when us.idle_timeout_time is not null then 'idle timeout'
         -- canceling sessions
         when us.public_id in(
           select 
@@ -247,6 +249,8 @@ update session us
 	case
 		-- timed out sessions
 		when now() > us.expiration_time then 'timed out'
+		-- sessions canceled by the worker for being idle
+		when us.idle_timeout_time is not null then 'idle timeout'
 		-- canceling sessions
 		when us.public_id in(
 			select
@@ -407,6 +411,19 @@ and
 and
 	session_state.start_time < wt_sub_seconds_from_now(@threshold_seconds)
 ;
+`
+
+	// setIdleTimeoutTime records that the session is being canceled for
+	// being idle, unless it is already terminated.
+	setIdleTimeoutTime = `
+update session
+	set idle_timeout_time = now()
+where
+	public_id = @public_id and
+	idle_timeout_seconds > 0 and
+	idle_timeout_time is null and
+	termination_reason is null
+;
 `
 )
 
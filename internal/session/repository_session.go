@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 )
 
@@ -230,7 +232,7 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 
 // ListSessions lists sessions. Sessions returned will be limited by the list
 // permissions of the repository. Supports the WithTerminated, WithLimit,
-// WithOrderByCreateTime options.
+// WithOrderByCreateTime and WithPage options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	const op = "session.(Repository).ListSessions"
 	opts := getOpts(opt...)
@@ -271,6 +273,20 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		withOrder = "order by create_time"
 	}
 
+	if opts.withPage != nil {
+		if pageWhere, pageArgs := opts.withPage.NamedWhere(); pageWhere != "" {
+			whereClause += " and " + pageWhere
+			args = append(args, pageArgs...)
+		}
+		switch {
+		case opts.withPage.Size < 0:
+			limit = ""
+		case opts.withPage.Size > 0:
+			limit = fmt.Sprintf("limit %d", opts.withPage.Size)
+		}
+		withOrder = "order by " + opts.withPage.OrderBy()
+	}
+
 	q := sessionList
 	query := fmt.Sprintf(q, whereClause, withOrder, limit, withOrder)
 
@@ -295,6 +311,17 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	return sessions, nil
 }
 
+// ListDeletedIds lists the public ids of the sessions of the projects
+// deleted after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, projectIds []string, since time.Time) ([]string, error) {
+	const op = "session.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.Session, projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // DeleteSession will delete a session from the repository.
 func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteSession"
@@ -356,6 +383,53 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// CancelIdleSession cancels a session which has been idle for longer than its
+// idle timeout. The session is terminated with the IdleTimeout termination
+// reason once all its connections are closed. An error is returned if the
+// session does not have an idle timeout.
+func (r *Repository) CancelIdleSession(ctx context.Context, sessionId string, sessionVersion uint32) (*Session, error) {
+	const op = "session.(Repository).CancelIdleSession"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sessionVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session version")
+	}
+	var updatedSession *Session
+	var returnedStates []*State
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			s := AllocSession()
+			s.PublicId = sessionId
+			if err := reader.LookupById(ctx, &s); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			if s.IdleTimeoutSeconds == 0 {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("session %s does not have an idle timeout", sessionId))
+			}
+			// The idle timeout time is only kept if the session is canceled,
+			// as it determines the termination reason of the session.
+			if _, err := w.Exec(ctx, setIdleTimeoutTime, []interface{}{sql.Named("public_id", sessionId)}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			var err error
+			updatedSession, returnedStates, err = updateStateTx(ctx, reader, w, r.kms, sessionId, sessionVersion, StatusCanceling)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	updatedSession.States = returnedStates
+	return updatedSession, nil
+}
+
 // TerminateCompletedSessions will terminate sessions in the repo based on:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
@@ -533,8 +607,7 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "you must call ActivateSession to update a session's state to active")
 	}
 
-	var rowsAffected int
-	updatedSession := AllocSession()
+	var updatedSession *Session
 	var returnedStates []*State
 	_, err := r.writer.DoTx(
 		ctx,
@@ -542,51 +615,60 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
 			var err error
-			// We need to update the session version as that's the aggregate
-			updatedSession.PublicId = sessionId
-			updatedSession.Version = uint32(sessionVersion) + 1
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"Version"}, nil, db.WithVersion(&sessionVersion))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if rowsUpdated != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
-			}
-			if len(updatedSession.CtTofuToken) > 0 {
-				databaseWrapper, err := r.kms.GetWrapper(ctx, updatedSession.ProjectId, kms.KeyPurposeDatabase)
-				if err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
-				}
-				if err := updatedSession.decrypt(ctx, databaseWrapper); err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("cannot decrypt session value"))
-				}
-			} else {
-				updatedSession.CtTofuToken = nil
-			}
-
-			rowsAffected, err = w.Exec(ctx, updateSessionState, []interface{}{
-				sql.Named("session_id", sessionId),
-				sql.Named("status", s.String()),
-			})
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update session %s state to %s", sessionId, s.String())))
-			}
-			if rowsAffected != 0 && rowsAffected != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session %s to state %s and %d rows inserted (should be 0 or 1)", sessionId, s.String(), rowsAffected))
-			}
-			returnedStates, err = fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if len(returnedStates) < 1 && returnedStates[0].Status != s {
-				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("failed to update %s to a state of %s", sessionId, s.String()))
-			}
-			return nil
+			updatedSession, returnedStates, err = updateStateTx(ctx, reader, w, r.kms, sessionId, sessionVersion, s)
+			return err
 		},
 	)
 	if err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("error creating new state"))
 	}
+	return updatedSession, returnedStates, nil
+}
+
+// updateStateTx updates the state of the session within the transaction of
+// reader and w. See updateState.
+func updateStateTx(ctx context.Context, reader db.Reader, w db.Writer, kmsCache *kms.Kms, sessionId string, sessionVersion uint32, s Status) (*Session, []*State, error) {
+	const op = "session.updateStateTx"
+	// We need to update the session version as that's the aggregate
+	updatedSession := AllocSession()
+	updatedSession.PublicId = sessionId
+	updatedSession.Version = uint32(sessionVersion) + 1
+	rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"Version"}, nil, db.WithVersion(&sessionVersion))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated != 1 {
+		return nil, nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
+	}
+	if len(updatedSession.CtTofuToken) > 0 {
+		databaseWrapper, err := kmsCache.GetWrapper(ctx, updatedSession.ProjectId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := updatedSession.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("cannot decrypt session value"))
+		}
+	} else {
+		updatedSession.CtTofuToken = nil
+	}
+
+	rowsAffected, err := w.Exec(ctx, updateSessionState, []interface{}{
+		sql.Named("session_id", sessionId),
+		sql.Named("status", s.String()),
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update session %s state to %s", sessionId, s.String())))
+	}
+	if rowsAffected != 0 && rowsAffected != 1 {
+		return nil, nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session %s to state %s and %d rows inserted (should be 0 or 1)", sessionId, s.String(), rowsAffected))
+	}
+	returnedStates, err := fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if len(returnedStates) < 1 && returnedStates[0].Status != s {
+		return nil, nil, errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("failed to update %s to a state of %s", sessionId, s.String()))
+	}
 	return &updatedSession, returnedStates, nil
 }
 
@@ -1095,6 +1095,95 @@ func TestRepository_CancelSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CancelIdleSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	connRepo, err := NewConnectionRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+		composedOf.IdleTimeoutSeconds = 60
+		session := TestSession(t, conn, wrapper, composedOf)
+		c := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+
+		s, err := repo.CancelIdleSession(ctx, session.PublicId, session.Version)
+		require.NoError(err)
+		require.NotEmpty(s.States)
+		assert.Equal(StatusCanceling, s.States[0].Status)
+		assert.Equal(uint32(60), s.IdleTimeoutSeconds)
+
+		_, err = CloseConnections(ctx, repo, connRepo, []CloseWith{{
+			ConnectionId: c.PublicId,
+			BytesUp:      1,
+			BytesDown:    1,
+			ClosedReason: ConnectionCanceled,
+		}})
+		require.NoError(err)
+		s, _, err = repo.LookupSession(ctx, session.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusTerminated, s.States[0].Status)
+		assert.Equal(IdleTimeout.String(), s.TerminationReason)
+	})
+	t.Run("canceled-without-idle-timeout", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+		composedOf.IdleTimeoutSeconds = 60
+		session := TestSession(t, conn, wrapper, composedOf)
+
+		_, err := repo.CancelSession(ctx, session.PublicId, session.Version)
+		require.NoError(err)
+		_, err = repo.TerminateCompletedSessions(ctx)
+		require.NoError(err)
+		s, _, err := repo.LookupSession(ctx, session.PublicId)
+		require.NoError(err)
+		assert.Equal(SessionCanceled.String(), s.TerminationReason)
+	})
+	t.Run("version-mismatch", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+		composedOf.IdleTimeoutSeconds = 60
+		session := TestSession(t, conn, wrapper, composedOf)
+
+		_, err := repo.CancelIdleSession(ctx, session.PublicId, session.Version+1)
+		require.Error(err)
+
+		// The failed idle cancellation must not change the termination reason
+		_, err = repo.CancelSession(ctx, session.PublicId, session.Version)
+		require.NoError(err)
+		_, err = repo.TerminateCompletedSessions(ctx)
+		require.NoError(err)
+		s, _, err := repo.LookupSession(ctx, session.PublicId)
+		require.NoError(err)
+		assert.Equal(SessionCanceled.String(), s.TerminationReason)
+	})
+	t.Run("no-idle-timeout", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		session := TestDefaultSession(t, conn, wrapper, iamRepo)
+		_, err := repo.CancelIdleSession(ctx, session.PublicId, session.Version)
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %s", err.Error())
+	})
+	t.Run("missing-session-id", func(t *testing.T) {
+		_, err := repo.CancelIdleSession(ctx, "", 1)
+		require.Error(t, err)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %s", err.Error())
+	})
+	t.Run("missing-version", func(t *testing.T) {
+		_, err := repo.CancelIdleSession(ctx, "s_1234567890", 0)
+		require.Error(t, err)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %s", err.Error())
+	})
+}
+
 func TestRepository_CancelSessionViaFKNull(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
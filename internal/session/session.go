@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/util"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -46,6 +47,9 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// IdleTimeoutSeconds is the amount of time the session can go without any
+	// traffic before the worker cancels it. Zero means no idle timeout.
+	IdleTimeoutSeconds uint32
 	// Worker filter. Active filter when the session was created, used to
 	// validate the session via the same set of rules at consumption time as
 	// existed at creation time. Round tripping it through here saves a lookup
@@ -97,6 +101,9 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// IdleTimeoutSeconds is the amount of time the session can go without any
+	// traffic before the worker cancels it. Zero means no idle timeout.
+	IdleTimeoutSeconds uint32 `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
 	// Worker filter
 	WorkerFilter string `json:"-" gorm:"default:null"`
 
@@ -134,6 +141,14 @@ func (s Session) GetUserId() string {
 	return s.UserId
 }
 
+func (s Session) GetCreateTime() *timestamp.Timestamp {
+	return s.CreateTime
+}
+
+func (s Session) GetUpdateTime() *timestamp.Timestamp {
+	return s.UpdateTime
+}
+
 var (
 	_ Cloneable                     = (*Session)(nil)
 	_ db.VetForWriter               = (*Session)(nil)
@@ -153,6 +168,7 @@ func New(c ComposedOf, _ ...Option) (*Session, error) {
 		Endpoint:           c.Endpoint,
 		ExpirationTime:     c.ExpirationTime,
 		ConnectionLimit:    c.ConnectionLimit,
+		IdleTimeoutSeconds: c.IdleTimeoutSeconds,
 		WorkerFilter:       c.WorkerFilter,
 		DynamicCredentials: c.DynamicCredentials,
 		StaticCredentials:  c.StaticCredentials,
@@ -171,19 +187,20 @@ func AllocSession() Session {
 // Clone creates a clone of the Session
 func (s *Session) Clone() interface{} {
 	clone := &Session{
-		PublicId:          s.PublicId,
-		UserId:            s.UserId,
-		HostId:            s.HostId,
-		TargetId:          s.TargetId,
-		HostSetId:         s.HostSetId,
-		AuthTokenId:       s.AuthTokenId,
-		ProjectId:         s.ProjectId,
-		TerminationReason: s.TerminationReason,
-		Version:           s.Version,
-		Endpoint:          s.Endpoint,
-		ConnectionLimit:   s.ConnectionLimit,
-		WorkerFilter:      s.WorkerFilter,
-		KeyId:             s.KeyId,
+		PublicId:           s.PublicId,
+		UserId:             s.UserId,
+		HostId:             s.HostId,
+		TargetId:           s.TargetId,
+		HostSetId:          s.HostSetId,
+		AuthTokenId:        s.AuthTokenId,
+		ProjectId:          s.ProjectId,
+		TerminationReason:  s.TerminationReason,
+		Version:            s.Version,
+		Endpoint:           s.Endpoint,
+		ConnectionLimit:    s.ConnectionLimit,
+		IdleTimeoutSeconds: s.IdleTimeoutSeconds,
+		WorkerFilter:       s.WorkerFilter,
+		KeyId:              s.KeyId,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -287,6 +304,8 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "expiration time is immutable")
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return errors.New(ctx, errors.InvalidParameter, op, "connection limit is immutable")
+		case contains(opts.WithFieldMaskPaths, "IdleTimeoutSeconds"):
+			return errors.New(ctx, errors.InvalidParameter, op, "idle timeout seconds is immutable")
 		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
 			return errors.New(ctx, errors.InvalidParameter, op, "worker filter is immutable")
 		case contains(opts.WithFieldMaskPaths, "DynamicCredentials"):
@@ -401,11 +420,9 @@ func newCert(ctx context.Context, wrapper wrapping.Wrapper, userId, jobId string
 
 	for _, addr := range addresses {
 		// First ensure we aren't looking at ports, regardless of IP or not
-		host, _, err := net.SplitHostPort(addr)
+		host, _, err := util.SplitHostPort(addr)
 		if err != nil {
-			if strings.Contains(err.Error(), "missing port") {
-				host = addr
-			} else {
+			if !errors.Is(err, util.ErrMissingPort) {
 				return nil, nil, errors.Wrap(ctx, err, op)
 			}
 		}
@@ -15,6 +15,10 @@ import (
 type StaticCredential struct {
 	*store.StaticCredential
 	tableName string `gorm:"-"`
+
+	// credentialType is only set when the source is being attached to a
+	// target, so the target subtype can vet it.
+	credentialType credential.Type `gorm:"-"`
 }
 
 // NewStaticCredential creates a new in memory StaticCredential
@@ -42,9 +46,16 @@ func (t *StaticCredential) clone() *StaticCredential {
 	cp := proto.Clone(t.StaticCredential)
 	return &StaticCredential{
 		StaticCredential: cp.(*store.StaticCredential),
+		credentialType:   t.credentialType,
 	}
 }
 
+// CredentialType returns the type of the credentials of the static credential.
+// It is only set for the sources vetted by a target subtype.
+func (t *StaticCredential) CredentialType() credential.Type {
+	return t.credentialType
+}
+
 // TableName returns the table name.
 func (t *StaticCredential) TableName() string {
 	if t.tableName != "" {
@@ -15,6 +15,10 @@ import (
 type CredentialLibrary struct {
 	*store.CredentialLibrary
 	tableName string `gorm:"-"`
+
+	// credentialType is only set when the source is being attached to a
+	// target, so the target subtype can vet it.
+	credentialType credential.Type `gorm:"-"`
 }
 
 // NewCredentialLibrary creates a new in memory CredentialLibrary
@@ -48,9 +52,16 @@ func (t *CredentialLibrary) clone() *CredentialLibrary {
 	cp := proto.Clone(t.CredentialLibrary)
 	return &CredentialLibrary{
 		CredentialLibrary: cp.(*store.CredentialLibrary),
+		credentialType:    t.credentialType,
 	}
 }
 
+// CredentialType returns the type of the credentials of the credential library.
+// It is only set for the sources vetted by a target subtype.
+func (t *CredentialLibrary) CredentialType() credential.Type {
+	return t.credentialType
+}
+
 // TableName returns the table name.
 func (t *CredentialLibrary) TableName() string {
 	if t.tableName != "" {
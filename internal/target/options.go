@@ -3,6 +3,7 @@ package target
 import (
 	"time"
 
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 )
@@ -21,45 +22,48 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	WithName                   string
-	WithDescription            string
-	WithDefaultPort            uint32
-	WithLimit                  int
-	WithProjectId              string
-	WithProjectIds             []string
-	WithProjectName            string
-	WithUserId                 string
-	WithType                   subtypes.Subtype
-	WithHostSources            []string
-	WithCredentialLibraries    []*CredentialLibrary
-	WithStaticCredentials      []*StaticCredential
-	WithSessionMaxSeconds      uint32
-	WithSessionConnectionLimit int32
-	WithPermissions            []perms.Permission
-	WithPublicId               string
-	WithWorkerFilter           string
-	WithTargetIds              []string
+	WithName                      string
+	WithDescription               string
+	WithDefaultPort               uint32
+	WithLimit                     int
+	WithProjectId                 string
+	WithProjectIds                []string
+	WithProjectName               string
+	WithUserId                    string
+	WithType                      subtypes.Subtype
+	WithHostSources               []string
+	WithCredentialLibraries       []*CredentialLibrary
+	WithStaticCredentials         []*StaticCredential
+	WithSessionMaxSeconds         uint32
+	WithSessionConnectionLimit    int32
+	WithSessionIdleTimeoutSeconds uint32
+	WithPermissions               []perms.Permission
+	WithPublicId                  string
+	WithWorkerFilter              string
+	WithTargetIds                 []string
+	WithPage                      *pagination.Page
 }
 
 func getDefaultOptions() options {
 	return options{
-		WithName:                   "",
-		WithDescription:            "",
-		WithLimit:                  0,
-		WithDefaultPort:            0,
-		WithProjectId:              "",
-		WithProjectIds:             nil,
-		WithProjectName:            "",
-		WithUserId:                 "",
-		WithType:                   "",
-		WithHostSources:            nil,
-		WithCredentialLibraries:    nil,
-		WithStaticCredentials:      nil,
-		WithSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
-		WithSessionConnectionLimit: -1,
-		WithPermissions:            nil,
-		WithPublicId:               "",
-		WithWorkerFilter:           "",
+		WithName:                      "",
+		WithDescription:               "",
+		WithLimit:                     0,
+		WithDefaultPort:               0,
+		WithProjectId:                 "",
+		WithProjectIds:                nil,
+		WithProjectName:               "",
+		WithUserId:                    "",
+		WithType:                      "",
+		WithHostSources:               nil,
+		WithCredentialLibraries:       nil,
+		WithStaticCredentials:         nil,
+		WithSessionMaxSeconds:         uint32((8 * time.Hour).Seconds()),
+		WithSessionConnectionLimit:    -1,
+		WithSessionIdleTimeoutSeconds: 0,
+		WithPermissions:               nil,
+		WithPublicId:                  "",
+		WithWorkerFilter:              "",
 	}
 }
 
@@ -161,6 +165,14 @@ func WithSessionConnectionLimit(limit int32) Option {
 	}
 }
 
+// WithSessionIdleTimeoutSeconds provides an optional idle timeout of the
+// sessions of a target. Zero means the default of the controller.
+func WithSessionIdleTimeoutSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.WithSessionIdleTimeoutSeconds = secs
+	}
+}
+
 // WithPublicId provides an optional public id
 func WithPublicId(id string) Option {
 	return func(o *options) {
@@ -189,3 +201,11 @@ func WithPermissions(perms []perms.Permission) Option {
 		o.WithPermissions = perms
 	}
 }
+
+// WithPage provides an option to list a page of targets. It overrides the
+// WithLimit option.
+func WithPage(p *pagination.Page) Option {
+	return func(o *options) {
+		o.WithPage = p
+	}
+}
@@ -0,0 +1,110 @@
+package rdp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+type targetHooks struct{}
+
+func init() {
+	target.Register(Subtype, targetHooks{}, TargetPrefix)
+}
+
+const (
+	// TargetPrefix is the prefix for public ids of a rdp.Target.
+	TargetPrefix = "trdp"
+)
+
+// Vet validates that the given target.Target is a rdp.Target and that it
+// has a Target store.
+func (h targetHooks) Vet(ctx context.Context, t target.Target) error {
+	const op = "rdp.vet"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a rdp.Target")
+	}
+
+	if tt == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	}
+
+	if tt.Target == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+	if tt.GetDefaultPort() == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target default port")
+	}
+	return nil
+}
+
+// VetForUpdate validates that the given target.Target is a rdp.Target,
+// and that it has a Target store and that it isn't attempting to clear or
+// set to zero the default port.
+func (h targetHooks) VetForUpdate(ctx context.Context, t target.Target, paths []string) error {
+	const op = "rdp.vetForUpdate"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a rdp.Target")
+	}
+
+	switch {
+	case tt == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	case tt.Target == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+
+	for _, f := range paths {
+		if strings.EqualFold("defaultport", f) && tt.GetDefaultPort() == 0 {
+			return errors.New(ctx, errors.InvalidParameter, op, "clearing or setting default port to zero")
+		}
+	}
+
+	return nil
+}
+
+// VetCredentialSources checks that all the provided credential sources have a
+// CredentialPurpose of BrokeredPurpose or InjectedApplicationPurpose. Any
+// other CredentialPurpose will result in an error. The credentials injected
+// by the worker must be of the username_password type.
+func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.CredentialLibrary, creds []*target.StaticCredential) error {
+	const op = "rdp.VetCredentialSources"
+
+	for _, c := range libs {
+		if err := vetCredentialSource(ctx, op, c.GetCredentialLibraryId(), c.GetCredentialPurpose(), c.CredentialType()); err != nil {
+			return err
+		}
+	}
+	for _, c := range creds {
+		if err := vetCredentialSource(ctx, op, c.GetCredentialId(), c.GetCredentialPurpose(), c.CredentialType()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func vetCredentialSource(ctx context.Context, op errors.Op, id, purpose string, credType credential.Type) error {
+	if !supportedPurpose(purpose) {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("rdp.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+	}
+	if credential.Purpose(purpose) == credential.InjectedApplicationPurpose && credType != credential.UsernamePasswordType {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("rdp.Target only supports %q credentials for the %q purpose, %s has %q credentials", credential.UsernamePasswordType, credential.InjectedApplicationPurpose, id, credType))
+	}
+	return nil
+}
+
+func supportedPurpose(p string) bool {
+	switch credential.Purpose(p) {
+	case credential.BrokeredPurpose, credential.InjectedApplicationPurpose:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package rdp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetHooks_VetCredentialSources(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		libs    []*target.CredentialLibrary
+		creds   []*target.StaticCredential
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "brokered",
+			libs: []*target.CredentialLibrary{target.TestNewCredentialLibrary("trdp_1234567890", "clvlt_1234567890", credential.BrokeredPurpose)},
+		},
+		{
+			name: "brokered-ssh-private-key",
+			creds: []*target.StaticCredential{
+				target.TestNewStaticCredentialWithType("trdp_1234567890", "credspk_1234567890", credential.BrokeredPurpose, credential.SshPrivateKeyType),
+			},
+		},
+		{
+			name: "injected-application",
+			creds: []*target.StaticCredential{
+				target.TestNewStaticCredentialWithType("trdp_1234567890", "credup_1234567890", credential.InjectedApplicationPurpose, credential.UsernamePasswordType),
+			},
+		},
+		{
+			name: "injected-application-library",
+			libs: []*target.CredentialLibrary{
+				target.TestNewCredentialLibraryWithType("trdp_1234567890", "clvlt_1234567890", credential.InjectedApplicationPurpose, credential.UsernamePasswordType),
+			},
+		},
+		{
+			name: "injected-application-ssh-private-key",
+			creds: []*target.StaticCredential{
+				target.TestNewStaticCredentialWithType("trdp_1234567890", "credspk_1234567890", credential.InjectedApplicationPurpose, credential.SshPrivateKeyType),
+			},
+			wantErr: true,
+		},
+		{
+			name: "injected-application-json",
+			creds: []*target.StaticCredential{
+				target.TestNewStaticCredentialWithType("trdp_1234567890", "credjson_1234567890", credential.InjectedApplicationPurpose, credential.JsonType),
+			},
+			wantErr: true,
+		},
+		{
+			name: "injected-application-unspecified-library",
+			libs: []*target.CredentialLibrary{
+				target.TestNewCredentialLibraryWithType("trdp_1234567890", "clvlt_1234567890", credential.InjectedApplicationPurpose, credential.UnspecifiedType),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "injected-application-missing-type",
+			creds:   []*target.StaticCredential{target.TestNewStaticCredential("trdp_1234567890", "credup_1234567890", credential.InjectedApplicationPurpose)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-purpose-library",
+			libs:    []*target.CredentialLibrary{target.TestNewCredentialLibrary("trdp_1234567890", "clvlt_1234567890", credential.Purpose("unknown"))},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-purpose-credential",
+			creds:   []*target.StaticCredential{target.TestNewStaticCredential("trdp_1234567890", "credup_1234567890", credential.Purpose("unknown"))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := targetHooks{}.VetCredentialSources(ctx, tt.libs, tt.creds)
+			if tt.wantErr {
+				assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
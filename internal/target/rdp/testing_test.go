@@ -0,0 +1,48 @@
+package rdp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/rdp"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TestRdpTarget(t *testing.T) {
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	ctx := context.Background()
+	repo, err := target.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(err)
+
+	cats := static.TestCatalogs(t, conn, proj.PublicId, 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 2)
+	var sets []string
+	for _, s := range hsets {
+		sets = append(sets, s.PublicId)
+	}
+	name := rdp.TestTargetName(t, proj.PublicId)
+	tar := rdp.TestTarget(ctx, t, conn, proj.PublicId, name, target.WithHostSources(sets))
+	require.NotNil(t)
+	require.NotEmpty(tar.GetPublicId())
+	require.Equal(name, tar.GetName())
+
+	_, foundSources, _, err := repo.LookupTarget(context.Background(), tar.GetPublicId())
+	require.NoError(err)
+	foundIds := make([]string, 0, len(foundSources))
+	for _, s := range foundSources {
+		foundIds = append(foundIds, s.Id())
+	}
+	require.ElementsMatch(sets, foundIds)
+}
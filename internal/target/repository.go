@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/boundary"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
@@ -216,7 +218,8 @@ func (r *Repository) FetchAuthzProtectedEntitiesByScope(ctx context.Context, pro
 // ListTargets lists targets in a project based on the data in the WithPermissions option
 // provided to the Repository constructor. If no permissions are available, this function
 // is a no-op.
-// Supports WithLimit which overrides the limit set in the Repository object.
+// Supports WithLimit which overrides the limit set in the Repository object,
+// and WithPage.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	const op = "target.(Repository).ListTargets"
 
@@ -235,9 +238,21 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		limit = opts.WithLimit
 	}
 
+	whereClause := strings.Join(where, " or ")
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.WithPage != nil {
+		if pageWhere, pageArgs := opts.WithPage.NamedWhere(); pageWhere != "" {
+			whereClause = fmt.Sprintf("(%s) and %s", whereClause, pageWhere)
+			args = append(args, pageArgs...)
+		}
+		if opts.WithPage.Size != 0 {
+			dbOpts[0] = db.WithLimit(opts.WithPage.Size)
+		}
+		dbOpts = append(dbOpts, db.WithOrder(opts.WithPage.OrderBy()))
+	}
+
 	var foundTargets []*targetView
-	err := r.reader.SearchWhere(ctx, &foundTargets, strings.Join(where, " or "), args,
-		db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &foundTargets, whereClause, args, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -254,6 +269,17 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	return targets, nil
 }
 
+// ListDeletedIds lists the public ids of the targets of the projects deleted
+// after the provided time.
+func (r *Repository) ListDeletedIds(ctx context.Context, projectIds []string, since time.Time) ([]string, error) {
+	const op = "target.(Repository).ListDeletedIds"
+	ids, err := pagination.ListDeletedIds(ctx, r.reader, resource.Target, projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 func (r *Repository) listPermissionWhereClauses() ([]string, []interface{}) {
 	var where []string
 	var args []interface{}
@@ -523,6 +549,7 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
 		case strings.EqualFold("workerfilter", f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
@@ -531,15 +558,16 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":                   target.GetName(),
-			"Description":            target.GetDescription(),
-			"DefaultPort":            target.GetDefaultPort(),
-			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
-			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
-			"WorkerFilter":           target.GetWorkerFilter(),
+			"Name":                      target.GetName(),
+			"Description":               target.GetDescription(),
+			"DefaultPort":               target.GetDefaultPort(),
+			"SessionMaxSeconds":         target.GetSessionMaxSeconds(),
+			"SessionConnectionLimit":    target.GetSessionConnectionLimit(),
+			"SessionIdleTimeoutSeconds": target.GetSessionIdleTimeoutSeconds(),
+			"WorkerFilter":              target.GetWorkerFilter(),
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
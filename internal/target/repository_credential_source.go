@@ -307,6 +307,9 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
 	}
 	// Validate add sources on target
+	if err := setCredentialTypes(ctx, r.reader, addCredLibs, addStaticCred); err != nil {
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
 	if err := vetCredentialSources(ctx, addCredLibs, addStaticCred); err != nil {
 		return nil, nil, db.NoRowsAffected, err
 	}
@@ -522,6 +525,38 @@ func fetchCredentialSources(ctx context.Context, r db.Reader, targetId string) (
 	return ret, nil
 }
 
+// setCredentialTypes sets the types of the credentials of the credential
+// libraries and static credentials, so they can be vetted by the target
+// subtype.
+func setCredentialTypes(ctx context.Context, r db.Reader, libs []*CredentialLibrary, creds []*StaticCredential) error {
+	const op = "target.setCredentialTypes"
+	ids := make([]string, 0, len(libs)+len(creds))
+	for _, l := range libs {
+		ids = append(ids, l.GetCredentialLibraryId())
+	}
+	for _, c := range creds {
+		ids = append(ids, c.GetCredentialId())
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var credView []*credentialSourceView
+	if err := r.SearchWhere(ctx, &credView, "public_id in (?)", []interface{}{ids}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("can't retrieve credentials"))
+	}
+	credentialTypeById := make(map[string]credential.Type, len(credView))
+	for _, cv := range credView {
+		credentialTypeById[cv.GetPublicId()] = credential.Type(cv.GetCredentialType())
+	}
+	for _, l := range libs {
+		l.credentialType = credentialTypeById[l.GetCredentialLibraryId()]
+	}
+	for _, c := range creds {
+		c.credentialType = credentialTypeById[c.GetCredentialId()]
+	}
+	return nil
+}
+
 func (r *Repository) createSources(ctx context.Context, tId string, tSubtype subtypes.Subtype, credSources CredentialSources) ([]*CredentialLibrary, []*StaticCredential, error) {
 	const op = "target.(Repository).createSources"
 
@@ -546,8 +581,10 @@ func (r *Repository) createSources(ctx context.Context, tId string, tSubtype sub
 	// Create a map between credential source ID and it's type (library or static).
 	// This will allow for a quick lookup when calling the corresponding New below
 	credTypeById := make(map[string]CredentialSourceType, len(ids))
+	credentialTypeById := make(map[string]credential.Type, len(ids))
 	for _, cv := range credView {
 		credTypeById[cv.GetPublicId()] = CredentialSourceType(cv.GetType())
+		credentialTypeById[cv.GetPublicId()] = credential.Type(cv.GetCredentialType())
 	}
 
 	credLibs := make([]*CredentialLibrary, 0, totalCreds)
@@ -564,12 +601,14 @@ func (r *Repository) createSources(ctx context.Context, tId string, tSubtype sub
 				if err != nil {
 					return nil, nil, errors.Wrap(ctx, err, op)
 				}
+				lib.credentialType = credentialTypeById[id]
 				credLibs = append(credLibs, lib)
 			case StaticCredentialSourceType:
 				cred, err := NewStaticCredential(tId, id, purpose)
 				if err != nil {
 					return nil, nil, errors.Wrap(ctx, err, op)
 				}
+				cred.credentialType = credentialTypeById[id]
 				staticCred = append(staticCred, cred)
 			}
 		}
@@ -25,6 +25,7 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetSessionIdleTimeoutSeconds() uint32
 	GetWorkerFilter() string
 	Clone() Target
 	SetPublicId(context.Context, string) error
@@ -37,6 +38,7 @@ type Target interface {
 	SetUpdateTime(*timestamp.Timestamp)
 	SetSessionMaxSeconds(uint32)
 	SetSessionConnectionLimit(int32)
+	SetSessionIdleTimeoutSeconds(uint32)
 	SetWorkerFilter(string)
 	Oplog(op oplog.OpType) oplog.Metadata
 }
@@ -122,6 +124,7 @@ func (t *targetView) targetSubtype(ctx context.Context) (Target, error) {
 	tt.SetUpdateTime(t.UpdateTime)
 	tt.SetSessionMaxSeconds(t.SessionMaxSeconds)
 	tt.SetSessionConnectionLimit(t.SessionConnectionLimit)
+	tt.SetSessionIdleTimeoutSeconds(t.SessionIdleTimeoutSeconds)
 	tt.SetWorkerFilter(t.WorkerFilter)
 	return tt, nil
 }
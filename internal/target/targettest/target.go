@@ -113,6 +113,10 @@ func (t *Target) GetSessionConnectionLimit() int32 {
 	return t.SessionConnectionLimit
 }
 
+func (t *Target) GetSessionIdleTimeoutSeconds() uint32 {
+	return t.SessionIdleTimeoutSeconds
+}
+
 func (t *Target) GetWorkerFilter() string {
 	return t.WorkerFilter
 }
@@ -165,6 +169,10 @@ func (t *Target) SetSessionConnectionLimit(l int32) {
 	t.SessionConnectionLimit = l
 }
 
+func (t *Target) SetSessionIdleTimeoutSeconds(s uint32) {
+	t.SessionIdleTimeoutSeconds = s
+}
+
 func (t *Target) SetWorkerFilter(f string) {
 	t.WorkerFilter = f
 }
@@ -244,13 +252,14 @@ func New(projectId string, opt ...target.Option) (target.Target, error) {
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
+			ProjectId:                 projectId,
+			Name:                      opts.WithName,
+			Description:               opts.WithDescription,
+			DefaultPort:               opts.WithDefaultPort,
+			SessionConnectionLimit:    opts.WithSessionConnectionLimit,
+			SessionIdleTimeoutSeconds: opts.WithSessionIdleTimeoutSeconds,
+			SessionMaxSeconds:         opts.WithSessionMaxSeconds,
+			WorkerFilter:              opts.WithWorkerFilter,
 		},
 	}
 	return t, nil
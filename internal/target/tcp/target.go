@@ -47,13 +47,14 @@ func (h targetHooks) NewTarget(projectId string, opt ...target.Option) (target.T
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
+			ProjectId:                 projectId,
+			Name:                      opts.WithName,
+			Description:               opts.WithDescription,
+			DefaultPort:               opts.WithDefaultPort,
+			SessionConnectionLimit:    opts.WithSessionConnectionLimit,
+			SessionIdleTimeoutSeconds: opts.WithSessionIdleTimeoutSeconds,
+			SessionMaxSeconds:         opts.WithSessionMaxSeconds,
+			WorkerFilter:              opts.WithWorkerFilter,
 		},
 	}
 	return t, nil
@@ -168,6 +169,10 @@ func (t *Target) SetSessionConnectionLimit(limit int32) {
 	t.SessionConnectionLimit = limit
 }
 
+func (t *Target) SetSessionIdleTimeoutSeconds(s uint32) {
+	t.SessionIdleTimeoutSeconds = s
+}
+
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
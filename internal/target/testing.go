@@ -36,6 +36,23 @@ func TestNewStaticCredential(targetId, credentialId string, purpose credential.P
 	}
 }
 
+// TestNewCredentialLibraryWithType creates a new in memory CredentialLibrary
+// like TestNewCredentialLibrary, for a library issuing credentials of the
+// given type.
+func TestNewCredentialLibraryWithType(targetId, credentialLibraryId string, purpose credential.Purpose, credType credential.Type) *CredentialLibrary {
+	l := TestNewCredentialLibrary(targetId, credentialLibraryId, purpose)
+	l.credentialType = credType
+	return l
+}
+
+// TestNewStaticCredentialWithType creates a new in memory StaticCredential
+// like TestNewStaticCredential, for a credential of the given type.
+func TestNewStaticCredentialWithType(targetId, credentialId string, purpose credential.Purpose, credType credential.Type) *StaticCredential {
+	c := TestNewStaticCredential(targetId, credentialId, purpose)
+	c.credentialType = credType
+	return c
+}
+
 // TestCredentialLibrary creates a CredentialLibrary for targetId and
 // libraryId with the credential purpose of brokered.
 func TestCredentialLibrary(t testing.TB, conn *db.DB, targetId, libraryId string) *CredentialLibrary {
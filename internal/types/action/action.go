@@ -60,6 +60,11 @@ const (
 	CreateControllerLed              Type = 49
 	ReinitializeCertificateAuthority Type = 50
 	ReadCertificateAuthority         Type = 51
+	SetRotation                      Type = 52
+	ReadRotation                     Type = 53
+	RemoveRotation                   Type = 54
+	RotateKeys                       Type = 55
+	ReadKeyRewrapStatus              Type = 56
 
 	// When adding new actions, be sure to update:
 	//
@@ -119,6 +124,11 @@ var Map = map[string]Type{
 	CreateControllerLed.String():              CreateControllerLed,
 	ReinitializeCertificateAuthority.String(): ReinitializeCertificateAuthority,
 	ReadCertificateAuthority.String():         ReadCertificateAuthority,
+	SetRotation.String():                      SetRotation,
+	ReadRotation.String():                     ReadRotation,
+	RemoveRotation.String():                   RemoveRotation,
+	RotateKeys.String():                       RotateKeys,
+	ReadKeyRewrapStatus.String():              ReadKeyRewrapStatus,
 }
 
 var DeprecatedMap = map[string]Type{
@@ -184,6 +194,11 @@ func (a Type) String() string {
 		"create:controller-led",
 		"reinitialize-certificate-authority",
 		"read-certificate-authority",
+		"set-rotation",
+		"read-rotation",
+		"remove-rotation",
+		"rotate-keys",
+		"read-key-rewrap-status",
 	}[a]
 }
 
@@ -115,6 +115,18 @@ func TestAction(t *testing.T) {
 			action: CreateControllerLed,
 			want:   "create:controller-led",
 		},
+		{
+			action: SetRotation,
+			want:   "set-rotation",
+		},
+		{
+			action: ReadRotation,
+			want:   "read-rotation",
+		},
+		{
+			action: RemoveRotation,
+			want:   "remove-rotation",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -26,6 +26,7 @@ const (
 	CredentialStore
 	CredentialLibrary
 	Credential
+	Alias
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -61,6 +62,7 @@ func (r Type) String() string {
 		"credential-store",
 		"credential-library",
 		"credential",
+		"alias",
 	}[r]
 }
 
@@ -68,6 +70,8 @@ func (r Type) PluralString() string {
 	switch r {
 	case CredentialLibrary:
 		return "credential-libraries"
+	case Alias:
+		return "aliases"
 	default:
 		return r.String() + "s"
 	}
@@ -94,4 +98,5 @@ var Map = map[string]Type{
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
 	Credential.String():        Credential,
+	Alias.String():             Alias,
 }
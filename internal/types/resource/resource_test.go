@@ -87,6 +87,10 @@ func Test_Resource(t *testing.T) {
 			typeString: "credential-library",
 			want:       CredentialLibrary,
 		},
+		{
+			typeString: "alias",
+			want:       Alias,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
@@ -95,3 +99,9 @@ func Test_Resource(t *testing.T) {
 		})
 	}
 }
+
+func Test_PluralString(t *testing.T) {
+	assert.Equal(t, "targets", Target.PluralString())
+	assert.Equal(t, "credential-libraries", CredentialLibrary.PluralString())
+	assert.Equal(t, "aliases", Alias.PluralString())
+}
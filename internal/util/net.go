@@ -0,0 +1,37 @@
+package util
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// ErrMissingPort is returned by SplitHostPort when the address has no port.
+var ErrMissingPort = errors.New("missing port in address")
+
+// SplitHostPort splits an address of the form "host:port", "[host]:port",
+// "host", "[host]" or a bare IPv6 literal into host and port. Unlike
+// net.SplitHostPort, the returned host never contains brackets, so it can
+// safely be passed to net.JoinHostPort or net.ParseIP. If the address has no
+// port, the host is returned along with an empty port and ErrMissingPort.
+func SplitHostPort(hostport string) (host string, port string, err error) {
+	host, port, err = net.SplitHostPort(hostport)
+	switch {
+	case err == nil:
+		return host, port, nil
+	case strings.HasPrefix(hostport, "[") && strings.HasSuffix(hostport, "]"):
+		host = hostport[1 : len(hostport)-1]
+		if net.ParseIP(host) == nil {
+			return "", "", err
+		}
+		return host, "", ErrMissingPort
+	case strings.Contains(err.Error(), "missing port in address"):
+		return hostport, "", ErrMissingPort
+	case net.ParseIP(hostport) != nil:
+		// A bare IPv6 literal, which net.SplitHostPort rejects because of its
+		// colons
+		return hostport, "", ErrMissingPort
+	default:
+		return "", "", err
+	}
+}
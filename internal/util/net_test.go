@@ -0,0 +1,54 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitHostPort(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		hostport string
+		wantHost string
+		wantPort string
+		wantErr  error
+		anyErr   bool
+	}{
+		{name: "ipv4 with port", hostport: "127.0.0.1:9200", wantHost: "127.0.0.1", wantPort: "9200"},
+		{name: "ipv4", hostport: "127.0.0.1", wantHost: "127.0.0.1", wantErr: util.ErrMissingPort},
+		{name: "name with port", hostport: "example.com:9200", wantHost: "example.com", wantPort: "9200"},
+		{name: "name", hostport: "example.com", wantHost: "example.com", wantErr: util.ErrMissingPort},
+		{name: "ipv6 with port", hostport: "[2001:db8::1]:9200", wantHost: "2001:db8::1", wantPort: "9200"},
+		{name: "bracketed ipv6", hostport: "[2001:db8::1]", wantHost: "2001:db8::1", wantErr: util.ErrMissingPort},
+		{name: "bare ipv6", hostport: "2001:db8::1", wantHost: "2001:db8::1", wantErr: util.ErrMissingPort},
+		{name: "ipv6 unspecified with port", hostport: "[::]:9200", wantHost: "::", wantPort: "9200"},
+		{name: "ipv6 unspecified", hostport: "::", wantHost: "::", wantErr: util.ErrMissingPort},
+		{name: "ipv6 zone with port", hostport: "[fe80::1%eth0]:9200", wantHost: "fe80::1%eth0", wantPort: "9200"},
+		{name: "bracketed name", hostport: "[example.com]", anyErr: true},
+		{name: "too many colons", hostport: "example.com:9200:9201", anyErr: true},
+	}
+	for _, tt := range tc {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			host, port, err := util.SplitHostPort(tt.hostport)
+			switch {
+			case tt.anyErr:
+				assert.Error(err)
+				assert.NotErrorIs(err, util.ErrMissingPort)
+				return
+			case tt.wantErr != nil:
+				assert.ErrorIs(err, tt.wantErr)
+			default:
+				assert.NoError(err)
+			}
+			assert.Equal(tt.wantHost, host)
+			assert.Equal(tt.wantPort, port)
+		})
+	}
+}
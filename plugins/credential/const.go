@@ -0,0 +1,6 @@
+package credential_plugin_assets
+
+// CredentialPluginPrefix is the prefix of the file names of external
+// credential plugins. A plugin named "example" is loaded from the file
+// boundary-plugin-credential-example.
+const CredentialPluginPrefix = "boundary-plugin-credential-"
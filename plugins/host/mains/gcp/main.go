@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	gcphp "github.com/hashicorp/boundary/plugins/host/mains/gcp/plugin"
+	hp "github.com/hashicorp/boundary/sdk/plugins/host"
+)
+
+func main() {
+	if err := hp.ServeHostPlugin(new(gcphp.GcpPlugin)); err != nil {
+		fmt.Println("Error serving plugin", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
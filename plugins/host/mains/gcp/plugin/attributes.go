@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// ConstProjectId is the catalog attribute holding the ID of the GCP
+	// project the instances are discovered in.
+	ConstProjectId = "project_id"
+
+	// ConstZone is the catalog attribute holding the default zone used by
+	// host sets which do not specify zones.
+	ConstZone = "zone"
+
+	// ConstServiceAccountKey is the catalog secret holding the JSON key of
+	// the service account used to call the Compute Engine API. If it is not
+	// provided, the application default credentials of the controller are
+	// used.
+	ConstServiceAccountKey = "service_account_key"
+
+	// ConstZones is the set attribute holding the zones the instances are
+	// discovered in.
+	ConstZones = "zones"
+
+	// ConstFilters is the set attribute holding the Compute Engine filter
+	// expressions the instances must match, for example
+	// "labels.env = prod".
+	ConstFilters = "filters"
+)
+
+// catalogAttributes are the attributes of a GCP host catalog.
+type catalogAttributes struct {
+	ProjectId string
+	Zone      string
+}
+
+// catalogSecrets are the secrets of a GCP host catalog.
+type catalogSecrets struct {
+	ServiceAccountKey string
+}
+
+// setAttributes are the attributes of a GCP host set.
+type setAttributes struct {
+	Zones   []string
+	Filters []string
+}
+
+func getCatalogAttributes(in *structpb.Struct) (*catalogAttributes, error) {
+	badFields := map[string]string{}
+	attrs := &catalogAttributes{}
+	for k, v := range in.GetFields() {
+		switch k {
+		case ConstProjectId:
+			attrs.ProjectId = v.GetStringValue()
+		case ConstZone:
+			attrs.Zone = v.GetStringValue()
+		default:
+			badFields[fmt.Sprintf("attributes.%s", k)] = "unrecognized field"
+		}
+	}
+	if attrs.ProjectId == "" {
+		badFields[fmt.Sprintf("attributes.%s", ConstProjectId)] = "missing required value"
+	}
+	if err := invalidArgumentError("Invalid arguments in the catalog attributes", badFields); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+func getCatalogSecrets(in *structpb.Struct) (*catalogSecrets, error) {
+	badFields := map[string]string{}
+	secrets := &catalogSecrets{}
+	for k, v := range in.GetFields() {
+		switch k {
+		case ConstServiceAccountKey:
+			secrets.ServiceAccountKey = v.GetStringValue()
+			var key map[string]interface{}
+			if err := json.Unmarshal([]byte(secrets.ServiceAccountKey), &key); err != nil {
+				badFields[fmt.Sprintf("secrets.%s", k)] = "must be a JSON service account key"
+			}
+		default:
+			badFields[fmt.Sprintf("secrets.%s", k)] = "unrecognized field"
+		}
+	}
+	if err := invalidArgumentError("Invalid arguments in the catalog secrets", badFields); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func getSetAttributes(in *structpb.Struct) (*setAttributes, error) {
+	badFields := map[string]string{}
+	attrs := &setAttributes{}
+	for k, v := range in.GetFields() {
+		var err error
+		switch k {
+		case ConstZones:
+			attrs.Zones, err = stringList(v)
+		case ConstFilters:
+			attrs.Filters, err = stringList(v)
+		default:
+			err = fmt.Errorf("unrecognized field")
+		}
+		if err != nil {
+			badFields[fmt.Sprintf("attributes.%s", k)] = err.Error()
+		}
+	}
+	if err := invalidArgumentError("Invalid arguments in the set attributes", badFields); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// stringList returns the strings of a list value.
+func stringList(v *structpb.Value) ([]string, error) {
+	l, ok := v.GetKind().(*structpb.Value_ListValue)
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+	var out []string
+	for _, e := range l.ListValue.GetValues() {
+		s, ok := e.GetKind().(*structpb.Value_StringValue)
+		if !ok || strings.TrimSpace(s.StringValue) == "" {
+			return nil, fmt.Errorf("must be a list of non-empty strings")
+		}
+		out = append(out, s.StringValue)
+	}
+	return out, nil
+}
+
+// invalidArgumentError returns an InvalidArgument status error listing the
+// bad fields, or nil if there are none.
+func invalidArgumentError(msg string, badFields map[string]string) error {
+	if len(badFields) == 0 {
+		return nil
+	}
+	var fields []string
+	for k, v := range badFields {
+		fields = append(fields, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(fields)
+	return status.Errorf(codes.InvalidArgument, "%s: %s", msg, strings.Join(fields, ", "))
+}
@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// instanceLister lists the Compute Engine instances of a project.
+type instanceLister interface {
+	// listInstances returns the instances of zone matching filter. If zone
+	// is empty, the instances of all the zones of the project are returned.
+	listInstances(ctx context.Context, projectId, zone, filter string) ([]*compute.Instance, error)
+}
+
+type computeLister struct {
+	svc *compute.Service
+}
+
+// newComputeLister returns an instanceLister calling the Compute Engine API
+// authenticated with the provided service account key, or the application
+// default credentials if it is empty.
+func newComputeLister(ctx context.Context, serviceAccountKey string) (instanceLister, error) {
+	opts := []option.ClientOption{
+		option.WithScopes(compute.ComputeReadonlyScope),
+	}
+	if serviceAccountKey != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(serviceAccountKey)))
+	}
+	svc, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &computeLister{svc: svc}, nil
+}
+
+func (l *computeLister) listInstances(ctx context.Context, projectId, zone, filter string) ([]*compute.Instance, error) {
+	var out []*compute.Instance
+	if zone != "" {
+		call := l.svc.Instances.List(projectId, zone)
+		if filter != "" {
+			call = call.Filter(filter)
+		}
+		err := call.Pages(ctx, func(page *compute.InstanceList) error {
+			out = append(out, page.Items...)
+			return nil
+		})
+		return out, err
+	}
+
+	call := l.svc.Instances.AggregatedList(projectId)
+	if filter != "" {
+		call = call.Filter(filter)
+	}
+	err := call.Pages(ctx, func(page *compute.InstanceAggregatedList) error {
+		for _, scoped := range page.Items {
+			out = append(out, scoped.Instances...)
+		}
+		return nil
+	})
+	return out, err
+}
@@ -0,0 +1,275 @@
+// Package plugin provides a host plugin discovering GCP Compute Engine
+// instances.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	pb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// runningFilter restricts the discovered instances to running ones.
+const runningFilter = `status = "RUNNING"`
+
+// GcpPlugin implements the HostPluginServiceServer interface for GCP Compute
+// Engine instances.
+type GcpPlugin struct {
+	pb.UnimplementedHostPluginServiceServer
+
+	// testListerFn is used instead of the Compute Engine API when set. It is
+	// only used in tests.
+	testListerFn func(ctx context.Context, serviceAccountKey string) (instanceLister, error)
+}
+
+// Ensure that we are implementing HostPluginServiceServer
+var _ pb.HostPluginServiceServer = (*GcpPlugin)(nil)
+
+// NormalizeSetData converts a zones or filters attribute provided as a single
+// string into a list.
+func (p *GcpPlugin) NormalizeSetData(_ context.Context, req *pb.NormalizeSetDataRequest) (*pb.NormalizeSetDataResponse, error) {
+	attrs := req.GetAttributes()
+	if attrs == nil {
+		return &pb.NormalizeSetDataResponse{}, nil
+	}
+	for _, k := range []string{ConstZones, ConstFilters} {
+		v, ok := attrs.GetFields()[k]
+		if !ok {
+			continue
+		}
+		if s, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			attrs.Fields[k] = structpb.NewListValue(&structpb.ListValue{
+				Values: []*structpb.Value{structpb.NewStringValue(s.StringValue)},
+			})
+		}
+	}
+	return &pb.NormalizeSetDataResponse{Attributes: attrs}, nil
+}
+
+// OnCreateCatalog validates the catalog attributes and secrets and persists
+// the secrets.
+func (p *GcpPlugin) OnCreateCatalog(_ context.Context, req *pb.OnCreateCatalogRequest) (*pb.OnCreateCatalogResponse, error) {
+	catalog := req.GetCatalog()
+	if catalog == nil {
+		return nil, status.Error(codes.InvalidArgument, "catalog is nil")
+	}
+	if _, err := getCatalogAttributes(catalog.GetAttributes()); err != nil {
+		return nil, err
+	}
+	if _, err := getCatalogSecrets(catalog.GetSecrets()); err != nil {
+		return nil, err
+	}
+	if catalog.GetSecrets() == nil {
+		return &pb.OnCreateCatalogResponse{}, nil
+	}
+	return &pb.OnCreateCatalogResponse{
+		Persisted: &pb.HostCatalogPersisted{
+			Secrets: catalog.GetSecrets(),
+		},
+	}, nil
+}
+
+// OnUpdateCatalog validates the new catalog attributes and secrets. If new
+// secrets are provided they replace the persisted ones, an empty set of
+// secrets deletes them.
+func (p *GcpPlugin) OnUpdateCatalog(_ context.Context, req *pb.OnUpdateCatalogRequest) (*pb.OnUpdateCatalogResponse, error) {
+	catalog := req.GetNewCatalog()
+	if catalog == nil {
+		return nil, status.Error(codes.InvalidArgument, "new catalog is nil")
+	}
+	if _, err := getCatalogAttributes(catalog.GetAttributes()); err != nil {
+		return nil, err
+	}
+	if catalog.GetSecrets() == nil {
+		return &pb.OnUpdateCatalogResponse{}, nil
+	}
+	if _, err := getCatalogSecrets(catalog.GetSecrets()); err != nil {
+		return nil, err
+	}
+	return &pb.OnUpdateCatalogResponse{
+		Persisted: &pb.HostCatalogPersisted{
+			Secrets: catalog.GetSecrets(),
+		},
+	}, nil
+}
+
+// OnDeleteCatalog is a no-op, no resources are created by the plugin.
+func (p *GcpPlugin) OnDeleteCatalog(context.Context, *pb.OnDeleteCatalogRequest) (*pb.OnDeleteCatalogResponse, error) {
+	return &pb.OnDeleteCatalogResponse{}, nil
+}
+
+// OnCreateSet validates the set attributes.
+func (p *GcpPlugin) OnCreateSet(_ context.Context, req *pb.OnCreateSetRequest) (*pb.OnCreateSetResponse, error) {
+	if req.GetSet() == nil {
+		return nil, status.Error(codes.InvalidArgument, "set is nil")
+	}
+	if _, err := getSetAttributes(req.GetSet().GetAttributes()); err != nil {
+		return nil, err
+	}
+	return &pb.OnCreateSetResponse{}, nil
+}
+
+// OnUpdateSet validates the new set attributes.
+func (p *GcpPlugin) OnUpdateSet(_ context.Context, req *pb.OnUpdateSetRequest) (*pb.OnUpdateSetResponse, error) {
+	if req.GetNewSet() == nil {
+		return nil, status.Error(codes.InvalidArgument, "new set is nil")
+	}
+	if _, err := getSetAttributes(req.GetNewSet().GetAttributes()); err != nil {
+		return nil, err
+	}
+	return &pb.OnUpdateSetResponse{}, nil
+}
+
+// OnDeleteSet is a no-op, no resources are created by the plugin.
+func (p *GcpPlugin) OnDeleteSet(context.Context, *pb.OnDeleteSetRequest) (*pb.OnDeleteSetResponse, error) {
+	return &pb.OnDeleteSetResponse{}, nil
+}
+
+// ListHosts returns the running instances matching the zones and filters of
+// each of the provided sets. An instance matching several sets is returned
+// once with the IDs of all the sets it matches.
+func (p *GcpPlugin) ListHosts(ctx context.Context, req *pb.ListHostsRequest) (*pb.ListHostsResponse, error) {
+	catalog := req.GetCatalog()
+	if catalog == nil {
+		return nil, status.Error(codes.InvalidArgument, "catalog is nil")
+	}
+	catalogAttrs, err := getCatalogAttributes(catalog.GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := getCatalogSecrets(req.GetPersisted().GetSecrets())
+	if err != nil {
+		return nil, err
+	}
+	lister, err := p.lister(ctx, secrets.ServiceAccountKey)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "error creating compute engine client: %s", err)
+	}
+
+	hosts := make(map[string]*pb.ListHostsResponseHost)
+	var ids []string
+	for _, set := range req.GetSets() {
+		if set.GetId() == "" {
+			return nil, status.Error(codes.InvalidArgument, "set missing id")
+		}
+		setAttrs, err := getSetAttributes(set.GetAttributes())
+		if err != nil {
+			return nil, err
+		}
+		instances, err := listSetInstances(ctx, lister, catalogAttrs, setAttrs)
+		if err != nil {
+			return nil, status.Errorf(codes.Unknown, "error listing instances for set %q: %s", set.GetId(), err)
+		}
+		for _, inst := range instances {
+			h, err := hostFromInstance(catalogAttrs.ProjectId, inst)
+			if err != nil {
+				return nil, err
+			}
+			if existing, ok := hosts[h.ExternalId]; ok {
+				if existing.SetIds[len(existing.SetIds)-1] != set.GetId() {
+					existing.SetIds = append(existing.SetIds, set.GetId())
+				}
+				continue
+			}
+			h.SetIds = []string{set.GetId()}
+			hosts[h.ExternalId] = h
+			ids = append(ids, h.ExternalId)
+		}
+	}
+
+	resp := &pb.ListHostsResponse{}
+	for _, id := range ids {
+		resp.Hosts = append(resp.Hosts, hosts[id])
+	}
+	return resp, nil
+}
+
+func (p *GcpPlugin) lister(ctx context.Context, serviceAccountKey string) (instanceLister, error) {
+	if p.testListerFn != nil {
+		return p.testListerFn(ctx, serviceAccountKey)
+	}
+	return newComputeLister(ctx, serviceAccountKey)
+}
+
+// listSetInstances lists the running instances matching the set attributes.
+// When the set has no zones, the catalog's zone is used and when neither is
+// set the instances of all zones are listed.
+func listSetInstances(ctx context.Context, lister instanceLister, catalogAttrs *catalogAttributes, setAttrs *setAttributes) ([]*compute.Instance, error) {
+	zones := setAttrs.Zones
+	switch {
+	case len(zones) > 0:
+	case catalogAttrs.Zone != "":
+		zones = []string{catalogAttrs.Zone}
+	default:
+		zones = []string{""}
+	}
+	filter := instanceFilter(setAttrs.Filters)
+
+	var out []*compute.Instance
+	for _, zone := range zones {
+		instances, err := lister.listInstances(ctx, catalogAttrs.ProjectId, zone, filter)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, instances...)
+	}
+	return out, nil
+}
+
+// instanceFilter combines the filters with the running filter.
+func instanceFilter(filters []string) string {
+	exprs := []string{fmt.Sprintf("(%s)", runningFilter)}
+	for _, f := range filters {
+		exprs = append(exprs, fmt.Sprintf("(%s)", f))
+	}
+	return strings.Join(exprs, " AND ")
+}
+
+// hostFromInstance returns the host for an instance. The IP addresses are the
+// internal and external IPv4 and IPv6 addresses of all its network interfaces
+// and the DNS name is its zonal internal DNS name, along with its custom
+// hostname if it has one.
+func hostFromInstance(projectId string, inst *compute.Instance) (*pb.ListHostsResponseHost, error) {
+	if inst.Id == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "instance %q is missing an id", inst.Name)
+	}
+	h := &pb.ListHostsResponseHost{
+		ExternalId:  strconv.FormatUint(inst.Id, 10),
+		Name:        inst.Name,
+		Description: inst.Description,
+	}
+
+	seen := make(map[string]bool)
+	addIp := func(ip string) {
+		if ip != "" && !seen[ip] {
+			seen[ip] = true
+			h.IpAddresses = append(h.IpAddresses, ip)
+		}
+	}
+	for _, ni := range inst.NetworkInterfaces {
+		addIp(ni.NetworkIP)
+		addIp(ni.Ipv6Address)
+		for _, ac := range ni.AccessConfigs {
+			addIp(ac.NatIP)
+			addIp(ac.ExternalIpv6)
+		}
+		for _, ac := range ni.Ipv6AccessConfigs {
+			addIp(ac.ExternalIpv6)
+		}
+	}
+
+	if inst.Name != "" && inst.Zone != "" {
+		h.DnsNames = append(h.DnsNames, fmt.Sprintf("%s.%s.c.%s.internal", inst.Name, path.Base(inst.Zone), projectId))
+	}
+	if inst.Hostname != "" {
+		h.DnsNames = append(h.DnsNames, inst.Hostname)
+	}
+	return h, nil
+}
@@ -0,0 +1,258 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
+	pb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type listCall struct {
+	projectId, zone, filter string
+}
+
+type testLister struct {
+	calls     []listCall
+	instances map[string][]*compute.Instance
+}
+
+func (l *testLister) listInstances(_ context.Context, projectId, zone, filter string) ([]*compute.Instance, error) {
+	l.calls = append(l.calls, listCall{projectId: projectId, zone: zone, filter: filter})
+	return l.instances[zone], nil
+}
+
+func mustStruct(t *testing.T, in map[string]interface{}) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(in)
+	require.NoError(t, err)
+	return s
+}
+
+func TestGetCatalogAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      map[string]interface{}
+		want    *catalogAttributes
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			in:   map[string]interface{}{ConstProjectId: "my-project", ConstZone: "us-central1-a"},
+			want: &catalogAttributes{ProjectId: "my-project", Zone: "us-central1-a"},
+		},
+		{
+			name:    "missing-project",
+			in:      map[string]interface{}{ConstZone: "us-central1-a"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-field",
+			in:      map[string]interface{}{ConstProjectId: "my-project", "region": "us-central1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCatalogAttributes(mustStruct(t, tt.in))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetCatalogSecrets(t *testing.T) {
+	got, err := getCatalogSecrets(nil)
+	require.NoError(t, err)
+	assert.Empty(t, got.ServiceAccountKey)
+
+	got, err = getCatalogSecrets(mustStruct(t, map[string]interface{}{ConstServiceAccountKey: `{"type":"service_account"}`}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"service_account"}`, got.ServiceAccountKey)
+
+	_, err = getCatalogSecrets(mustStruct(t, map[string]interface{}{ConstServiceAccountKey: "not json"}))
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSetAttributes(t *testing.T) {
+	got, err := getSetAttributes(mustStruct(t, map[string]interface{}{
+		ConstZones:   []interface{}{"us-central1-a", "us-central1-b"},
+		ConstFilters: []interface{}{"labels.env = prod"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &setAttributes{
+		Zones:   []string{"us-central1-a", "us-central1-b"},
+		Filters: []string{"labels.env = prod"},
+	}, got)
+
+	_, err = getSetAttributes(mustStruct(t, map[string]interface{}{ConstZones: "us-central1-a"}))
+	require.Error(t, err)
+	_, err = getSetAttributes(mustStruct(t, map[string]interface{}{ConstFilters: []interface{}{""}}))
+	require.Error(t, err)
+	_, err = getSetAttributes(mustStruct(t, map[string]interface{}{"tags": []interface{}{"a"}}))
+	require.Error(t, err)
+}
+
+func TestNormalizeSetData(t *testing.T) {
+	p := new(GcpPlugin)
+	resp, err := p.NormalizeSetData(context.Background(), &pb.NormalizeSetDataRequest{
+		Attributes: mustStruct(t, map[string]interface{}{
+			ConstZones:   "us-central1-a",
+			ConstFilters: []interface{}{"labels.env = prod"},
+		}),
+	})
+	require.NoError(t, err)
+	got, err := getSetAttributes(resp.GetAttributes())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-central1-a"}, got.Zones)
+	assert.Equal(t, []string{"labels.env = prod"}, got.Filters)
+}
+
+func TestOnUpdateCatalog(t *testing.T) {
+	p := new(GcpPlugin)
+	attrs := mustStruct(t, map[string]interface{}{ConstProjectId: "my-project"})
+
+	resp, err := p.OnUpdateCatalog(context.Background(), &pb.OnUpdateCatalogRequest{
+		NewCatalog: &hostcatalogs.HostCatalog{Attrs: &hostcatalogs.HostCatalog_Attributes{Attributes: attrs}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GetPersisted(), "secrets must be left unchanged")
+
+	secrets := mustStruct(t, map[string]interface{}{ConstServiceAccountKey: `{"type":"service_account"}`})
+	resp, err = p.OnUpdateCatalog(context.Background(), &pb.OnUpdateCatalogRequest{
+		NewCatalog: &hostcatalogs.HostCatalog{Attrs: &hostcatalogs.HostCatalog_Attributes{Attributes: attrs}, Secrets: secrets},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, secrets, resp.GetPersisted().GetSecrets())
+}
+
+func TestHostFromInstance(t *testing.T) {
+	inst := &compute.Instance{
+		Id:          1234,
+		Name:        "web-1",
+		Description: "web server",
+		Zone:        "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a",
+		Hostname:    "web-1.example.com",
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{
+				NetworkIP:   "10.0.0.2",
+				Ipv6Address: "fd20::2",
+				AccessConfigs: []*compute.AccessConfig{
+					{NatIP: "34.1.2.3"},
+				},
+				Ipv6AccessConfigs: []*compute.AccessConfig{
+					{ExternalIpv6: "2600:1900::2"},
+				},
+			},
+			{
+				NetworkIP: "10.0.0.2",
+			},
+		},
+	}
+	h, err := hostFromInstance("my-project", inst)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", h.GetExternalId())
+	assert.Equal(t, "web-1", h.GetName())
+	assert.Equal(t, "web server", h.GetDescription())
+	assert.Equal(t, []string{"10.0.0.2", "fd20::2", "34.1.2.3", "2600:1900::2"}, h.GetIpAddresses())
+	assert.Equal(t, []string{"web-1.us-central1-a.c.my-project.internal", "web-1.example.com"}, h.GetDnsNames())
+
+	_, err = hostFromInstance("my-project", &compute.Instance{Name: "no-id"})
+	require.Error(t, err)
+}
+
+func TestListHosts(t *testing.T) {
+	instance := func(id uint64, zone string) *compute.Instance {
+		return &compute.Instance{
+			Id:   id,
+			Name: fmt.Sprintf("vm-%d", id),
+			Zone: zone,
+			NetworkInterfaces: []*compute.NetworkInterface{
+				{NetworkIP: fmt.Sprintf("10.0.0.%d", id)},
+			},
+		}
+	}
+	lister := &testLister{
+		instances: map[string][]*compute.Instance{
+			"us-central1-a": {instance(1, "us-central1-a"), instance(2, "us-central1-a")},
+			"us-central1-b": {instance(3, "us-central1-b")},
+		},
+	}
+	var gotKey string
+	p := &GcpPlugin{
+		testListerFn: func(_ context.Context, key string) (instanceLister, error) {
+			gotKey = key
+			return lister, nil
+		},
+	}
+
+	resp, err := p.ListHosts(context.Background(), &pb.ListHostsRequest{
+		Catalog: &hostcatalogs.HostCatalog{
+			Attrs: &hostcatalogs.HostCatalog_Attributes{
+				Attributes: mustStruct(t, map[string]interface{}{
+					ConstProjectId: "my-project",
+					ConstZone:      "us-central1-a",
+				}),
+			},
+		},
+		Persisted: &pb.HostCatalogPersisted{
+			Secrets: mustStruct(t, map[string]interface{}{ConstServiceAccountKey: `{}`}),
+		},
+		Sets: []*hostsets.HostSet{
+			{
+				Id: "hsplg_1",
+				Attrs: &hostsets.HostSet_Attributes{
+					Attributes: mustStruct(t, map[string]interface{}{
+						ConstFilters: []interface{}{"labels.env = prod"},
+					}),
+				},
+			},
+			{
+				Id: "hsplg_2",
+				Attrs: &hostsets.HostSet_Attributes{
+					Attributes: mustStruct(t, map[string]interface{}{
+						ConstZones: []interface{}{"us-central1-a", "us-central1-b"},
+					}),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, gotKey)
+	assert.Equal(t, []listCall{
+		{projectId: "my-project", zone: "us-central1-a", filter: `(status = "RUNNING") AND (labels.env = prod)`},
+		{projectId: "my-project", zone: "us-central1-a", filter: `(status = "RUNNING")`},
+		{projectId: "my-project", zone: "us-central1-b", filter: `(status = "RUNNING")`},
+	}, lister.calls)
+
+	require.Len(t, resp.GetHosts(), 3)
+	got := resp.GetHosts()
+	assert.Equal(t, "1", got[0].GetExternalId())
+	assert.Equal(t, []string{"hsplg_1", "hsplg_2"}, got[0].GetSetIds())
+	assert.Equal(t, []string{"10.0.0.1"}, got[0].GetIpAddresses())
+	assert.Equal(t, []string{"vm-1.us-central1-a.c.my-project.internal"}, got[0].GetDnsNames())
+	assert.Equal(t, "2", got[1].GetExternalId())
+	assert.Equal(t, []string{"hsplg_1", "hsplg_2"}, got[1].GetSetIds())
+	assert.Equal(t, "3", got[2].GetExternalId())
+	assert.Equal(t, []string{"hsplg_2"}, got[2].GetSetIds())
+
+	_, err = p.ListHosts(context.Background(), &pb.ListHostsRequest{
+		Catalog: &hostcatalogs.HostCatalog{Attrs: &hostcatalogs.HostCatalog_Attributes{Attributes: mustStruct(t, map[string]interface{}{})}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,6 @@
+package rotation_plugin_assets
+
+// RotationPluginPrefix is the prefix of the file names of external rotation
+// plugins. A plugin named "example" is loaded from the file
+// boundary-plugin-rotation-example.
+const RotationPluginPrefix = "boundary-plugin-rotation-"
@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: plugin/v1/credential_plugin_service.proto
+
+package plugin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CredentialPluginServiceClient is the client API for CredentialPluginService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CredentialPluginServiceClient interface {
+	// NormalizeStoreData is a hook that passes attributes to the plugin and
+	// allows those values to be normalized prior to creating or updating those
+	// values in the credential store data.
+	//
+	// NormalizeStoreData is called before:
+	// * OnCreateStore
+	// * OnUpdateStore
+	NormalizeStoreData(ctx context.Context, in *NormalizeStoreDataRequest, opts ...grpc.CallOption) (*NormalizeStoreDataResponse, error)
+	// OnCreateStore is a hook that runs when a credential store is created.
+	OnCreateStore(ctx context.Context, in *OnCreateStoreRequest, opts ...grpc.CallOption) (*OnCreateStoreResponse, error)
+	// OnUpdateStore is a hook that runs when a credential store is updated.
+	OnUpdateStore(ctx context.Context, in *OnUpdateStoreRequest, opts ...grpc.CallOption) (*OnUpdateStoreResponse, error)
+	// OnDeleteStore is a hook that runs when a credential store is deleted.
+	OnDeleteStore(ctx context.Context, in *OnDeleteStoreRequest, opts ...grpc.CallOption) (*OnDeleteStoreResponse, error)
+	// IssueCredentials retrieves or generates the credentials of a credential
+	// library for a session.
+	IssueCredentials(ctx context.Context, in *IssueCredentialsRequest, opts ...grpc.CallOption) (*IssueCredentialsResponse, error)
+	// RevokeCredentials is a hook that runs when the credentials issued for a
+	// session are no longer needed, ie: when the session is terminated. The
+	// plugin should revoke the credentials in the external system if
+	// supported.
+	RevokeCredentials(ctx context.Context, in *RevokeCredentialsRequest, opts ...grpc.CallOption) (*RevokeCredentialsResponse, error)
+}
+
+type credentialPluginServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCredentialPluginServiceClient(cc grpc.ClientConnInterface) CredentialPluginServiceClient {
+	return &credentialPluginServiceClient{cc}
+}
+
+func (c *credentialPluginServiceClient) NormalizeStoreData(ctx context.Context, in *NormalizeStoreDataRequest, opts ...grpc.CallOption) (*NormalizeStoreDataResponse, error) {
+	out := new(NormalizeStoreDataResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/NormalizeStoreData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialPluginServiceClient) OnCreateStore(ctx context.Context, in *OnCreateStoreRequest, opts ...grpc.CallOption) (*OnCreateStoreResponse, error) {
+	out := new(OnCreateStoreResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/OnCreateStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialPluginServiceClient) OnUpdateStore(ctx context.Context, in *OnUpdateStoreRequest, opts ...grpc.CallOption) (*OnUpdateStoreResponse, error) {
+	out := new(OnUpdateStoreResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/OnUpdateStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialPluginServiceClient) OnDeleteStore(ctx context.Context, in *OnDeleteStoreRequest, opts ...grpc.CallOption) (*OnDeleteStoreResponse, error) {
+	out := new(OnDeleteStoreResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/OnDeleteStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialPluginServiceClient) IssueCredentials(ctx context.Context, in *IssueCredentialsRequest, opts ...grpc.CallOption) (*IssueCredentialsResponse, error) {
+	out := new(IssueCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/IssueCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialPluginServiceClient) RevokeCredentials(ctx context.Context, in *RevokeCredentialsRequest, opts ...grpc.CallOption) (*RevokeCredentialsResponse, error) {
+	out := new(RevokeCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/plugin.v1.CredentialPluginService/RevokeCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CredentialPluginServiceServer is the server API for CredentialPluginService service.
+// All implementations must embed UnimplementedCredentialPluginServiceServer
+// for forward compatibility
+type CredentialPluginServiceServer interface {
+	// NormalizeStoreData is a hook that passes attributes to the plugin and
+	// allows those values to be normalized prior to creating or updating those
+	// values in the credential store data.
+	//
+	// NormalizeStoreData is called before:
+	// * OnCreateStore
+	// * OnUpdateStore
+	NormalizeStoreData(context.Context, *NormalizeStoreDataRequest) (*NormalizeStoreDataResponse, error)
+	// OnCreateStore is a hook that runs when a credential store is created.
+	OnCreateStore(context.Context, *OnCreateStoreRequest) (*OnCreateStoreResponse, error)
+	// OnUpdateStore is a hook that runs when a credential store is updated.
+	OnUpdateStore(context.Context, *OnUpdateStoreRequest) (*OnUpdateStoreResponse, error)
+	// OnDeleteStore is a hook that runs when a credential store is deleted.
+	OnDeleteStore(context.Context, *OnDeleteStoreRequest) (*OnDeleteStoreResponse, error)
+	// IssueCredentials retrieves or generates the credentials of a credential
+	// library for a session.
+	IssueCredentials(context.Context, *IssueCredentialsRequest) (*IssueCredentialsResponse, error)
+	// RevokeCredentials is a hook that runs when the credentials issued for a
+	// session are no longer needed, ie: when the session is terminated. The
+	// plugin should revoke the credentials in the external system if
+	// supported.
+	RevokeCredentials(context.Context, *RevokeCredentialsRequest) (*RevokeCredentialsResponse, error)
+	mustEmbedUnimplementedCredentialPluginServiceServer()
+}
+
+// UnimplementedCredentialPluginServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCredentialPluginServiceServer struct {
+}
+
+func (UnimplementedCredentialPluginServiceServer) NormalizeStoreData(context.Context, *NormalizeStoreDataRequest) (*NormalizeStoreDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalizeStoreData not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) OnCreateStore(context.Context, *OnCreateStoreRequest) (*OnCreateStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnCreateStore not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) OnUpdateStore(context.Context, *OnUpdateStoreRequest) (*OnUpdateStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnUpdateStore not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) OnDeleteStore(context.Context, *OnDeleteStoreRequest) (*OnDeleteStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnDeleteStore not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) IssueCredentials(context.Context, *IssueCredentialsRequest) (*IssueCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueCredentials not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) RevokeCredentials(context.Context, *RevokeCredentialsRequest) (*RevokeCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCredentials not implemented")
+}
+func (UnimplementedCredentialPluginServiceServer) mustEmbedUnimplementedCredentialPluginServiceServer() {
+}
+
+// UnsafeCredentialPluginServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CredentialPluginServiceServer will
+// result in compilation errors.
+type UnsafeCredentialPluginServiceServer interface {
+	mustEmbedUnimplementedCredentialPluginServiceServer()
+}
+
+func RegisterCredentialPluginServiceServer(s grpc.ServiceRegistrar, srv CredentialPluginServiceServer) {
+	s.RegisterService(&CredentialPluginService_ServiceDesc, srv)
+}
+
+func _CredentialPluginService_NormalizeStoreData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizeStoreDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).NormalizeStoreData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/NormalizeStoreData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).NormalizeStoreData(ctx, req.(*NormalizeStoreDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialPluginService_OnCreateStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnCreateStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).OnCreateStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/OnCreateStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).OnCreateStore(ctx, req.(*OnCreateStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialPluginService_OnUpdateStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnUpdateStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).OnUpdateStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/OnUpdateStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).OnUpdateStore(ctx, req.(*OnUpdateStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialPluginService_OnDeleteStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnDeleteStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).OnDeleteStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/OnDeleteStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).OnDeleteStore(ctx, req.(*OnDeleteStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialPluginService_IssueCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).IssueCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/IssueCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).IssueCredentials(ctx, req.(*IssueCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialPluginService_RevokeCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialPluginServiceServer).RevokeCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/plugin.v1.CredentialPluginService/RevokeCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialPluginServiceServer).RevokeCredentials(ctx, req.(*RevokeCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CredentialPluginService_ServiceDesc is the grpc.ServiceDesc for CredentialPluginService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CredentialPluginService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plugin.v1.CredentialPluginService",
+	HandlerType: (*CredentialPluginServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NormalizeStoreData",
+			Handler:    _CredentialPluginService_NormalizeStoreData_Handler,
+		},
+		{
+			MethodName: "OnCreateStore",
+			Handler:    _CredentialPluginService_OnCreateStore_Handler,
+		},
+		{
+			MethodName: "OnUpdateStore",
+			Handler:    _CredentialPluginService_OnUpdateStore_Handler,
+		},
+		{
+			MethodName: "OnDeleteStore",
+			Handler:    _CredentialPluginService_OnDeleteStore_Handler,
+		},
+		{
+			MethodName: "IssueCredentials",
+			Handler:    _CredentialPluginService_IssueCredentials_Handler,
+		},
+		{
+			MethodName: "RevokeCredentials",
+			Handler:    _CredentialPluginService_RevokeCredentials_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin/v1/credential_plugin_service.proto",
+}
@@ -0,0 +1,89 @@
+package external_rotation_plugins
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	pb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+)
+
+const (
+	rotationServicePluginSetName = "rotation-plugin"
+)
+
+// HandshakeConfig is a shared config that can be used regardless of plugin, to
+// avoid having to know type-specific things about each plugin
+var HandshakeConfig = plugin.HandshakeConfig{
+	MagicCookieKey:   "HASHICORP_BOUNDARY_ROTATION_PLUGIN",
+	MagicCookieValue: rotationServicePluginSetName,
+}
+
+// ServeRotationPlugin is a generic function to start serving a rotation
+// plugin service as a plugin
+func ServeRotationPlugin(svc pb.RotationPluginServiceServer, opt ...Option) error {
+	opts, err := getOpts(opt...)
+	if err != nil {
+		return err
+	}
+	rotationServiceServer, err := NewRotationPluginServiceServer(svc)
+	if err != nil {
+		return err
+	}
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: HandshakeConfig,
+		VersionedPlugins: map[int]plugin.PluginSet{
+			1: {rotationServicePluginSetName: rotationServiceServer},
+		},
+		Logger:     opts.withLogger,
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+	return nil
+}
+
+type rotationPlugin struct {
+	plugin.Plugin
+
+	impl pb.RotationPluginServiceServer
+}
+
+func NewRotationPluginServiceServer(impl pb.RotationPluginServiceServer) (*rotationPlugin, error) {
+	if impl == nil {
+		return nil, fmt.Errorf("empty underlying rotation plugin passed in")
+	}
+	return &rotationPlugin{
+		impl: impl,
+	}, nil
+}
+
+func NewRotationPluginClient(pluginPath string, opt ...Option) (*plugin.Client, error) {
+	opts, err := getOpts(opt...)
+	if err != nil {
+		return nil, err
+	}
+	rotationServiceClient := &rotationPlugin{}
+
+	return plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: HandshakeConfig,
+		VersionedPlugins: map[int]plugin.PluginSet{
+			1: {rotationServicePluginSetName: rotationServiceClient},
+		},
+		Cmd: exec.Command(pluginPath),
+		AllowedProtocols: []plugin.Protocol{
+			plugin.ProtocolGRPC,
+		},
+		Logger:   opts.withLogger,
+		AutoMTLS: true,
+	}), nil
+}
+
+func (c *rotationPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterRotationPluginServiceServer(s, c.impl)
+	return nil
+}
+
+func (c *rotationPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, cc *grpc.ClientConn) (interface{}, error) {
+	return pb.NewRotationPluginServiceClient(cc), nil
+}